@@ -5,6 +5,7 @@ import (
 	"flag"
 	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
 	"syscall"
@@ -19,8 +20,11 @@ import (
 	"github.com/dev/personal-finance-tracker/backend/internal/domain"
 	"github.com/dev/personal-finance-tracker/backend/internal/handler"
 	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/mailer"
+	"github.com/dev/personal-finance-tracker/backend/internal/metrics"
 	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
 	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
 	"github.com/dev/personal-finance-tracker/backend/internal/service"
 )
 
@@ -75,10 +79,25 @@ func main() {
 
 	log.Info().Msg("Database connected successfully")
 
+	// Connection pool statistics for /metrics
+	if cfg.Metrics.Enabled {
+		if err := metrics.RegisterDB(sqlDB, cfg.Database.DBName); err != nil {
+			log.Fatal().Err(err).Msg("Failed to register database metrics")
+		}
+	}
+
 	// Auto-migrate the schema (for development and test only)
 	// In production, use golang-migrate instead
 	if cfg.Server.Mode == "debug" || cfg.Server.Mode == "test" {
-		if err := db.AutoMigrate(&domain.Transaction{}, &domain.User{}); err != nil {
+		if err := db.AutoMigrate(&domain.Transaction{}, &domain.User{}, &domain.Budget{},
+			&domain.Envelope{}, &domain.EnvelopeAllocation{}, &domain.EnvelopeMove{},
+			&domain.ScheduledTransaction{}, &domain.ScheduledPayment{},
+			&domain.Goal{}, &domain.GoalContribution{}, &domain.TransactionAnomaly{},
+			&domain.Payee{}, &domain.PayeeAlias{}, &domain.ImportProfile{},
+			&domain.DigestSubscription{}, &domain.WebhookEndpoint{}, &domain.WebhookDelivery{},
+			&domain.RateLimitBucket{}, &domain.LoginThrottle{}, &domain.SecurityEvent{},
+			&domain.UserMFA{}, &domain.MFARecoveryCode{}, &domain.PasswordResetToken{},
+			&domain.EmailChangeToken{}, &domain.Takeout{}); err != nil {
 			log.Fatal().Err(err).Msg("Failed to migrate database")
 		}
 		log.Info().Msg("Database migration completed")
@@ -86,26 +105,235 @@ func main() {
 		log.Warn().Msg("AutoMigrate disabled in production mode. Use golang-migrate for schema migrations.")
 	}
 
+	// Account mapping for plain-text accounting exports
+	ledgerMapping := domain.NewLedgerMapping(cfg.Ledger.Currency, cfg.Ledger.Accounts, cfg.Ledger.Categories)
+	if err := ledgerMapping.Validate(); err != nil {
+		log.Fatal().Err(err).Msg("Invalid ledger configuration")
+	}
+
 	// Initialize repositories
 	txRepo := repository.NewTransactionRepository(db)
 	userRepo := repository.NewUserRepository(db)
+	budgetRepo := repository.NewBudgetRepository(db)
+	envelopeRepo := repository.NewEnvelopeRepository(db)
+	recurringRepo := repository.NewRecurringRepository(db)
+	scheduledRepo := repository.NewScheduledRepository(db)
+	goalRepo := repository.NewGoalRepository(db)
+	anomalyRepo := repository.NewAnomalyRepository(db)
+	payeeRepo := repository.NewPayeeRepository(db)
+	importRepo := repository.NewImportRepository(db)
+	digestRepo := repository.NewDigestRepository(db)
+	outgoingWebhookRepo := repository.NewOutgoingWebhookRepository(db)
+	streamRepo := repository.NewStreamRepository(db, cfg.DatabaseDSN())
+	securityRepo := repository.NewSecurityRepository(db)
+	mfaRepo := repository.NewMFARepository(db)
+	passwordResetRepo := repository.NewPasswordResetRepository(db)
+	accountRepo := repository.NewAccountRepository(db)
+	emailChangeRepo := repository.NewEmailChangeRepository(db)
+	takeoutRepo := repository.NewTakeoutRepository(db)
+
+	// Failed login delays and account lockouts
+	loginPolicy := domain.LoginPolicy{
+		MaxFailures:     cfg.Login.MaxFailures,
+		LockoutDuration: time.Duration(cfg.Login.LockoutDuration) * time.Second,
+		FailureWindow:   time.Duration(cfg.Login.FailureWindow) * time.Second,
+		BaseDelay:       time.Duration(cfg.Login.BaseDelay) * time.Second,
+		MaxDelay:        time.Duration(cfg.Login.MaxDelay) * time.Second,
+	}
+	if err := loginPolicy.Validate(); err != nil {
+		log.Fatal().Err(err).Msg("Invalid login configuration")
+	}
+	webhookPolicy, err := security.NewAddressPolicy(cfg.Webhooks.AllowedNetworks)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid webhooks configuration")
+	}
 
 	// Initialize services
-	txService := service.NewTransactionService(txRepo)
-	authService := service.NewAuthService(userRepo, cfg.JWT.Secret)
+	scheduledService := service.NewScheduledService(scheduledRepo)
+	anomalyService := service.NewAnomalyService(anomalyRepo, cfg.Analyzer.LookbackMonths)
+	payeeService := service.NewPayeeService(payeeRepo)
+	outgoingWebhookService := service.NewOutgoingWebhookService(outgoingWebhookRepo, budgetRepo, anomalyRepo,
+		webhookPolicy, time.Duration(cfg.Webhooks.Timeout)*time.Second, cfg.Webhooks.MaxAttempts)
+	streamService := service.NewStreamService(streamRepo, txRepo)
+	// Outgoing webhooks come after anomaly detection to see the transactions it flags
+	txService := service.NewTransactionService(txRepo, payeeService, scheduledService, anomalyService,
+		outgoingWebhookService, streamService)
+	securityService := service.NewSecurityService(securityRepo, userRepo, loginPolicy)
+	mfaService := service.NewMFAService(mfaRepo, userRepo, securityService)
+	authService := service.NewAuthService(userRepo, securityService, mfaService, cfg.JWT.Secret)
+	budgetService := service.NewBudgetService(budgetRepo)
+	envelopeService := service.NewEnvelopeService(envelopeRepo, txRepo)
+	recurringService := service.NewRecurringService(recurringRepo, cfg.Analyzer.LookbackMonths)
+	forecastService := service.NewForecastService(txRepo, recurringRepo, scheduledRepo, recurringService)
+	goalService := service.NewGoalService(goalRepo)
+	importService := service.NewImportService(importRepo, txService)
+	exportService := service.NewExportService(txRepo, ledgerMapping)
+	reportService := service.NewReportService(txRepo, payeeRepo, budgetRepo, ledgerMapping.Currency, cfg.Reports.FontPath)
+
+	// Outgoing email is optional; without an SMTP host no digests are sent
+	var emailSender mailer.Mailer
+	if cfg.SMTP.Host != "" {
+		emailSender, err = mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTP.Host,
+			Port:     cfg.SMTP.Port,
+			Username: cfg.SMTP.Username,
+			Password: cfg.SMTP.Password,
+			From:     cfg.SMTP.From,
+			TLS:      cfg.SMTP.TLS,
+		})
+		if err != nil {
+			log.Fatal().Err(err).Msg("Invalid SMTP configuration")
+		}
+	}
+	digestService := service.NewDigestService(digestRepo, userRepo, txRepo, budgetRepo, anomalyRepo, emailSender, ledgerMapping.Currency)
+
+	// Password reset and email confirmation links go out by email; without
+	// a notifier password resets and email changes are disabled
+	var notifier service.Notifier
+	switch cfg.PasswordReset.Notifier {
+	case "smtp":
+		if emailSender != nil {
+			notifier = service.NewEmailNotifier(emailSender)
+		} else {
+			log.Info().Msg("SMTP host not configured, password resets and email changes disabled")
+		}
+	case "log":
+		log.Warn().Msg("Password reset and email confirmation links are written to the log, do not use in production")
+		notifier = service.NewLogNotifier()
+	default:
+		log.Fatal().Str("notifier", cfg.PasswordReset.Notifier).Msg("password_reset.notifier must be smtp or log")
+	}
+	if resetURL, err := url.Parse(cfg.PasswordReset.URL); err != nil || !resetURL.IsAbs() {
+		log.Fatal().Str("url", cfg.PasswordReset.URL).Msg("password_reset.url must be an absolute URL")
+	}
+	if confirmURL, err := url.Parse(cfg.Account.EmailConfirmURL); err != nil || !confirmURL.IsAbs() {
+		log.Fatal().Str("url", cfg.Account.EmailConfirmURL).Msg("account.email_confirm_url must be an absolute URL")
+	}
+	if cfg.Account.ClosureGracePeriod < 1 {
+		log.Fatal().Msg("account.closure_grace_period must be at least 1 day")
+	}
+	passwordService := service.NewPasswordService(userRepo, passwordResetRepo, securityService, notifier,
+		authService.GetJWTManager(), cfg.PasswordReset.URL, time.Duration(cfg.PasswordReset.TokenTTL)*time.Minute)
+	accountService := service.NewAccountService(userRepo, accountRepo, emailChangeRepo, securityService, notifier,
+		cfg.Account.EmailConfirmURL, time.Duration(cfg.Account.EmailTokenTTL)*time.Minute,
+		time.Duration(cfg.Account.ClosureGracePeriod)*24*time.Hour)
+	takeoutService := service.NewTakeoutService(userRepo, takeoutRepo, txRepo, payeeRepo, budgetRepo,
+		time.Duration(cfg.Takeout.TTL)*time.Hour, cfg.Takeout.SyncLimit)
 
 	// Initialize handlers
 	webhookHandler := handler.NewWebhookHandler(txService)
 	analyticsHandler := handler.NewAnalyticsHandler(txService)
 	authHandler := handler.NewAuthHandler(authService)
+	budgetHandler := handler.NewBudgetHandler(budgetService)
+	envelopeHandler := handler.NewEnvelopeHandler(envelopeService)
+	recurringHandler := handler.NewRecurringHandler(recurringService)
+	scheduledHandler := handler.NewScheduledHandler(scheduledService)
+	forecastHandler := handler.NewForecastHandler(forecastService)
+	goalHandler := handler.NewGoalHandler(goalService)
+	anomalyHandler := handler.NewAnomalyHandler(anomalyService)
+	payeeHandler := handler.NewPayeeHandler(payeeService)
+	importHandler := handler.NewImportHandler(importService)
+	exportHandler := handler.NewExportHandler(exportService)
+	reportHandler := handler.NewReportHandler(reportService)
+	digestHandler := handler.NewDigestHandler(digestService)
+	outgoingWebhookHandler := handler.NewOutgoingWebhookHandler(outgoingWebhookService)
+	securityHandler := handler.NewSecurityHandler(securityService)
+	mfaHandler := handler.NewMFAHandler(mfaService)
+	passwordHandler := handler.NewPasswordHandler(passwordService)
+	accountHandler := handler.NewAccountHandler(accountService)
+	takeoutHandler := handler.NewTakeoutHandler(takeoutService)
+	if cfg.Stream.HeartbeatInterval <= 0 {
+		log.Fatal().Msg("stream.heartbeat_interval must be positive")
+	}
+	streamHandler := handler.NewStreamHandler(streamService, time.Duration(cfg.Stream.HeartbeatInterval)*time.Second)
+
+	// Start background analyzers; they stop when the server shuts down
+	analyzerCtx, stopAnalyzers := context.WithCancel(context.Background())
+	defer stopAnalyzers()
+	if cfg.Analyzer.RecurringInterval > 0 {
+		recurringService.Start(analyzerCtx, time.Duration(cfg.Analyzer.RecurringInterval)*time.Minute)
+	}
+	// Stopping the stream listener also ends open streams, so shutdown does not wait for them
+	streamService.Start(analyzerCtx)
+
+	// Start scheduled jobs; Stop waits for a running job when the server shuts down
+	jobs := newScheduler()
+	switch {
+	case emailSender == nil:
+		log.Info().Msg("SMTP host not configured, email digests disabled")
+	case cfg.Digest.CheckInterval > 0:
+		jobs.Every("digests", time.Duration(cfg.Digest.CheckInterval)*time.Minute, func(ctx context.Context) error {
+			result, err := digestService.SendDue(ctx)
+			if result.Sent > 0 || result.Failed > 0 {
+				log.Info().Int("sent", result.Sent).Int("failed", result.Failed).Msg("Email digests sent")
+			}
+			return err
+		})
+	}
+	if cfg.Webhooks.PollInterval > 0 {
+		jobs.Every("webhooks", time.Duration(cfg.Webhooks.PollInterval)*time.Second, func(ctx context.Context) error {
+			result, err := outgoingWebhookService.DeliverDue(ctx)
+			if result.Failed > 0 {
+				log.Warn().Int("failed", result.Failed).Msg("Webhook deliveries failed after their last attempt")
+			}
+			return err
+		})
+	}
+
+	jobs.Every("login-throttles", 10*time.Minute, func(ctx context.Context) error {
+		_, err := securityService.DeleteExpiredThrottles()
+		return err
+	})
+	jobs.Every("password-reset-tokens", time.Hour, func(ctx context.Context) error {
+		_, err := passwordService.DeleteExpiredResetTokens()
+		return err
+	})
+	jobs.Every("email-change-tokens", time.Hour, func(ctx context.Context) error {
+		_, err := accountService.DeleteExpiredEmailChanges()
+		return err
+	})
+	jobs.Every("account-removals", time.Hour, func(ctx context.Context) error {
+		removed, err := accountService.RemoveDue()
+		if removed > 0 {
+			log.Info().Int("removed", removed).Msg("Sign-in accounts of closed accounts removed")
+		}
+		return err
+	})
+	if cfg.Takeout.PollInterval > 0 {
+		jobs.Every("takeouts", time.Duration(cfg.Takeout.PollInterval)*time.Second, func(ctx context.Context) error {
+			built, err := takeoutService.BuildDue(ctx)
+			if built > 0 {
+				log.Info().Int("built", built).Msg("Takeout archives built")
+			}
+			return err
+		})
+	}
+	jobs.Every("expired-takeouts", time.Hour, func(ctx context.Context) error {
+		_, err := takeoutService.DeleteExpired()
+		return err
+	})
+
+	// Rate limits; the Postgres store registers its cleanup job
+	limits, err := newRateLimits(cfg, db, jobs)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid rate limit configuration")
+	}
+	userRateLimit := middleware.RateLimit(limits.store, limits.user, middleware.UserKey)
 
 	// Setup router
 	router := gin.New()
+	// Client IPs for rate limits come from X-Forwarded-For only behind trusted proxies
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatal().Err(err).Msg("Invalid trusted proxies")
+	}
 	router.Use(gin.Recovery())
 	router.Use(middleware.CORS(middleware.CORSConfig{
 		AllowedOrigins: cfg.Server.AllowedOrigins,
 	}))
 	router.Use(middleware.RequestID())
+	if cfg.Metrics.Enabled {
+		router.Use(middleware.Metrics())
+	}
 	router.Use(middleware.MaxBodySize(10 << 20)) // 10 MB max body size
 	router.Use(middleware.LoggingMiddleware())
 	router.Use(middleware.ErrorHandler())
@@ -156,14 +384,38 @@ func main() {
 		})
 	})
 
-	// API v1 routes
-	v1 := router.Group("/api/v1")
+	// Prometheus metrics, on the server port unless an admin port is set
+	if cfg.Metrics.Enabled && cfg.Metrics.Port == "" {
+		router.GET("/metrics", gin.WrapH(metrics.Handler()))
+	}
+
+	// API v1 routes, limited per client IP and for the API key
+	v1 := router.Group("/api/v1",
+		middleware.RateLimit(limits.store, limits.public, middleware.ClientIPKey),
+		middleware.RateLimit(limits.store, limits.apiKey, middleware.APIKeyKey(cfg.APIKey)))
 	{
-		// Auth endpoints (public - registration and login)
-		auth := v1.Group("/auth")
+		// Auth endpoints (public - registration and login, with a stricter limit per client IP)
+		auth := v1.Group("/auth", middleware.RateLimit(limits.store, limits.auth, middleware.ClientIPKey))
 		{
 			auth.POST("/register", authHandler.Register)
 			auth.POST("/login", authHandler.Login)
+			auth.POST("/mfa/verify", authHandler.VerifyMFA)
+			auth.POST("/password/forgot", passwordHandler.ForgotPassword)
+			auth.POST("/password/reset", passwordHandler.ResetPassword)
+			auth.POST("/email/confirm", accountHandler.ConfirmEmail)
+
+			// Password change of the signed-in user (requires JWT)
+			auth.POST("/password", middleware.JWTAuth(authService), userRateLimit, passwordHandler.ChangePassword)
+
+			// Two-factor authentication of the signed-in user (require JWT)
+			mfa := auth.Group("/mfa", middleware.JWTAuth(authService), userRateLimit)
+			{
+				mfa.GET("", mfaHandler.GetStatus)
+				mfa.POST("/enroll", mfaHandler.Enroll)
+				mfa.POST("/activate", mfaHandler.Activate)
+				mfa.POST("/disable", mfaHandler.Disable)
+				mfa.POST("/recovery-codes", mfaHandler.RegenerateRecoveryCodes)
+			}
 		}
 
 		// Webhook endpoints (require API key)
@@ -174,6 +426,60 @@ func main() {
 			webhook.POST("/transactions/batch", webhookHandler.CreateBatchTransaction)
 		}
 
+		// Statement import endpoints (require API key to modify)
+		imports := v1.Group("/import")
+		{
+			imports.POST("/csv", middleware.APIKeyAuth(cfg.APIKey), importHandler.ImportCSV)
+			imports.POST("/ofx", middleware.APIKeyAuth(cfg.APIKey), importHandler.ImportOFX)
+			imports.POST("/qif", middleware.APIKeyAuth(cfg.APIKey), importHandler.ImportQIF)
+			imports.GET("/profiles", importHandler.ListProfiles)
+			imports.GET("/profiles/:id", importHandler.GetProfile)
+			imports.POST("/profiles", middleware.APIKeyAuth(cfg.APIKey), importHandler.CreateProfile)
+			imports.PUT("/profiles/:id", middleware.APIKeyAuth(cfg.APIKey), importHandler.UpdateProfile)
+			imports.DELETE("/profiles/:id", middleware.APIKeyAuth(cfg.APIKey), importHandler.DeleteProfile)
+		}
+
+		// Profile and account of the signed-in user (require JWT)
+		me := v1.Group("/me", middleware.JWTAuth(authService), userRateLimit)
+		{
+			me.GET("", accountHandler.GetProfile)
+			me.PATCH("", accountHandler.UpdateProfile)
+			me.POST("/deactivate", accountHandler.Deactivate)
+			me.DELETE("", accountHandler.Close)
+
+			// Takeout archives; restoring writes to the ledger, so it also requires the API key
+			me.POST("/takeouts", takeoutHandler.RequestTakeout)
+			me.GET("/takeouts", takeoutHandler.ListTakeouts)
+			me.POST("/takeouts/restore", middleware.APIKeyAuth(cfg.APIKey), takeoutHandler.RestoreTakeout)
+			me.GET("/takeouts/:id", takeoutHandler.GetTakeout)
+			me.GET("/takeouts/:id/download", takeoutHandler.DownloadTakeout)
+		}
+
+		// Digest email preferences of the signed-in user (require JWT)
+		digests := v1.Group("/digests", middleware.JWTAuth(authService), userRateLimit)
+		{
+			digests.GET("/preferences", digestHandler.GetPreferences)
+			digests.PUT("/preferences", digestHandler.UpdatePreferences)
+		}
+
+		// Security log of the signed-in user (require JWT)
+		v1.GET("/security-events", middleware.JWTAuth(authService), userRateLimit, securityHandler.ListEvents)
+
+		// Live transaction stream (require JWT)
+		v1.GET("/stream", middleware.JWTAuth(authService), userRateLimit, streamHandler.Stream)
+
+		// Outgoing webhook endpoints of the signed-in user (require JWT)
+		webhookEndpoints := v1.Group("/webhook-endpoints", middleware.JWTAuth(authService), userRateLimit)
+		{
+			webhookEndpoints.GET("", outgoingWebhookHandler.ListEndpoints)
+			webhookEndpoints.POST("", outgoingWebhookHandler.CreateEndpoint)
+			webhookEndpoints.GET("/:id", outgoingWebhookHandler.GetEndpoint)
+			webhookEndpoints.PUT("/:id", outgoingWebhookHandler.UpdateEndpoint)
+			webhookEndpoints.DELETE("/:id", outgoingWebhookHandler.DeleteEndpoint)
+			webhookEndpoints.GET("/:id/deliveries", outgoingWebhookHandler.ListDeliveries)
+			webhookEndpoints.POST("/:id/deliveries/:delivery_id/redeliver", outgoingWebhookHandler.Redeliver)
+		}
+
 		// Analytics endpoints (no auth required for single-user app)
 		analytics := v1.Group("/analytics")
 		{
@@ -181,14 +487,94 @@ func main() {
 			analytics.GET("/trends", analyticsHandler.GetTrends)
 			analytics.GET("/by-source", analyticsHandler.GetBreakdownBySource)
 			analytics.GET("/by-category", analyticsHandler.GetBreakdownByCategory)
+			analytics.GET("/by-payee", payeeHandler.GetPayeeBreakdown)
+			analytics.GET("/breakdown", analyticsHandler.GetBreakdown)
+			analytics.GET("/pivot", analyticsHandler.GetPivot)
+			analytics.GET("/budgets", budgetHandler.GetBudgetProgress)
+			analytics.GET("/envelopes", envelopeHandler.GetBalances)
+			analytics.GET("/recurring", recurringHandler.GetRecurring)
+			analytics.GET("/forecast", forecastHandler.GetForecast)
+			analytics.GET("/goals", goalHandler.ListProgress)
+			analytics.GET("/anomalies", anomalyHandler.ListAnomalies)
+		}
+
+		// Report endpoints (no auth required for single-user app)
+		reports := v1.Group("/reports")
+		{
+			reports.GET("/monthly", reportHandler.GetMonthlyReport)
 		}
 
 		// Transaction endpoints
 		transactions := v1.Group("/transactions")
 		{
 			transactions.GET("", analyticsHandler.ListTransactions)
+			transactions.GET("/upcoming", scheduledHandler.ListUpcoming)
+			transactions.GET("/export", exportHandler.ExportTransactions)
 			transactions.GET("/:id", analyticsHandler.GetTransactionByID)
+			transactions.PUT("/:id", middleware.APIKeyAuth(cfg.APIKey), webhookHandler.UpdateTransaction)
+		}
+
+		// Budget endpoints (require API key to modify)
+		budgets := v1.Group("/budgets")
+		{
+			budgets.GET("", budgetHandler.ListBudgets)
+			budgets.GET("/:id", budgetHandler.GetBudget)
+			budgets.POST("", middleware.APIKeyAuth(cfg.APIKey), budgetHandler.CreateBudget)
+			budgets.PUT("/:id", middleware.APIKeyAuth(cfg.APIKey), budgetHandler.UpdateBudget)
+			budgets.DELETE("/:id", middleware.APIKeyAuth(cfg.APIKey), budgetHandler.DeleteBudget)
+		}
+
+		// Scheduled transaction endpoints (require API key to modify)
+		scheduled := v1.Group("/scheduled")
+		{
+			scheduled.GET("", scheduledHandler.ListScheduled)
+			scheduled.GET("/:id", scheduledHandler.GetScheduled)
+			scheduled.GET("/:id/payments", scheduledHandler.ListPayments)
+			scheduled.POST("", middleware.APIKeyAuth(cfg.APIKey), scheduledHandler.CreateScheduled)
+			scheduled.PUT("/:id", middleware.APIKeyAuth(cfg.APIKey), scheduledHandler.UpdateScheduled)
+			scheduled.DELETE("/:id", middleware.APIKeyAuth(cfg.APIKey), scheduledHandler.DeleteScheduled)
+		}
+
+		// Envelope endpoints (require API key to modify)
+		envelopes := v1.Group("/envelopes")
+		{
+			envelopes.GET("", envelopeHandler.ListEnvelopes)
+			envelopes.GET("/:id", envelopeHandler.GetEnvelope)
+			envelopes.GET("/:id/allocations", envelopeHandler.ListAllocations)
+			envelopes.POST("", middleware.APIKeyAuth(cfg.APIKey), envelopeHandler.CreateEnvelope)
+			envelopes.PUT("/:id", middleware.APIKeyAuth(cfg.APIKey), envelopeHandler.UpdateEnvelope)
+			envelopes.DELETE("/:id", middleware.APIKeyAuth(cfg.APIKey), envelopeHandler.DeleteEnvelope)
+			envelopes.POST("/:id/allocations", middleware.APIKeyAuth(cfg.APIKey), envelopeHandler.Allocate)
+			envelopes.POST("/:id/moves", middleware.APIKeyAuth(cfg.APIKey), envelopeHandler.Move)
+		}
+
+		// Payee directory endpoints (require API key to modify)
+		payees := v1.Group("/payees")
+		{
+			payees.GET("", payeeHandler.ListPayees)
+			payees.GET("/:id", payeeHandler.GetPayee)
+			payees.POST("", middleware.APIKeyAuth(cfg.APIKey), payeeHandler.CreatePayee)
+			payees.PUT("/:id", middleware.APIKeyAuth(cfg.APIKey), payeeHandler.UpdatePayee)
+			payees.DELETE("/:id", middleware.APIKeyAuth(cfg.APIKey), payeeHandler.DeletePayee)
+			payees.POST("/:id/merge", middleware.APIKeyAuth(cfg.APIKey), payeeHandler.MergePayees)
 		}
+
+		// Savings goal endpoints (require API key to modify)
+		goals := v1.Group("/goals")
+		{
+			goals.GET("", goalHandler.ListGoals)
+			goals.GET("/:id", goalHandler.GetGoal)
+			goals.GET("/:id/progress", goalHandler.GetProgress)
+			goals.GET("/:id/contributions", goalHandler.ListContributions)
+			goals.POST("", middleware.APIKeyAuth(cfg.APIKey), goalHandler.CreateGoal)
+			goals.PUT("/:id", middleware.APIKeyAuth(cfg.APIKey), goalHandler.UpdateGoal)
+			goals.DELETE("/:id", middleware.APIKeyAuth(cfg.APIKey), goalHandler.DeleteGoal)
+			goals.POST("/:id/contributions", middleware.APIKeyAuth(cfg.APIKey), goalHandler.AddContribution)
+		}
+	}
+
+	if cfg.Metrics.Enabled && cfg.Metrics.Port != "" && cfg.Metrics.Port == cfg.Server.Port {
+		log.Fatal().Msg("metrics.port must differ from server.port")
 	}
 
 	// Create HTTP server
@@ -211,12 +597,34 @@ func main() {
 		}
 	}()
 
+	// Serve /metrics on the admin port, kept off the public load balancer
+	var adminServer *http.Server
+	if cfg.Metrics.Enabled && cfg.Metrics.Port != "" {
+		adminMux := http.NewServeMux()
+		adminMux.Handle("/metrics", metrics.Handler())
+		adminServer = &http.Server{
+			Addr:              fmt.Sprintf(":%s", cfg.Metrics.Port),
+			Handler:           adminMux,
+			ReadHeaderTimeout: 5 * time.Second,
+			WriteTimeout:      time.Duration(cfg.Server.Timeout) * time.Second,
+			IdleTimeout:       60 * time.Second,
+		}
+		go func() {
+			log.Info().Str("address", adminServer.Addr).Msg("Metrics server starting")
+			if err := adminServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatal().Err(err).Msg("Failed to start metrics server")
+			}
+		}()
+	}
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Info().Msg("Shutting down server...")
+	stopAnalyzers()
+	jobs.Stop(30 * time.Second)
 
 	// Give outstanding requests 30 seconds to complete
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -225,6 +633,11 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Error().Err(err).Msg("Server forced to shutdown")
 	}
+	if adminServer != nil {
+		if err := adminServer.Shutdown(ctx); err != nil {
+			log.Error().Err(err).Msg("Metrics server forced to shutdown")
+		}
+	}
 
 	// Close database connection
 	closeErr := sqlDB.Close()
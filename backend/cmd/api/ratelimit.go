@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/config"
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// rateLimits holds the store and the rate limit policies of the route groups
+type rateLimits struct {
+	store  middleware.RateLimitStore
+	public domain.RateLimitPolicy
+	apiKey domain.RateLimitPolicy
+	auth   domain.RateLimitPolicy
+	user   domain.RateLimitPolicy
+}
+
+// newRateLimits builds the configured policies and their store. The Postgres
+// store is cleaned up by a scheduled job that deletes buckets which have
+// refilled completely.
+func newRateLimits(cfg *config.Config, db *gorm.DB, jobs *scheduler) (*rateLimits, error) {
+	limits := &rateLimits{
+		public: rateLimitPolicy("public", cfg.RateLimit.Public),
+		apiKey: rateLimitPolicy("api_key", cfg.RateLimit.APIKey),
+		auth:   rateLimitPolicy("auth", cfg.RateLimit.Auth),
+		user:   rateLimitPolicy("user", cfg.RateLimit.User),
+	}
+
+	var idle time.Duration
+	for _, policy := range []domain.RateLimitPolicy{limits.public, limits.apiKey, limits.auth, limits.user} {
+		if err := policy.Validate(); err != nil {
+			return nil, err
+		}
+		if policy.Enabled() {
+			idle = max(idle, policy.RefillTime())
+		}
+	}
+
+	switch cfg.RateLimit.Store {
+	case "memory":
+		limits.store = middleware.NewMemoryRateLimitStore()
+	case "postgres":
+		repo := repository.NewRateLimitRepository(db)
+		limits.store = repo
+		jobs.Every("rate-limit-buckets", 10*time.Minute, func(ctx context.Context) error {
+			_, err := repo.DeleteIdle(idle)
+			return err
+		})
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q, use memory or postgres", cfg.RateLimit.Store)
+	}
+
+	return limits, nil
+}
+
+func rateLimitPolicy(name string, policy config.RateLimitPolicy) domain.RateLimitPolicy {
+	return domain.RateLimitPolicy{
+		Name:     name,
+		Requests: policy.Requests,
+		Period:   time.Duration(policy.Period) * time.Second,
+		Burst:    policy.Burst,
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+)
+
+// scheduler runs background jobs at fixed intervals. Stop cancels the jobs'
+// context and waits for running jobs to return, so the process exits only
+// after in-flight work such as an email delivery has ended.
+type scheduler struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newScheduler() *scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &scheduler{ctx: ctx, cancel: cancel}
+}
+
+// Every runs job after interval and then every interval until Stop.
+// Errors are logged; a failing job runs again at its next tick.
+func (s *scheduler) Every(name string, interval time.Duration, job func(ctx context.Context) error) {
+	log := logger.Get()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+				if err := job(s.ctx); err != nil && s.ctx.Err() == nil {
+					log.Error().Err(err).Str("job", name).Msg("Scheduled job failed")
+				}
+			}
+		}
+	}()
+}
+
+// Stop cancels all jobs and waits up to timeout for them to return
+func (s *scheduler) Stop(timeout time.Duration) {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log := logger.Get()
+		log.Warn().Msg("Scheduled jobs did not stop in time")
+	}
+}
@@ -0,0 +1,106 @@
+// Command export streams transactions to a file or stdout in any export
+// format, including Beancount, hledger and ledger journals mapped through the
+// ledger section of the config file.
+//
+//	go run ./cmd/export -format beancount -o finances.beancount
+//	go run ./cmd/export -format hledger -start 2026-01-01 -end 2026-03-31
+//	go run ./cmd/export -format csv -columns transaction_date,amount -locale de-DE
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/config"
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+func main() {
+	// Parse command line flags
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	output := flag.String("o", "", "Output file (default: stdout)")
+	format := flag.String("format", "beancount", "Export format: csv, xlsx, ndjson, beancount, hledger or ledger")
+	txType := flag.String("type", "", "Only export in or out transactions")
+	source := flag.String("source", "", "Only export transactions of this source")
+	category := flag.String("category", "", "Only export transactions of this category")
+	startDate := flag.String("start", "", "Earliest transaction date (RFC3339 or YYYY-MM-DD)")
+	endDate := flag.String("end", "", "Latest transaction date (RFC3339 or YYYY-MM-DD)")
+	columns := flag.String("columns", "", "Comma-separated columns for csv, xlsx and ndjson")
+	locale := flag.String("locale", "", "Number formatting of csv exports, e.g. de-DE")
+	flag.Parse()
+
+	params := &domain.ExportQueryParams{
+		ListTransactionsQueryParams: domain.ListTransactionsQueryParams{
+			Type:      domain.TransactionType(*txType),
+			Source:    *source,
+			Category:  *category,
+			StartDate: *startDate,
+			EndDate:   *endDate,
+		},
+		Format:  domain.ExportFormat(*format),
+		Columns: *columns,
+		Locale:  *locale,
+	}
+	if err := params.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger.Init(cfg)
+
+	ledgerMapping := domain.NewLedgerMapping(cfg.Ledger.Currency, cfg.Ledger.Accounts, cfg.Ledger.Categories)
+	if err := ledgerMapping.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid ledger configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Connect to database
+	db, err := gorm.Open(postgres.Open(cfg.DatabaseDSN()), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Warn),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+
+	exportService := service.NewExportService(repository.NewTransactionRepository(db), ledgerMapping)
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create output file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	buffered := bufio.NewWriter(out)
+	if err := exportService.Export(context.Background(), buffered, params); err != nil {
+		fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
+		os.Exit(1)
+	}
+	if err := buffered.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,187 @@
+// Command import loads a bank statement file (CSV, OFX/QFX or QIF) into the
+// database and prints how many rows were created, skipped and failed.
+//
+//	go run ./cmd/import -file statement.ofx -dry-run
+//	go run ./cmd/import -file export.qif -source "Cash" -date-format 02/01/2006
+//	go run ./cmd/import -file vcb.csv -profile 1
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/config"
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+func main() {
+	// Parse command line flags
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	filePath := flag.String("file", "", "Statement file to import")
+	format := flag.String("format", "", "File format: csv, ofx or qif (default: from the file extension)")
+	source := flag.String("source", "", "Bank/wallet recorded on imported transactions (OFX: defaults to the file's institution)")
+	account := flag.String("account", "", "Source account recorded on imported transactions")
+	dateFormat := flag.String("date-format", "", "QIF date layout in Go format (default 1/2/2006)")
+	profileID := flag.Int64("profile", 0, "CSV import profile ID")
+	mapping := flag.String("mapping", "", "CSV column mapping as JSON")
+	dryRun := flag.Bool("dry-run", false, "Validate and report without storing anything")
+	flag.Parse()
+
+	if *filePath == "" {
+		fmt.Fprintln(os.Stderr, "-file is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *format == "" {
+		*format = formatFromExtension(*filePath)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger.Init(cfg)
+
+	// Connect to database
+	db, err := gorm.Open(postgres.Open(cfg.DatabaseDSN()), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Warn),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Imported transactions go through the same hooks as the API; webhook
+	// deliveries are queued here and sent by the API server, which also
+	// pushes the transactions to live streams
+	txRepo := repository.NewTransactionRepository(db)
+	anomalyRepo := repository.NewAnomalyRepository(db)
+	scheduledService := service.NewScheduledService(repository.NewScheduledRepository(db))
+	anomalyService := service.NewAnomalyService(anomalyRepo, cfg.Analyzer.LookbackMonths)
+	payeeService := service.NewPayeeService(repository.NewPayeeRepository(db))
+	webhookPolicy, err := security.NewAddressPolicy(cfg.Webhooks.AllowedNetworks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid webhooks configuration: %v\n", err)
+		os.Exit(1)
+	}
+	outgoingWebhookService := service.NewOutgoingWebhookService(repository.NewOutgoingWebhookRepository(db),
+		repository.NewBudgetRepository(db), anomalyRepo, webhookPolicy, time.Duration(cfg.Webhooks.Timeout)*time.Second, cfg.Webhooks.MaxAttempts)
+	streamService := service.NewStreamService(repository.NewStreamRepository(db, cfg.DatabaseDSN()), txRepo)
+	txService := service.NewTransactionService(txRepo,
+		payeeService, scheduledService, anomalyService, outgoingWebhookService, streamService)
+	importService := service.NewImportService(repository.NewImportRepository(db), txService)
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open file: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+
+	statement := &domain.StatementImportRequest{
+		Source:        *source,
+		SourceAccount: *account,
+		DateFormat:    *dateFormat,
+		DryRun:        *dryRun,
+	}
+
+	var result *domain.ImportResult
+	switch *format {
+	case "csv":
+		var req *domain.CSVImportRequest
+		req, err = csvRequest(*profileID, *mapping, *source, *account, *dryRun)
+		if err == nil {
+			result, err = importService.ImportCSV(file, req)
+		}
+	case "ofx":
+		result, err = importService.ImportOFX(file, statement)
+	case "qif":
+		result, err = importService.ImportQIF(file, statement)
+	default:
+		err = fmt.Errorf("unsupported format %q (use csv, ofx or qif)", *format)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Import failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	printReport(os.Stdout, result)
+}
+
+// formatFromExtension infers the import format from a file name
+func formatFromExtension(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ofx", ".qfx":
+		return "ofx"
+	case ".qif":
+		return "qif"
+	default:
+		return "csv"
+	}
+}
+
+// csvRequest builds a CSV import request from a saved profile or an inline
+// JSON mapping. -source and -account override the mapping's values.
+func csvRequest(profileID int64, mappingJSON, source, account string, dryRun bool) (*domain.CSVImportRequest, error) {
+	req := &domain.CSVImportRequest{ProfileID: profileID, DryRun: dryRun}
+	if mappingJSON == "" {
+		return req, nil
+	}
+
+	req.Mapping = &domain.ImportMapping{}
+	if err := json.Unmarshal([]byte(mappingJSON), req.Mapping); err != nil {
+		return nil, fmt.Errorf("-mapping must be a JSON object: %w", err)
+	}
+	if source != "" {
+		req.Mapping.Source = source
+	}
+	if account != "" {
+		req.Mapping.SourceAccount = account
+	}
+	return req, nil
+}
+
+// printReport writes the created, skipped and failed counts followed by the skipped and failed rows
+func printReport(w io.Writer, result *domain.ImportResult) {
+	created := result.Imported
+	if result.DryRun {
+		created = result.Valid
+		fmt.Fprintln(w, "Dry run: nothing was stored")
+	}
+
+	fmt.Fprintf(w, "Rows:    %d\n", result.Total)
+	fmt.Fprintf(w, "Created: %d\n", created)
+	fmt.Fprintf(w, "Skipped: %d (duplicates)\n", result.Duplicates)
+	fmt.Fprintf(w, "Failed:  %d\n", result.Errors)
+
+	for _, row := range result.Rows {
+		switch row.Status {
+		case domain.ImportRowStatusError:
+			fmt.Fprintf(w, "  line %d: %s\n", row.Line, row.Error)
+		case domain.ImportRowStatusDuplicate:
+			if row.DuplicateOf != nil {
+				fmt.Fprintf(w, "  line %d: skipped, matches transaction %d\n", row.Line, *row.DuplicateOf)
+			} else {
+				fmt.Fprintf(w, "  line %d: skipped, repeated in file\n", row.Line)
+			}
+		}
+	}
+}
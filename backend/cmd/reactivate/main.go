@@ -0,0 +1,74 @@
+// Command reactivate makes a deactivated account active again, cancelling
+// the removal of its sign-in account if it was closed.
+//
+//	go run ./cmd/reactivate -email user@example.com
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/config"
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+func main() {
+	// Parse command line flags
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	email := flag.String("email", "", "Email of the account to reactivate")
+	flag.Parse()
+
+	if *email == "" {
+		fmt.Fprintln(os.Stderr, "Specify -email")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger.Init(cfg)
+
+	// Connect to database
+	db, err := gorm.Open(postgres.Open(cfg.DatabaseDSN()), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Warn),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Reactivation sends no links and deletes nothing
+	userRepo := repository.NewUserRepository(db)
+	accountService := service.NewAccountService(
+		userRepo,
+		repository.NewAccountRepository(db),
+		repository.NewEmailChangeRepository(db),
+		service.NewSecurityService(repository.NewSecurityRepository(db), userRepo, domain.LoginPolicy{}),
+		nil, "", 0, 0,
+	)
+
+	err = accountService.Reactivate(*email)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		fmt.Fprintf(os.Stderr, "No account with email %s; deleted accounts cannot be restored\n", *email)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to reactivate %s: %v\n", *email, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Reactivated %s\n", *email)
+}
@@ -0,0 +1,97 @@
+// Command report generates the monthly statement offline, as an HTML page or
+// a PDF document.
+//
+//	go run ./cmd/report -month 2026-03 -format pdf -o statement-2026-03.pdf
+//	go run ./cmd/report -o statement.html
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/config"
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+func main() {
+	// Parse command line flags
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	output := flag.String("o", "", "Output file (default: stdout)")
+	month := flag.String("month", "", "Month of the statement, YYYY-MM (default: current month)")
+	format := flag.String("format", "html", "Output format: html or pdf")
+	flag.Parse()
+
+	params := &domain.MonthlyReportQueryParams{
+		Month:  *month,
+		Format: domain.ReportFormat(*format),
+	}
+	if err := params.Validate(time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid options: %v\n", err)
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger.Init(cfg)
+
+	// Connect to database
+	db, err := gorm.Open(postgres.Open(cfg.DatabaseDSN()), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Warn),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+
+	reportService := service.NewReportService(
+		repository.NewTransactionRepository(db),
+		repository.NewPayeeRepository(db),
+		repository.NewBudgetRepository(db),
+		cfg.Ledger.Currency,
+		cfg.Reports.FontPath,
+	)
+
+	report, err := reportService.GetMonthlyReport(context.Background(), params)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to build report: %v\n", err)
+		os.Exit(1)
+	}
+
+	var out io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create output file: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	buffered := bufio.NewWriter(out)
+	if err := reportService.RenderMonthlyReport(buffered, report, params.Format); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to render report: %v\n", err)
+		os.Exit(1)
+	}
+	if err := buffered.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report: %v\n", err)
+		os.Exit(1)
+	}
+}
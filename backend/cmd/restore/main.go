@@ -0,0 +1,92 @@
+// Command restore brings a takeout archive into an account and the empty
+// ledger of this instance. Unlike the API it has no upload size limit.
+//
+//	go run ./cmd/restore -email user@example.com -file takeout-20260310.zip
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/config"
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+func main() {
+	// Parse command line flags
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	email := flag.String("email", "", "Email of the account to restore into")
+	filePath := flag.String("file", "", "Takeout archive to restore")
+	flag.Parse()
+
+	if *email == "" || *filePath == "" {
+		fmt.Fprintln(os.Stderr, "Specify -email and -file")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger.Init(cfg)
+
+	// Connect to database
+	db, err := gorm.Open(postgres.Open(cfg.DatabaseDSN()), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Warn),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+
+	userRepo := repository.NewUserRepository(db)
+	user, err := userRepo.FindByEmail(domain.SanitizeEmail(*email))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		fmt.Fprintf(os.Stderr, "No account with email %s; register it first\n", *email)
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to find %s: %v\n", *email, err)
+		os.Exit(1)
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open archive: %v\n", err)
+		os.Exit(1)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read archive: %v\n", err)
+		os.Exit(1)
+	}
+
+	takeoutService := service.NewTakeoutService(userRepo, repository.NewTakeoutRepository(db),
+		repository.NewTransactionRepository(db), repository.NewPayeeRepository(db), repository.NewBudgetRepository(db),
+		0, 0)
+	result, err := takeoutService.Restore(user.ID, file, info.Size())
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			err = errors.New(validationErr.Message)
+		}
+		fmt.Fprintf(os.Stderr, "Failed to restore %s: %v\n", *filePath, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Restored %d transactions, %d payees and %d budgets into %s\n",
+		result.Transactions, result.Payees, result.Budgets, user.Email)
+}
@@ -0,0 +1,79 @@
+// Command unlock lifts the lockout of an account, or the login delay of a
+// client IP, after failed logins.
+//
+//	go run ./cmd/unlock -email user@example.com
+//	go run ./cmd/unlock -ip 203.0.113.7
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/config"
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+func main() {
+	// Parse command line flags
+	configPath := flag.String("config", "config.yaml", "Path to configuration file")
+	email := flag.String("email", "", "Email of the account to unlock")
+	ip := flag.String("ip", "", "Client IP whose login delay to lift")
+	flag.Parse()
+
+	if (*email == "") == (*ip == "") {
+		fmt.Fprintln(os.Stderr, "Specify either -email or -ip")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger.Init(cfg)
+
+	// Connect to database
+	db, err := gorm.Open(postgres.Open(cfg.DatabaseDSN()), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Warn),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Unlocking does not depend on the login policy
+	securityService := service.NewSecurityService(
+		repository.NewSecurityRepository(db),
+		repository.NewUserRepository(db),
+		domain.LoginPolicy{},
+	)
+
+	target := *email
+	if *email != "" {
+		err = securityService.Unlock(*email)
+	} else {
+		target = *ip
+		err = securityService.UnlockIP(*ip)
+	}
+	if errors.Is(err, repository.ErrLoginThrottleNotFound) {
+		fmt.Printf("%s has no failed logins on record\n", target)
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to unlock %s: %v\n", target, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Unlocked %s\n", target)
+}
@@ -18,6 +18,7 @@ type Config struct {
 		Port           string   `mapstructure:"port"`
 		Mode           string   `mapstructure:"mode"`            // debug, release
 		AllowedOrigins []string `mapstructure:"allowed_origins"` // CORS allowed origins
+		TrustedProxies []string `mapstructure:"trusted_proxies"` // proxies allowed to set X-Forwarded-For, none by default
 	} `mapstructure:"server"`
 
 	// Secrets (from .env only)
@@ -41,6 +42,106 @@ type Config struct {
 		LogLevel  string `mapstructure:"log_level"`  // debug, info, warn, error
 		LogFormat string `mapstructure:"log_format"` // json, text
 	} `mapstructure:"app"`
+
+	// Background analyzer config (from config file, can be overridden by env vars)
+	Analyzer struct {
+		RecurringInterval int `mapstructure:"recurring_interval"` // minutes between recurring detection runs, 0 disables
+		LookbackMonths    int `mapstructure:"lookback_months"`    // history scanned by analyzers
+	} `mapstructure:"analyzer"`
+
+	// Plain-text accounting export config (from config file)
+	Ledger struct {
+		Currency   string            `mapstructure:"currency"`   // commodity of all postings, e.g. VND
+		Accounts   map[string]string `mapstructure:"accounts"`   // "source" or "source/account" → asset account
+		Categories map[string]string `mapstructure:"categories"` // category → expense/income account
+	} `mapstructure:"ledger"`
+
+	// Monthly statement config (from config file)
+	Reports struct {
+		FontPath string `mapstructure:"font_path"` // optional UTF-8 TrueType font for PDF statements
+	} `mapstructure:"reports"`
+
+	// SMTP mailer config (from config file + env vars, password from .env only)
+	SMTP struct {
+		Host     string `mapstructure:"host"` // empty disables email
+		Port     int    `mapstructure:"port"`
+		Username string `mapstructure:"username"`
+		Password string `mapstructure:"-"` // from .env only
+		From     string `mapstructure:"from"`
+		TLS      string `mapstructure:"tls"` // none, starttls, tls
+	} `mapstructure:"smtp"`
+
+	// Email digest scheduler config (from config file, can be overridden by env vars)
+	Digest struct {
+		CheckInterval int `mapstructure:"check_interval"` // minutes between checks for due digests, 0 disables
+	} `mapstructure:"digest"`
+
+	// Outgoing webhook delivery config (from config file, can be overridden by env vars)
+	Webhooks struct {
+		PollInterval int `mapstructure:"poll_interval"` // seconds between checks for due deliveries, 0 disables
+		Timeout      int `mapstructure:"timeout"`       // seconds per delivery attempt
+		MaxAttempts  int `mapstructure:"max_attempts"`  // attempts before a delivery fails
+		// Networks in CIDR notation endpoints may be in although they are
+		// loopback, private or link-local, such as 127.0.0.0/8 for local development
+		AllowedNetworks []string `mapstructure:"allowed_networks"`
+	} `mapstructure:"webhooks"`
+
+	// Live transaction stream config (from config file, can be overridden by env vars)
+	Stream struct {
+		HeartbeatInterval int `mapstructure:"heartbeat_interval"` // seconds between heartbeats on an idle stream
+	} `mapstructure:"stream"`
+
+	// Login brute-force protection config (from config file)
+	Login struct {
+		MaxFailures     int `mapstructure:"max_failures"`     // failed logins in a row before an account locks, 0 disables lockouts
+		LockoutDuration int `mapstructure:"lockout_duration"` // seconds a locked account stays locked
+		FailureWindow   int `mapstructure:"failure_window"`   // seconds without failures after which they are forgotten
+		BaseDelay       int `mapstructure:"base_delay"`       // seconds logins are blocked after a failure, doubling with each further one
+		MaxDelay        int `mapstructure:"max_delay"`        // longest block in seconds
+	} `mapstructure:"login"`
+
+	// Password reset config (from config file, can be overridden by env vars)
+	PasswordReset struct {
+		TokenTTL int    `mapstructure:"token_ttl"` // minutes a reset link stays valid
+		URL      string `mapstructure:"url"`       // frontend page reset links point to, the token is added as ?token=
+		Notifier string `mapstructure:"notifier"`  // smtp, or log to write links to the log in development
+	} `mapstructure:"password_reset"`
+
+	// Account config (from config file, can be overridden by env vars)
+	Account struct {
+		EmailConfirmURL    string `mapstructure:"email_confirm_url"`    // frontend page email confirmation links point to, the token is added as ?token=
+		EmailTokenTTL      int    `mapstructure:"email_token_ttl"`      // minutes an email confirmation link stays valid
+		ClosureGracePeriod int    `mapstructure:"closure_grace_period"` // days between closing an account and removing its sign-in account
+	} `mapstructure:"account"`
+
+	// Takeout config (from config file, can be overridden by env vars)
+	Takeout struct {
+		SyncLimit    int `mapstructure:"sync_limit"`    // ledgers of up to this many transactions are archived while the request waits
+		PollInterval int `mapstructure:"poll_interval"` // seconds between checks for archives to build in the background
+		TTL          int `mapstructure:"ttl"`           // hours a finished archive can be downloaded
+	} `mapstructure:"takeout"`
+
+	// Prometheus metrics config (from config file, can be overridden by env vars)
+	Metrics struct {
+		Enabled bool   `mapstructure:"enabled"` // serve /metrics
+		Port    string `mapstructure:"port"`    // separate admin port for /metrics, empty serves it on the server port
+	} `mapstructure:"metrics"`
+
+	// Rate limiting config (from config file, can be overridden by env vars)
+	RateLimit struct {
+		Store  string          `mapstructure:"store"`   // memory, or postgres to share limits between replicas
+		Public RateLimitPolicy `mapstructure:"public"`  // every /api/v1 request, per client IP
+		APIKey RateLimitPolicy `mapstructure:"api_key"` // requests with the valid API key
+		Auth   RateLimitPolicy `mapstructure:"auth"`    // /api/v1/auth, per client IP
+		User   RateLimitPolicy `mapstructure:"user"`    // routes that require a JWT, per user
+	} `mapstructure:"rate_limit"`
+}
+
+// RateLimitPolicy is a token bucket of burst requests refilled at requests per period
+type RateLimitPolicy struct {
+	Requests int `mapstructure:"requests"` // 0 disables the policy
+	Period   int `mapstructure:"period"`   // seconds
+	Burst    int `mapstructure:"burst"`    // defaults to requests
 }
 
 // Load loads configuration from config file and environment variables
@@ -93,6 +194,9 @@ func Load(configPath string) (*Config, error) {
 		return nil, fmt.Errorf("JWT_SECRET is required (set in .env or environment)")
 	}
 
+	// SMTP password is optional; relays without authentication need none
+	cfg.SMTP.Password = os.Getenv("SMTP_PASSWORD")
+
 	// Step 7: Explicitly check for env var overrides for common keys
 	// This ensures Unmarshal didn't miss env vars
 	checkEnvOverrides(cfg)
@@ -139,6 +243,32 @@ func checkEnvOverrides(cfg *Config) {
 	if logFormat := os.Getenv("APP_LOG_FORMAT"); logFormat != "" {
 		cfg.App.LogFormat = logFormat
 	}
+
+	// Analyzer overrides
+	if interval := os.Getenv("ANALYZER_RECURRING_INTERVAL"); interval != "" {
+		// nolint:errcheck // Partial parse is acceptable, default value if invalid
+		fmt.Sscanf(interval, "%d", &cfg.Analyzer.RecurringInterval)
+	}
+
+	// SMTP overrides (except password)
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		cfg.SMTP.Host = host
+	}
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		// nolint:errcheck // Partial parse is acceptable, default value if invalid
+		fmt.Sscanf(port, "%d", &cfg.SMTP.Port)
+	}
+	if username := os.Getenv("SMTP_USERNAME"); username != "" {
+		cfg.SMTP.Username = username
+	}
+	if tls := os.Getenv("SMTP_TLS"); tls != "" {
+		cfg.SMTP.TLS = tls
+	}
+
+	// Rate limit overrides
+	if store := os.Getenv("RATE_LIMIT_STORE"); store != "" {
+		cfg.RateLimit.Store = store
+	}
 }
 
 // WatchConfig watches for config file changes and calls the callback
@@ -151,6 +281,7 @@ func WatchConfig(callback func(*Config)) {
 		apiKey := os.Getenv("API_KEY")
 		dbPassword := os.Getenv("DATABASE_PASSWORD")
 		jwtSecret := os.Getenv("JWT_SECRET")
+		smtpPassword := os.Getenv("SMTP_PASSWORD")
 		// if dbPassword == "" {
 		// 	dbPassword = os.Getenv("DB_PASSWORD")
 		// }
@@ -166,6 +297,7 @@ func WatchConfig(callback func(*Config)) {
 		cfg.APIKey = apiKey
 		cfg.Database.Password = dbPassword
 		cfg.JWT.Secret = jwtSecret
+		cfg.SMTP.Password = smtpPassword
 
 		// Apply env overrides
 		checkEnvOverrides(cfg)
@@ -223,6 +355,69 @@ func setDefaults() {
 	// App defaults
 	viper.SetDefault("app.log_level", "info")
 	viper.SetDefault("app.log_format", "json")
+
+	// Analyzer defaults
+	viper.SetDefault("analyzer.recurring_interval", 60)
+	viper.SetDefault("analyzer.lookback_months", 24)
+
+	// Ledger export defaults
+	viper.SetDefault("ledger.currency", "USD")
+
+	// SMTP and digest defaults
+	viper.SetDefault("smtp.port", 587)
+	viper.SetDefault("smtp.tls", "starttls")
+	viper.SetDefault("smtp.from", "Finance Tracker <no-reply@localhost>")
+	viper.SetDefault("digest.check_interval", 15)
+
+	// Outgoing webhook defaults
+	viper.SetDefault("webhooks.poll_interval", 5)
+	viper.SetDefault("webhooks.timeout", 10)
+	viper.SetDefault("webhooks.max_attempts", 8)
+	viper.SetDefault("webhooks.allowed_networks", []string{})
+
+	// Live stream defaults
+	viper.SetDefault("stream.heartbeat_interval", 15)
+
+	// Login protection defaults
+	viper.SetDefault("login.max_failures", 5)
+	viper.SetDefault("login.lockout_duration", 900)
+	viper.SetDefault("login.failure_window", 900)
+	viper.SetDefault("login.base_delay", 1)
+	viper.SetDefault("login.max_delay", 60)
+
+	// Password reset defaults
+	viper.SetDefault("password_reset.token_ttl", 60)
+	viper.SetDefault("password_reset.url", "http://localhost:3000/reset-password")
+	viper.SetDefault("password_reset.notifier", "smtp")
+
+	// Account defaults
+	viper.SetDefault("account.email_confirm_url", "http://localhost:3000/confirm-email")
+	viper.SetDefault("account.email_token_ttl", 1440)
+	viper.SetDefault("account.closure_grace_period", 30)
+
+	// Takeout defaults
+	viper.SetDefault("takeout.sync_limit", 5000)
+	viper.SetDefault("takeout.poll_interval", 30)
+	viper.SetDefault("takeout.ttl", 168)
+
+	// Metrics defaults
+	viper.SetDefault("metrics.enabled", true)
+	viper.SetDefault("metrics.port", "")
+
+	// Rate limit defaults
+	viper.SetDefault("rate_limit.store", "memory")
+	viper.SetDefault("rate_limit.public.requests", 300)
+	viper.SetDefault("rate_limit.public.period", 60)
+	viper.SetDefault("rate_limit.public.burst", 100)
+	viper.SetDefault("rate_limit.api_key.requests", 120)
+	viper.SetDefault("rate_limit.api_key.period", 60)
+	viper.SetDefault("rate_limit.api_key.burst", 60)
+	viper.SetDefault("rate_limit.auth.requests", 10)
+	viper.SetDefault("rate_limit.auth.period", 60)
+	viper.SetDefault("rate_limit.auth.burst", 5)
+	viper.SetDefault("rate_limit.user.requests", 120)
+	viper.SetDefault("rate_limit.user.period", 60)
+	viper.SetDefault("rate_limit.user.burst", 60)
 }
 
 func (c *Config) DatabaseDSN() string {
@@ -0,0 +1,192 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultEmailChangeTTL is how long an email confirmation link stays valid
+	DefaultEmailChangeTTL = 24 * time.Hour
+	// DefaultAccountClosureGracePeriod is how long a closed account can be
+	// reactivated before its sign-in account is removed
+	DefaultAccountClosureGracePeriod = 30 * 24 * time.Hour
+)
+
+// UserSettings are the preferences of a user. Clients use them to format
+// amounts and dates; empty fields fall back to the client's defaults.
+type UserSettings struct {
+	Locale   string `json:"locale,omitempty"`   // e.g. de-DE, any locale the exports support
+	Timezone string `json:"timezone,omitempty"` // IANA name, e.g. Europe/Berlin
+}
+
+// Validate checks the locale and timezone
+func (s *UserSettings) Validate() error {
+	s.Locale = strings.TrimSpace(s.Locale)
+	s.Timezone = strings.TrimSpace(s.Timezone)
+
+	if _, ok := LookupNumberFormat(s.Locale); !ok {
+		return &ValidationError{
+			Field:   "settings.locale",
+			Message: "unsupported locale " + strconv.Quote(s.Locale),
+		}
+	}
+	// LoadLocation takes "Local" for the server's zone, which means nothing to clients
+	if _, err := time.LoadLocation(s.Timezone); err != nil || s.Timezone == "Local" {
+		return &ValidationError{
+			Field:   "settings.timezone",
+			Message: "unknown timezone " + strconv.Quote(s.Timezone),
+		}
+	}
+	return nil
+}
+
+// ProfileResponse is the profile of the signed-in user
+type ProfileResponse struct {
+	CreatedAt    time.Time    `json:"created_at"`
+	LastLoginAt  *time.Time   `json:"last_login_at"`
+	Settings     UserSettings `json:"settings"`
+	Email        string       `json:"email"`
+	PendingEmail string       `json:"pending_email,omitempty"` // new email waiting for confirmation
+	Name         string       `json:"name"`
+	APIKey       string       `json:"api_key"`
+	ID           int64        `json:"id"`
+	UUID         string       `json:"uuid"`
+}
+
+// NewProfileResponse returns the profile of user
+func NewProfileResponse(user *User) *ProfileResponse {
+	return &ProfileResponse{
+		CreatedAt:   user.CreatedAt,
+		LastLoginAt: user.LastLoginAt,
+		Settings:    user.Settings,
+		Email:       user.Email,
+		Name:        user.Name,
+		APIKey:      user.APIKey,
+		ID:          user.ID,
+		UUID:        user.UUID.String(),
+	}
+}
+
+// UpdateProfileRequest is the request body for updating the profile of the
+// signed-in user. Omitted fields stay unchanged; settings replace the
+// current settings as a whole. A new email takes effect once confirmed
+// through the link sent to it, and requires the current password.
+type UpdateProfileRequest struct {
+	Name            *string       `json:"name" binding:"omitempty,max=100"`
+	Email           *string       `json:"email" binding:"omitempty,max=255"`
+	Settings        *UserSettings `json:"settings"`
+	CurrentPassword string        `json:"current_password"`
+	Client          ClientInfo    `json:"-"` // set by the handler, for brute-force protection and the security log
+}
+
+// Validate normalizes and checks the given fields
+func (r *UpdateProfileRequest) Validate() error {
+	if r.Name == nil && r.Email == nil && r.Settings == nil {
+		return &ValidationError{
+			Field:   "name",
+			Message: "name, email or settings is required",
+		}
+	}
+
+	if r.Name != nil {
+		name := strings.TrimSpace(*r.Name)
+		if utf8.RuneCountInString(name) > MaxNameLength {
+			return &ValidationError{
+				Field:   "name",
+				Message: "name must be at most " + strconv.Itoa(MaxNameLength) + " characters",
+			}
+		}
+		r.Name = &name
+	}
+
+	if r.Email != nil {
+		email := SanitizeEmail(*r.Email)
+		if len(email) > MaxEmailLength || !EmailRegex.MatchString(email) {
+			return &ValidationError{
+				Field:   "email",
+				Message: "invalid email format",
+			}
+		}
+		r.Email = &email
+		if r.CurrentPassword == "" {
+			return &ValidationError{
+				Field:   "current_password",
+				Message: "current_password is required to change the email",
+			}
+		}
+	}
+
+	if r.Settings != nil {
+		return r.Settings.Validate()
+	}
+	return nil
+}
+
+// EmailChangeToken is a single-use token that confirms a new email of a
+// user. Only its SHA-256 is stored.
+type EmailChangeToken struct {
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UsedAt    *time.Time `json:"used_at"`
+	NewEmail  string     `json:"new_email" gorm:"type:varchar(255);not null"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;unique"`
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	UserID    int64      `json:"user_id" gorm:"not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (EmailChangeToken) TableName() string {
+	return "email_change_tokens"
+}
+
+// ConfirmEmailRequest is the request body for confirming a new email with
+// the token of a confirmation link
+type ConfirmEmailRequest struct {
+	Token  string     `json:"token" binding:"required,max=128"`
+	Client ClientInfo `json:"-"` // set by the handler, for the security log
+}
+
+// Validate checks that the token is given
+func (r *ConfirmEmailRequest) Validate() error {
+	if r.Token == "" {
+		return &ValidationError{
+			Field:   "token",
+			Message: "token is required",
+		}
+	}
+	return nil
+}
+
+// CloseAccountRequest is the request body for deactivating or deleting the
+// account of the signed-in user
+type CloseAccountRequest struct {
+	Password string     `json:"password" binding:"required"`
+	Client   ClientInfo `json:"-"` // set by the handler, for brute-force protection and the security log
+}
+
+// Validate checks that the password is given
+func (r *CloseAccountRequest) Validate() error {
+	if r.Password == "" {
+		return &ValidationError{
+			Field:   "password",
+			Message: "password is required",
+		}
+	}
+	return nil
+}
+
+// SharedLedgerData lists the data closing an account keeps: the ledger is
+// shared by all users, so its records do not belong to the closed account
+var SharedLedgerData = []string{
+	"transactions", "budgets", "envelopes", "scheduled_transactions", "goals", "payees", "import_profiles",
+}
+
+// AccountClosure tells when the sign-in account of a closed account is
+// removed and which shared ledger data is kept
+type AccountClosure struct {
+	RemovalScheduledAt time.Time `json:"removal_scheduled_at"`
+	Retained           []string  `json:"retained"`
+}
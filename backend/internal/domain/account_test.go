@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		settings  UserSettings
+		wantField string
+	}{
+		{"empty", UserSettings{}, ""},
+		{"locale and timezone", UserSettings{Locale: "de-DE", Timezone: "Europe/Berlin"}, ""},
+		{"utc", UserSettings{Timezone: "UTC"}, ""},
+		{"unsupported locale", UserSettings{Locale: "xx-XX"}, "settings.locale"},
+		{"unknown timezone", UserSettings{Timezone: "Mars/Olympus"}, "settings.timezone"},
+		{"server local time", UserSettings{Timezone: "Local"}, "settings.timezone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.settings.Validate()
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestUpdateProfileRequest_Validate(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name      string
+		req       UpdateProfileRequest
+		wantField string
+	}{
+		{"name", UpdateProfileRequest{Name: ptr("New Name")}, ""},
+		{"settings", UpdateProfileRequest{Settings: &UserSettings{Locale: "en-US"}}, ""},
+		{"email with password", UpdateProfileRequest{Email: ptr("new@example.com"), CurrentPassword: "Password123"}, ""},
+		{"nothing to change", UpdateProfileRequest{}, "name"},
+		{"name too long", UpdateProfileRequest{Name: ptr(strings.Repeat("ä", MaxNameLength+1))}, "name"},
+		{"invalid email", UpdateProfileRequest{Email: ptr("not-an-email"), CurrentPassword: "Password123"}, "email"},
+		{"email without password", UpdateProfileRequest{Email: ptr("new@example.com")}, "current_password"},
+		{"invalid settings", UpdateProfileRequest{Settings: &UserSettings{Timezone: "Nowhere"}}, "settings.timezone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestUpdateProfileRequest_Validate_Normalizes(t *testing.T) {
+	name := "  New Name  "
+	email := "  New@Example.COM "
+	req := UpdateProfileRequest{Name: &name, Email: &email, CurrentPassword: "Password123"}
+
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "New Name", *req.Name)
+	assert.Equal(t, "new@example.com", *req.Email)
+}
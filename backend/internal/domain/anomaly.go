@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"time"
+)
+
+// AnomalyReason identifies why a transaction was flagged as unusual
+type AnomalyReason string
+
+const (
+	AnomalyReasonCategoryAmount  AnomalyReason = "category_amount"  // Far above the category's usual amounts
+	AnomalyReasonRecipientAmount AnomalyReason = "recipient_amount" // Far above the recipient's usual amounts
+	AnomalyReasonNewRecipient    AnomalyReason = "new_recipient"    // First payment to a recipient with a large amount
+	AnomalyReasonBurst           AnomalyReason = "burst"            // Many expenses within a short window
+)
+
+// ValidAnomalyReasons contains all anomaly reasons
+var ValidAnomalyReasons = map[AnomalyReason]bool{
+	AnomalyReasonCategoryAmount:  true,
+	AnomalyReasonRecipientAmount: true,
+	AnomalyReasonNewRecipient:    true,
+	AnomalyReasonBurst:           true,
+}
+
+const (
+	// DefaultAnomalyDays is the default window for listing anomalies
+	DefaultAnomalyDays = 30
+	// MaxAnomalyDays is the longest window for listing anomalies
+	MaxAnomalyDays = 365
+)
+
+// TransactionAnomaly records one reason a transaction was flagged
+type TransactionAnomaly struct {
+	CreatedAt     time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	Reason        AnomalyReason `json:"reason" gorm:"type:varchar(30);not null"`
+	Detail        string        `json:"detail" gorm:"type:varchar(255)"`
+	ID            int64         `json:"id" gorm:"primaryKey"`
+	TransactionID int64         `json:"transaction_id" gorm:"not null;index"`
+	Score         float64       `json:"score"` // How far the transaction exceeds the threshold; 1 means exactly at it
+}
+
+// TableName specifies the table name for GORM
+func (TransactionAnomaly) TableName() string {
+	return "transaction_anomalies"
+}
+
+// AnomalyQueryParams represents query parameters for the anomalies endpoint
+type AnomalyQueryParams struct {
+	Reason AnomalyReason `form:"reason"`
+	Days   int           `form:"days,default=30"`
+}
+
+// Validate validates the query parameters
+func (p *AnomalyQueryParams) Validate() error {
+	if p.Days < 1 || p.Days > MaxAnomalyDays {
+		return &ValidationError{
+			Field:   "days",
+			Message: "days must be between 1 and 365",
+		}
+	}
+
+	if p.Reason != "" && !ValidAnomalyReasons[p.Reason] {
+		return &ValidationError{
+			Field:   "reason",
+			Message: "reason must be one of: category_amount, recipient_amount, new_recipient, burst",
+		}
+	}
+
+	return nil
+}
+
+// AnomalousTransaction is a flagged transaction with all of its reasons
+type AnomalousTransaction struct {
+	Transaction Transaction          `json:"transaction"`
+	Reasons     []TransactionAnomaly `json:"reasons"`
+}
+
+// AnomaliesResponse is the response for the anomalies endpoint
+type AnomaliesResponse struct {
+	Data  []AnomalousTransaction `json:"data"`
+	Days  int                    `json:"days"`
+	Total int                    `json:"total"`
+}
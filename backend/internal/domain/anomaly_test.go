@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test AnomalyQueryParams Validate
+
+func TestAnomalyQueryParams_Validate_Valid(t *testing.T) {
+	params := &AnomalyQueryParams{Days: 30, Reason: AnomalyReasonBurst}
+
+	assert.NoError(t, params.Validate())
+}
+
+func TestAnomalyQueryParams_Validate_DaysOutOfRange(t *testing.T) {
+	params := &AnomalyQueryParams{Days: 400}
+
+	err := params.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "days", validationErr.Field)
+}
+
+func TestAnomalyQueryParams_Validate_UnknownReason(t *testing.T) {
+	params := &AnomalyQueryParams{Days: 30, Reason: "weekend"}
+
+	err := params.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "reason", validationErr.Field)
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	// BudgetWarningThreshold is the percent used at which a budget is flagged as a warning
+	BudgetWarningThreshold = 80.0
+)
+
+// Budget status values reported by the budget progress endpoint
+const (
+	BudgetStatusOnTrack  = "on_track"
+	BudgetStatusWarning  = "warning"
+	BudgetStatusExceeded = "exceeded"
+)
+
+// Budget is a monthly spending cap for a category, optionally scoped to a source
+type Budget struct {
+	Category  string    `json:"category" gorm:"type:varchar(50);not null;uniqueIndex:idx_budgets_category_source"`
+	Source    string    `json:"source" gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_budgets_category_source"` // Empty means all sources
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID        int64     `json:"id" gorm:"primaryKey"`
+	Amount    float64   `json:"amount" gorm:"type:decimal(15,2);not null"` // Monthly amount
+}
+
+// TableName specifies the table name for GORM
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// BudgetRequest is the request body for creating or updating a budget
+type BudgetRequest struct {
+	Category string  `json:"category" binding:"required,max=50"`
+	Source   string  `json:"source" binding:"omitempty,max=100"`
+	Amount   float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *BudgetRequest) Validate() error {
+	if r.Category == "" || !ValidCategories[r.Category] {
+		return &ValidationError{
+			Field:   "category",
+			Message: "invalid category. Valid categories are: Food, Transportation, Housing, Utilities, Entertainment, Healthcare, Shopping, Education, Salary, Investment, Transfer, Other",
+		}
+	}
+
+	if r.Amount <= 0 {
+		return &ValidationError{
+			Field:   "amount",
+			Message: "amount must be greater than zero",
+		}
+	}
+
+	if r.Amount > MaxAmount {
+		return &ValidationError{
+			Field:   "amount",
+			Message: "amount exceeds maximum allowed value",
+		}
+	}
+
+	if len(r.Source) > MaxSourceLength {
+		return &ValidationError{
+			Field:   "source",
+			Message: "source is too long",
+		}
+	}
+
+	return nil
+}
+
+// ToBudget converts BudgetRequest to Budget domain
+func (r *BudgetRequest) ToBudget() *Budget {
+	return &Budget{
+		Category: r.Category,
+		Source:   r.Source,
+		Amount:   r.Amount,
+	}
+}
+
+// CategorySpending is the amount spent for a category and source in a period
+type CategorySpending struct {
+	Category string  `json:"category"`
+	Source   string  `json:"source"`
+	Amount   float64 `json:"amount"`
+}
+
+// BudgetProgress reports how much of a budget has been used in the current period
+type BudgetProgress struct {
+	Category       string  `json:"category"`
+	Source         string  `json:"source"`
+	Status         string  `json:"status"` // on_track, warning, exceeded
+	ID             int64   `json:"id"`
+	Amount         float64 `json:"amount"`
+	Spent          float64 `json:"spent"`
+	Remaining      float64 `json:"remaining"`
+	PercentUsed    float64 `json:"percent_used"`
+	ProjectedSpend float64 `json:"projected_spend"` // Linear projection to month end
+}
+
+// BudgetProgressResponse is the response for the budget progress endpoint
+type BudgetProgressResponse struct {
+	Period       string           `json:"period"` // YYYY-MM
+	PeriodStart  time.Time        `json:"period_start"`
+	PeriodEnd    time.Time        `json:"period_end"`
+	Budgets      []BudgetProgress `json:"budgets"`
+	DaysElapsed  int              `json:"days_elapsed"`
+	DaysInPeriod int              `json:"days_in_period"`
+	TotalBudget  float64          `json:"total_budget"`
+	TotalSpent   float64          `json:"total_spent"`
+}
+
+// MonthPeriod returns the first and last instant of the calendar month containing t (UTC)
+func MonthPeriod(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+	return start, end
+}
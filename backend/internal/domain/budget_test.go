@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test BudgetRequest Validate
+
+func TestBudgetRequest_Validate_Valid(t *testing.T) {
+	req := &BudgetRequest{Category: "Food", Amount: 5000000}
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestBudgetRequest_Validate_WithSource(t *testing.T) {
+	req := &BudgetRequest{Category: "Transportation", Source: "MoMo", Amount: 1000000}
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestBudgetRequest_Validate_InvalidCategory(t *testing.T) {
+	req := &BudgetRequest{Category: "Gambling", Amount: 100}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "category", validationErr.Field)
+}
+
+func TestBudgetRequest_Validate_EmptyCategory(t *testing.T) {
+	req := &BudgetRequest{Amount: 100}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "category", validationErr.Field)
+}
+
+func TestBudgetRequest_Validate_ExcessiveAmount(t *testing.T) {
+	req := &BudgetRequest{Category: "Food", Amount: MaxAmount + 1}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "amount", validationErr.Field)
+}
+
+func TestBudgetRequest_ToBudget(t *testing.T) {
+	req := &BudgetRequest{Category: "Food", Source: "Bank ABC", Amount: 300}
+
+	budget := req.ToBudget()
+
+	assert.Equal(t, "Food", budget.Category)
+	assert.Equal(t, "Bank ABC", budget.Source)
+	assert.Equal(t, 300.0, budget.Amount)
+}
+
+func TestBudget_TableName(t *testing.T) {
+	assert.Equal(t, "budgets", Budget{}.TableName())
+}
+
+// Test MonthPeriod
+
+func TestMonthPeriod(t *testing.T) {
+	start, end := MonthPeriod(time.Date(2026, 2, 14, 10, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, 28, end.Day())
+	assert.Equal(t, time.February, end.Month())
+	assert.Equal(t, 23, end.Hour())
+}
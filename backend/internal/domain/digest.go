@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// DigestFrequency is how often a digest email is sent
+type DigestFrequency string
+
+// Supported digest frequencies
+const (
+	DigestWeekly  DigestFrequency = "weekly"
+	DigestMonthly DigestFrequency = "monthly"
+)
+
+// Number of rows in the ranked sections of a digest
+const (
+	DigestTopCategories = 5
+	DigestMaxAnomalies  = 10
+)
+
+// DigestSubscription records which digests a user receives. Users without a
+// subscription receive none. The last period fields hold the key of the
+// most recent digest sent, so a period is never mailed twice.
+type DigestSubscription struct {
+	LastSentAt        *time.Time `json:"last_sent_at"`
+	CreatedAt         time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	LastWeeklyPeriod  string     `json:"last_weekly_period" gorm:"type:varchar(10);not null;default:''"`  // e.g. 2026-W10
+	LastMonthlyPeriod string     `json:"last_monthly_period" gorm:"type:varchar(10);not null;default:''"` // e.g. 2026-03
+	UserID            int64      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	Weekly            bool       `json:"weekly" gorm:"not null;default:false"`
+	Monthly           bool       `json:"monthly" gorm:"not null;default:false"`
+}
+
+// TableName specifies the table name for GORM
+func (DigestSubscription) TableName() string {
+	return "digest_subscriptions"
+}
+
+// Wants reports whether the subscription includes digests of frequency
+func (s *DigestSubscription) Wants(frequency DigestFrequency) bool {
+	if frequency == DigestWeekly {
+		return s.Weekly
+	}
+	return s.Monthly
+}
+
+// LastPeriod returns the key of the last digest of frequency sent
+func (s *DigestSubscription) LastPeriod(frequency DigestFrequency) string {
+	if frequency == DigestWeekly {
+		return s.LastWeeklyPeriod
+	}
+	return s.LastMonthlyPeriod
+}
+
+// DigestPreferencesRequest is the request body for opting in or out of digests
+type DigestPreferencesRequest struct {
+	Weekly  *bool `json:"weekly"`
+	Monthly *bool `json:"monthly"`
+}
+
+// Validate checks that at least one preference is given
+func (r *DigestPreferencesRequest) Validate() error {
+	if r.Weekly == nil && r.Monthly == nil {
+		return &ValidationError{
+			Field:   "weekly",
+			Message: "weekly or monthly is required",
+		}
+	}
+	return nil
+}
+
+// DigestPeriod returns the most recent complete period of frequency before
+// now (UTC): the ISO week ending last Sunday or the previous calendar month.
+// The key identifies the period, e.g. 2026-W10 or 2026-03.
+func DigestPeriod(frequency DigestFrequency, now time.Time) (key string, start, end time.Time) {
+	now = now.UTC()
+	if frequency == DigestMonthly {
+		start, end = MonthPeriod(time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0))
+		return start.Format(MonthLayout), start, end
+	}
+
+	// Days since Monday, with Sunday as the last day of the week
+	offset := (int(now.Weekday()) + 6) % 7
+	thisWeek := time.Date(now.Year(), now.Month(), now.Day()-offset, 0, 0, 0, 0, time.UTC)
+	start = thisWeek.AddDate(0, 0, -7)
+	end = thisWeek.Add(-time.Nanosecond)
+	year, week := start.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week), start, end
+}
+
+// Digest is the content of one digest email
+type Digest struct {
+	Frequency     DigestFrequency        `json:"frequency"`
+	Period        string                 `json:"period"`
+	Name          string                 `json:"name"` // Recipient's name
+	Currency      string                 `json:"currency"`
+	PeriodStart   time.Time              `json:"period_start"`
+	PeriodEnd     time.Time              `json:"period_end"`
+	TopCategories []BreakdownResponse    `json:"top_categories"` // Expenses by category
+	Budgets       []BudgetProgress       `json:"budgets"`        // Month to date at the end of the period
+	Anomalies     []AnomalousTransaction `json:"anomalies"`
+	Summary       PeriodSummary          `json:"summary"`
+	Previous      PeriodSummary          `json:"previous"`
+	Comparison    PeriodComparison       `json:"comparison"`
+}
+
+// DigestRunResult reports the outcome of one scheduler run
+type DigestRunResult struct {
+	Sent   int `json:"sent"`
+	Failed int `json:"failed"`
+}
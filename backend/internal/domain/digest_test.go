@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test DigestPeriod
+
+func TestDigestPeriod(t *testing.T) {
+	tests := []struct {
+		name      string
+		frequency DigestFrequency
+		now       time.Time
+		key       string
+		start     time.Time
+	}{
+		{"weekly midweek", DigestWeekly, time.Date(2026, 3, 11, 8, 0, 0, 0, time.UTC), "2026-W10", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"weekly on sunday", DigestWeekly, time.Date(2026, 3, 15, 23, 0, 0, 0, time.UTC), "2026-W10", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{"weekly on monday", DigestWeekly, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), "2026-W11", time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"weekly across years", DigestWeekly, time.Date(2027, 1, 5, 0, 0, 0, 0, time.UTC), "2026-W53", time.Date(2026, 12, 28, 0, 0, 0, 0, time.UTC)},
+		{"monthly", DigestMonthly, time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC), "2026-02", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"monthly across years", DigestMonthly, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), "2025-12", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, start, end := DigestPeriod(tt.frequency, tt.now)
+
+			assert.Equal(t, tt.key, key)
+			assert.Equal(t, tt.start, start)
+			assert.True(t, end.After(start))
+			assert.True(t, end.Before(tt.now), "period must be complete")
+		})
+	}
+}
+
+func TestDigestPeriod_WeekEnd(t *testing.T) {
+	_, _, end := DigestPeriod(DigestWeekly, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), end)
+}
+
+// Test DigestSubscription
+
+func TestDigestSubscription_WantsAndLastPeriod(t *testing.T) {
+	subscription := &DigestSubscription{Weekly: true, LastWeeklyPeriod: "2026-W10", LastMonthlyPeriod: "2026-02"}
+
+	assert.True(t, subscription.Wants(DigestWeekly))
+	assert.False(t, subscription.Wants(DigestMonthly))
+	assert.Equal(t, "2026-W10", subscription.LastPeriod(DigestWeekly))
+	assert.Equal(t, "2026-02", subscription.LastPeriod(DigestMonthly))
+}
+
+// Test DigestPreferencesRequest Validate
+
+func TestDigestPreferencesRequest_Validate(t *testing.T) {
+	off := false
+	assert.NoError(t, (&DigestPreferencesRequest{Monthly: &off}).Validate())
+
+	err := (&DigestPreferencesRequest{}).Validate()
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "weekly", validationErr.Field)
+}
@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"time"
+)
+
+const (
+	// MaxEnvelopeNameLength is the maximum length for envelope names
+	MaxEnvelopeNameLength = 100
+	// MaxNoteLength is the maximum length for free-text notes
+	MaxNoteLength = 255
+	// MonthLayout is the format used for month identifiers (YYYY-MM)
+	MonthLayout = "2006-01"
+)
+
+// Envelope is a zero-based budgeting envelope; spending in its category draws it down
+type Envelope struct {
+	Name      string    `json:"name" gorm:"type:varchar(100);not null;unique"`
+	Category  string    `json:"category" gorm:"type:varchar(50);not null;unique"` // Spending category drawn from this envelope
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID        int64     `json:"id" gorm:"primaryKey"`
+}
+
+// TableName specifies the table name for GORM
+func (Envelope) TableName() string {
+	return "envelopes"
+}
+
+// EnvelopeAllocation assigns income to an envelope for a month
+type EnvelopeAllocation struct {
+	Month         string    `json:"month" gorm:"type:varchar(7);not null;index"` // YYYY-MM
+	Note          string    `json:"note" gorm:"type:varchar(255)"`
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	TransactionID *int64    `json:"transaction_id" gorm:"index"` // Income transaction funding this allocation
+	ID            int64     `json:"id" gorm:"primaryKey"`
+	EnvelopeID    int64     `json:"envelope_id" gorm:"not null;index"`
+	Amount        float64   `json:"amount" gorm:"type:decimal(15,2);not null"`
+}
+
+// TableName specifies the table name for GORM
+func (EnvelopeAllocation) TableName() string {
+	return "envelope_allocations"
+}
+
+// EnvelopeMove moves available money from one envelope to another
+type EnvelopeMove struct {
+	Month          string    `json:"month" gorm:"type:varchar(7);not null;index"` // YYYY-MM
+	Note           string    `json:"note" gorm:"type:varchar(255)"`
+	CreatedAt      time.Time `json:"created_at" gorm:"autoCreateTime"`
+	ID             int64     `json:"id" gorm:"primaryKey"`
+	FromEnvelopeID int64     `json:"from_envelope_id" gorm:"not null;index"`
+	ToEnvelopeID   int64     `json:"to_envelope_id" gorm:"not null;index"`
+	Amount         float64   `json:"amount" gorm:"type:decimal(15,2);not null"`
+}
+
+// TableName specifies the table name for GORM
+func (EnvelopeMove) TableName() string {
+	return "envelope_moves"
+}
+
+// EnvelopeRequest is the request body for creating or updating an envelope
+type EnvelopeRequest struct {
+	Name     string `json:"name" binding:"required,max=100"`
+	Category string `json:"category" binding:"required,max=50"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *EnvelopeRequest) Validate() error {
+	if r.Name == "" || len(r.Name) > MaxEnvelopeNameLength {
+		return &ValidationError{
+			Field:   "name",
+			Message: "name is required and must be at most 100 characters",
+		}
+	}
+
+	if r.Category == "" || !ValidCategories[r.Category] {
+		return &ValidationError{
+			Field:   "category",
+			Message: "invalid category. Valid categories are: Food, Transportation, Housing, Utilities, Entertainment, Healthcare, Shopping, Education, Salary, Investment, Transfer, Other",
+		}
+	}
+
+	return nil
+}
+
+// AllocationRequest is the request body for allocating money into an envelope
+type AllocationRequest struct {
+	TransactionID *int64  `json:"transaction_id"`
+	Month         string  `json:"month" binding:"omitempty,len=7"`
+	Note          string  `json:"note" binding:"omitempty,max=255"`
+	Amount        float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *AllocationRequest) Validate() error {
+	if r.Amount <= 0 || r.Amount > MaxAmount {
+		return &ValidationError{
+			Field:   "amount",
+			Message: "amount must be greater than zero and within the maximum allowed value",
+		}
+	}
+
+	if r.Month != "" {
+		if _, err := time.Parse(MonthLayout, r.Month); err != nil {
+			return &ValidationError{
+				Field:   "month",
+				Message: "invalid month format. Must be YYYY-MM",
+			}
+		}
+	}
+
+	if r.TransactionID != nil && *r.TransactionID <= 0 {
+		return &ValidationError{
+			Field:   "transaction_id",
+			Message: "invalid transaction ID",
+		}
+	}
+
+	return nil
+}
+
+// MoveRequest is the request body for moving money between envelopes
+type MoveRequest struct {
+	Month        string  `json:"month" binding:"omitempty,len=7"`
+	Note         string  `json:"note" binding:"omitempty,max=255"`
+	ToEnvelopeID int64   `json:"to_envelope_id" binding:"required,gt=0"`
+	Amount       float64 `json:"amount" binding:"required,gt=0"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *MoveRequest) Validate() error {
+	if r.Amount <= 0 || r.Amount > MaxAmount {
+		return &ValidationError{
+			Field:   "amount",
+			Message: "amount must be greater than zero and within the maximum allowed value",
+		}
+	}
+
+	if r.Month != "" {
+		if _, err := time.Parse(MonthLayout, r.Month); err != nil {
+			return &ValidationError{
+				Field:   "month",
+				Message: "invalid month format. Must be YYYY-MM",
+			}
+		}
+	}
+
+	if r.ToEnvelopeID <= 0 {
+		return &ValidationError{
+			Field:   "to_envelope_id",
+			Message: "invalid envelope ID",
+		}
+	}
+
+	return nil
+}
+
+// EnvelopeMonthAmount is an aggregated amount for an envelope in a month
+type EnvelopeMonthAmount struct {
+	Month      string  `json:"month"`
+	EnvelopeID int64   `json:"envelope_id"`
+	Amount     float64 `json:"amount"`
+}
+
+// EnvelopeMoveTotal is an aggregated amount moved between two envelopes in a month
+type EnvelopeMoveTotal struct {
+	Month          string  `json:"month"`
+	FromEnvelopeID int64   `json:"from_envelope_id"`
+	ToEnvelopeID   int64   `json:"to_envelope_id"`
+	Amount         float64 `json:"amount"`
+}
+
+// CategoryMonthAmount is an aggregated transaction amount for a category in a month
+type CategoryMonthAmount struct {
+	Month    string  `json:"month"`
+	Category string  `json:"category"`
+	Amount   float64 `json:"amount"`
+}
+
+// MonthAmount is an aggregated amount for a month
+type MonthAmount struct {
+	Month  string  `json:"month"`
+	Amount float64 `json:"amount"`
+}
+
+// EnvelopeBalancePoint is an envelope's activity and available balance for one month
+type EnvelopeBalancePoint struct {
+	Month     string  `json:"month"`
+	Overspent bool    `json:"overspent"` // Available is negative and must be covered by a move
+	Rollover  float64 `json:"rollover"`  // Available carried over from the previous month
+	Allocated float64 `json:"allocated"`
+	MovedIn   float64 `json:"moved_in"`
+	MovedOut  float64 `json:"moved_out"`
+	Spent     float64 `json:"spent"`
+	Available float64 `json:"available"`
+}
+
+// EnvelopeBalanceSeries is the balance history of a single envelope
+type EnvelopeBalanceSeries struct {
+	Name     string                 `json:"name"`
+	Category string                 `json:"category"`
+	Points   []EnvelopeBalancePoint `json:"points"`
+	ID       int64                  `json:"id"`
+}
+
+// EnvelopeBalancesResponse is the response for the envelope balances endpoint
+type EnvelopeBalancesResponse struct {
+	Months      []string                `json:"months"`
+	Envelopes   []EnvelopeBalanceSeries `json:"envelopes"`
+	Unallocated []float64               `json:"unallocated"` // Cumulative income not yet assigned to an envelope, per month
+}
+
+// MaxEnvelopeBalanceMonths caps the number of months returned by the balances endpoint
+const MaxEnvelopeBalanceMonths = 120
+
+// EnvelopeBalancesQueryParams represents query parameters for the envelope balances endpoint
+type EnvelopeBalancesQueryParams struct {
+	StartMonth string `form:"start_month"`
+	EndMonth   string `form:"end_month"`
+}
+
+// Validate applies defaults relative to now and checks the month range.
+// The range defaults to the six months ending with the current month.
+func (p *EnvelopeBalancesQueryParams) Validate(now time.Time) error {
+	if p.EndMonth == "" {
+		p.EndMonth = now.UTC().Format(MonthLayout)
+	}
+
+	end, err := time.Parse(MonthLayout, p.EndMonth)
+	if err != nil {
+		return &ValidationError{
+			Field:   "end_month",
+			Message: "invalid month format. Must be YYYY-MM",
+		}
+	}
+
+	if p.StartMonth == "" {
+		p.StartMonth = end.AddDate(0, -5, 0).Format(MonthLayout)
+	}
+
+	start, err := time.Parse(MonthLayout, p.StartMonth)
+	if err != nil {
+		return &ValidationError{
+			Field:   "start_month",
+			Message: "invalid month format. Must be YYYY-MM",
+		}
+	}
+
+	if start.After(end) {
+		return &ValidationError{
+			Field:   "start_month",
+			Message: "start_month must not be after end_month",
+		}
+	}
+
+	if start.AddDate(0, MaxEnvelopeBalanceMonths, 0).Before(end) {
+		return &ValidationError{
+			Field:   "start_month",
+			Message: "month range is too large (max 120 months)",
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test EnvelopeRequest Validate
+
+func TestEnvelopeRequest_Validate_Valid(t *testing.T) {
+	req := &EnvelopeRequest{Name: "Groceries", Category: "Food"}
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestEnvelopeRequest_Validate_InvalidCategory(t *testing.T) {
+	req := &EnvelopeRequest{Name: "Fun", Category: "Gambling"}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "category", validationErr.Field)
+}
+
+// Test AllocationRequest Validate
+
+func TestAllocationRequest_Validate_InvalidMonth(t *testing.T) {
+	req := &AllocationRequest{Month: "2026-13", Amount: 100}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "month", validationErr.Field)
+}
+
+func TestAllocationRequest_Validate_InvalidTransactionID(t *testing.T) {
+	id := int64(0)
+	req := &AllocationRequest{TransactionID: &id, Amount: 100}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "transaction_id", validationErr.Field)
+}
+
+// Test MoveRequest Validate
+
+func TestMoveRequest_Validate_ZeroAmount(t *testing.T) {
+	req := &MoveRequest{ToEnvelopeID: 2}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "amount", validationErr.Field)
+}
+
+// Test EnvelopeBalancesQueryParams Validate
+
+func TestEnvelopeBalancesQueryParams_Validate_Defaults(t *testing.T) {
+	params := &EnvelopeBalancesQueryParams{}
+
+	err := params.Validate(time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-10", params.StartMonth)
+	assert.Equal(t, "2026-03", params.EndMonth)
+}
+
+func TestEnvelopeBalancesQueryParams_Validate_StartAfterEnd(t *testing.T) {
+	params := &EnvelopeBalancesQueryParams{StartMonth: "2026-05", EndMonth: "2026-01"}
+
+	err := params.Validate(time.Now())
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "start_month", validationErr.Field)
+}
+
+func TestEnvelopeBalancesQueryParams_Validate_InvalidFormat(t *testing.T) {
+	params := &EnvelopeBalancesQueryParams{EndMonth: "March"}
+
+	err := params.Validate(time.Now())
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "end_month", validationErr.Field)
+}
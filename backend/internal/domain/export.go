@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat is the file format of a transaction export
+type ExportFormat string
+
+const (
+	ExportFormatCSV    ExportFormat = "csv"
+	ExportFormatXLSX   ExportFormat = "xlsx"
+	ExportFormatNDJSON ExportFormat = "ndjson"
+	// Plain-text accounting journals of double-entry postings, see LedgerMapping
+	ExportFormatBeancount ExportFormat = "beancount"
+	ExportFormatHledger   ExportFormat = "hledger"
+	ExportFormatLedger    ExportFormat = "ledger"
+)
+
+// validExportFormats contains the accepted export formats
+var validExportFormats = map[ExportFormat]bool{
+	ExportFormatCSV:       true,
+	ExportFormatXLSX:      true,
+	ExportFormatNDJSON:    true,
+	ExportFormatBeancount: true,
+	ExportFormatHledger:   true,
+	ExportFormatLedger:    true,
+}
+
+// IsJournal reports whether the format is a plain-text accounting journal,
+// which ignores the column and locale selection
+func (f ExportFormat) IsJournal() bool {
+	return f == ExportFormatBeancount || f == ExportFormatHledger || f == ExportFormatLedger
+}
+
+// ExportColumn is a transaction field that can be exported
+type ExportColumn string
+
+const (
+	ExportColumnID              ExportColumn = "id"
+	ExportColumnTransactionDate ExportColumn = "transaction_date"
+	ExportColumnType            ExportColumn = "type"
+	ExportColumnAmount          ExportColumn = "amount"
+	ExportColumnSignedAmount    ExportColumn = "signed_amount" // Negative for expenses
+	ExportColumnCategory        ExportColumn = "category"
+	ExportColumnDescription     ExportColumn = "description"
+	ExportColumnSource          ExportColumn = "source"
+	ExportColumnSourceAccount   ExportColumn = "source_account"
+	ExportColumnRecipient       ExportColumn = "recipient"
+	ExportColumnExternalID      ExportColumn = "external_id"
+	ExportColumnAnomalous       ExportColumn = "anomalous"
+	ExportColumnCreatedAt       ExportColumn = "created_at"
+)
+
+// ValidExportColumns contains the columns accepted in the columns parameter
+var ValidExportColumns = map[ExportColumn]bool{
+	ExportColumnID:              true,
+	ExportColumnTransactionDate: true,
+	ExportColumnType:            true,
+	ExportColumnAmount:          true,
+	ExportColumnSignedAmount:    true,
+	ExportColumnCategory:        true,
+	ExportColumnDescription:     true,
+	ExportColumnSource:          true,
+	ExportColumnSourceAccount:   true,
+	ExportColumnRecipient:       true,
+	ExportColumnExternalID:      true,
+	ExportColumnAnomalous:       true,
+	ExportColumnCreatedAt:       true,
+}
+
+// DefaultExportColumns are the columns exported when none are selected
+var DefaultExportColumns = []ExportColumn{
+	ExportColumnID,
+	ExportColumnTransactionDate,
+	ExportColumnType,
+	ExportColumnAmount,
+	ExportColumnCategory,
+	ExportColumnDescription,
+	ExportColumnSource,
+	ExportColumnSourceAccount,
+	ExportColumnRecipient,
+}
+
+// NumberFormat is how a locale writes decimal numbers
+type NumberFormat struct {
+	Decimal string
+	Group   string // Thousands separator; empty for none
+}
+
+// PlainNumberFormat writes numbers without grouping, as most tools expect
+var PlainNumberFormat = NumberFormat{Decimal: "."}
+
+// localeNumberFormats maps a locale's language to its number format
+var localeNumberFormats = map[string]NumberFormat{
+	"en": {Decimal: ".", Group: ","},
+	"ja": {Decimal: ".", Group: ","},
+	"ko": {Decimal: ".", Group: ","},
+	"th": {Decimal: ".", Group: ","},
+	"zh": {Decimal: ".", Group: ","},
+	"da": {Decimal: ",", Group: "."},
+	"de": {Decimal: ",", Group: "."},
+	"es": {Decimal: ",", Group: "."},
+	"id": {Decimal: ",", Group: "."},
+	"it": {Decimal: ",", Group: "."},
+	"nl": {Decimal: ",", Group: "."},
+	"pt": {Decimal: ",", Group: "."},
+	"tr": {Decimal: ",", Group: "."},
+	"vi": {Decimal: ",", Group: "."},
+	"cs": {Decimal: ",", Group: "\u00a0"},
+	"fi": {Decimal: ",", Group: "\u00a0"},
+	"fr": {Decimal: ",", Group: "\u00a0"},
+	"nb": {Decimal: ",", Group: "\u00a0"},
+	"pl": {Decimal: ",", Group: "\u00a0"},
+	"ru": {Decimal: ",", Group: "\u00a0"},
+	"sv": {Decimal: ",", Group: "\u00a0"},
+	"uk": {Decimal: ",", Group: "\u00a0"},
+}
+
+// LookupNumberFormat returns the number format of a locale such as en-US,
+// de_DE or vi. An empty locale gives PlainNumberFormat.
+func LookupNumberFormat(locale string) (NumberFormat, bool) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" {
+		return PlainNumberFormat, true
+	}
+
+	language := strings.ToLower(locale)
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	format, ok := localeNumberFormats[language]
+	return format, ok
+}
+
+// Format writes value with two decimals, e.g. -1234.5 as -1.234,50 in de-DE
+func (f NumberFormat) Format(value float64) string {
+	formatted := strconv.FormatFloat(math.Abs(value), 'f', 2, 64)
+	integer, fraction := formatted[:len(formatted)-3], formatted[len(formatted)-2:]
+
+	if f.Group != "" && len(integer) > 3 {
+		var b strings.Builder
+		lead := len(integer) % 3
+		if lead > 0 {
+			b.WriteString(integer[:lead])
+		}
+		for i := lead; i < len(integer); i += 3 {
+			if b.Len() > 0 {
+				b.WriteString(f.Group)
+			}
+			b.WriteString(integer[i : i+3])
+		}
+		integer = b.String()
+	}
+
+	sign := ""
+	if value < 0 && formatted != "0.00" {
+		sign = "-"
+	}
+	return sign + integer + f.Decimal + fraction
+}
+
+// ExportQueryParams represents query parameters for the export endpoint.
+// The listing filters apply; pagination is ignored and every match is exported.
+type ExportQueryParams struct {
+	ListTransactionsQueryParams
+	Format  ExportFormat `form:"format"`
+	Columns string       `form:"columns"` // Comma-separated ExportColumn names
+	Locale  string       `form:"locale"`  // Number formatting of CSV exports, e.g. de-DE
+
+	columns      []ExportColumn
+	numberFormat NumberFormat
+}
+
+// Validate applies defaults and validates the query parameters
+func (p *ExportQueryParams) Validate() error {
+	if p.Format == "" {
+		p.Format = ExportFormatCSV
+	}
+	if !validExportFormats[p.Format] {
+		return &ValidationError{
+			Field:   "format",
+			Message: "format must be one of: csv, xlsx, ndjson, beancount, hledger, ledger",
+		}
+	}
+
+	if p.Type != "" && p.Type != TransactionTypeIn && p.Type != TransactionTypeOut {
+		return &ValidationError{
+			Field:   "type",
+			Message: "type must be one of: in, out",
+		}
+	}
+
+	dates, err := ParseDateRange(p.StartDate, p.EndDate)
+	if err != nil {
+		return err
+	}
+	p.Dates = dates
+
+	p.columns = DefaultExportColumns
+	if strings.TrimSpace(p.Columns) != "" {
+		p.columns = nil
+		seen := make(map[ExportColumn]bool)
+		for _, name := range strings.Split(p.Columns, ",") {
+			column := ExportColumn(strings.ToLower(strings.TrimSpace(name)))
+			if !ValidExportColumns[column] {
+				return &ValidationError{
+					Field:   "columns",
+					Message: "unknown column " + strconv.Quote(string(column)),
+				}
+			}
+			if seen[column] {
+				return &ValidationError{
+					Field:   "columns",
+					Message: "column " + strconv.Quote(string(column)) + " is selected twice",
+				}
+			}
+			seen[column] = true
+			p.columns = append(p.columns, column)
+		}
+	}
+
+	format, ok := LookupNumberFormat(p.Locale)
+	if !ok {
+		return &ValidationError{
+			Field:   "locale",
+			Message: "unsupported locale " + strconv.Quote(p.Locale),
+		}
+	}
+	p.numberFormat = format
+
+	return nil
+}
+
+// SelectedColumns returns the validated column selection
+func (p *ExportQueryParams) SelectedColumns() []ExportColumn {
+	if p.columns == nil {
+		return DefaultExportColumns
+	}
+	return p.columns
+}
+
+// NumberFormat returns the validated number format
+func (p *ExportQueryParams) NumberFormat() NumberFormat {
+	if p.numberFormat.Decimal == "" {
+		return PlainNumberFormat
+	}
+	return p.numberFormat
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test ExportQueryParams Validate
+
+func TestExportQueryParams_Validate_Defaults(t *testing.T) {
+	params := &ExportQueryParams{}
+
+	assert.NoError(t, params.Validate())
+	assert.Equal(t, ExportFormatCSV, params.Format)
+	assert.Equal(t, DefaultExportColumns, params.SelectedColumns())
+	assert.Equal(t, PlainNumberFormat, params.NumberFormat())
+}
+
+func TestExportQueryParams_Validate_Columns(t *testing.T) {
+	params := &ExportQueryParams{Columns: "transaction_date, Signed_Amount,payee_free", Format: ExportFormatNDJSON}
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, params.Validate(), &validationErr)
+	assert.Equal(t, "columns", validationErr.Field)
+
+	params.Columns = "transaction_date, Signed_Amount"
+	assert.NoError(t, params.Validate())
+	assert.Equal(t, []ExportColumn{ExportColumnTransactionDate, ExportColumnSignedAmount}, params.SelectedColumns())
+}
+
+func TestExportQueryParams_Validate_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		params ExportQueryParams
+		field  string
+	}{
+		{"format", ExportQueryParams{Format: "pdf"}, "format"},
+		{"type", ExportQueryParams{ListTransactionsQueryParams: ListTransactionsQueryParams{Type: "both"}}, "type"},
+		{"date", ExportQueryParams{ListTransactionsQueryParams: ListTransactionsQueryParams{StartDate: "yesterday"}}, "start_date"},
+		{"repeated column", ExportQueryParams{Columns: "amount,amount"}, "columns"},
+		{"locale", ExportQueryParams{Locale: "xx-YY"}, "locale"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+// Test NumberFormat
+
+func TestNumberFormat_Format(t *testing.T) {
+	tests := []struct {
+		locale string
+		value  float64
+		want   string
+	}{
+		{"", 1234567.891, "1234567.89"},
+		{"en-US", 1234567.891, "1,234,567.89"},
+		{"de_DE", -1234.5, "-1.234,50"},
+		{"vi", 45000, "45.000,00"},
+		{"fr-FR", 999.999, "1\u00a0000,00"},
+		{"en", 123, "123.00"},
+		{"en", -0.001, "0.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+"/"+tt.want, func(t *testing.T) {
+			format, ok := LookupNumberFormat(tt.locale)
+
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, format.Format(tt.value))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package domain
+
+const (
+	// MinForecastDays is the shortest supported forecast horizon
+	MinForecastDays = 30
+	// MaxForecastDays is the longest supported forecast horizon
+	MaxForecastDays = 90
+	// DiscretionaryLookbackDays is the history used to estimate daily discretionary spend
+	DiscretionaryLookbackDays = 90
+)
+
+// ForecastItemOrigin identifies where an expected forecast item comes from
+type ForecastItemOrigin string
+
+const (
+	ForecastItemScheduled ForecastItemOrigin = "scheduled"
+	ForecastItemRecurring ForecastItemOrigin = "recurring"
+)
+
+// ForecastQueryParams represents query parameters for the forecast endpoint
+type ForecastQueryParams struct {
+	Days int `form:"days,default=30"`
+}
+
+// Validate validates the query parameters
+func (p *ForecastQueryParams) Validate() error {
+	if p.Days < MinForecastDays || p.Days > MaxForecastDays {
+		return &ValidationError{
+			Field:   "days",
+			Message: "days must be between 30 and 90",
+		}
+	}
+	return nil
+}
+
+// ForecastItem is an expected transaction included in a forecast day
+type ForecastItem struct {
+	Name   string             `json:"name"`
+	Type   TransactionType    `json:"type"`
+	Origin ForecastItemOrigin `json:"origin"`
+	Amount float64            `json:"amount"`
+}
+
+// ForecastDataPoint is a projected day. It embeds TrendDataPoint so charts
+// consuming trends can plot forecasts unchanged.
+type ForecastDataPoint struct {
+	TrendDataPoint
+	Items     []ForecastItem `json:"items,omitempty"`
+	Balance   float64        `json:"balance"`     // Projected balance at the end of the day
+	BelowZero bool           `json:"below_zero"` // Projected balance is negative
+}
+
+// ForecastResponse is the response for the forecast endpoint
+type ForecastResponse struct {
+	LowestBalanceDate       string              `json:"lowest_balance_date"`
+	Data                    []ForecastDataPoint `json:"data"`
+	Days                    int                 `json:"days"`
+	StartingBalance         float64             `json:"starting_balance"`
+	DailyDiscretionarySpend float64             `json:"daily_discretionary_spend"`
+	EndingBalance           float64             `json:"ending_balance"`
+	LowestBalance           float64             `json:"lowest_balance"`
+	DaysBelowZero           int                 `json:"days_below_zero"`
+}
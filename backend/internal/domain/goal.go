@@ -0,0 +1,202 @@
+package domain
+
+import (
+	"regexp"
+	"time"
+)
+
+// GoalStatus describes a goal's progress against its target date
+type GoalStatus string
+
+const (
+	GoalStatusOnTrack   GoalStatus = "on_track"
+	GoalStatusBehind    GoalStatus = "behind"
+	GoalStatusCompleted GoalStatus = "completed"
+	GoalStatusOverdue   GoalStatus = "overdue"
+)
+
+const (
+	// MaxGoalNameLength is the maximum length for goal names
+	MaxGoalNameLength = 100
+	// MaxGoalTagLength is the maximum length for goal tags
+	MaxGoalTagLength = 50
+)
+
+// goalTagPattern restricts tags to characters that can follow # in a description
+var goalTagPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Goal is a savings goal. Transactions contribute to it when they hit the
+// linked account, carry the goal's #tag in their description or use its category.
+type Goal struct {
+	TargetDate    time.Time `json:"target_date" gorm:"not null"`
+	StartDate     time.Time `json:"start_date" gorm:"not null"` // Transactions before this date are not counted
+	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	Name          string    `json:"name" gorm:"type:varchar(100);not null;unique"`
+	SourceAccount string    `json:"source_account" gorm:"type:varchar(100)"` // Linked savings account
+	Tag           string    `json:"tag" gorm:"type:varchar(50)"`             // Matched as #tag in transaction descriptions
+	Category      string    `json:"category" gorm:"type:varchar(50)"`
+	ID            int64     `json:"id" gorm:"primaryKey"`
+	TargetAmount  float64   `json:"target_amount" gorm:"type:decimal(15,2);not null"`
+}
+
+// TableName specifies the table name for GORM
+func (Goal) TableName() string {
+	return "goals"
+}
+
+// GoalContribution is a manual allocation to (or withdrawal from) a goal
+type GoalContribution struct {
+	ContributionDate time.Time `json:"contribution_date" gorm:"not null"`
+	CreatedAt        time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Note             string    `json:"note" gorm:"type:varchar(255)"`
+	ID               int64     `json:"id" gorm:"primaryKey"`
+	GoalID           int64     `json:"goal_id" gorm:"not null;index"`
+	Amount           float64   `json:"amount" gorm:"type:decimal(15,2);not null"` // Negative for withdrawals
+}
+
+// TableName specifies the table name for GORM
+func (GoalContribution) TableName() string {
+	return "goal_contributions"
+}
+
+// GoalRequest is the request body for creating or updating a goal
+type GoalRequest struct {
+	Name          string  `json:"name" binding:"required,max=100"`
+	TargetDate    string  `json:"target_date" binding:"required"`
+	StartDate     string  `json:"start_date"`
+	SourceAccount string  `json:"source_account" binding:"omitempty,max=100"`
+	Tag           string  `json:"tag" binding:"omitempty,max=50"`
+	Category      string  `json:"category" binding:"omitempty,max=50"`
+	TargetAmount  float64 `json:"target_amount" binding:"required,gt=0"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *GoalRequest) Validate() error {
+	if r.Name == "" || len(r.Name) > MaxGoalNameLength {
+		return &ValidationError{
+			Field:   "name",
+			Message: "name is required and must be at most 100 characters",
+		}
+	}
+
+	if r.TargetAmount <= 0 || r.TargetAmount > MaxAmount {
+		return &ValidationError{
+			Field:   "target_amount",
+			Message: "target_amount must be greater than zero and within the maximum allowed value",
+		}
+	}
+
+	if _, _, err := parseDateParam(r.TargetDate); err != nil {
+		return &ValidationError{
+			Field:   "target_date",
+			Message: "invalid date format. Must be RFC3339 or YYYY-MM-DD",
+		}
+	}
+
+	if r.StartDate != "" {
+		if _, _, err := parseDateParam(r.StartDate); err != nil {
+			return &ValidationError{
+				Field:   "start_date",
+				Message: "invalid date format. Must be RFC3339 or YYYY-MM-DD",
+			}
+		}
+	}
+
+	if r.Tag != "" && (len(r.Tag) > MaxGoalTagLength || !goalTagPattern.MatchString(r.Tag)) {
+		return &ValidationError{
+			Field:   "tag",
+			Message: "tag may only contain letters, digits, '-' and '_'",
+		}
+	}
+
+	if r.Category != "" && !ValidCategories[r.Category] {
+		return &ValidationError{
+			Field:   "category",
+			Message: "invalid category. Valid categories are: Food, Transportation, Housing, Utilities, Entertainment, Healthcare, Shopping, Education, Salary, Investment, Transfer, Other",
+		}
+	}
+
+	return nil
+}
+
+// ToGoal converts a validated request to a goal. The start date defaults to now
+// and a date-only target date means the end of that day.
+func (r *GoalRequest) ToGoal(now time.Time) *Goal {
+	target, dateOnly, _ := parseDateParam(r.TargetDate)
+	if dateOnly {
+		target = target.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+
+	start := now
+	if r.StartDate != "" {
+		start, _, _ = parseDateParam(r.StartDate)
+	}
+
+	return &Goal{
+		Name:          r.Name,
+		TargetAmount:  r.TargetAmount,
+		TargetDate:    target,
+		StartDate:     start,
+		SourceAccount: r.SourceAccount,
+		Tag:           r.Tag,
+		Category:      r.Category,
+	}
+}
+
+// GoalContributionRequest is the request body for a manual goal contribution
+type GoalContributionRequest struct {
+	ContributionDate string  `json:"contribution_date"`
+	Note             string  `json:"note" binding:"omitempty,max=255"`
+	Amount           float64 `json:"amount" binding:"required"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *GoalContributionRequest) Validate() error {
+	if r.Amount == 0 || r.Amount > MaxAmount || r.Amount < -MaxAmount {
+		return &ValidationError{
+			Field:   "amount",
+			Message: "amount must be non-zero and within the maximum allowed value",
+		}
+	}
+
+	if r.ContributionDate != "" {
+		if _, _, err := parseDateParam(r.ContributionDate); err != nil {
+			return &ValidationError{
+				Field:   "contribution_date",
+				Message: "invalid date format. Must be RFC3339 or YYYY-MM-DD",
+			}
+		}
+	}
+
+	return nil
+}
+
+// ToGoalContribution converts a validated request to a contribution. The date defaults to now.
+func (r *GoalContributionRequest) ToGoalContribution(goalID int64, now time.Time) *GoalContribution {
+	date := now
+	if r.ContributionDate != "" {
+		date, _, _ = parseDateParam(r.ContributionDate)
+	}
+
+	return &GoalContribution{
+		GoalID:           goalID,
+		ContributionDate: date,
+		Note:             r.Note,
+		Amount:           r.Amount,
+	}
+}
+
+// GoalProgress is a goal with its saved amount and pace towards the target date
+type GoalProgress struct {
+	Goal
+	Status                      GoalStatus `json:"status"`
+	ManualContributions         float64    `json:"manual_contributions"`
+	TransactionContributions    float64    `json:"transaction_contributions"`
+	Saved                       float64    `json:"saved"`
+	Remaining                   float64    `json:"remaining"`
+	PercentComplete             float64    `json:"percent_complete"`
+	ExpectedSaved               float64    `json:"expected_saved"` // Linear pace from start to target date
+	RequiredMonthlyContribution float64    `json:"required_monthly_contribution"`
+	MonthsRemaining             int        `json:"months_remaining"`
+}
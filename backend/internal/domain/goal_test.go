@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test GoalRequest Validate
+
+func TestGoalRequest_Validate_Valid(t *testing.T) {
+	req := &GoalRequest{
+		Name:          "Vacation",
+		TargetAmount:  30000000,
+		TargetDate:    "2027-06-30",
+		SourceAccount: "SAVINGS-01",
+		Tag:           "vacation",
+	}
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestGoalRequest_Validate_InvalidTargetDate(t *testing.T) {
+	req := &GoalRequest{Name: "Vacation", TargetAmount: 100, TargetDate: "next summer"}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "target_date", validationErr.Field)
+}
+
+func TestGoalRequest_Validate_InvalidTag(t *testing.T) {
+	req := &GoalRequest{Name: "Vacation", TargetAmount: 100, TargetDate: "2027-06-30", Tag: "trip%"}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "tag", validationErr.Field)
+}
+
+func TestGoalRequest_Validate_InvalidCategory(t *testing.T) {
+	req := &GoalRequest{Name: "Vacation", TargetAmount: 100, TargetDate: "2027-06-30", Category: "Travel"}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "category", validationErr.Field)
+}
+
+// Test GoalRequest ToGoal
+
+func TestGoalRequest_ToGoal_Defaults(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	req := &GoalRequest{Name: "Vacation", TargetAmount: 100, TargetDate: "2027-06-30"}
+
+	goal := req.ToGoal(now)
+
+	assert.Equal(t, now, goal.StartDate)
+	assert.Equal(t, time.Date(2027, 6, 30, 23, 59, 59, 999999999, time.UTC), goal.TargetDate)
+}
+
+// Test GoalContributionRequest Validate
+
+func TestGoalContributionRequest_Validate_ZeroAmount(t *testing.T) {
+	req := &GoalContributionRequest{}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "amount", validationErr.Field)
+}
+
+func TestGoalContributionRequest_Validate_Withdrawal(t *testing.T) {
+	req := &GoalContributionRequest{Amount: -500, ContributionDate: "2026-03-01"}
+
+	assert.NoError(t, req.Validate())
+}
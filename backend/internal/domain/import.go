@@ -0,0 +1,298 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// AmountSign is how a single signed amount column encodes the transaction type
+type AmountSign string
+
+const (
+	// AmountSignNegativeOut treats negative amounts as expenses, as in most bank accounts
+	AmountSignNegativeOut AmountSign = "negative_out"
+	// AmountSignPositiveOut treats positive amounts as expenses, as in most credit card statements
+	AmountSignPositiveOut AmountSign = "positive_out"
+)
+
+// ImportRowStatus is the outcome of one imported row
+type ImportRowStatus string
+
+const (
+	ImportRowStatusValid     ImportRowStatus = "valid"
+	ImportRowStatusImported  ImportRowStatus = "imported"
+	ImportRowStatusDuplicate ImportRowStatus = "duplicate"
+	ImportRowStatusError     ImportRowStatus = "error"
+)
+
+const (
+	// MaxImportProfileNameLength is the maximum length for import profile names
+	MaxImportProfileNameLength = 100
+	// MaxImportColumnLength is the maximum length for a mapped column header
+	MaxImportColumnLength = 100
+	// MaxImportSkipRows is the maximum number of lines skipped before the header row
+	MaxImportSkipRows = 50
+	// MaxImportRows is the maximum number of data rows in one import
+	MaxImportRows = 10000
+	// DefaultImportDateFormat is the date layout used when a mapping sets none
+	DefaultImportDateFormat = "2006-01-02"
+	// DefaultQIFDateFormat is the date layout of QIF files, which are month-first
+	DefaultQIFDateFormat = "1/2/2006"
+)
+
+// validImportDelimiters contains the accepted CSV field delimiters
+var validImportDelimiters = map[string]bool{
+	",":  true,
+	";":  true,
+	"\t": true,
+	"|":  true,
+}
+
+// ImportMapping describes how the columns of a bank statement export map onto
+// transactions. Columns are referenced by their header, ignoring case.
+// Amounts come either from a single signed amount column or from separate
+// debit (out) and credit (in) columns.
+type ImportMapping struct {
+	Source            string     `json:"source" gorm:"type:varchar(100);not null"` // Bank/wallet recorded on imported transactions
+	SourceAccount     string     `json:"source_account" gorm:"type:varchar(100)"`
+	Delimiter         string     `json:"delimiter" gorm:"type:varchar(1);not null"`
+	DateColumn        string     `json:"date_column" gorm:"type:varchar(100);not null"`
+	DateFormat        string     `json:"date_format" gorm:"type:varchar(50);not null"` // Go layout, e.g. 02/01/2006
+	DecimalSeparator  string     `json:"decimal_separator" gorm:"type:varchar(1);not null"`
+	AmountColumn      string     `json:"amount_column" gorm:"type:varchar(100)"`
+	AmountSign        AmountSign `json:"amount_sign" gorm:"type:varchar(20);not null"`
+	DebitColumn       string     `json:"debit_column" gorm:"type:varchar(100)"`
+	CreditColumn      string     `json:"credit_column" gorm:"type:varchar(100)"`
+	DescriptionColumn string     `json:"description_column" gorm:"type:varchar(100)"`
+	RecipientColumn   string     `json:"recipient_column" gorm:"type:varchar(100)"`
+	CategoryColumn    string     `json:"category_column" gorm:"type:varchar(100)"`
+	SkipRows          int        `json:"skip_rows" gorm:"not null;default:0"` // Lines before the header row, e.g. a bank letterhead
+}
+
+// Validate applies defaults and checks the mapping
+func (m *ImportMapping) Validate() error {
+	m.Source = strings.TrimSpace(m.Source)
+	if m.Source == "" || len(m.Source) > MaxSourceLength {
+		return &ValidationError{
+			Field:   "source",
+			Message: "source is required and must be at most 100 characters",
+		}
+	}
+
+	if len(m.SourceAccount) > MaxAccountLength {
+		return &ValidationError{
+			Field:   "source_account",
+			Message: "source_account must be at most 100 characters",
+		}
+	}
+
+	if m.Delimiter == "" {
+		m.Delimiter = ","
+	}
+	if !validImportDelimiters[m.Delimiter] {
+		return &ValidationError{
+			Field:   "delimiter",
+			Message: "delimiter must be one of: comma, semicolon, tab, pipe",
+		}
+	}
+
+	if m.DateFormat == "" {
+		m.DateFormat = DefaultImportDateFormat
+	}
+	if !isDateLayout(m.DateFormat) {
+		return &ValidationError{
+			Field:   "date_format",
+			Message: "date_format must be a Go date layout containing a year, month and day (e.g. 02/01/2006)",
+		}
+	}
+
+	if m.DecimalSeparator == "" {
+		m.DecimalSeparator = "."
+	}
+	if m.DecimalSeparator != "." && m.DecimalSeparator != "," {
+		return &ValidationError{
+			Field:   "decimal_separator",
+			Message: "decimal_separator must be . or ,",
+		}
+	}
+
+	if m.AmountSign == "" {
+		m.AmountSign = AmountSignNegativeOut
+	}
+	if m.AmountSign != AmountSignNegativeOut && m.AmountSign != AmountSignPositiveOut {
+		return &ValidationError{
+			Field:   "amount_sign",
+			Message: "amount_sign must be one of: negative_out, positive_out",
+		}
+	}
+
+	if m.SkipRows < 0 || m.SkipRows > MaxImportSkipRows {
+		return &ValidationError{
+			Field:   "skip_rows",
+			Message: "skip_rows must be between 0 and 50",
+		}
+	}
+
+	columns := map[string]*string{
+		"date_column":        &m.DateColumn,
+		"amount_column":      &m.AmountColumn,
+		"debit_column":       &m.DebitColumn,
+		"credit_column":      &m.CreditColumn,
+		"description_column": &m.DescriptionColumn,
+		"recipient_column":   &m.RecipientColumn,
+		"category_column":    &m.CategoryColumn,
+	}
+	for field, column := range columns {
+		*column = strings.TrimSpace(*column)
+		if len(*column) > MaxImportColumnLength {
+			return &ValidationError{
+				Field:   field,
+				Message: field + " must be at most 100 characters",
+			}
+		}
+	}
+
+	if m.DateColumn == "" {
+		return &ValidationError{
+			Field:   "date_column",
+			Message: "date_column is required",
+		}
+	}
+
+	hasDebitCredit := m.DebitColumn != "" || m.CreditColumn != ""
+	if m.AmountColumn == "" && !hasDebitCredit {
+		return &ValidationError{
+			Field:   "amount_column",
+			Message: "either amount_column or debit_column/credit_column is required",
+		}
+	}
+	if m.AmountColumn != "" && hasDebitCredit {
+		return &ValidationError{
+			Field:   "amount_column",
+			Message: "amount_column cannot be combined with debit_column/credit_column",
+		}
+	}
+
+	return nil
+}
+
+// isDateLayout reports whether layout formats a year, month and day
+func isDateLayout(layout string) bool {
+	ref := time.Date(2031, 11, 29, 0, 0, 0, 0, time.UTC)
+	formatted := ref.Format(layout)
+	parsed, err := time.Parse(layout, formatted)
+	if err != nil {
+		return false
+	}
+	return parsed.Year() == ref.Year() && parsed.Month() == ref.Month() && parsed.Day() == ref.Day()
+}
+
+// ImportProfile is a saved column mapping for a bank's statement export
+type ImportProfile struct {
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null;unique"`
+	ImportMapping
+	ID int64 `json:"id" gorm:"primaryKey"`
+}
+
+// TableName specifies the table name for GORM
+func (ImportProfile) TableName() string {
+	return "import_profiles"
+}
+
+// ImportProfileRequest is the request body for creating or updating an import profile
+type ImportProfileRequest struct {
+	Name string `json:"name" binding:"required,max=100"`
+	ImportMapping
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *ImportProfileRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" || len(r.Name) > MaxImportProfileNameLength {
+		return &ValidationError{
+			Field:   "name",
+			Message: "name is required and must be at most 100 characters",
+		}
+	}
+
+	return r.ImportMapping.Validate()
+}
+
+// ToImportProfile converts a validated request to an import profile
+func (r *ImportProfileRequest) ToImportProfile() *ImportProfile {
+	return &ImportProfile{
+		Name:          r.Name,
+		ImportMapping: r.ImportMapping,
+	}
+}
+
+// CSVImportRequest selects how an uploaded CSV file is mapped and whether it is stored.
+// Exactly one of ProfileID and Mapping is set.
+type CSVImportRequest struct {
+	Mapping   *ImportMapping
+	ProfileID int64
+	DryRun    bool
+}
+
+// StatementImportRequest holds the options for importing an OFX, QFX or QIF
+// statement. Source is optional for OFX files, which name their institution.
+type StatementImportRequest struct {
+	Source        string
+	SourceAccount string
+	DateFormat    string // QIF only; OFX dates are standardised
+	DryRun        bool
+}
+
+// Validate applies defaults and checks the options
+func (r *StatementImportRequest) Validate() error {
+	r.Source = strings.TrimSpace(r.Source)
+	if len(r.Source) > MaxSourceLength {
+		return &ValidationError{
+			Field:   "source",
+			Message: "source must be at most 100 characters",
+		}
+	}
+
+	r.SourceAccount = strings.TrimSpace(r.SourceAccount)
+	if len(r.SourceAccount) > MaxAccountLength {
+		return &ValidationError{
+			Field:   "source_account",
+			Message: "source_account must be at most 100 characters",
+		}
+	}
+
+	if r.DateFormat != "" && !isDateLayout(r.DateFormat) {
+		return &ValidationError{
+			Field:   "date_format",
+			Message: "date_format must be a Go date layout containing a year, month and day (e.g. 02/01/2006)",
+		}
+	}
+
+	return nil
+}
+
+// ImportRow is the outcome of one data row of an import. Line is the
+// 1-based line number in the uploaded file.
+type ImportRow struct {
+	Transaction *CreateTransactionRequest `json:"transaction,omitempty"`
+	DuplicateOf *int64                    `json:"duplicate_of,omitempty"` // Existing transaction the row matches
+	Status      ImportRowStatus           `json:"status"`
+	Error       string                    `json:"error,omitempty"`
+	Field       string                    `json:"field,omitempty"`
+	Line        int                       `json:"line"`
+}
+
+// ImportResult summarises an import. In a dry run nothing is stored and valid
+// rows keep the valid status. Imported, Duplicates and Errors are the created,
+// skipped and failed rows.
+type ImportResult struct {
+	Rows       []ImportRow `json:"rows"`
+	DryRun     bool        `json:"dry_run"`
+	Total      int         `json:"total"`
+	Valid      int         `json:"valid"`
+	Imported   int         `json:"imported"`
+	Duplicates int         `json:"duplicates"`
+	Errors     int         `json:"errors"`
+}
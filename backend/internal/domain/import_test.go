@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test ImportMapping Validate
+
+func TestImportMapping_Validate_AppliesDefaults(t *testing.T) {
+	mapping := &ImportMapping{Source: "Vietcombank", DateColumn: "Date", AmountColumn: "Amount"}
+
+	assert.NoError(t, mapping.Validate())
+	assert.Equal(t, ",", mapping.Delimiter)
+	assert.Equal(t, DefaultImportDateFormat, mapping.DateFormat)
+	assert.Equal(t, ".", mapping.DecimalSeparator)
+	assert.Equal(t, AmountSignNegativeOut, mapping.AmountSign)
+}
+
+func TestImportMapping_Validate_DebitCreditColumns(t *testing.T) {
+	mapping := &ImportMapping{
+		Source: "Techcombank", DateColumn: "Ngày", DateFormat: "02/01/2006",
+		DebitColumn: "Nợ", CreditColumn: "Có", DecimalSeparator: ",", Delimiter: ";",
+	}
+
+	assert.NoError(t, mapping.Validate())
+}
+
+func TestImportMapping_Validate_Invalid(t *testing.T) {
+	valid := func() *ImportMapping {
+		return &ImportMapping{Source: "Bank", DateColumn: "Date", AmountColumn: "Amount"}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(m *ImportMapping)
+		field  string
+	}{
+		{"missing source", func(m *ImportMapping) { m.Source = " " }, "source"},
+		{"bad delimiter", func(m *ImportMapping) { m.Delimiter = ":" }, "delimiter"},
+		{"layout without date", func(m *ImportMapping) { m.DateFormat = "DD/MM/YYYY" }, "date_format"},
+		{"layout without year", func(m *ImportMapping) { m.DateFormat = "02/01" }, "date_format"},
+		{"bad decimal separator", func(m *ImportMapping) { m.DecimalSeparator = "'" }, "decimal_separator"},
+		{"bad amount sign", func(m *ImportMapping) { m.AmountSign = "inverted" }, "amount_sign"},
+		{"negative skip rows", func(m *ImportMapping) { m.SkipRows = -1 }, "skip_rows"},
+		{"missing date column", func(m *ImportMapping) { m.DateColumn = "" }, "date_column"},
+		{"no amount columns", func(m *ImportMapping) { m.AmountColumn = "" }, "amount_column"},
+		{"amount with debit", func(m *ImportMapping) { m.DebitColumn = "Debit" }, "amount_column"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := valid()
+			tt.modify(mapping)
+
+			err := mapping.Validate()
+
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+// Test ImportProfileRequest Validate
+
+func TestImportProfileRequest_Validate_MissingName(t *testing.T) {
+	req := &ImportProfileRequest{
+		ImportMapping: ImportMapping{Source: "Bank", DateColumn: "Date", AmountColumn: "Amount"},
+	}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "name", validationErr.Field)
+}
+
+func TestImportProfileRequest_ToImportProfile(t *testing.T) {
+	req := &ImportProfileRequest{
+		Name:          "VCB export",
+		ImportMapping: ImportMapping{Source: "Vietcombank", DateColumn: "Date", AmountColumn: "Amount"},
+	}
+
+	assert.NoError(t, req.Validate())
+	profile := req.ToImportProfile()
+
+	assert.Equal(t, "VCB export", profile.Name)
+	assert.Equal(t, "Vietcombank", profile.Source)
+	assert.Equal(t, DefaultImportDateFormat, profile.DateFormat)
+}
+
+// Test StatementImportRequest Validate
+
+func TestStatementImportRequest_Validate(t *testing.T) {
+	req := &StatementImportRequest{Source: "  Chase  ", DateFormat: "02/01/2006"}
+
+	assert.NoError(t, req.Validate())
+	assert.Equal(t, "Chase", req.Source)
+
+	req = &StatementImportRequest{DateFormat: "dd/mm/yyyy"}
+	var validationErr *ValidationError
+	assert.ErrorAs(t, req.Validate(), &validationErr)
+	assert.Equal(t, "date_format", validationErr.Field)
+
+	req = &StatementImportRequest{Source: strings.Repeat("a", MaxSourceLength+1)}
+	assert.ErrorAs(t, req.Validate(), &validationErr)
+	assert.Equal(t, "source", validationErr.Field)
+}
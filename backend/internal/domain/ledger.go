@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Ledger account roots shared by Beancount, hledger and ledger
+const (
+	LedgerRootAssets      = "Assets"
+	LedgerRootLiabilities = "Liabilities"
+	LedgerRootEquity      = "Equity"
+	LedgerRootIncome      = "Income"
+	LedgerRootExpenses    = "Expenses"
+)
+
+// ledgerUncategorized is the leaf account of transactions without a category
+const ledgerUncategorized = "Uncategorized"
+
+var (
+	// ledgerAccountPattern matches a full account name such as Assets:Bank:Vietcombank
+	ledgerAccountPattern = regexp.MustCompile(`^(Assets|Liabilities|Equity|Income|Expenses)(:[\p{Lu}\p{Nd}][\p{L}\p{Nd}-]*)+$`)
+	// ledgerCurrencyPattern matches a Beancount commodity such as VND or USD
+	ledgerCurrencyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9'._-]{0,22}[A-Z0-9]$`)
+)
+
+// LedgerMapping maps transactions onto double-entry accounts for plain-text
+// accounting exports. Sources map to asset (or liability) accounts and
+// categories to expense or income accounts; anything unmapped gets a
+// default such as Assets:Vietcombank or Expenses:Food.
+type LedgerMapping struct {
+	Currency   string
+	accounts   map[string]string // lower-case "source" or "source/account" → account
+	categories map[string]string // lower-case category → account
+}
+
+// NewLedgerMapping creates a mapping. Keys of accounts are a source name or
+// "source/account" for a single account of a source; keys of categories are
+// category names. Keys are matched ignoring case.
+func NewLedgerMapping(currency string, accounts, categories map[string]string) *LedgerMapping {
+	m := &LedgerMapping{
+		Currency:   strings.ToUpper(strings.TrimSpace(currency)),
+		accounts:   make(map[string]string, len(accounts)),
+		categories: make(map[string]string, len(categories)),
+	}
+	for key, account := range accounts {
+		m.accounts[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(account)
+	}
+	for key, account := range categories {
+		m.categories[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(account)
+	}
+	return m
+}
+
+// Validate checks the currency and every mapped account name
+func (m *LedgerMapping) Validate() error {
+	if !ledgerCurrencyPattern.MatchString(m.Currency) {
+		return &ValidationError{
+			Field:   "currency",
+			Message: "currency must be an upper-case commodity such as USD or VND",
+		}
+	}
+
+	for key, account := range m.accounts {
+		if !ledgerAccountPattern.MatchString(account) {
+			return &ValidationError{
+				Field:   "accounts",
+				Message: "account for " + key + " must look like Assets:Bank:Name",
+			}
+		}
+	}
+	for key, account := range m.categories {
+		if !ledgerAccountPattern.MatchString(account) {
+			return &ValidationError{
+				Field:   "categories",
+				Message: "account for " + key + " must look like Expenses:Food",
+			}
+		}
+	}
+
+	return nil
+}
+
+// SourceAccount returns the account holding the money of a transaction's source
+func (m *LedgerMapping) SourceAccount(tx *Transaction) string {
+	source := strings.ToLower(strings.TrimSpace(tx.Source))
+	if tx.SourceAccount != "" {
+		if account, ok := m.accounts[source+"/"+strings.ToLower(strings.TrimSpace(tx.SourceAccount))]; ok {
+			return account
+		}
+	}
+	if account, ok := m.accounts[source]; ok {
+		return account
+	}
+
+	account := LedgerRootAssets + ":" + LedgerAccountComponent(tx.Source)
+	if tx.SourceAccount != "" {
+		account += ":" + LedgerAccountComponent(tx.SourceAccount)
+	}
+	return account
+}
+
+// CategoryAccount returns the expense or income account of a transaction's category
+func (m *LedgerMapping) CategoryAccount(tx *Transaction) string {
+	if account, ok := m.categories[strings.ToLower(strings.TrimSpace(tx.Category))]; ok && tx.Category != "" {
+		return account
+	}
+
+	root := LedgerRootExpenses
+	if tx.Type == TransactionTypeIn {
+		root = LedgerRootIncome
+	}
+	if tx.Category == "" {
+		return root + ":" + ledgerUncategorized
+	}
+	return root + ":" + LedgerAccountComponent(tx.Category)
+}
+
+// LedgerAccountComponent turns a name into one account name component:
+// letters and digits are kept, anything else becomes a dash, and the first
+// letter is upper-cased, e.g. "bank abc" becomes "Bank-abc".
+func LedgerAccountComponent(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.TrimSpace(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			if b.Len() == 0 {
+				r = unicode.ToUpper(r)
+			}
+			b.WriteRune(r)
+			continue
+		}
+		dash = true
+	}
+
+	if b.Len() == 0 {
+		return "Unknown"
+	}
+	return b.String()
+}
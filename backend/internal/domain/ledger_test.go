@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test LedgerMapping
+
+func TestLedgerMapping_SourceAccount(t *testing.T) {
+	mapping := NewLedgerMapping("vnd", map[string]string{
+		"Vietcombank":      "Assets:Bank:Vietcombank",
+		"vietcombank/9999": "Liabilities:CreditCard:Vietcombank",
+	}, nil)
+
+	assert.Equal(t, "VND", mapping.Currency)
+	assert.Equal(t, "Assets:Bank:Vietcombank", mapping.SourceAccount(&Transaction{Source: "VIETCOMBANK", SourceAccount: "1234"}))
+	assert.Equal(t, "Liabilities:CreditCard:Vietcombank", mapping.SourceAccount(&Transaction{Source: "Vietcombank", SourceAccount: "9999"}))
+	assert.Equal(t, "Assets:Bank-ABC:1234-5678", mapping.SourceAccount(&Transaction{Source: "Bank ABC", SourceAccount: "1234****5678"}))
+	assert.Equal(t, "Assets:MoMo", mapping.SourceAccount(&Transaction{Source: "MoMo"}))
+}
+
+func TestLedgerMapping_CategoryAccount(t *testing.T) {
+	mapping := NewLedgerMapping("USD", nil, map[string]string{"food": "Expenses:Food:Groceries"})
+
+	assert.Equal(t, "Expenses:Food:Groceries", mapping.CategoryAccount(&Transaction{Type: TransactionTypeOut, Category: "Food"}))
+	assert.Equal(t, "Expenses:Food:Groceries", mapping.CategoryAccount(&Transaction{Type: TransactionTypeIn, Category: "Food"}))
+	assert.Equal(t, "Income:Salary", mapping.CategoryAccount(&Transaction{Type: TransactionTypeIn, Category: "Salary"}))
+	assert.Equal(t, "Expenses:Uncategorized", mapping.CategoryAccount(&Transaction{Type: TransactionTypeOut}))
+	assert.Equal(t, "Income:Uncategorized", mapping.CategoryAccount(&Transaction{Type: TransactionTypeIn}))
+}
+
+func TestLedgerMapping_Validate(t *testing.T) {
+	assert.NoError(t, NewLedgerMapping("USD", map[string]string{"Bank": "Assets:Bank:Checking"}, nil).Validate())
+
+	tests := []struct {
+		name    string
+		mapping *LedgerMapping
+		field   string
+	}{
+		{"currency", NewLedgerMapping("us dollar", nil, nil), "currency"},
+		{"account root", NewLedgerMapping("USD", map[string]string{"Bank": "Bank:Checking"}, nil), "accounts"},
+		{"account component", NewLedgerMapping("USD", map[string]string{"Bank": "Assets:bank"}, nil), "accounts"},
+		{"category", NewLedgerMapping("USD", nil, map[string]string{"Food": "Expenses"}), "categories"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *ValidationError
+			assert.ErrorAs(t, tt.mapping.Validate(), &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestLedgerAccountComponent(t *testing.T) {
+	assert.Equal(t, "Bank-abc", LedgerAccountComponent("bank abc"))
+	assert.Equal(t, "Ví-MoMo", LedgerAccountComponent("  ví (MoMo) "))
+	assert.Equal(t, "Unknown", LedgerAccountComponent("***"))
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Two-factor authentication settings
+const (
+	MFAIssuer            = "Finance Tracker" // Account label in authenticator apps
+	MFAQRCodeSize        = 256               // Pixels of the enrolment QR code
+	MFARecoveryCodeCount = 10
+)
+
+// UserMFA is the TOTP two-factor authentication of a user. It is created
+// by enrolment and only guards logins once a first code activates it.
+type UserMFA struct {
+	EnabledAt    *time.Time `json:"enabled_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	Secret       string     `json:"-" gorm:"type:varchar(64);not null"` // Base32 TOTP secret
+	UserID       int64      `json:"user_id" gorm:"primaryKey;autoIncrement:false"`
+	LastUsedStep int64      `json:"-" gorm:"not null;default:0"` // Time step of the last accepted code, refused from then on
+	Enabled      bool       `json:"enabled" gorm:"not null;default:false"`
+}
+
+// TableName specifies the table name for GORM
+func (UserMFA) TableName() string {
+	return "user_mfa"
+}
+
+// MFARecoveryCode is a one-time code that replaces a TOTP code, for users
+// who lost their authenticator. Only its SHA-256 is stored.
+type MFARecoveryCode struct {
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	CodeHash  string    `gorm:"type:varchar(64);not null"`
+	ID        int64     `gorm:"primaryKey"`
+	UserID    int64     `gorm:"not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (MFARecoveryCode) TableName() string {
+	return "mfa_recovery_codes"
+}
+
+// MFAStatus tells whether a user has two-factor authentication
+type MFAStatus struct {
+	Enabled                bool       `json:"enabled"`
+	EnabledAt              *time.Time `json:"enabled_at"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// MFAEnrollment is a new TOTP secret to add to an authenticator app, by
+// scanning the QR code or entering the secret
+type MFAEnrollment struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+	QRCodePNG  []byte `json:"qr_code_png"` // Base64 in JSON
+}
+
+// MFARecoveryCodes are new recovery codes, shown only once
+type MFARecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// MFACodeRequest is the request body of enrolment and 2FA changes: a TOTP
+// code from the authenticator app, or a recovery code where allowed
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required,max=32"`
+}
+
+// Validate normalizes and checks the code
+func (r *MFACodeRequest) Validate() error {
+	return validateMFACode(&r.Code)
+}
+
+// MFAVerifyRequest is the request body of the second login step
+type MFAVerifyRequest struct {
+	MFAToken string     `json:"mfa_token" binding:"required"`
+	Code     string     `json:"code" binding:"required,max=32"`
+	Client   ClientInfo `json:"-"` // set by the handler, for brute-force protection and the security log
+}
+
+// Validate normalizes and checks the code
+func (r *MFAVerifyRequest) Validate() error {
+	if r.MFAToken == "" {
+		return &ValidationError{
+			Field:   "mfa_token",
+			Message: "mfa_token is required",
+		}
+	}
+	return validateMFACode(&r.Code)
+}
+
+// IsTOTPCode reports whether a normalized code is a 6-digit TOTP code
+// rather than a recovery code
+func IsTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// validateMFACode trims the code and drops the spaces some apps show in TOTP codes
+func validateMFACode(code *string) error {
+	*code = strings.TrimSpace(*code)
+	if digits := strings.ReplaceAll(*code, " ", ""); IsTOTPCode(digits) {
+		*code = digits
+	}
+	if *code == "" {
+		return &ValidationError{
+			Field:   "code",
+			Message: "code is required",
+		}
+	}
+	return nil
+}
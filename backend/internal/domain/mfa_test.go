@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFACodeRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    string
+		wantErr bool
+	}{
+		{"totp code", "123456", "123456", false},
+		{"totp code with spaces", " 123 456 ", "123456", false},
+		{"recovery code", " k7m2p-x9qrt\n", "k7m2p-x9qrt", false},
+		{"recovery code keeps spaces", "k7m2p x9qrt", "k7m2p x9qrt", false},
+		{"empty", "   ", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := MFACodeRequest{Code: tt.code}
+			err := req.Validate()
+			if tt.wantErr {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "code", validationErr.Field)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, req.Code)
+		})
+	}
+}
+
+func TestMFAVerifyRequest_Validate(t *testing.T) {
+	req := MFAVerifyRequest{Code: "123456"}
+	var validationErr *ValidationError
+	require.ErrorAs(t, req.Validate(), &validationErr)
+	assert.Equal(t, "mfa_token", validationErr.Field)
+
+	req = MFAVerifyRequest{MFAToken: "challenge", Code: "123 456"}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "123456", req.Code)
+}
+
+func TestIsTOTPCode(t *testing.T) {
+	assert.True(t, IsTOTPCode("012345"))
+	assert.False(t, IsTOTPCode("12345"))
+	assert.False(t, IsTOTPCode("1234567"))
+	assert.False(t, IsTOTPCode("12345a"))
+	assert.False(t, IsTOTPCode("k7m2p-x9qrt"))
+}
+
+func TestUserMFA_JSONHidesSecret(t *testing.T) {
+	data, err := json.Marshal(UserMFA{UserID: 1, Secret: "JBSWY3DPEHPK3PXP", LastUsedStep: 42, Enabled: true})
+
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "JBSWY3DPEHPK3PXP")
+	assert.NotContains(t, string(data), "42")
+}
+
+func TestAuthResponse_MFAChallengeJSON(t *testing.T) {
+	data, err := json.Marshal(AuthResponse{MFARequired: true, MFAToken: "challenge"})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"mfa_required": true, "mfa_token": "challenge"}`, string(data))
+}
@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// WebhookEventType names an event delivered to outgoing webhooks
+type WebhookEventType string
+
+// Events that outgoing webhooks can subscribe to
+const (
+	WebhookEventTransactionCreated WebhookEventType = "transaction.created"
+	WebhookEventTransactionUpdated WebhookEventType = "transaction.updated"
+	WebhookEventBudgetExceeded     WebhookEventType = "budget.exceeded"
+	WebhookEventAnomalyDetected    WebhookEventType = "anomaly.detected"
+)
+
+// ValidWebhookEventTypes contains the events that can be subscribed to
+var ValidWebhookEventTypes = map[WebhookEventType]bool{
+	WebhookEventTransactionCreated: true,
+	WebhookEventTransactionUpdated: true,
+	WebhookEventBudgetExceeded:     true,
+	WebhookEventAnomalyDetected:    true,
+}
+
+// Webhook delivery statuses
+const (
+	WebhookDeliveryPending   = "pending"   // waiting for its first or next attempt
+	WebhookDeliveryDelivered = "delivered" // the endpoint answered with 2xx
+	WebhookDeliveryFailed    = "failed"    // every attempt failed
+)
+
+// Webhook limits
+const (
+	MaxWebhookURLLength         = 2048
+	MaxWebhookDescriptionLength = 255
+	MaxWebhookResponseLength    = 1024 // body of 2xx responses kept in the delivery log
+	DefaultWebhookDeliveryLimit = 50
+	MaxWebhookDeliveryLimit     = 100
+)
+
+// WebhookEndpoint is a URL that receives signed event notifications.
+// The secret signs every delivery and is only returned when the endpoint is created.
+type WebhookEndpoint struct {
+	Events      []WebhookEventType `json:"events" gorm:"type:jsonb;serializer:json;not null"`
+	URL         string             `json:"url" gorm:"type:varchar(2048);not null"`
+	Description string             `json:"description" gorm:"type:varchar(255)"`
+	Secret      string             `json:"-" gorm:"type:varchar(100);not null"`
+	CreatedAt   time.Time          `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time          `json:"updated_at" gorm:"autoUpdateTime"`
+	ID          int64              `json:"id" gorm:"primaryKey"`
+	UserID      int64              `json:"user_id" gorm:"not null;index"`
+	Active      bool               `json:"active" gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (WebhookEndpoint) TableName() string {
+	return "webhook_endpoints"
+}
+
+// Subscribes reports whether the endpoint receives events of eventType
+func (e *WebhookEndpoint) Subscribes(eventType WebhookEventType) bool {
+	if !e.Active {
+		return false
+	}
+	for _, event := range e.Events {
+		if event == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEndpointCreated is returned once when an endpoint is created, with its secret
+type WebhookEndpointCreated struct {
+	WebhookEndpoint
+	Secret string `json:"secret"`
+}
+
+// WebhookEndpointRequest is the request body for creating or updating an endpoint
+type WebhookEndpointRequest struct {
+	URL         string             `json:"url" binding:"required"`
+	Description string             `json:"description"`
+	Events      []WebhookEventType `json:"events" binding:"required"`
+	Active      *bool              `json:"active"` // defaults to true
+}
+
+// Validate checks the URL and the subscribed events
+func (r *WebhookEndpointRequest) Validate() error {
+	if len(r.URL) > MaxWebhookURLLength {
+		return &ValidationError{
+			Field:   "url",
+			Message: "url is too long",
+		}
+	}
+	parsed, err := url.Parse(r.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return &ValidationError{
+			Field:   "url",
+			Message: "url must be an absolute http or https URL",
+		}
+	}
+	if parsed.User != nil {
+		return &ValidationError{
+			Field:   "url",
+			Message: "url must not contain credentials; deliveries are signed instead",
+		}
+	}
+
+	if len(r.Description) > MaxWebhookDescriptionLength {
+		return &ValidationError{
+			Field:   "description",
+			Message: "description is too long",
+		}
+	}
+
+	if len(r.Events) == 0 {
+		return &ValidationError{
+			Field:   "events",
+			Message: "at least one event is required",
+		}
+	}
+	seen := make(map[WebhookEventType]bool, len(r.Events))
+	events := r.Events[:0]
+	for _, event := range r.Events {
+		if !ValidWebhookEventTypes[event] {
+			return &ValidationError{
+				Field:   "events",
+				Message: "invalid event. Valid events are: transaction.created, transaction.updated, budget.exceeded, anomaly.detected",
+			}
+		}
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+	r.Events = events
+
+	return nil
+}
+
+// WebhookEvent is the JSON body of every delivery
+type WebhookEvent struct {
+	ID        string           `json:"id"`
+	Type      WebhookEventType `json:"type"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      interface{}      `json:"data"`
+}
+
+// TransactionEventData is the data of transaction.created and transaction.updated events
+type TransactionEventData struct {
+	Transaction Transaction  `json:"transaction"`
+	Previous    *Transaction `json:"previous,omitempty"` // Before an update
+}
+
+// BudgetExceededEventData is the data of a budget.exceeded event
+type BudgetExceededEventData struct {
+	Period      string         `json:"period"` // YYYY-MM
+	Budget      BudgetProgress `json:"budget"`
+	Transaction Transaction    `json:"transaction"` // The expense that went over the budget
+}
+
+// WebhookDelivery is one attempt series of sending an event to an endpoint.
+// Redelivering creates a new delivery with the same event.
+type WebhookDelivery struct {
+	Payload        json.RawMessage  `json:"payload" gorm:"type:jsonb;serializer:json;not null"`
+	NextAttemptAt  *time.Time       `json:"next_attempt_at" gorm:"index"` // Nil once delivered or failed
+	DeliveredAt    *time.Time       `json:"delivered_at"`
+	RedeliveryOf   *int64           `json:"redelivery_of"`
+	Endpoint       *WebhookEndpoint `json:"-" gorm:"foreignKey:EndpointID;constraint:OnDelete:CASCADE"`
+	CreatedAt      time.Time        `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+	EventID        string           `json:"event_id" gorm:"type:varchar(50);not null;index"`
+	EventType      WebhookEventType `json:"event_type" gorm:"type:varchar(50);not null"`
+	Status         string           `json:"status" gorm:"type:varchar(20);not null;index"`
+	Error          string           `json:"error" gorm:"type:text"`
+	ResponseBody   string           `json:"response_body" gorm:"type:text"`
+	ID             int64            `json:"id" gorm:"primaryKey"`
+	EndpointID     int64            `json:"endpoint_id" gorm:"not null;index"`
+	DurationMs     int64            `json:"duration_ms"`
+	Attempts       int              `json:"attempts" gorm:"not null;default:0"`
+	ResponseStatus int              `json:"response_status"`
+}
+
+// TableName specifies the table name for GORM
+func (WebhookDelivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// WebhookDeliveryQueryParams are the query parameters for the delivery log
+type WebhookDeliveryQueryParams struct {
+	Status string `form:"status"`
+	Limit  int    `form:"limit"`
+}
+
+// Validate checks the status filter and applies the default limit
+func (p *WebhookDeliveryQueryParams) Validate() error {
+	switch p.Status {
+	case "", WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryFailed:
+	default:
+		return &ValidationError{
+			Field:   "status",
+			Message: "status must be pending, delivered or failed",
+		}
+	}
+
+	if p.Limit == 0 {
+		p.Limit = DefaultWebhookDeliveryLimit
+	}
+	if p.Limit < 1 || p.Limit > MaxWebhookDeliveryLimit {
+		return &ValidationError{
+			Field:   "limit",
+			Message: "limit must be between 1 and 100",
+		}
+	}
+
+	return nil
+}
+
+// WebhookRunResult reports the outcome of one delivery run
+type WebhookRunResult struct {
+	Delivered int `json:"delivered"`
+	Retrying  int `json:"retrying"`
+	Failed    int `json:"failed"`
+}
@@ -0,0 +1,87 @@
+package domain
+
+import "time"
+
+// DefaultPasswordResetTTL is how long a password reset link stays valid
+const DefaultPasswordResetTTL = time.Hour
+
+// PasswordResetToken is a single-use token that lets a user who forgot the
+// password choose a new one. Only its SHA-256 is stored.
+type PasswordResetToken struct {
+	ExpiresAt time.Time  `json:"expires_at" gorm:"not null;index"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UsedAt    *time.Time `json:"used_at"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;unique"`
+	ID        int64      `json:"id" gorm:"primaryKey"`
+	UserID    int64      `json:"user_id" gorm:"not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
+
+// ChangePasswordRequest is the request body for changing the password of
+// the signed-in user
+type ChangePasswordRequest struct {
+	CurrentPassword string     `json:"current_password" binding:"required"`
+	NewPassword     string     `json:"new_password" binding:"required,min=10,max=128"`
+	Client          ClientInfo `json:"-"` // set by the handler, for brute-force protection and the security log
+}
+
+// Validate checks the new password against the password rules
+func (r *ChangePasswordRequest) Validate() error {
+	if r.CurrentPassword == "" {
+		return &ValidationError{
+			Field:   "current_password",
+			Message: "current_password is required",
+		}
+	}
+	if err := validatePassword("new_password", r.NewPassword); err != nil {
+		return err
+	}
+	if r.NewPassword == r.CurrentPassword {
+		return &ValidationError{
+			Field:   "new_password",
+			Message: "new_password must differ from the current password",
+		}
+	}
+	return nil
+}
+
+// ForgotPasswordRequest is the request body for requesting a password reset link
+type ForgotPasswordRequest struct {
+	Email  string     `json:"email" binding:"required,email,max=255"`
+	Client ClientInfo `json:"-"` // set by the handler, for the security log
+}
+
+// Validate normalizes and checks the email
+func (r *ForgotPasswordRequest) Validate() error {
+	r.Email = SanitizeEmail(r.Email)
+	if !EmailRegex.MatchString(r.Email) {
+		return &ValidationError{
+			Field:   "email",
+			Message: "invalid email format",
+		}
+	}
+	return nil
+}
+
+// ResetPasswordRequest is the request body for choosing a new password with
+// the token of a reset link
+type ResetPasswordRequest struct {
+	Token       string     `json:"token" binding:"required,max=128"`
+	NewPassword string     `json:"new_password" binding:"required,min=10,max=128"`
+	Client      ClientInfo `json:"-"` // set by the handler, for the security log
+}
+
+// Validate checks the token and the new password
+func (r *ResetPasswordRequest) Validate() error {
+	if r.Token == "" {
+		return &ValidationError{
+			Field:   "token",
+			Message: "token is required",
+		}
+	}
+	return validatePassword("new_password", r.NewPassword)
+}
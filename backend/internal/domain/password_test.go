@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangePasswordRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   string
+		new       string
+		wantField string
+	}{
+		{"valid", "Password123", "NewPassword456", ""},
+		{"missing current password", "", "NewPassword456", "current_password"},
+		{"too short", "Password123", "Short1", "new_password"},
+		{"no digit", "Password123", "NoDigitsHere", "new_password"},
+		{"no uppercase", "Password123", "lowercase123", "new_password"},
+		{"same as current", "Password123", "Password123", "new_password"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := ChangePasswordRequest{CurrentPassword: tt.current, NewPassword: tt.new}
+			err := req.Validate()
+			if tt.wantField == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.wantField, validationErr.Field)
+		})
+	}
+}
+
+func TestForgotPasswordRequest_Validate(t *testing.T) {
+	req := ForgotPasswordRequest{Email: "  Test@Example.COM "}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "test@example.com", req.Email)
+
+	req = ForgotPasswordRequest{Email: "not-an-email"}
+	var validationErr *ValidationError
+	require.ErrorAs(t, req.Validate(), &validationErr)
+	assert.Equal(t, "email", validationErr.Field)
+}
+
+func TestResetPasswordRequest_Validate(t *testing.T) {
+	req := ResetPasswordRequest{Token: "abc", NewPassword: "NewPassword456"}
+	assert.NoError(t, req.Validate())
+
+	var validationErr *ValidationError
+	req = ResetPasswordRequest{NewPassword: "NewPassword456"}
+	require.ErrorAs(t, req.Validate(), &validationErr)
+	assert.Equal(t, "token", validationErr.Field)
+
+	req = ResetPasswordRequest{Token: "abc", NewPassword: "weakpassword"}
+	require.ErrorAs(t, req.Validate(), &validationErr)
+	assert.Equal(t, "new_password", validationErr.Field)
+}
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// MaxPayeeNameLength is the maximum length for payee names
+	MaxPayeeNameLength = 100
+	// MaxPayeeAliasLength is the maximum length for a payee alias pattern
+	MaxPayeeAliasLength = 100
+	// MaxPayeeAliases is the maximum number of alias patterns per payee
+	MaxPayeeAliases = 20
+	// MaxPayeeBreakdownLimit is the maximum number of payees returned by the breakdown
+	MaxPayeeBreakdownLimit = 100
+)
+
+// Payee is a merchant or person that transactions are normalised onto.
+// Transactions are linked when their recipient or description matches the
+// payee's name or one of its alias patterns.
+type Payee struct {
+	CreatedAt       time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
+	Name            string       `json:"name" gorm:"type:varchar(100);not null;unique"`
+	DefaultCategory string       `json:"default_category" gorm:"type:varchar(50)"` // Applied to linked transactions without a category
+	Aliases         []PayeeAlias `json:"aliases" gorm:"foreignKey:PayeeID"`
+	ID              int64        `json:"id" gorm:"primaryKey"`
+}
+
+// TableName specifies the table name for GORM
+func (Payee) TableName() string {
+	return "payees"
+}
+
+// PayeeAlias is a case-insensitive pattern matched against the whole recipient
+// or description, where * matches any run of characters (e.g. "GRAB*")
+type PayeeAlias struct {
+	Pattern string `json:"pattern" gorm:"type:varchar(100);not null;unique"`
+	ID      int64  `json:"id" gorm:"primaryKey"`
+	PayeeID int64  `json:"payee_id" gorm:"not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (PayeeAlias) TableName() string {
+	return "payee_aliases"
+}
+
+// PayeeRequest is the request body for creating or updating a payee
+type PayeeRequest struct {
+	Name            string   `json:"name" binding:"required,max=100"`
+	DefaultCategory string   `json:"default_category" binding:"omitempty,max=50"`
+	Aliases         []string `json:"aliases"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *PayeeRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" || len(r.Name) > MaxPayeeNameLength {
+		return &ValidationError{
+			Field:   "name",
+			Message: "name is required and must be at most 100 characters",
+		}
+	}
+
+	if r.DefaultCategory != "" && !ValidCategories[r.DefaultCategory] {
+		return &ValidationError{
+			Field:   "default_category",
+			Message: "invalid category. Valid categories are: Food, Transportation, Housing, Utilities, Entertainment, Healthcare, Shopping, Education, Salary, Investment, Transfer, Other",
+		}
+	}
+
+	if len(r.Aliases) > MaxPayeeAliases {
+		return &ValidationError{
+			Field:   "aliases",
+			Message: "at most 20 aliases are allowed",
+		}
+	}
+
+	seen := make(map[string]bool, len(r.Aliases))
+	for _, alias := range r.Aliases {
+		pattern := strings.TrimSpace(alias)
+		if strings.Trim(pattern, "*") == "" || len(pattern) > MaxPayeeAliasLength {
+			return &ValidationError{
+				Field:   "aliases",
+				Message: "each alias must contain text besides * and be at most 100 characters",
+			}
+		}
+		if seen[strings.ToLower(pattern)] {
+			return &ValidationError{
+				Field:   "aliases",
+				Message: "aliases must be unique",
+			}
+		}
+		seen[strings.ToLower(pattern)] = true
+	}
+
+	return nil
+}
+
+// ToPayee converts a validated request to a payee
+func (r *PayeeRequest) ToPayee() *Payee {
+	payee := &Payee{
+		Name:            strings.TrimSpace(r.Name),
+		DefaultCategory: r.DefaultCategory,
+		Aliases:         make([]PayeeAlias, 0, len(r.Aliases)),
+	}
+	for _, alias := range r.Aliases {
+		payee.Aliases = append(payee.Aliases, PayeeAlias{Pattern: strings.TrimSpace(alias)})
+	}
+	return payee
+}
+
+// MergePayeesRequest is the request body for merging payees into another one
+type MergePayeesRequest struct {
+	SourcePayeeIDs []int64 `json:"source_payee_ids" binding:"required,min=1"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *MergePayeesRequest) Validate(targetID int64) error {
+	if len(r.SourcePayeeIDs) == 0 {
+		return &ValidationError{
+			Field:   "source_payee_ids",
+			Message: "at least one payee to merge is required",
+		}
+	}
+
+	for _, id := range r.SourcePayeeIDs {
+		if id <= 0 || id == targetID {
+			return &ValidationError{
+				Field:   "source_payee_ids",
+				Message: "source_payee_ids must be valid IDs other than the target payee",
+			}
+		}
+	}
+
+	return nil
+}
+
+// MergePayeesResponse is the result of merging payees
+type MergePayeesResponse struct {
+	Payee                *Payee `json:"payee"`
+	MergedPayees         int    `json:"merged_payees"`
+	RelinkedTransactions int64  `json:"relinked_transactions"`
+}
+
+// PayeeBreakdownQueryParams represents query parameters for the by-payee endpoint
+type PayeeBreakdownQueryParams struct {
+	Type      TransactionType `form:"type"`
+	StartDate string          `form:"start_date"`
+	EndDate   string          `form:"end_date"`
+	Limit     int             `form:"limit,default=10"`
+}
+
+// Validate applies defaults and checks the query parameters
+func (p *PayeeBreakdownQueryParams) Validate() error {
+	if p.Type == "" {
+		p.Type = TransactionTypeOut
+	}
+
+	if p.Type != TransactionTypeIn && p.Type != TransactionTypeOut {
+		return &ValidationError{
+			Field:   "type",
+			Message: "type must be one of: in, out",
+		}
+	}
+
+	if p.Limit < 1 || p.Limit > MaxPayeeBreakdownLimit {
+		return &ValidationError{
+			Field:   "limit",
+			Message: "limit must be between 1 and 100",
+		}
+	}
+
+	_, err := ParseDateRange(p.StartDate, p.EndDate)
+	return err
+}
+
+// ToFilter converts validated query parameters to a repository filter
+func (p *PayeeBreakdownQueryParams) ToFilter() (*PayeeBreakdownFilter, error) {
+	dates, err := ParseDateRange(p.StartDate, p.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayeeBreakdownFilter{
+		Type:      p.Type,
+		Limit:     p.Limit,
+		DateRange: dates,
+	}, nil
+}
+
+// PayeeBreakdownFilter is the validated filter for the payee breakdown query
+type PayeeBreakdownFilter struct {
+	DateRange
+	Type  TransactionType
+	Limit int
+}
+
+// PayeeBreakdown is the total for one payee. The percentage is relative to all
+// transactions of the type in the range, including those without a payee.
+type PayeeBreakdown struct {
+	Name       string  `json:"name"`
+	PayeeID    int64   `json:"payee_id"`
+	Amount     float64 `json:"amount"`
+	Average    float64 `json:"average"`
+	Percentage float64 `json:"percentage"`
+	Count      int64   `json:"count"`
+}
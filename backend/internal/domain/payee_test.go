@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test PayeeRequest Validate
+
+func TestPayeeRequest_Validate_Valid(t *testing.T) {
+	req := &PayeeRequest{Name: "Grab", DefaultCategory: "Transportation", Aliases: []string{"GRAB*", "GrabPay"}}
+
+	assert.NoError(t, req.Validate())
+}
+
+func TestPayeeRequest_Validate_MissingName(t *testing.T) {
+	req := &PayeeRequest{Name: "   "}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "name", validationErr.Field)
+}
+
+func TestPayeeRequest_Validate_InvalidCategory(t *testing.T) {
+	req := &PayeeRequest{Name: "Grab", DefaultCategory: "Taxi"}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "default_category", validationErr.Field)
+}
+
+func TestPayeeRequest_Validate_InvalidAliases(t *testing.T) {
+	tests := []struct {
+		name    string
+		aliases []string
+	}{
+		{"wildcard only", []string{"**"}},
+		{"blank", []string{" "}},
+		{"too long", []string{strings.Repeat("a", MaxPayeeAliasLength+1)}},
+		{"duplicate ignoring case", []string{"GRAB*", "grab*"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &PayeeRequest{Name: "Grab", Aliases: tt.aliases}
+
+			err := req.Validate()
+
+			var validationErr *ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "aliases", validationErr.Field)
+		})
+	}
+}
+
+func TestPayeeRequest_ToPayee_TrimsAliases(t *testing.T) {
+	req := &PayeeRequest{Name: " Grab ", Aliases: []string{" GRAB* "}}
+
+	payee := req.ToPayee()
+
+	assert.Equal(t, "Grab", payee.Name)
+	assert.Equal(t, []PayeeAlias{{Pattern: "GRAB*"}}, payee.Aliases)
+}
+
+// Test MergePayeesRequest Validate
+
+func TestMergePayeesRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&MergePayeesRequest{SourcePayeeIDs: []int64{2, 3}}).Validate(1))
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, (&MergePayeesRequest{}).Validate(1), &validationErr)
+	assert.ErrorAs(t, (&MergePayeesRequest{SourcePayeeIDs: []int64{1}}).Validate(1), &validationErr)
+	assert.ErrorAs(t, (&MergePayeesRequest{SourcePayeeIDs: []int64{0}}).Validate(1), &validationErr)
+}
+
+// Test PayeeBreakdownQueryParams Validate
+
+func TestPayeeBreakdownQueryParams_Validate_Defaults(t *testing.T) {
+	params := &PayeeBreakdownQueryParams{Limit: 10}
+
+	assert.NoError(t, params.Validate())
+	assert.Equal(t, TransactionTypeOut, params.Type)
+}
+
+func TestPayeeBreakdownQueryParams_Validate_LimitOutOfRange(t *testing.T) {
+	params := &PayeeBreakdownQueryParams{Limit: MaxPayeeBreakdownLimit + 1}
+
+	err := params.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "limit", validationErr.Field)
+}
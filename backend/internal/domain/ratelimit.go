@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimitPolicy is a token bucket holding up to Burst requests that refills
+// at Requests per Period. A policy without requests is disabled.
+type RateLimitPolicy struct {
+	Name     string // Prefix of the bucket keys, so policies sharing a key do not share buckets
+	Requests int
+	Period   time.Duration
+	Burst    int // Defaults to Requests
+}
+
+// Enabled reports whether the policy limits requests
+func (p RateLimitPolicy) Enabled() bool {
+	return p.Requests > 0
+}
+
+// Validate checks that an enabled policy has a period and a usable burst
+func (p RateLimitPolicy) Validate() error {
+	if !p.Enabled() {
+		return nil
+	}
+	if p.Period <= 0 {
+		return &ValidationError{
+			Field:   p.Name + ".period",
+			Message: p.Name + " period must be positive",
+		}
+	}
+	if p.Burst < 0 {
+		return &ValidationError{
+			Field:   p.Name + ".burst",
+			Message: p.Name + " burst must not be negative",
+		}
+	}
+	return nil
+}
+
+// Capacity returns the number of tokens in a full bucket
+func (p RateLimitPolicy) Capacity() float64 {
+	if p.Burst > 0 {
+		return float64(p.Burst)
+	}
+	return float64(p.Requests)
+}
+
+// Rate returns the refill rate in tokens per second
+func (p RateLimitPolicy) Rate() float64 {
+	return float64(p.Requests) / p.Period.Seconds()
+}
+
+// RefillTime returns how long an empty bucket takes to fill up
+func (p RateLimitPolicy) RefillTime() time.Duration {
+	return p.refillTime(p.Capacity())
+}
+
+// Take refills a bucket that held tokens elapsed ago and takes a token from
+// it if one is left, returning the tokens left afterwards
+func (p RateLimitPolicy) Take(tokens float64, elapsed time.Duration) (float64, bool) {
+	tokens = math.Min(p.Capacity(), tokens+elapsed.Seconds()*p.Rate())
+	if tokens < 1 {
+		return tokens, false
+	}
+	return tokens - 1, true
+}
+
+// Result describes a bucket holding tokens after a request was allowed or denied
+func (p RateLimitPolicy) Result(tokens float64, allowed bool) RateLimitResult {
+	result := RateLimitResult{
+		Allowed:   allowed,
+		Limit:     int(p.Capacity()),
+		Remaining: int(math.Floor(tokens)),
+		Reset:     p.refillTime(p.Capacity() - tokens),
+	}
+	if !allowed {
+		result.RetryAfter = p.refillTime(1 - tokens)
+	}
+	return result
+}
+
+// refillTime returns how long it takes to refill tokens
+func (p RateLimitPolicy) refillTime(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(tokens / p.Rate() * float64(time.Second)))
+}
+
+// RateLimitResult is the outcome of taking a token for a request
+type RateLimitResult struct {
+	Allowed    bool
+	Limit      int           // Tokens in a full bucket
+	Remaining  int           // Whole tokens left
+	RetryAfter time.Duration // Until the next token when denied
+	Reset      time.Duration // Until the bucket is full again
+}
+
+// RateLimitBucket is a token bucket shared by all API replicas
+type RateLimitBucket struct {
+	Key       string    `gorm:"primaryKey;type:varchar(255)"`
+	Tokens    float64   `gorm:"not null"`
+	Allowed   bool      `gorm:"not null"` // Whether the last request was allowed
+	UpdatedAt time.Time `gorm:"not null;index;autoUpdateTime:false"`
+}
+
+// TableName specifies the table name for GORM
+func (RateLimitBucket) TableName() string {
+	return "rate_limit_buckets"
+}
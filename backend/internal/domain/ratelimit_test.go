@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitPolicy_Take(t *testing.T) {
+	policy := RateLimitPolicy{Name: "auth", Requests: 10, Period: time.Minute, Burst: 5}
+
+	tests := []struct {
+		name      string
+		tokens    float64
+		elapsed   time.Duration
+		remaining float64
+		allowed   bool
+	}{
+		{"full bucket", 5, 0, 4, true},
+		{"last token", 1, 0, 0, true},
+		{"empty bucket", 0.5, 0, 0.5, false},
+		{"refilled", 0, 6 * time.Second, 0, true},
+		{"refill capped at burst", 2, time.Hour, 4, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining, allowed := policy.Take(tt.tokens, tt.elapsed)
+
+			assert.InDelta(t, tt.remaining, remaining, 1e-9)
+			assert.Equal(t, tt.allowed, allowed)
+		})
+	}
+}
+
+func TestRateLimitPolicy_Result(t *testing.T) {
+	policy := RateLimitPolicy{Name: "auth", Requests: 10, Period: time.Minute, Burst: 5}
+
+	denied := policy.Result(0.5, false)
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, 5, denied.Limit)
+	assert.Equal(t, 0, denied.Remaining)
+	assert.Equal(t, 3*time.Second, denied.RetryAfter)
+	assert.Equal(t, 27*time.Second, denied.Reset)
+
+	allowed := policy.Result(3.2, true)
+	assert.Equal(t, 3, allowed.Remaining)
+	assert.Zero(t, allowed.RetryAfter)
+}
+
+func TestRateLimitPolicy_CapacityDefaultsToRequests(t *testing.T) {
+	policy := RateLimitPolicy{Requests: 60, Period: time.Minute}
+
+	assert.Equal(t, 60.0, policy.Capacity())
+	assert.Equal(t, time.Minute, policy.RefillTime())
+}
+
+func TestRateLimitPolicy_Validate(t *testing.T) {
+	assert.NoError(t, RateLimitPolicy{Name: "user"}.Validate(), "disabled policies need no period")
+	assert.NoError(t, RateLimitPolicy{Name: "user", Requests: 1, Period: time.Second}.Validate())
+
+	err := RateLimitPolicy{Name: "user", Requests: 1}.Validate()
+	assert.EqualError(t, err, "user period must be positive")
+
+	err = RateLimitPolicy{Name: "user", Requests: 1, Period: time.Second, Burst: -1}.Validate()
+	assert.EqualError(t, err, "user burst must not be negative")
+}
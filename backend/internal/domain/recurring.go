@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+)
+
+// RecurringInterval is the detected cadence of a recurring series
+type RecurringInterval string
+
+const (
+	RecurringIntervalWeekly  RecurringInterval = "weekly"
+	RecurringIntervalMonthly RecurringInterval = "monthly"
+	RecurringIntervalYearly  RecurringInterval = "yearly"
+)
+
+// RecurringSeries is a group of transactions that repeat with a similar
+// recipient, amount and interval, e.g. a subscription or a monthly bill
+type RecurringSeries struct {
+	LastDate           time.Time         `json:"last_date"`
+	NextExpectedDate   time.Time         `json:"next_expected_date"`
+	Payee              string            `json:"payee"` // Recipient, or description when no recipient is set
+	Type               TransactionType   `json:"type"`
+	Interval           RecurringInterval `json:"interval"`
+	Category           string            `json:"category"`
+	Source             string            `json:"source"`
+	TransactionIDs     []int64           `json:"transaction_ids"`
+	Occurrences        int               `json:"occurrences"`
+	AverageAmount      float64           `json:"average_amount"`
+	LastAmount         float64           `json:"last_amount"`
+	PreviousAmount     float64           `json:"previous_amount"`
+	PriceChangePercent float64           `json:"price_change_percent"` // Change of the last charge against the previous one
+	MonthlyAmount      float64           `json:"monthly_amount"`       // Average amount normalized to a month
+	Missed             bool              `json:"missed"`               // The next charge is overdue
+	PriceChanged       bool              `json:"price_changed"`
+}
+
+// RecurringResponse is the response for the recurring transactions endpoint
+type RecurringResponse struct {
+	AnalyzedAt   time.Time         `json:"analyzed_at"`
+	Series       []RecurringSeries `json:"series"`
+	MonthlyTotal float64           `json:"monthly_total"` // Sum of monthly amounts for series that are not missed
+}
+
+// RecurringQueryParams represents query parameters for the recurring transactions endpoint
+type RecurringQueryParams struct {
+	Type TransactionType `form:"type"`
+}
+
+// Validate validates the query parameters
+func (p *RecurringQueryParams) Validate() error {
+	if p.Type != "" && p.Type != TransactionTypeIn && p.Type != TransactionTypeOut {
+		return &ValidationError{
+			Field:   "type",
+			Message: "invalid type. Must be 'in' or 'out'",
+		}
+	}
+	return nil
+}
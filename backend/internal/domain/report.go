@@ -0,0 +1,118 @@
+package domain
+
+import "time"
+
+// ReportFormat is the output format of a rendered report
+type ReportFormat string
+
+// Supported report formats
+const (
+	ReportFormatHTML ReportFormat = "html"
+	ReportFormatPDF  ReportFormat = "pdf"
+)
+
+// Number of rows in the ranked sections of the monthly statement
+const (
+	ReportTopPayees           = 5
+	ReportBiggestTransactions = 10
+)
+
+// MonthlyReportQueryParams represents query parameters for the monthly statement
+type MonthlyReportQueryParams struct {
+	Month  string       `form:"month"`  // YYYY-MM, defaults to the current month
+	Format ReportFormat `form:"format"` // html or pdf, defaults to html
+}
+
+// Validate applies defaults relative to now and checks the month and format.
+// Months after the current one are rejected.
+func (p *MonthlyReportQueryParams) Validate(now time.Time) error {
+	if p.Format == "" {
+		p.Format = ReportFormatHTML
+	}
+	if p.Format != ReportFormatHTML && p.Format != ReportFormatPDF {
+		return &ValidationError{
+			Field:   "format",
+			Message: "format must be 'html' or 'pdf'",
+		}
+	}
+
+	current := now.UTC().Format(MonthLayout)
+	if p.Month == "" {
+		p.Month = current
+	}
+
+	month, err := time.Parse(MonthLayout, p.Month)
+	if err != nil {
+		return &ValidationError{
+			Field:   "month",
+			Message: "invalid month format. Must be YYYY-MM",
+		}
+	}
+	if month.Format(MonthLayout) > current {
+		return &ValidationError{
+			Field:   "month",
+			Message: "month must not be in the future",
+		}
+	}
+
+	return nil
+}
+
+// PeriodSummary holds the totals of a month or week
+type PeriodSummary struct {
+	Income           float64 `json:"income"`
+	Expense          float64 `json:"expense"`
+	Net              float64 `json:"net"`
+	TransactionCount int64   `json:"transaction_count"`
+}
+
+// PeriodComparison compares a period with the one before it. Percentages
+// are nil when the prior period has nothing to compare against.
+type PeriodComparison struct {
+	IncomePercent  *float64 `json:"income_percent"`
+	ExpensePercent *float64 `json:"expense_percent"`
+	IncomeChange   float64  `json:"income_change"`
+	ExpenseChange  float64  `json:"expense_change"`
+	NetChange      float64  `json:"net_change"`
+}
+
+// MonthlyReport is the monthly statement: totals compared with the prior
+// month, where the money went and how the budgets held up
+type MonthlyReport struct {
+	Month               string              `json:"month"` // YYYY-MM
+	Currency            string              `json:"currency"`
+	PeriodStart         time.Time           `json:"period_start"`
+	PeriodEnd           time.Time           `json:"period_end"`
+	GeneratedAt         time.Time           `json:"generated_at"`
+	Categories          []BreakdownResponse `json:"categories"` // Expenses by category
+	Sources             []BreakdownResponse `json:"sources"`    // Expenses by source
+	TopPayees           []PayeeBreakdown    `json:"top_payees"`
+	BiggestTransactions []Transaction       `json:"biggest_transactions"`
+	Budgets             []BudgetProgress    `json:"budgets"`
+	Summary             PeriodSummary       `json:"summary"`
+	PreviousSummary     PeriodSummary       `json:"previous_summary"`
+	Comparison          PeriodComparison    `json:"comparison"`
+	TotalBudget         float64             `json:"total_budget"`
+	TotalBudgetSpent    float64             `json:"total_budget_spent"`
+}
+
+// ComparePeriods computes the change from previous to current
+func ComparePeriods(current, previous PeriodSummary) PeriodComparison {
+	return PeriodComparison{
+		IncomePercent:  percentChange(current.Income, previous.Income),
+		ExpensePercent: percentChange(current.Expense, previous.Expense),
+		IncomeChange:   current.Income - previous.Income,
+		ExpenseChange:  current.Expense - previous.Expense,
+		NetChange:      current.Net - previous.Net,
+	}
+}
+
+// percentChange returns the change from previous to current in percent,
+// or nil when previous is zero
+func percentChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / previous * 100
+	return &change
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test MonthlyReportQueryParams Validate
+
+func TestMonthlyReportQueryParams_Validate_Defaults(t *testing.T) {
+	params := &MonthlyReportQueryParams{}
+
+	err := params.Validate(time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-03", params.Month)
+	assert.Equal(t, ReportFormatHTML, params.Format)
+}
+
+func TestMonthlyReportQueryParams_Validate_Errors(t *testing.T) {
+	now := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		params MonthlyReportQueryParams
+		field  string
+	}{
+		{"future month", MonthlyReportQueryParams{Month: "2026-04"}, "month"},
+		{"invalid month", MonthlyReportQueryParams{Month: "2026-3"}, "month"},
+		{"unknown format", MonthlyReportQueryParams{Month: "2026-02", Format: "docx"}, "format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate(now)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestComparePeriods(t *testing.T) {
+	comparison := ComparePeriods(
+		PeriodSummary{Income: 1200, Expense: 900, Net: 300},
+		PeriodSummary{Income: 1000, Expense: 0, Net: 1000},
+	)
+
+	require.NotNil(t, comparison.IncomePercent)
+	assert.InDelta(t, 20, *comparison.IncomePercent, 0.001)
+	assert.Nil(t, comparison.ExpensePercent, "no expenses in the prior month")
+	assert.Equal(t, 200.0, comparison.IncomeChange)
+	assert.Equal(t, 900.0, comparison.ExpenseChange)
+	assert.Equal(t, -700.0, comparison.NetChange)
+}
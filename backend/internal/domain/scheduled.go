@@ -0,0 +1,299 @@
+package domain
+
+import (
+	"time"
+)
+
+// ScheduleFrequency is how often a scheduled transaction repeats
+type ScheduleFrequency string
+
+const (
+	ScheduleFrequencyOnce    ScheduleFrequency = "once"
+	ScheduleFrequencyWeekly  ScheduleFrequency = "weekly"
+	ScheduleFrequencyMonthly ScheduleFrequency = "monthly"
+	ScheduleFrequencyYearly  ScheduleFrequency = "yearly"
+)
+
+// UpcomingStatus describes when an unpaid occurrence is due
+type UpcomingStatus string
+
+const (
+	UpcomingStatusOverdue  UpcomingStatus = "overdue"
+	UpcomingStatusDueToday UpcomingStatus = "due_today"
+	UpcomingStatusUpcoming UpcomingStatus = "upcoming"
+)
+
+const (
+	// MaxScheduleNameLength is the maximum length for scheduled transaction names
+	MaxScheduleNameLength = 100
+	// DefaultAmountTolerance is the default accepted amount deviation in percent when matching
+	DefaultAmountTolerance = 5.0
+	// MaxAmountTolerance is the maximum accepted amount deviation in percent
+	MaxAmountTolerance = 50.0
+	// ScheduleMatchWindowDays is how many days before its due date a transaction may pay an occurrence
+	ScheduleMatchWindowDays = 7
+	// MaxUpcomingDays is the maximum look-ahead for upcoming transactions
+	MaxUpcomingDays = 365
+)
+
+// ValidScheduleFrequencies contains the accepted recurrence frequencies
+var ValidScheduleFrequencies = map[ScheduleFrequency]bool{
+	ScheduleFrequencyOnce:    true,
+	ScheduleFrequencyWeekly:  true,
+	ScheduleFrequencyMonthly: true,
+	ScheduleFrequencyYearly:  true,
+}
+
+// ScheduledTransaction is an expected future transaction with a recurrence rule,
+// e.g. rent on the 5th of every month
+type ScheduledTransaction struct {
+	StartDate       time.Time         `json:"start_date" gorm:"not null"` // First due date; anchors the day of month
+	NextDueDate     time.Time         `json:"next_due_date" gorm:"not null;index"`
+	CreatedAt       time.Time         `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt       time.Time         `json:"updated_at" gorm:"autoUpdateTime"`
+	EndDate         *time.Time        `json:"end_date"`
+	Name            string            `json:"name" gorm:"type:varchar(100);not null"`
+	Type            TransactionType   `json:"type" gorm:"type:varchar(20);not null"`
+	Frequency       ScheduleFrequency `json:"frequency" gorm:"type:varchar(20);not null"`
+	Category        string            `json:"category" gorm:"type:varchar(50)"`
+	Source          string            `json:"source" gorm:"type:varchar(100)"`    // Empty matches any source
+	Recipient       string            `json:"recipient" gorm:"type:varchar(100)"` // Empty matches any recipient
+	ID              int64             `json:"id" gorm:"primaryKey"`
+	IntervalCount   int               `json:"interval" gorm:"column:interval_count;not null;default:1"` // Repeat every N periods
+	Occurrences     int               `json:"occurrences" gorm:"not null;default:0"`                    // Occurrences already paid
+	Amount          float64           `json:"amount" gorm:"type:decimal(15,2);not null"`
+	AmountTolerance float64           `json:"amount_tolerance" gorm:"type:decimal(5,2);not null"` // Accepted deviation in percent
+	Active          bool              `json:"active" gorm:"not null;default:true"`
+}
+
+// TableName specifies the table name for GORM
+func (ScheduledTransaction) TableName() string {
+	return "scheduled_transactions"
+}
+
+// DueDate returns the due date of the n-th occurrence (zero-based).
+// Monthly and yearly schedules keep the start date's day, clamped to the
+// last day of shorter months.
+func (s *ScheduledTransaction) DueDate(n int) time.Time {
+	interval := s.IntervalCount
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch s.Frequency {
+	case ScheduleFrequencyWeekly:
+		return s.StartDate.AddDate(0, 0, 7*interval*n)
+	case ScheduleFrequencyMonthly:
+		return addMonthsClamped(s.StartDate, interval*n)
+	case ScheduleFrequencyYearly:
+		return addMonthsClamped(s.StartDate, 12*interval*n)
+	default:
+		return s.StartDate
+	}
+}
+
+// HasOccurrence reports whether the n-th occurrence exists under the schedule's end rules
+func (s *ScheduledTransaction) HasOccurrence(n int) bool {
+	if s.Frequency == ScheduleFrequencyOnce && n > 0 {
+		return false
+	}
+	if s.EndDate != nil && s.DueDate(n).After(*s.EndDate) {
+		return false
+	}
+	return true
+}
+
+// addMonthsClamped adds months to t without overflowing into the following month
+func addMonthsClamped(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	target := firstOfMonth.AddDate(0, months, 0)
+
+	lastDay := target.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return target.AddDate(0, 0, day-1)
+}
+
+// ScheduledPayment links a paid occurrence of a scheduled transaction to the matching transaction
+type ScheduledPayment struct {
+	DueDate                time.Time `json:"due_date" gorm:"not null"`
+	MatchedAt              time.Time `json:"matched_at" gorm:"autoCreateTime"`
+	ID                     int64     `json:"id" gorm:"primaryKey"`
+	ScheduledTransactionID int64     `json:"scheduled_transaction_id" gorm:"not null;index"`
+	TransactionID          int64     `json:"transaction_id" gorm:"not null;uniqueIndex"`
+	Amount                 float64   `json:"amount" gorm:"type:decimal(15,2);not null"`
+}
+
+// TableName specifies the table name for GORM
+func (ScheduledPayment) TableName() string {
+	return "scheduled_payments"
+}
+
+// ScheduledTransactionRequest is the request body for creating or updating a scheduled transaction
+type ScheduledTransactionRequest struct {
+	AmountTolerance *float64          `json:"amount_tolerance"`
+	Name            string            `json:"name" binding:"required,max=100"`
+	Type            TransactionType   `json:"type" binding:"required,oneof=in out"`
+	Frequency       ScheduleFrequency `json:"frequency" binding:"required"`
+	StartDate       string            `json:"start_date" binding:"required"`
+	EndDate         string            `json:"end_date"`
+	Category        string            `json:"category" binding:"omitempty,max=50"`
+	Source          string            `json:"source" binding:"omitempty,max=100"`
+	Recipient       string            `json:"recipient" binding:"omitempty,max=100"`
+	Interval        int               `json:"interval"`
+	Amount          float64           `json:"amount" binding:"required,gt=0"`
+}
+
+// Validate performs additional validation beyond struct tags
+func (r *ScheduledTransactionRequest) Validate() error {
+	if r.Name == "" || len(r.Name) > MaxScheduleNameLength {
+		return &ValidationError{
+			Field:   "name",
+			Message: "name is required and must be at most 100 characters",
+		}
+	}
+
+	if r.Type != TransactionTypeIn && r.Type != TransactionTypeOut {
+		return &ValidationError{
+			Field:   "type",
+			Message: "invalid type. Must be 'in' or 'out'",
+		}
+	}
+
+	if !ValidScheduleFrequencies[r.Frequency] {
+		return &ValidationError{
+			Field:   "frequency",
+			Message: "invalid frequency. Must be 'once', 'weekly', 'monthly' or 'yearly'",
+		}
+	}
+
+	if r.Interval < 0 || r.Interval > 100 {
+		return &ValidationError{
+			Field:   "interval",
+			Message: "interval must be between 1 and 100",
+		}
+	}
+
+	if r.Amount <= 0 || r.Amount > MaxAmount {
+		return &ValidationError{
+			Field:   "amount",
+			Message: "amount must be greater than zero and within the maximum allowed value",
+		}
+	}
+
+	if r.AmountTolerance != nil && (*r.AmountTolerance < 0 || *r.AmountTolerance > MaxAmountTolerance) {
+		return &ValidationError{
+			Field:   "amount_tolerance",
+			Message: "amount_tolerance must be between 0 and 50 percent",
+		}
+	}
+
+	if !ValidCategories[r.Category] {
+		return &ValidationError{
+			Field:   "category",
+			Message: "invalid category. Valid categories are: Food, Transportation, Housing, Utilities, Entertainment, Healthcare, Shopping, Education, Salary, Investment, Transfer, Other",
+		}
+	}
+
+	start, _, err := parseDateParam(r.StartDate)
+	if err != nil {
+		return &ValidationError{
+			Field:   "start_date",
+			Message: "invalid date format. Must be RFC3339 or YYYY-MM-DD",
+		}
+	}
+
+	if r.EndDate != "" {
+		end, _, err := parseDateParam(r.EndDate)
+		if err != nil {
+			return &ValidationError{
+				Field:   "end_date",
+				Message: "invalid date format. Must be RFC3339 or YYYY-MM-DD",
+			}
+		}
+		if end.Before(start) {
+			return &ValidationError{
+				Field:   "end_date",
+				Message: "end_date must not be before start_date",
+			}
+		}
+	}
+
+	return nil
+}
+
+// ToScheduledTransaction converts a validated request to a scheduled transaction
+func (r *ScheduledTransactionRequest) ToScheduledTransaction() *ScheduledTransaction {
+	start, _, _ := parseDateParam(r.StartDate)
+
+	scheduled := &ScheduledTransaction{
+		Name:            r.Name,
+		Type:            r.Type,
+		Frequency:       r.Frequency,
+		Category:        r.Category,
+		Source:          r.Source,
+		Recipient:       r.Recipient,
+		StartDate:       start,
+		NextDueDate:     start,
+		IntervalCount:   r.Interval,
+		Amount:          r.Amount,
+		AmountTolerance: DefaultAmountTolerance,
+		Active:          true,
+	}
+	if scheduled.IntervalCount == 0 {
+		scheduled.IntervalCount = 1
+	}
+	if r.AmountTolerance != nil {
+		scheduled.AmountTolerance = *r.AmountTolerance
+	}
+	if r.EndDate != "" {
+		end, dateOnly, _ := parseDateParam(r.EndDate)
+		if dateOnly {
+			end = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		scheduled.EndDate = &end
+	}
+
+	return scheduled
+}
+
+// UpcomingQueryParams represents query parameters for the upcoming transactions endpoint
+type UpcomingQueryParams struct {
+	Days int `form:"days,default=30"`
+}
+
+// Validate validates the query parameters
+func (p *UpcomingQueryParams) Validate() error {
+	if p.Days < 1 || p.Days > MaxUpcomingDays {
+		return &ValidationError{
+			Field:   "days",
+			Message: "days must be between 1 and 365",
+		}
+	}
+	return nil
+}
+
+// UpcomingTransaction is an unpaid occurrence of a scheduled transaction
+type UpcomingTransaction struct {
+	DueDate                time.Time       `json:"due_date"`
+	Name                   string          `json:"name"`
+	Type                   TransactionType `json:"type"`
+	Category               string          `json:"category"`
+	Recipient              string          `json:"recipient"`
+	Status                 UpcomingStatus  `json:"status"`
+	ScheduledTransactionID int64           `json:"scheduled_transaction_id"`
+	DaysUntilDue           int             `json:"days_until_due"` // Negative when overdue
+	Amount                 float64         `json:"amount"`
+}
+
+// UpcomingResponse is the response for the upcoming transactions endpoint
+type UpcomingResponse struct {
+	Data     []UpcomingTransaction `json:"data"`
+	Days     int                   `json:"days"`
+	TotalIn  float64               `json:"total_in"`
+	TotalOut float64               `json:"total_out"`
+	Overdue  int                   `json:"overdue"`
+}
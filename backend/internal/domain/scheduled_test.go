@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Test ScheduledTransaction DueDate
+
+func TestScheduledTransaction_DueDate_MonthlyClampsToMonthEnd(t *testing.T) {
+	scheduled := &ScheduledTransaction{
+		Frequency:     ScheduleFrequencyMonthly,
+		StartDate:     time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		IntervalCount: 1,
+	}
+
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), scheduled.DueDate(1))
+	assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC), scheduled.DueDate(2))
+}
+
+func TestScheduledTransaction_DueDate_WeeklyInterval(t *testing.T) {
+	scheduled := &ScheduledTransaction{
+		Frequency:     ScheduleFrequencyWeekly,
+		StartDate:     time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+		IntervalCount: 2,
+	}
+
+	assert.Equal(t, time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC), scheduled.DueDate(2))
+}
+
+func TestScheduledTransaction_HasOccurrence(t *testing.T) {
+	end := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	scheduled := &ScheduledTransaction{
+		Frequency:     ScheduleFrequencyMonthly,
+		StartDate:     time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		IntervalCount: 1,
+		EndDate:       &end,
+	}
+
+	assert.True(t, scheduled.HasOccurrence(2))
+	assert.False(t, scheduled.HasOccurrence(3))
+
+	once := &ScheduledTransaction{Frequency: ScheduleFrequencyOnce}
+	assert.True(t, once.HasOccurrence(0))
+	assert.False(t, once.HasOccurrence(1))
+}
+
+// Test ScheduledTransactionRequest Validate
+
+func TestScheduledTransactionRequest_Validate_Valid(t *testing.T) {
+	req := &ScheduledTransactionRequest{
+		Name:      "Salary",
+		Type:      TransactionTypeIn,
+		Frequency: ScheduleFrequencyMonthly,
+		StartDate: "2026-01-25",
+		Amount:    30000000,
+	}
+
+	assert.NoError(t, req.Validate())
+
+	scheduled := req.ToScheduledTransaction()
+	assert.Equal(t, 1, scheduled.IntervalCount)
+	assert.Equal(t, DefaultAmountTolerance, scheduled.AmountTolerance)
+	assert.True(t, scheduled.Active)
+}
+
+func TestScheduledTransactionRequest_Validate_InvalidFrequency(t *testing.T) {
+	req := &ScheduledTransactionRequest{
+		Name:      "Rent",
+		Type:      TransactionTypeOut,
+		Frequency: "daily",
+		StartDate: "2026-01-05",
+		Amount:    100,
+	}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "frequency", validationErr.Field)
+}
+
+func TestScheduledTransactionRequest_Validate_EndBeforeStart(t *testing.T) {
+	req := &ScheduledTransactionRequest{
+		Name:      "Rent",
+		Type:      TransactionTypeOut,
+		Frequency: ScheduleFrequencyMonthly,
+		StartDate: "2026-05-05",
+		EndDate:   "2026-01-05",
+		Amount:    100,
+	}
+
+	err := req.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "end_date", validationErr.Field)
+}
@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"time"
+)
+
+// Security event types recorded in a user's security log
+const (
+	SecurityEventLoginSucceeded           = "login.succeeded"
+	SecurityEventLoginFailed              = "login.failed"
+	SecurityEventAccountLocked            = "account.locked"
+	SecurityEventAccountUnlocked          = "account.unlocked"
+	SecurityEventAccountDeactivated       = "account.deactivated"
+	SecurityEventAccountReactivated       = "account.reactivated"
+	SecurityEventAccountClosed            = "account.closed"
+	SecurityEventPasswordChanged          = "password.changed"
+	SecurityEventPasswordResetRequested   = "password.reset_requested"
+	SecurityEventPasswordReset            = "password.reset"
+	SecurityEventEmailChangeRequested     = "email.change_requested"
+	SecurityEventEmailChanged             = "email.changed"
+	SecurityEventMFAEnabled               = "mfa.enabled"
+	SecurityEventMFADisabled              = "mfa.disabled"
+	SecurityEventMFAFailed                = "mfa.failed"
+	SecurityEventRecoveryCodeUsed         = "mfa.recovery_code_used"
+	SecurityEventRecoveryCodesRegenerated = "mfa.recovery_codes_regenerated"
+)
+
+// ValidSecurityEventTypes contains the event types the security log can be filtered by
+var ValidSecurityEventTypes = map[string]bool{
+	SecurityEventLoginSucceeded:           true,
+	SecurityEventLoginFailed:              true,
+	SecurityEventAccountLocked:            true,
+	SecurityEventAccountUnlocked:          true,
+	SecurityEventAccountDeactivated:       true,
+	SecurityEventAccountReactivated:       true,
+	SecurityEventAccountClosed:            true,
+	SecurityEventPasswordChanged:          true,
+	SecurityEventPasswordResetRequested:   true,
+	SecurityEventPasswordReset:            true,
+	SecurityEventEmailChangeRequested:     true,
+	SecurityEventEmailChanged:             true,
+	SecurityEventMFAEnabled:               true,
+	SecurityEventMFADisabled:              true,
+	SecurityEventMFAFailed:                true,
+	SecurityEventRecoveryCodeUsed:         true,
+	SecurityEventRecoveryCodesRegenerated: true,
+}
+
+// Security log limits
+const (
+	MaxUserAgentLength        = 255
+	DefaultSecurityEventLimit = 50
+	MaxSecurityEventLimit     = 100
+)
+
+// ClientInfo identifies where a request came from
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
+}
+
+// SecurityEvent is an entry of a user's security log
+type SecurityEvent struct {
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	Type      string    `json:"type" gorm:"type:varchar(50);not null"`
+	IPAddress string    `json:"ip_address" gorm:"type:varchar(45)"`
+	UserAgent string    `json:"user_agent" gorm:"type:varchar(255)"`
+	Details   string    `json:"details" gorm:"type:varchar(255)"`
+	ID        int64     `json:"id" gorm:"primaryKey"`
+	UserID    int64     `json:"user_id" gorm:"not null;index"`
+}
+
+// TableName specifies the table name for GORM
+func (SecurityEvent) TableName() string {
+	return "security_events"
+}
+
+// NewSecurityEvent creates an event of a user from a client, cutting an
+// overlong user agent
+func NewSecurityEvent(userID int64, eventType string, client ClientInfo, details string) *SecurityEvent {
+	userAgent := client.UserAgent
+	if len(userAgent) > MaxUserAgentLength {
+		userAgent = userAgent[:MaxUserAgentLength]
+	}
+	return &SecurityEvent{
+		UserID:    userID,
+		Type:      eventType,
+		IPAddress: client.IPAddress,
+		UserAgent: userAgent,
+		Details:   details,
+	}
+}
+
+// SecurityEventQueryParams are the query parameters for the security log
+type SecurityEventQueryParams struct {
+	Type  string `form:"type"`
+	Limit int    `form:"limit"`
+}
+
+// Validate checks the type filter and applies the default limit
+func (p *SecurityEventQueryParams) Validate() error {
+	if p.Type != "" && !ValidSecurityEventTypes[p.Type] {
+		return &ValidationError{
+			Field:   "type",
+			Message: "unknown security event type " + p.Type,
+		}
+	}
+
+	if p.Limit == 0 {
+		p.Limit = DefaultSecurityEventLimit
+	}
+	if p.Limit < 1 || p.Limit > MaxSecurityEventLimit {
+		return &ValidationError{
+			Field:   "limit",
+			Message: "limit must be between 1 and 100",
+		}
+	}
+
+	return nil
+}
+
+// LoginThrottle counts recent failed logins of an account or a client IP
+// and blocks further attempts until BlockedUntil
+type LoginThrottle struct {
+	Key          string    `gorm:"primaryKey;type:varchar(320)"` // LoginAccountKey or LoginIPKey
+	LastFailedAt time.Time `gorm:"not null"`
+	BlockedUntil time.Time `gorm:"not null;index"`
+	Failures     int       `gorm:"not null"`
+	Locked       bool      `gorm:"not null"` // Whether the block is an account lockout
+}
+
+// TableName specifies the table name for GORM
+func (LoginThrottle) TableName() string {
+	return "login_throttles"
+}
+
+// Key prefixes of login throttles
+const (
+	loginThrottleAccountPrefix = "account:"
+	loginThrottleIPPrefix      = "ip:"
+)
+
+// LoginAccountKey returns the throttle key of the account an email signs in
+// to. Unknown emails are throttled alike, so lockouts do not reveal accounts.
+func LoginAccountKey(email string) string {
+	return loginThrottleAccountPrefix + SanitizeEmail(email)
+}
+
+// LoginIPKey returns the throttle key of a client IP
+func LoginIPKey(ip string) string {
+	return loginThrottleIPPrefix + ip
+}
+
+// LoginPolicy protects logins against password guessing. Every failed login
+// blocks the account and the client IP for a delay that doubles with each
+// failure, and an account locks after MaxFailures failures in a row.
+type LoginPolicy struct {
+	MaxFailures     int           // Failures before an account locks, 0 disables lockouts
+	LockoutDuration time.Duration // How long a locked account stays locked
+	FailureWindow   time.Duration // Failures are forgotten after this long without one
+	BaseDelay       time.Duration // Block after the first failure, 0 disables delays
+	MaxDelay        time.Duration // Longest block between failures
+}
+
+// Validate checks that the policy's durations fit together
+func (p LoginPolicy) Validate() error {
+	if p.MaxFailures < 0 {
+		return &ValidationError{
+			Field:   "max_failures",
+			Message: "login max_failures must not be negative",
+		}
+	}
+	if p.MaxFailures > 0 && p.LockoutDuration <= 0 {
+		return &ValidationError{
+			Field:   "lockout_duration",
+			Message: "login lockout_duration must be positive",
+		}
+	}
+	if p.FailureWindow <= 0 {
+		return &ValidationError{
+			Field:   "failure_window",
+			Message: "login failure_window must be positive",
+		}
+	}
+	if p.BaseDelay < 0 || p.MaxDelay < p.BaseDelay {
+		return &ValidationError{
+			Field:   "max_delay",
+			Message: "login max_delay must be at least base_delay",
+		}
+	}
+	return nil
+}
+
+// Locks reports whether an account locks after failures failed logins
+func (p LoginPolicy) Locks(failures int) bool {
+	return p.MaxFailures > 0 && failures >= p.MaxFailures
+}
+
+// Delay returns how long logins are blocked after failures failed logins:
+// BaseDelay after the first, doubling up to MaxDelay
+func (p LoginPolicy) Delay(failures int) time.Duration {
+	if failures < 1 || p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay
+	for i := 1; i < failures && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
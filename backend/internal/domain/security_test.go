@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginPolicy_Delay(t *testing.T) {
+	policy := LoginPolicy{BaseDelay: time.Second, MaxDelay: 30 * time.Second}
+
+	tests := []struct {
+		failures int
+		delay    time.Duration
+	}{
+		{0, 0},
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{5, 16 * time.Second},
+		{6, 30 * time.Second},
+		{1000, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.delay, policy.Delay(tt.failures), "failures: %d", tt.failures)
+	}
+
+	assert.Zero(t, LoginPolicy{MaxDelay: time.Minute}.Delay(3), "no base delay disables delays")
+}
+
+func TestLoginPolicy_Locks(t *testing.T) {
+	policy := LoginPolicy{MaxFailures: 5}
+
+	assert.False(t, policy.Locks(4))
+	assert.True(t, policy.Locks(5))
+	assert.True(t, policy.Locks(6))
+	assert.False(t, LoginPolicy{}.Locks(100), "max failures 0 disables lockouts")
+}
+
+func TestLoginPolicy_Validate(t *testing.T) {
+	valid := LoginPolicy{
+		MaxFailures:     5,
+		LockoutDuration: 15 * time.Minute,
+		FailureWindow:   15 * time.Minute,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *LoginPolicy)
+		field  string
+	}{
+		{"valid", func(p *LoginPolicy) {}, ""},
+		{"lockouts disabled", func(p *LoginPolicy) { p.MaxFailures, p.LockoutDuration = 0, 0 }, ""},
+		{"delays disabled", func(p *LoginPolicy) { p.BaseDelay, p.MaxDelay = 0, 0 }, ""},
+		{"negative max failures", func(p *LoginPolicy) { p.MaxFailures = -1 }, "max_failures"},
+		{"no lockout duration", func(p *LoginPolicy) { p.LockoutDuration = 0 }, "lockout_duration"},
+		{"no failure window", func(p *LoginPolicy) { p.FailureWindow = 0 }, "failure_window"},
+		{"max below base delay", func(p *LoginPolicy) { p.MaxDelay = time.Millisecond }, "max_delay"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := valid
+			tt.modify(&policy)
+
+			err := policy.Validate()
+
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Equal(t, tt.field, err.(*ValidationError).Field)
+		})
+	}
+}
+
+func TestLoginAccountKey_NormalizesEmail(t *testing.T) {
+	assert.Equal(t, "account:test@example.com", LoginAccountKey(" Test@Example.com "))
+	assert.Equal(t, "ip:2001:db8::1", LoginIPKey("2001:db8::1"))
+}
+
+func TestNewSecurityEvent_TruncatesUserAgent(t *testing.T) {
+	client := ClientInfo{IPAddress: "203.0.113.7", UserAgent: strings.Repeat("a", 300)}
+
+	event := NewSecurityEvent(1, SecurityEventLoginFailed, client, "")
+
+	assert.Len(t, event.UserAgent, MaxUserAgentLength)
+	assert.Equal(t, "203.0.113.7", event.IPAddress)
+}
+
+func TestSecurityEventQueryParams_Validate(t *testing.T) {
+	params := SecurityEventQueryParams{}
+	assert.NoError(t, params.Validate())
+	assert.Equal(t, DefaultSecurityEventLimit, params.Limit)
+
+	params = SecurityEventQueryParams{Type: SecurityEventPasswordChanged, Limit: MaxSecurityEventLimit}
+	assert.NoError(t, params.Validate())
+
+	params = SecurityEventQueryParams{Type: "logout"}
+	assert.Error(t, params.Validate())
+
+	params = SecurityEventQueryParams{Limit: MaxSecurityEventLimit + 1}
+	assert.Error(t, params.Validate())
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Event names sent on the live transaction stream
+const (
+	StreamEventTransactionCreated = "transaction.created"
+	StreamEventTransactionUpdated = "transaction.updated"
+	StreamEventSummary            = "summary"
+)
+
+// Stream limits
+const (
+	MaxStreamReplay       = 500 // transactions replayed after Last-Event-ID
+	MaxStreamNotification = 500 // transaction IDs per NOTIFY payload, which Postgres limits to 8000 bytes
+)
+
+// StreamEvent is one Server-Sent Event of the live transaction stream.
+// Only created transactions carry an ID, so a reconnecting client resumes
+// after the last transaction it received.
+type StreamEvent struct {
+	ID    int64       `json:"id,omitempty"`
+	Event string      `json:"event"`
+	Data  interface{} `json:"data"`
+}
+
+// NewTransactionCreatedEvent returns the stream event of a stored transaction
+func NewTransactionCreatedEvent(tx Transaction) StreamEvent {
+	return StreamEvent{ID: tx.ID, Event: StreamEventTransactionCreated, Data: tx}
+}
+
+// WriteTo writes the event in the text/event-stream format
+func (e StreamEvent) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		return 0, err
+	}
+
+	var id string
+	if e.ID != 0 {
+		id = "id: " + strconv.FormatInt(e.ID, 10) + "\n"
+	}
+	n, err := fmt.Fprintf(w, "%sevent: %s\ndata: %s\n\n", id, e.Event, data)
+	return int64(n), err
+}
+
+// TransactionNotification is the payload sent to every API replica when
+// transactions have been stored or updated
+type TransactionNotification struct {
+	Created []int64 `json:"created,omitempty"`
+	Updated []int64 `json:"updated,omitempty"`
+}
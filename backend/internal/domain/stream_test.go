@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamEvent_WriteTo(t *testing.T) {
+	var b strings.Builder
+
+	_, err := NewTransactionCreatedEvent(Transaction{ID: 12, Amount: 50, Type: TransactionTypeOut}).WriteTo(&b)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(b.String(), "id: 12\nevent: transaction.created\ndata: {\"type\":\"out\","))
+	assert.True(t, strings.HasSuffix(b.String(), "}\n\n"))
+	assert.Equal(t, 4, strings.Count(b.String(), "\n"), "the JSON data must stay on one line")
+}
+
+func TestStreamEvent_WriteTo_WithoutID(t *testing.T) {
+	var b strings.Builder
+
+	event := StreamEvent{Event: StreamEventSummary, Data: SummaryResponse{TotalIncome: 10}}
+	_, err := event.WriteTo(&b)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "event: summary\ndata: {\"total_income\":10,\"total_expense\":0,\"current_balance\":0,\"transaction_count\":0}\n\n", b.String())
+}
@@ -0,0 +1,232 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TakeoutSchemaVersion is the version of the archive layout. Restores
+	// accept archives of this version and older ones.
+	TakeoutSchemaVersion = 1
+	// TakeoutGenerator names the application in the manifest
+	TakeoutGenerator = "personal-finance-tracker"
+	// DefaultTakeoutTTL is how long a finished archive can be downloaded
+	DefaultTakeoutTTL = 7 * 24 * time.Hour
+	// DefaultTakeoutSyncLimit is the number of transactions up to which an
+	// archive is built while the request waits
+	DefaultTakeoutSyncLimit = 5000
+	// MaxTakeoutFileSize is the largest uncompressed file a restore reads
+	// from an archive
+	MaxTakeoutFileSize = 256 << 20
+)
+
+// Files of a takeout archive. JSON files are what a restore reads; the CSV
+// files carry the same records for spreadsheets.
+const (
+	TakeoutManifestFile          = "manifest.json"
+	TakeoutProfileFile           = "profile.json"
+	TakeoutAPIKeyFile            = "api_key.json"
+	TakeoutTransactionsFile      = "transactions.json"
+	TakeoutTransactionsCSVFile   = "transactions.csv"
+	TakeoutCategoriesFile        = "categories.json"
+	TakeoutCategoriesCSVFile     = "categories.csv"
+	TakeoutPayeesFile            = "payees.json"
+	TakeoutPayeesCSVFile         = "payees.csv"
+	TakeoutBudgetsFile           = "budgets.json"
+	TakeoutBudgetsCSVFile        = "budgets.csv"
+	TakeoutSecurityEventsFile    = "security_events.json"
+	TakeoutSecurityEventsCSVFile = "security_events.csv"
+)
+
+// takeoutRestoreFiles are the files a restore needs in the manifest
+var takeoutRestoreFiles = []string{
+	TakeoutProfileFile,
+	TakeoutTransactionsFile,
+	TakeoutPayeesFile,
+	TakeoutBudgetsFile,
+}
+
+// TakeoutStatus is the state of a takeout archive
+type TakeoutStatus string
+
+const (
+	TakeoutStatusPending TakeoutStatus = "pending"
+	TakeoutStatusReady   TakeoutStatus = "ready"
+	TakeoutStatusFailed  TakeoutStatus = "failed"
+)
+
+// Takeout is a zip archive of everything stored about a user. Small
+// accounts get it while the request waits; larger ones are built by a
+// background job. The archive can be downloaded until ExpiresAt.
+type Takeout struct {
+	LeaseUntil  time.Time     `json:"-" gorm:"not null;index"` // A pending takeout is built once this has passed
+	CreatedAt   time.Time     `json:"created_at" gorm:"autoCreateTime"`
+	CompletedAt *time.Time    `json:"completed_at"`
+	ExpiresAt   *time.Time    `json:"expires_at" gorm:"index"`
+	Status      TakeoutStatus `json:"status" gorm:"type:varchar(20);not null"`
+	Error       string        `json:"error,omitempty" gorm:"type:varchar(255)"`
+	DownloadURL string        `json:"download_url,omitempty" gorm:"-"` // Set once the archive is ready
+	Archive     []byte        `json:"-" gorm:"type:bytea"`
+	ID          int64         `json:"id" gorm:"primaryKey"`
+	UserID      int64         `json:"-" gorm:"not null;index"`
+	Size        int64         `json:"size" gorm:"not null;default:0"` // Bytes of the archive
+}
+
+// TableName specifies the table name for GORM
+func (Takeout) TableName() string {
+	return "takeouts"
+}
+
+// TakeoutManifest describes the files of an archive
+type TakeoutManifest struct {
+	CreatedAt     time.Time     `json:"created_at"`
+	Generator     string        `json:"generator"`
+	UserUUID      string        `json:"user_uuid"`
+	Files         []TakeoutFile `json:"files"`
+	SchemaVersion int           `json:"schema_version"`
+}
+
+// TakeoutFile is a file of an archive with its SHA-256 in hex and number of records
+type TakeoutFile struct {
+	Name    string `json:"name"`
+	SHA256  string `json:"sha256"`
+	Records int    `json:"records"`
+}
+
+// Validate checks that a restore can read the archive
+func (m *TakeoutManifest) Validate() error {
+	if m.SchemaVersion < 1 || m.SchemaVersion > TakeoutSchemaVersion {
+		return &ValidationError{
+			Field:   "file",
+			Message: "unsupported archive schema version " + strconv.Itoa(m.SchemaVersion),
+		}
+	}
+
+	listed := make(map[string]bool, len(m.Files))
+	for _, file := range m.Files {
+		listed[file.Name] = true
+	}
+	for _, name := range takeoutRestoreFiles {
+		if !listed[name] {
+			return &ValidationError{
+				Field:   "file",
+				Message: "archive must contain " + strings.Join(takeoutRestoreFiles, ", "),
+			}
+		}
+	}
+	return nil
+}
+
+// TakeoutProfile is the profile of the user in an archive
+type TakeoutProfile struct {
+	CreatedAt   time.Time    `json:"created_at"`
+	LastLoginAt *time.Time   `json:"last_login_at"`
+	Settings    UserSettings `json:"settings"`
+	UUID        string       `json:"uuid"`
+	Email       string       `json:"email"`
+	Name        string       `json:"name"`
+}
+
+// TakeoutAPIKey describes the API key of the user without revealing it
+type TakeoutAPIKey struct {
+	CreatedAt time.Time `json:"created_at"` // Keys are issued at registration
+	Prefix    string    `json:"prefix"`     // First characters of the key
+}
+
+// TakeoutCategory is a category with the transactions filed under it
+type TakeoutCategory struct {
+	Name         string  `json:"name"` // Empty for uncategorised transactions
+	TotalIn      float64 `json:"total_in"`
+	TotalOut     float64 `json:"total_out"`
+	Transactions int64   `json:"transactions"`
+}
+
+// TakeoutLedger is what a restore brings into the ledger
+type TakeoutLedger struct {
+	Transactions []Transaction
+	Payees       []Payee
+	Budgets      []Budget
+}
+
+// Validate checks the records of an archive before they are restored
+func (l *TakeoutLedger) Validate() error {
+	for i := range l.Transactions {
+		if err := validateTakeoutTransaction(&l.Transactions[i]); err != nil {
+			return takeoutRecordError(TakeoutTransactionsFile, i, err)
+		}
+	}
+
+	names := make(map[string]bool, len(l.Payees))
+	aliases := make(map[string]bool)
+	for i := range l.Payees {
+		payee := &l.Payees[i]
+		req := PayeeRequest{Name: payee.Name, DefaultCategory: payee.DefaultCategory}
+		for _, alias := range payee.Aliases {
+			req.Aliases = append(req.Aliases, alias.Pattern)
+		}
+		if err := req.Validate(); err != nil {
+			return takeoutRecordError(TakeoutPayeesFile, i, err)
+		}
+		if names[strings.ToLower(payee.Name)] {
+			return takeoutRecordError(TakeoutPayeesFile, i, &ValidationError{Field: "name", Message: "payee names must be unique"})
+		}
+		names[strings.ToLower(payee.Name)] = true
+		for _, alias := range payee.Aliases {
+			if aliases[strings.ToLower(alias.Pattern)] {
+				return takeoutRecordError(TakeoutPayeesFile, i, &ValidationError{Field: "aliases", Message: "aliases must be unique across payees"})
+			}
+			aliases[strings.ToLower(alias.Pattern)] = true
+		}
+	}
+
+	for i := range l.Budgets {
+		budget := &l.Budgets[i]
+		req := BudgetRequest{Category: budget.Category, Source: budget.Source, Amount: budget.Amount}
+		if err := req.Validate(); err != nil {
+			return takeoutRecordError(TakeoutBudgetsFile, i, err)
+		}
+	}
+	return nil
+}
+
+// validateTakeoutTransaction checks a transaction of an archive. Unlike new
+// transactions, old dates are fine.
+func validateTakeoutTransaction(tx *Transaction) error {
+	switch {
+	case tx.Type != TransactionTypeIn && tx.Type != TransactionTypeOut:
+		return &ValidationError{Field: "type", Message: "type must be one of: in, out"}
+	case tx.Amount <= 0 || tx.Amount > MaxAmount:
+		return &ValidationError{Field: "amount", Message: "amount must be greater than zero and at most the maximum allowed value"}
+	case !ValidCategories[tx.Category]:
+		return &ValidationError{Field: "category", Message: "invalid category"}
+	case strings.TrimSpace(tx.Source) == "" || len(tx.Source) > MaxSourceLength:
+		return &ValidationError{Field: "source", Message: "source is required and must be at most 100 characters"}
+	case len(tx.Description) > MaxDescriptionLength || len(tx.SourceAccount) > MaxAccountLength ||
+		len(tx.Recipient) > MaxRecipientLength || len(tx.ExternalID) > MaxExternalIDLength:
+		return &ValidationError{Field: "description", Message: "text fields exceed their maximum length"}
+	case tx.TransactionDate.IsZero():
+		return &ValidationError{Field: "transaction_date", Message: "transaction_date is required"}
+	}
+	return nil
+}
+
+// takeoutRecordError names the file and record of a validation error
+func takeoutRecordError(file string, index int, err error) error {
+	if validationErr, ok := err.(*ValidationError); ok {
+		return &ValidationError{
+			Field:   "file",
+			Message: fmt.Sprintf("%s record %d: %s: %s", file, index+1, validationErr.Field, validationErr.Message),
+		}
+	}
+	return err
+}
+
+// TakeoutRestoreResult reports what a restore brought into the ledger
+type TakeoutRestoreResult struct {
+	Transactions int `json:"transactions"`
+	Payees       int `json:"payees"`
+	Budgets      int `json:"budgets"`
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeoutManifest_Validate(t *testing.T) {
+	files := []TakeoutFile{
+		{Name: TakeoutProfileFile},
+		{Name: TakeoutTransactionsFile},
+		{Name: TakeoutPayeesFile},
+		{Name: TakeoutBudgetsFile},
+	}
+
+	tests := []struct {
+		name     string
+		manifest TakeoutManifest
+		wantErr  string
+	}{
+		{"current version", TakeoutManifest{SchemaVersion: TakeoutSchemaVersion, Files: files}, ""},
+		{"missing version", TakeoutManifest{Files: files}, "unsupported archive schema version 0"},
+		{"newer version", TakeoutManifest{SchemaVersion: TakeoutSchemaVersion + 1, Files: files}, "unsupported archive schema version 2"},
+		{"missing file", TakeoutManifest{SchemaVersion: TakeoutSchemaVersion, Files: files[:3]}, "archive must contain profile.json, transactions.json, payees.json, budgets.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.manifest.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "file", validationErr.Field)
+			assert.Equal(t, tt.wantErr, validationErr.Message)
+		})
+	}
+}
+
+func TestTakeoutLedger_Validate(t *testing.T) {
+	transaction := func() Transaction {
+		return Transaction{
+			Type:            TransactionTypeOut,
+			Amount:          12.5,
+			Category:        "Food",
+			Source:          "MoMo",
+			TransactionDate: time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	badType := transaction()
+	badType.Type = "sideways"
+	noDate := transaction()
+	noDate.TransactionDate = time.Time{}
+
+	tests := []struct {
+		name    string
+		ledger  TakeoutLedger
+		wantErr string
+	}{
+		{"valid", TakeoutLedger{
+			Transactions: []Transaction{transaction()},
+			Payees:       []Payee{{Name: "Shop", Aliases: []PayeeAlias{{Pattern: "shop"}}}},
+			Budgets:      []Budget{{Category: "Food", Amount: 200}},
+		}, ""},
+		{"empty", TakeoutLedger{}, ""},
+		{"invalid type", TakeoutLedger{Transactions: []Transaction{transaction(), badType}}, "transactions.json record 2: type:"},
+		{"missing date", TakeoutLedger{Transactions: []Transaction{noDate}}, "transactions.json record 1: transaction_date:"},
+		{"duplicate payee", TakeoutLedger{Payees: []Payee{{Name: "Shop"}, {Name: "shop"}}}, "payees.json record 2: name:"},
+		{"alias of two payees", TakeoutLedger{Payees: []Payee{
+			{Name: "Shop", Aliases: []PayeeAlias{{Pattern: "store"}}},
+			{Name: "Market", Aliases: []PayeeAlias{{Pattern: "Store"}}},
+		}}, "payees.json record 2: aliases:"},
+		{"invalid budget", TakeoutLedger{Budgets: []Budget{{Category: "Food", Amount: -1}}}, "budgets.json record 1: amount:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.ledger.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "file", validationErr.Field)
+			assert.Contains(t, validationErr.Message, tt.wantErr)
+		})
+	}
+}
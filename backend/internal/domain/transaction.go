@@ -42,6 +42,8 @@ const (
 	MaxRecipientLength = 100
 	// MaxCategoryLength is the maximum length for category
 	MaxCategoryLength = 50
+	// MaxExternalIDLength is the maximum length for external IDs
+	MaxExternalIDLength = 255
 )
 
 // Transaction represents a financial transaction from a bank or e-wallet
@@ -52,11 +54,14 @@ type Transaction struct {
 	Source          string          `json:"source" gorm:"type:varchar(100);not null;index"` // Bank/wallet name
 	SourceAccount   string          `json:"source_account" gorm:"type:varchar(100)"`        // Account identifier
 	Recipient       string          `json:"recipient" gorm:"type:varchar(100)"`             // For transfers
+	ExternalID      string          `json:"external_id" gorm:"type:varchar(255)"`           // Bank-assigned ID such as the OFX FITID
 	TransactionDate time.Time       `json:"transaction_date" gorm:"not null;index"`
 	CreatedAt       time.Time       `json:"created_at" gorm:"autoCreateTime"`
 	UpdatedAt       time.Time       `json:"updated_at" gorm:"autoUpdateTime"`
+	PayeeID         *int64          `json:"payee_id" gorm:"index"` // Normalised merchant, see Payee
 	ID              int64           `json:"id" gorm:"primaryKey"`
 	Amount          float64         `json:"amount" gorm:"type:decimal(15,2);not null"`
+	Anomalous       bool            `json:"anomalous" gorm:"not null;default:false"` // Flagged by anomaly detection
 }
 
 // TableName specifies the table name for GORM
@@ -72,6 +77,7 @@ type CreateTransactionRequest struct {
 	Source          string          `json:"source" binding:"required,min=1,max=100"`
 	SourceAccount   string          `json:"source_account" binding:"omitempty,max=100"`
 	Recipient       string          `json:"recipient" binding:"omitempty,max=100"`
+	ExternalID      string          `json:"external_id" binding:"omitempty,max=255"`
 	TransactionDate string          `json:"transaction_date" binding:"required"`
 	Amount          float64         `json:"amount" binding:"required,gt=0"`
 }
@@ -157,6 +163,11 @@ func (r *CreateTransactionRequest) ToTransaction() (*Transaction, error) {
 		recipient = recipient[:MaxRecipientLength]
 	}
 
+	externalID := r.ExternalID
+	if len(externalID) > MaxExternalIDLength {
+		externalID = externalID[:MaxExternalIDLength]
+	}
+
 	return &Transaction{
 		Amount:          r.Amount,
 		Type:            r.Type,
@@ -165,6 +176,7 @@ func (r *CreateTransactionRequest) ToTransaction() (*Transaction, error) {
 		Source:          source,
 		SourceAccount:   sourceAccount,
 		Recipient:       recipient,
+		ExternalID:      externalID,
 		TransactionDate: txDate,
 	}, nil
 }
@@ -248,6 +260,246 @@ type BreakdownResponse struct {
 	Count      int64   `json:"count"`
 }
 
+// Breakdown dimensions supported by the breakdown endpoint
+const (
+	BreakdownBySource        = "source"
+	BreakdownBySourceAccount = "source_account"
+	BreakdownByCategory      = "category"
+	BreakdownByRecipient     = "recipient"
+	BreakdownByWeekday       = "weekday"
+)
+
+// ValidBreakdownGroupBy lists the dimensions a breakdown can be grouped by
+var ValidBreakdownGroupBy = map[string]bool{
+	BreakdownBySource:        true,
+	BreakdownBySourceAccount: true,
+	BreakdownByCategory:      true,
+	BreakdownByRecipient:     true,
+	BreakdownByWeekday:       true,
+}
+
+// BreakdownQueryParams represents query parameters for the breakdown endpoint
+type BreakdownQueryParams struct {
+	Type      TransactionType `form:"type"`
+	GroupBy   string          `form:"group_by"`
+	StartDate string          `form:"start_date"`
+	EndDate   string          `form:"end_date"`
+}
+
+// Validate applies defaults and checks the breakdown query parameters
+func (p *BreakdownQueryParams) Validate() error {
+	// Default to expenses by category, matching the legacy breakdown endpoints
+	if p.Type == "" {
+		p.Type = TransactionTypeOut
+	}
+	if p.GroupBy == "" {
+		p.GroupBy = BreakdownByCategory
+	}
+
+	if p.Type != TransactionTypeIn && p.Type != TransactionTypeOut {
+		return &ValidationError{
+			Field:   "type",
+			Message: "type must be one of: in, out",
+		}
+	}
+
+	if !ValidBreakdownGroupBy[p.GroupBy] {
+		return &ValidationError{
+			Field:   "group_by",
+			Message: "group_by must be one of: source, source_account, category, recipient, weekday",
+		}
+	}
+
+	_, err := ParseDateRange(p.StartDate, p.EndDate)
+	return err
+}
+
+// ToFilter converts validated query parameters to a repository filter
+func (p *BreakdownQueryParams) ToFilter() (*BreakdownFilter, error) {
+	dates, err := ParseDateRange(p.StartDate, p.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BreakdownFilter{
+		Type:      p.Type,
+		GroupBy:   p.GroupBy,
+		DateRange: dates,
+	}, nil
+}
+
+// BreakdownFilter is the parsed form of BreakdownQueryParams used by the repository
+type BreakdownFilter struct {
+	Type    TransactionType
+	GroupBy string
+	DateRange
+}
+
+// ValidPivotPeriods lists the column periods supported by the pivot endpoint
+var ValidPivotPeriods = map[string]bool{
+	"daily":   true,
+	"weekly":  true,
+	"monthly": true,
+}
+
+// MaxPivotColumns caps the number of period columns in a pivot matrix
+const MaxPivotColumns = 400
+
+// PivotQueryParams represents query parameters for the pivot endpoint
+type PivotQueryParams struct {
+	Type      TransactionType `form:"type"`
+	Rows      string          `form:"rows"`
+	Period    string          `form:"period"`
+	StartDate string          `form:"start_date"`
+	EndDate   string          `form:"end_date"`
+}
+
+// Validate applies defaults and checks the pivot query parameters
+func (p *PivotQueryParams) Validate() error {
+	// Default to monthly spend per category
+	if p.Type == "" {
+		p.Type = TransactionTypeOut
+	}
+	if p.Rows == "" {
+		p.Rows = BreakdownByCategory
+	}
+	if p.Period == "" {
+		p.Period = "monthly"
+	}
+
+	if p.Type != TransactionTypeIn && p.Type != TransactionTypeOut {
+		return &ValidationError{
+			Field:   "type",
+			Message: "type must be one of: in, out",
+		}
+	}
+
+	if !ValidBreakdownGroupBy[p.Rows] {
+		return &ValidationError{
+			Field:   "rows",
+			Message: "rows must be one of: source, source_account, category, recipient, weekday",
+		}
+	}
+
+	if !ValidPivotPeriods[p.Period] {
+		return &ValidationError{
+			Field:   "period",
+			Message: "period must be one of: daily, weekly, monthly",
+		}
+	}
+
+	_, err := ParseDateRange(p.StartDate, p.EndDate)
+	return err
+}
+
+// ToFilter converts validated query parameters to a repository filter
+func (p *PivotQueryParams) ToFilter() (*PivotFilter, error) {
+	dates, err := ParseDateRange(p.StartDate, p.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PivotFilter{
+		Type:      p.Type,
+		Rows:      p.Rows,
+		Period:    p.Period,
+		DateRange: dates,
+	}, nil
+}
+
+// PivotFilter is the parsed form of PivotQueryParams used by the repository
+type PivotFilter struct {
+	Type   TransactionType
+	Rows   string
+	Period string
+	DateRange
+}
+
+// PivotCell is a single aggregated (row, column) value returned by the repository
+type PivotCell struct {
+	RowLabel    string  `json:"row_label"`
+	ColumnLabel string  `json:"column_label"`
+	Amount      float64 `json:"amount"`
+}
+
+// PivotRow is one row of the pivot matrix; Values align with PivotResponse.Columns
+type PivotRow struct {
+	Label  string    `json:"label"`
+	Values []float64 `json:"values"`
+	Total  float64   `json:"total"`
+}
+
+// PivotResponse is the response for the pivot endpoint
+type PivotResponse struct {
+	Type         TransactionType `json:"type"`
+	RowDimension string          `json:"row_dimension"`
+	Period       string          `json:"period"`
+	Columns      []string        `json:"columns"`
+	Rows         []PivotRow      `json:"rows"`
+	ColumnTotals []float64       `json:"column_totals"`
+	GrandTotal   float64         `json:"grand_total"`
+}
+
+// DateRange is an optional, inclusive transaction date filter
+type DateRange struct {
+	Start *time.Time
+	End   *time.Time
+}
+
+// dateOnlyLayout is the short date format accepted in query parameters
+const dateOnlyLayout = "2006-01-02"
+
+// ParseDateRange parses optional start and end query values.
+// Values may be RFC3339 timestamps or plain dates (YYYY-MM-DD);
+// a plain end date covers the whole day.
+func ParseDateRange(startDate, endDate string) (DateRange, error) {
+	var dates DateRange
+
+	if startDate != "" {
+		start, _, err := parseDateParam(startDate)
+		if err != nil {
+			return dates, &ValidationError{
+				Field:   "start_date",
+				Message: "invalid date format. Must be RFC3339 or YYYY-MM-DD",
+			}
+		}
+		dates.Start = &start
+	}
+
+	if endDate != "" {
+		end, dateOnly, err := parseDateParam(endDate)
+		if err != nil {
+			return dates, &ValidationError{
+				Field:   "end_date",
+				Message: "invalid date format. Must be RFC3339 or YYYY-MM-DD",
+			}
+		}
+		if dateOnly {
+			end = end.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		dates.End = &end
+	}
+
+	if dates.Start != nil && dates.End != nil && dates.Start.After(*dates.End) {
+		return dates, &ValidationError{
+			Field:   "start_date",
+			Message: "start_date must not be after end_date",
+		}
+	}
+
+	return dates, nil
+}
+
+// parseDateParam parses an RFC3339 timestamp or a plain date.
+// The boolean result reports whether the value was a plain date.
+func parseDateParam(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	t, err := time.Parse(dateOnlyLayout, value)
+	return t, true, err
+}
+
 // ListTransactionsQueryParams represents query parameters for listing transactions
 type ListTransactionsQueryParams struct {
 	Type      TransactionType `form:"type"`
@@ -257,4 +509,7 @@ type ListTransactionsQueryParams struct {
 	EndDate   string          `form:"end_date"`
 	Page      int             `form:"page,default=1"`
 	PageSize  int             `form:"page_size,default=20"`
+	// Dates is StartDate and EndDate once parsed; when set, it filters
+	// instead of them, so a date-only end includes that whole day
+	Dates DateRange `form:"-"`
 }
@@ -445,3 +445,135 @@ func TestValidCategories_InvalidCategoryNotPresent(t *testing.T) {
 	assert.False(t, ValidCategories["InvalidCategory"])
 	assert.False(t, ValidCategories["XYZ"])
 }
+
+// Test BreakdownQueryParams
+
+func TestBreakdownQueryParams_Validate_Defaults(t *testing.T) {
+	params := &BreakdownQueryParams{}
+
+	err := params.Validate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionTypeOut, params.Type)
+	assert.Equal(t, BreakdownByCategory, params.GroupBy)
+}
+
+func TestBreakdownQueryParams_Validate_AllGroupBy(t *testing.T) {
+	for groupBy := range ValidBreakdownGroupBy {
+		params := &BreakdownQueryParams{Type: TransactionTypeIn, GroupBy: groupBy}
+		assert.NoError(t, params.Validate(), "group_by %s should be valid", groupBy)
+	}
+}
+
+func TestBreakdownQueryParams_Validate_InvalidType(t *testing.T) {
+	params := &BreakdownQueryParams{Type: "sideways"}
+
+	err := params.Validate()
+
+	assert.Error(t, err)
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "type", validationErr.Field)
+}
+
+func TestBreakdownQueryParams_Validate_InvalidGroupBy(t *testing.T) {
+	params := &BreakdownQueryParams{GroupBy: "amount"}
+
+	err := params.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "group_by", validationErr.Field)
+}
+
+func TestBreakdownQueryParams_ToFilter(t *testing.T) {
+	params := &BreakdownQueryParams{
+		Type:      TransactionTypeIn,
+		GroupBy:   BreakdownByRecipient,
+		StartDate: "2026-01-01T00:00:00Z",
+	}
+
+	filter, err := params.ToFilter()
+
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionTypeIn, filter.Type)
+	assert.Equal(t, BreakdownByRecipient, filter.GroupBy)
+	assert.NotNil(t, filter.Start)
+	assert.Nil(t, filter.End)
+}
+
+// Test ParseDateRange
+
+func TestParseDateRange_Empty(t *testing.T) {
+	dates, err := ParseDateRange("", "")
+
+	assert.NoError(t, err)
+	assert.Nil(t, dates.Start)
+	assert.Nil(t, dates.End)
+}
+
+func TestParseDateRange_DateOnlyEndCoversWholeDay(t *testing.T) {
+	dates, err := ParseDateRange("2026-01-01", "2026-01-31")
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *dates.Start)
+	assert.Equal(t, 2026, dates.End.Year())
+	assert.Equal(t, time.January, dates.End.Month())
+	assert.Equal(t, 31, dates.End.Day())
+	assert.Equal(t, 23, dates.End.Hour())
+}
+
+func TestParseDateRange_RFC3339(t *testing.T) {
+	dates, err := ParseDateRange("2026-01-01T08:00:00Z", "2026-01-01T18:00:00Z")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, dates.Start.Hour())
+	assert.Equal(t, 18, dates.End.Hour())
+}
+
+func TestParseDateRange_InvalidFormat(t *testing.T) {
+	_, err := ParseDateRange("01/15/2026", "")
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "start_date", validationErr.Field)
+}
+
+func TestParseDateRange_StartAfterEnd(t *testing.T) {
+	_, err := ParseDateRange("2026-02-01", "2026-01-01")
+
+	assert.Error(t, err)
+}
+
+// Test PivotQueryParams
+
+func TestPivotQueryParams_Validate_Defaults(t *testing.T) {
+	params := &PivotQueryParams{}
+
+	err := params.Validate()
+
+	assert.NoError(t, err)
+	assert.Equal(t, TransactionTypeOut, params.Type)
+	assert.Equal(t, BreakdownByCategory, params.Rows)
+	assert.Equal(t, "monthly", params.Period)
+}
+
+func TestPivotQueryParams_Validate_InvalidPeriod(t *testing.T) {
+	params := &PivotQueryParams{Period: "hourly"}
+
+	err := params.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "period", validationErr.Field)
+}
+
+func TestPivotQueryParams_Validate_InvalidRows(t *testing.T) {
+	params := &PivotQueryParams{Rows: "amount"}
+
+	err := params.Validate()
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "rows", validationErr.Field)
+}
@@ -62,16 +62,20 @@ func validatePasswordComplexity(password string) bool {
 
 // User represents a user account
 type User struct {
-	ID           int64      `json:"id" gorm:"primaryKey"`
-	UUID         uuid.UUID  `json:"uuid" gorm:"type:uuid;not null;unique"`
-	Email        string     `json:"email" gorm:"type:varchar(255);not null;unique"`
-	PasswordHash string     `json:"-" gorm:"type:varchar(255);not null"` // never expose in JSON
-	Name         string     `json:"name" gorm:"type:varchar(100)"`
-	APIKey       string     `json:"api_key" gorm:"type:varchar(255);not null;unique"`
-	IsActive     bool       `json:"is_active" gorm:"not null;default:true"`
-	LastLoginAt  *time.Time `json:"last_login_at" gorm:"type:timestamp"`
-	CreatedAt    time.Time  `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt    time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+	ID                  int64        `json:"id" gorm:"primaryKey"`
+	UUID                uuid.UUID    `json:"uuid" gorm:"type:uuid;not null;unique"`
+	Email               string       `json:"email" gorm:"type:varchar(255);not null;unique"`
+	PasswordHash        string       `json:"-" gorm:"type:varchar(255);not null"` // never expose in JSON
+	Name                string       `json:"name" gorm:"type:varchar(100)"`
+	APIKey              string       `json:"api_key" gorm:"type:varchar(255);not null;unique"`
+	IsActive            bool         `json:"is_active" gorm:"not null;default:true"`
+	LastLoginAt         *time.Time   `json:"last_login_at" gorm:"type:timestamp"`
+	SessionsRevokedAt   *time.Time   `json:"-" gorm:"type:timestamp"` // tokens issued before are refused
+	Settings            UserSettings `json:"settings" gorm:"type:jsonb;serializer:json;not null;default:'{}'"`
+	DeactivatedAt       *time.Time   `json:"deactivated_at" gorm:"type:timestamp"`
+	DeletionScheduledAt *time.Time   `json:"deletion_scheduled_at" gorm:"type:timestamp;index"` // sign-in account of a closed account removed from then on
+	CreatedAt           time.Time    `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt           time.Time    `json:"updated_at" gorm:"autoUpdateTime"`
 }
 
 // TableName specifies the table name for GORM
@@ -96,25 +100,30 @@ func (r *RegisterRequest) Validate() error {
 		}
 	}
 
+	return validatePassword("password", r.Password)
+}
+
+// validatePassword checks the length and complexity of a new password
+func validatePassword(field, password string) error {
 	// Password length validation
-	if len(r.Password) < MinPasswordLength {
+	if len(password) < MinPasswordLength {
 		return &ValidationError{
-			Field:   "password",
+			Field:   field,
 			Message: fmt.Sprintf("password must be at least %d characters", MinPasswordLength),
 		}
 	}
 
-	if len(r.Password) > MaxPasswordLength {
+	if len(password) > MaxPasswordLength {
 		return &ValidationError{
-			Field:   "password",
+			Field:   field,
 			Message: fmt.Sprintf("password must be at most %d characters", MaxPasswordLength),
 		}
 	}
 
 	// Password complexity validation (must satisfy at least 3 of 4 criteria)
-	if !validatePasswordComplexity(r.Password) {
+	if !validatePasswordComplexity(password) {
 		return &ValidationError{
-			Field:   "password",
+			Field:   field,
 			Message: "password must satisfy at least 3 of the following: contain letters, numbers, special characters, or uppercase letters",
 		}
 	}
@@ -124,8 +133,9 @@ func (r *RegisterRequest) Validate() error {
 
 // LoginRequest is the request body for user login
 type LoginRequest struct {
-	Email    string `json:"email" binding:"required,email,max=255"`
-	Password string `json:"password" binding:"required"`
+	Email    string     `json:"email" binding:"required,email,max=255"`
+	Password string     `json:"password" binding:"required"`
+	Client   ClientInfo `json:"-"` // set by the handler, for brute-force protection and the security log
 }
 
 // Validate performs validation on login request
@@ -149,10 +159,14 @@ func (r *LoginRequest) Validate() error {
 	return nil
 }
 
-// AuthResponse is the response body for successful authentication
+// AuthResponse is the response body for successful authentication.
+// Users with two-factor authentication get only an MFA token from login,
+// which /auth/mfa/verify exchanges for the token and user.
 type AuthResponse struct {
-	Token string      `json:"token"`
-	User  UserResponse `json:"user"`
+	Token       string       `json:"token,omitempty"`
+	User        UserResponse `json:"user,omitzero"`
+	MFARequired bool         `json:"mfa_required,omitempty"`
+	MFAToken    string       `json:"mfa_token,omitempty"`
 }
 
 // UserResponse is a safe user representation (without sensitive data)
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// AccountHandler handles profile and account requests of the signed-in user
+type AccountHandler struct {
+	service service.AccountService
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(service service.AccountService) *AccountHandler {
+	return &AccountHandler{service: service}
+}
+
+// GetProfile returns the profile and settings of the user
+// GET /api/v1/me
+func (h *AccountHandler) GetProfile(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	profile, err := h.service.GetProfile(userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// UpdateProfile changes the name and settings of the user, and sends a
+// confirmation link to a new email
+// PATCH /api/v1/me
+func (h *AccountHandler) UpdateProfile(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.UpdateProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	req.Client = clientInfo(c)
+
+	profile, err := h.service.UpdateProfile(c.Request.Context(), userID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// ConfirmEmail makes the email of a confirmation link the user's email
+// POST /api/v1/auth/email/confirm
+func (h *AccountHandler) ConfirmEmail(c *gin.Context) {
+	var req domain.ConfirmEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	req.Client = clientInfo(c)
+
+	if err := h.service.ConfirmEmail(&req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Deactivate marks the account inactive; it stops working at once
+// POST /api/v1/me/deactivate
+func (h *AccountHandler) Deactivate(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CloseAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	req.Client = clientInfo(c)
+
+	if err := h.service.Deactivate(userID, &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Close deactivates the account and schedules the removal of its sign-in
+// account; the shared ledger is kept
+// DELETE /api/v1/me
+func (h *AccountHandler) Close(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.CloseAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	req.Client = clientInfo(c)
+
+	closure, err := h.service.Close(userID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, closure)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *AccountHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if respondLoginThrottled(c, err) {
+		return
+	}
+
+	if errors.Is(err, service.ErrWrongPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "password is incorrect",
+			"field": passwordField(c),
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrEmailChangeTokenNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+			"field": "token",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrUserAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+			"field": "email",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, service.ErrEmailChangeDisabled) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
+
+// passwordField returns the request field holding the password: profile
+// updates confirm with current_password, closing the account with password
+func passwordField(c *gin.Context) string {
+	if c.Request.Method == http.MethodPatch {
+		return "current_password"
+	}
+	return "password"
+}
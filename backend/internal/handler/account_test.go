@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// mockAccountService is a mock implementation of AccountService for testing
+type mockAccountService struct {
+	userID     int64
+	updateReq  *domain.UpdateProfileRequest
+	confirmReq *domain.ConfirmEmailRequest
+	closeReq   *domain.CloseAccountRequest
+	err        error
+}
+
+func (m *mockAccountService) GetProfile(userID int64) (*domain.ProfileResponse, error) {
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.ProfileResponse{ID: userID, Email: "test@example.com"}, nil
+}
+
+func (m *mockAccountService) UpdateProfile(ctx context.Context, userID int64, req *domain.UpdateProfileRequest) (*domain.ProfileResponse, error) {
+	m.userID, m.updateReq = userID, req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.ProfileResponse{ID: userID, Email: "test@example.com", PendingEmail: "new@example.com"}, nil
+}
+
+func (m *mockAccountService) ConfirmEmail(req *domain.ConfirmEmailRequest) error {
+	m.confirmReq = req
+	return m.err
+}
+
+func (m *mockAccountService) Deactivate(userID int64, req *domain.CloseAccountRequest) error {
+	m.userID, m.closeReq = userID, req
+	return m.err
+}
+
+func (m *mockAccountService) Close(userID int64, req *domain.CloseAccountRequest) (*domain.AccountClosure, error) {
+	m.userID, m.closeReq = userID, req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.AccountClosure{RemovalScheduledAt: time.Date(2026, 4, 9, 9, 0, 0, 0, time.UTC)}, nil
+}
+
+func (m *mockAccountService) Reactivate(email string) error {
+	return m.err
+}
+
+func (m *mockAccountService) RemoveDue() (int, error) {
+	return 0, nil
+}
+
+func (m *mockAccountService) DeleteExpiredEmailChanges() (int64, error) {
+	return 0, nil
+}
+
+func setupAccountRouter(handler *AccountHandler, userID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set(middleware.UserIDContextKey, userID)
+		}
+		c.Next()
+	})
+	router.GET("/me", handler.GetProfile)
+	router.PATCH("/me", handler.UpdateProfile)
+	router.POST("/me/deactivate", handler.Deactivate)
+	router.DELETE("/me", handler.Close)
+	router.POST("/auth/email/confirm", handler.ConfirmEmail)
+	return router
+}
+
+func sendAccountRequest(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "203.0.113.7:4711"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAccountHandler_GetProfile(t *testing.T) {
+	svc := &mockAccountService{}
+	router := setupAccountRouter(NewAccountHandler(svc), 42)
+
+	w := sendAccountRequest(router, "GET", "/me", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+	assert.Contains(t, w.Body.String(), `"email":"test@example.com"`)
+}
+
+func TestAccountHandler_GetProfile_Unauthenticated(t *testing.T) {
+	router := setupAccountRouter(NewAccountHandler(&mockAccountService{}), 0)
+
+	w := sendAccountRequest(router, "GET", "/me", "")
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAccountHandler_UpdateProfile(t *testing.T) {
+	svc := &mockAccountService{}
+	router := setupAccountRouter(NewAccountHandler(svc), 42)
+
+	w := sendAccountRequest(router, "PATCH", "/me", `{"name": "New Name", "email": "new@example.com", "current_password": "Password123", "settings": {"locale": "de-DE"}}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, svc.updateReq)
+	assert.Equal(t, "New Name", *svc.updateReq.Name)
+	assert.Equal(t, "de-DE", svc.updateReq.Settings.Locale)
+	assert.Equal(t, "203.0.113.7", svc.updateReq.Client.IPAddress)
+	assert.Contains(t, w.Body.String(), `"pending_email":"new@example.com"`)
+}
+
+func TestAccountHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		err        error
+		wantStatus int
+		wantField  string
+	}{
+		{"invalid settings", "PATCH", "/me", `{"settings": {"timezone": "Nowhere"}}`, &domain.ValidationError{Field: "settings.timezone", Message: "unknown timezone"}, http.StatusBadRequest, "settings.timezone"},
+		{"wrong current password", "PATCH", "/me", `{"email": "new@example.com", "current_password": "Wrong1234567"}`, service.ErrWrongPassword, http.StatusBadRequest, "current_password"},
+		{"email taken", "PATCH", "/me", `{"email": "taken@example.com", "current_password": "Password123"}`, repository.ErrUserAlreadyExists, http.StatusConflict, "email"},
+		{"email change disabled", "PATCH", "/me", `{"email": "new@example.com", "current_password": "Password123"}`, service.ErrEmailChangeDisabled, http.StatusServiceUnavailable, ""},
+		{"throttled", "PATCH", "/me", `{"email": "new@example.com", "current_password": "Wrong1234567"}`, &service.LoginThrottledError{RetryAfter: time.Second}, http.StatusTooManyRequests, ""},
+		{"invalid token", "POST", "/auth/email/confirm", `{"token": "expired"}`, repository.ErrEmailChangeTokenNotFound, http.StatusBadRequest, "token"},
+		{"wrong password", "POST", "/me/deactivate", `{"password": "Wrong1234567"}`, service.ErrWrongPassword, http.StatusBadRequest, "password"},
+		{"deleted user", "DELETE", "/me", `{"password": "Password123"}`, repository.ErrUserNotFound, http.StatusNotFound, ""},
+		{"internal error", "DELETE", "/me", `{"password": "Password123"}`, assert.AnError, http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupAccountRouter(NewAccountHandler(&mockAccountService{err: tt.err}), 42)
+
+			w := sendAccountRequest(router, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantField != "" {
+				assert.Contains(t, w.Body.String(), `"field":"`+tt.wantField+`"`)
+			}
+		})
+	}
+}
+
+func TestAccountHandler_ConfirmEmail(t *testing.T) {
+	svc := &mockAccountService{}
+	router := setupAccountRouter(NewAccountHandler(svc), 0)
+
+	w := sendAccountRequest(router, "POST", "/auth/email/confirm", `{"token": "abc"}`)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "abc", svc.confirmReq.Token)
+}
+
+func TestAccountHandler_Deactivate(t *testing.T) {
+	svc := &mockAccountService{}
+	router := setupAccountRouter(NewAccountHandler(svc), 42)
+
+	w := sendAccountRequest(router, "POST", "/me/deactivate", `{"password": "Password123"}`)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+	assert.Equal(t, "Password123", svc.closeReq.Password)
+}
+
+func TestAccountHandler_Close(t *testing.T) {
+	svc := &mockAccountService{}
+	router := setupAccountRouter(NewAccountHandler(svc), 42)
+
+	w := sendAccountRequest(router, "DELETE", "/me", `{"password": "Password123"}`)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+	assert.Contains(t, w.Body.String(), `"removal_scheduled_at":"2026-04-09T09:00:00Z"`)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -81,6 +82,62 @@ func (h *AnalyticsHandler) GetBreakdownByCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, breakdown)
 }
 
+// GetBreakdown returns a breakdown of income or expenses grouped by a dimension
+// GET /api/v1/analytics/breakdown?type=out&group_by=category&start_date=2026-01-01&end_date=2026-01-31
+func (h *AnalyticsHandler) GetBreakdown(c *gin.Context) {
+	var params domain.BreakdownQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	breakdown, err := h.service.GetBreakdown(params)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
+}
+
+// GetPivot returns a row dimension by period matrix of amounts
+// GET /api/v1/analytics/pivot?rows=category&period=monthly&type=out&start_date=2026-01-01
+func (h *AnalyticsHandler) GetPivot(c *gin.Context) {
+	var params domain.PivotQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	pivot, err := h.service.GetPivot(params)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, pivot)
+}
+
 // ListTransactions returns a paginated list of transactions
 func (h *AnalyticsHandler) ListTransactions(c *gin.Context) {
 	var params domain.ListTransactionsQueryParams
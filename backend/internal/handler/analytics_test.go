@@ -20,6 +20,8 @@ func setupAnalyticsRouter(handler *AnalyticsHandler) *gin.Engine {
 	router.GET("/analytics/trends", handler.GetTrends)
 	router.GET("/analytics/breakdown/source", handler.GetBreakdownBySource)
 	router.GET("/analytics/breakdown/category", handler.GetBreakdownByCategory)
+	router.GET("/analytics/breakdown", handler.GetBreakdown)
+	router.GET("/analytics/pivot", handler.GetPivot)
 	router.GET("/transactions", handler.ListTransactions)
 	router.GET("/transactions/:id", handler.GetTransactionByID)
 	return router
@@ -325,6 +327,138 @@ func TestAnalyticsHandler_GetBreakdownByCategory_DatabaseError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
+// Test AnalyticsHandler GetBreakdown
+
+func TestAnalyticsHandler_GetBreakdown_Success(t *testing.T) {
+	var captured domain.BreakdownQueryParams
+	mockService := &mockTransactionService{
+		getBreakdownFunc: func(params domain.BreakdownQueryParams) ([]domain.BreakdownResponse, error) {
+			captured = params
+			return []domain.BreakdownResponse{
+				{Label: "Company ABC", Amount: 2000, Percentage: 100.0, Count: 1},
+			}, nil
+		},
+	}
+
+	handler := NewAnalyticsHandler(mockService)
+	router := setupAnalyticsRouter(handler)
+
+	req := httptest.NewRequest("GET", "/analytics/breakdown?type=in&group_by=recipient&start_date=2026-01-01&end_date=2026-01-31", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.TransactionTypeIn, captured.Type)
+	assert.Equal(t, "recipient", captured.GroupBy)
+	assert.Equal(t, "2026-01-01", captured.StartDate)
+	assert.Equal(t, "2026-01-31", captured.EndDate)
+
+	var response []domain.BreakdownResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "Company ABC", response[0].Label)
+}
+
+func TestAnalyticsHandler_GetBreakdown_ValidationError(t *testing.T) {
+	mockService := &mockTransactionService{
+		getBreakdownFunc: func(params domain.BreakdownQueryParams) ([]domain.BreakdownResponse, error) {
+			return nil, &domain.ValidationError{Field: "group_by", Message: "invalid group_by"}
+		},
+	}
+
+	handler := NewAnalyticsHandler(mockService)
+	router := setupAnalyticsRouter(handler)
+
+	req := httptest.NewRequest("GET", "/analytics/breakdown?group_by=amount", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "group_by", response["field"])
+}
+
+func TestAnalyticsHandler_GetBreakdown_ServiceError(t *testing.T) {
+	mockService := &mockTransactionService{
+		getBreakdownFunc: func(params domain.BreakdownQueryParams) ([]domain.BreakdownResponse, error) {
+			return nil, errors.New("database error")
+		},
+	}
+
+	handler := NewAnalyticsHandler(mockService)
+	router := setupAnalyticsRouter(handler)
+
+	req := httptest.NewRequest("GET", "/analytics/breakdown", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+// Test AnalyticsHandler GetPivot
+
+func TestAnalyticsHandler_GetPivot_Success(t *testing.T) {
+	var captured domain.PivotQueryParams
+	mockService := &mockTransactionService{
+		getPivotFunc: func(params domain.PivotQueryParams) (*domain.PivotResponse, error) {
+			captured = params
+			return &domain.PivotResponse{
+				Type:         domain.TransactionTypeOut,
+				RowDimension: "source",
+				Period:       "monthly",
+				Columns:      []string{"2026-01"},
+				Rows:         []domain.PivotRow{{Label: "Bank ABC", Values: []float64{100}, Total: 100}},
+				ColumnTotals: []float64{100},
+				GrandTotal:   100,
+			}, nil
+		},
+	}
+
+	handler := NewAnalyticsHandler(mockService)
+	router := setupAnalyticsRouter(handler)
+
+	req := httptest.NewRequest("GET", "/analytics/pivot?rows=source&period=monthly&type=out", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "source", captured.Rows)
+	assert.Equal(t, "monthly", captured.Period)
+
+	var response domain.PivotResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2026-01"}, response.Columns)
+	assert.Len(t, response.Rows, 1)
+	assert.Equal(t, 100.0, response.GrandTotal)
+}
+
+func TestAnalyticsHandler_GetPivot_ValidationError(t *testing.T) {
+	mockService := &mockTransactionService{
+		getPivotFunc: func(params domain.PivotQueryParams) (*domain.PivotResponse, error) {
+			return nil, &domain.ValidationError{Field: "period", Message: "invalid period"}
+		},
+	}
+
+	handler := NewAnalyticsHandler(mockService)
+	router := setupAnalyticsRouter(handler)
+
+	req := httptest.NewRequest("GET", "/analytics/pivot?period=hourly", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // Test AnalyticsHandler ListTransactions
 
 func TestAnalyticsHandler_ListTransactions_DefaultPagination(t *testing.T) {
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// AnomalyHandler handles spending anomaly requests
+type AnomalyHandler struct {
+	service service.AnomalyService
+}
+
+// NewAnomalyHandler creates a new anomaly handler
+func NewAnomalyHandler(service service.AnomalyService) *AnomalyHandler {
+	return &AnomalyHandler{service: service}
+}
+
+// ListAnomalies returns transactions flagged as unusual with their reasons
+// GET /api/v1/analytics/anomalies?days=30&reason=burst
+func (h *AnomalyHandler) ListAnomalies(c *gin.Context) {
+	var params domain.AnomalyQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid query parameters",
+		})
+		return
+	}
+
+	anomalies, err := h.service.ListAnomalies(params)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, anomalies)
+}
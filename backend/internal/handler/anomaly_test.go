@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// mockAnomalyService is a mock implementation of AnomalyService for testing
+type mockAnomalyService struct {
+	params domain.AnomalyQueryParams
+}
+
+func (m *mockAnomalyService) Detect(transactions []domain.Transaction) ([]domain.TransactionAnomaly, error) {
+	return nil, nil
+}
+
+func (m *mockAnomalyService) ListAnomalies(params domain.AnomalyQueryParams) (*domain.AnomaliesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	m.params = params
+	return &domain.AnomaliesResponse{
+		Days:  params.Days,
+		Total: 1,
+		Data: []domain.AnomalousTransaction{
+			{
+				Transaction: domain.Transaction{ID: 9, Anomalous: true},
+				Reasons:     []domain.TransactionAnomaly{{TransactionID: 9, Reason: domain.AnomalyReasonBurst}},
+			},
+		},
+	}, nil
+}
+
+func (m *mockAnomalyService) OnTransactionsCreated(transactions []domain.Transaction) {}
+
+func setupAnomalyRouter(handler *AnomalyHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/analytics/anomalies", handler.ListAnomalies)
+	return router
+}
+
+func TestAnomalyHandler_ListAnomalies_Success(t *testing.T) {
+	svc := &mockAnomalyService{}
+	router := setupAnomalyRouter(NewAnomalyHandler(svc))
+
+	req := httptest.NewRequest("GET", "/analytics/anomalies?days=7&reason=burst", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 7, svc.params.Days)
+	assert.Equal(t, domain.AnomalyReasonBurst, svc.params.Reason)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	item := response["data"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, true, item["transaction"].(map[string]interface{})["anomalous"])
+}
+
+func TestAnomalyHandler_ListAnomalies_DefaultDays(t *testing.T) {
+	svc := &mockAnomalyService{}
+	router := setupAnomalyRouter(NewAnomalyHandler(svc))
+
+	req := httptest.NewRequest("GET", "/analytics/anomalies", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.DefaultAnomalyDays, svc.params.Days)
+}
+
+func TestAnomalyHandler_ListAnomalies_InvalidReason(t *testing.T) {
+	router := setupAnomalyRouter(NewAnomalyHandler(&mockAnomalyService{}))
+
+	req := httptest.NewRequest("GET", "/analytics/anomalies?reason=weekend", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -39,10 +41,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		// Check for specific error types
 		var validationErr *domain.ValidationError
 		if errors.As(err, &validationErr) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": validationErr.Message,
-				"field": validationErr.Field,
-			})
+			respondValidationError(c, validationErr)
 			return
 		}
 
@@ -75,16 +74,20 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
+	req.Client = clientInfo(c)
+
 	// Call service to authenticate user
 	response, err := h.authService.Login(&req)
 	if err != nil {
 		// Check for validation errors
 		var validationErr *domain.ValidationError
 		if errors.As(err, &validationErr) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": validationErr.Message,
-				"field": validationErr.Field,
-			})
+			respondValidationError(c, validationErr)
+			return
+		}
+
+		// Blocked after failed logins, return 429 with the wait in seconds
+		if respondLoginThrottled(c, err) {
 			return
 		}
 
@@ -107,3 +110,69 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// VerifyMFA handles the second login step of users with two-factor authentication
+// POST /api/v1/auth/mfa/verify
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req domain.MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	req.Client = clientInfo(c)
+
+	response, err := h.authService.VerifyMFA(&req)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+
+		if respondLoginThrottled(c, err) {
+			return
+		}
+
+		// An expired challenge means logging in again
+		if errors.Is(err, service.ErrInvalidMFAToken) ||
+			errors.Is(err, service.ErrUserInactive) ||
+			errors.Is(err, service.ErrMFANotEnabled) {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "invalid or expired MFA token",
+			})
+			return
+		}
+
+		if errors.Is(err, service.ErrInvalidMFACode) {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "authentication failed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondLoginThrottled answers 429 with the wait in seconds when err blocks
+// a login after failed attempts, and reports whether it did
+func respondLoginThrottled(c *gin.Context, err error) bool {
+	var throttledErr *service.LoginThrottledError
+	if !errors.As(err, &throttledErr) {
+		return false
+	}
+
+	c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttledErr.RetryAfter.Seconds()))))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error": throttledErr.Error(),
+	})
+	return true
+}
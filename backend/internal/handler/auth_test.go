@@ -7,10 +7,12 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/dev/personal-finance-tracker/backend/internal/domain"
 	"github.com/dev/personal-finance-tracker/backend/internal/repository"
@@ -22,8 +24,12 @@ import (
 type mockAuthService struct {
 	registerResp *domain.AuthResponse
 	registerErr  error
+	loginReq     *domain.LoginRequest
 	loginResp    *domain.AuthResponse
 	loginErr     error
+	verifyReq    *domain.MFAVerifyRequest
+	verifyResp   *domain.AuthResponse
+	verifyErr    error
 	validateResp *security.Claims
 	validateErr  error
 	jwtManager   *security.JWTManager
@@ -37,12 +43,21 @@ func (m *mockAuthService) Register(req *domain.RegisterRequest) (*domain.AuthRes
 }
 
 func (m *mockAuthService) Login(req *domain.LoginRequest) (*domain.AuthResponse, error) {
+	m.loginReq = req
 	if m.loginErr != nil {
 		return nil, m.loginErr
 	}
 	return m.loginResp, nil
 }
 
+func (m *mockAuthService) VerifyMFA(req *domain.MFAVerifyRequest) (*domain.AuthResponse, error) {
+	m.verifyReq = req
+	if m.verifyErr != nil {
+		return nil, m.verifyErr
+	}
+	return m.verifyResp, nil
+}
+
 func (m *mockAuthService) ValidateToken(token string) (*security.Claims, error) {
 	if m.validateErr != nil {
 		return nil, m.validateErr
@@ -65,6 +80,7 @@ func setupAuthTestRouter(authService service.AuthService) *gin.Engine {
 	authHandler := NewAuthHandler(authService)
 	router.POST("/register", authHandler.Register)
 	router.POST("/login", authHandler.Login)
+	router.POST("/mfa/verify", authHandler.VerifyMFA)
 
 	return router
 }
@@ -245,6 +261,47 @@ func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
 	assert.Equal(t, "invalid email or password", response["error"])
 }
 
+func TestAuthHandler_Login_PassesClientInfo(t *testing.T) {
+	mockAuth := &mockAuthService{
+		loginResp: createTestAuthResponse(t),
+	}
+	router := setupAuthTestRouter(mockAuth)
+
+	body := `{"email": "test@example.com", "password": "Password123"}`
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "FinanceApp/2.1")
+	req.RemoteAddr = "203.0.113.7:52814"
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.ClientInfo{IPAddress: "203.0.113.7", UserAgent: "FinanceApp/2.1"}, mockAuth.loginReq.Client)
+}
+
+func TestAuthHandler_Login_Throttled(t *testing.T) {
+	mockAuth := &mockAuthService{
+		loginErr: &service.LoginThrottledError{RetryAfter: 899500 * time.Millisecond, Locked: true},
+	}
+	router := setupAuthTestRouter(mockAuth)
+
+	body := `{"email": "test@example.com", "password": "Password123"}`
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "900", w.Header().Get("Retry-After"))
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "account temporarily locked after too many failed logins", response["error"])
+}
+
 func TestAuthHandler_Login_InactiveUser(t *testing.T) {
 	mockAuth := &mockAuthService{
 		loginErr: service.ErrUserInactive,
@@ -395,3 +452,76 @@ func TestAuthHandler_Login_ServiceError(t *testing.T) {
 	assert.Contains(t, response, "error")
 	assert.Equal(t, "authentication failed", response["error"])
 }
+
+func TestAuthHandler_Login_MFARequired(t *testing.T) {
+	mockAuth := &mockAuthService{
+		loginResp: &domain.AuthResponse{MFARequired: true, MFAToken: "challenge"},
+	}
+	router := setupAuthTestRouter(mockAuth)
+
+	body := `{"email": "test@example.com", "password": "Password123"}`
+	req, _ := http.NewRequest("POST", "/login", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"mfa_required": true, "mfa_token": "challenge"}`, w.Body.String(), "no user data before the second factor")
+}
+
+func TestAuthHandler_VerifyMFA_Success(t *testing.T) {
+	mockAuth := &mockAuthService{
+		verifyResp: createTestAuthResponse(t),
+	}
+	router := setupAuthTestRouter(mockAuth)
+
+	body := `{"mfa_token": "challenge", "code": "123456"}`
+	req, _ := http.NewRequest("POST", "/mfa/verify", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "curl/8.5.0")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NotNil(t, mockAuth.verifyReq)
+	assert.Equal(t, "123456", mockAuth.verifyReq.Code)
+	assert.Equal(t, "curl/8.5.0", mockAuth.verifyReq.Client.UserAgent)
+
+	var response domain.AuthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.NotEmpty(t, response.Token)
+}
+
+func TestAuthHandler_VerifyMFA_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantError  string
+	}{
+		{"invalid code", service.ErrInvalidMFACode, http.StatusUnauthorized, "invalid two-factor code"},
+		{"expired token", service.ErrInvalidMFAToken, http.StatusUnauthorized, "invalid or expired MFA token"},
+		{"throttled", &service.LoginThrottledError{RetryAfter: 2 * time.Second}, http.StatusTooManyRequests, "too many failed logins, try again later"},
+		{"internal", errors.New("connection refused"), http.StatusInternalServerError, "authentication failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupAuthTestRouter(&mockAuthService{verifyErr: tt.err})
+
+			body := `{"mfa_token": "challenge", "code": "123456"}`
+			req, _ := http.NewRequest("POST", "/mfa/verify", bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			var response map[string]interface{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.wantError, response["error"])
+		})
+	}
+}
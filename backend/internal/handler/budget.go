@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// BudgetHandler handles budget management and progress requests
+type BudgetHandler struct {
+	service service.BudgetService
+}
+
+// NewBudgetHandler creates a new budget handler
+func NewBudgetHandler(service service.BudgetService) *BudgetHandler {
+	return &BudgetHandler{service: service}
+}
+
+// CreateBudget creates a monthly budget
+// POST /api/v1/budgets
+func (h *BudgetHandler) CreateBudget(c *gin.Context) {
+	var req domain.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	budget, err := h.service.CreateBudget(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, budget)
+}
+
+// ListBudgets returns all budgets
+// GET /api/v1/budgets
+func (h *BudgetHandler) ListBudgets(c *gin.Context) {
+	budgets, err := h.service.ListBudgets()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": budgets,
+	})
+}
+
+// GetBudget returns a single budget by ID
+// GET /api/v1/budgets/:id
+func (h *BudgetHandler) GetBudget(c *gin.Context) {
+	id, ok := parseIDParam(c, "budget")
+	if !ok {
+		return
+	}
+
+	budget, err := h.service.GetBudget(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// UpdateBudget replaces a budget's category, source and amount
+// PUT /api/v1/budgets/:id
+func (h *BudgetHandler) UpdateBudget(c *gin.Context) {
+	id, ok := parseIDParam(c, "budget")
+	if !ok {
+		return
+	}
+
+	var req domain.BudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	budget, err := h.service.UpdateBudget(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, budget)
+}
+
+// DeleteBudget removes a budget
+// DELETE /api/v1/budgets/:id
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	id, ok := parseIDParam(c, "budget")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteBudget(id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetBudgetProgress returns spent, remaining and projected spend for the current month
+// GET /api/v1/analytics/budgets
+func (h *BudgetHandler) GetBudgetProgress(c *gin.Context) {
+	progress, err := h.service.GetBudgetProgress()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *BudgetHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, repository.ErrBudgetNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "budget not found",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrBudgetAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockBudgetService is a mock implementation of BudgetService for testing
+type mockBudgetService struct {
+	createFunc   func(req *domain.BudgetRequest) (*domain.Budget, error)
+	getFunc      func(id int64) (*domain.Budget, error)
+	deleteFunc   func(id int64) error
+	progressResp *domain.BudgetProgressResponse
+}
+
+func (m *mockBudgetService) CreateBudget(req *domain.BudgetRequest) (*domain.Budget, error) {
+	if m.createFunc != nil {
+		return m.createFunc(req)
+	}
+	return req.ToBudget(), nil
+}
+
+func (m *mockBudgetService) GetBudget(id int64) (*domain.Budget, error) {
+	if m.getFunc != nil {
+		return m.getFunc(id)
+	}
+	return &domain.Budget{ID: id}, nil
+}
+
+func (m *mockBudgetService) ListBudgets() ([]domain.Budget, error) {
+	return []domain.Budget{{ID: 1, Category: "Food", Amount: 100}}, nil
+}
+
+func (m *mockBudgetService) UpdateBudget(id int64, req *domain.BudgetRequest) (*domain.Budget, error) {
+	budget := req.ToBudget()
+	budget.ID = id
+	return budget, nil
+}
+
+func (m *mockBudgetService) DeleteBudget(id int64) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(id)
+	}
+	return nil
+}
+
+func (m *mockBudgetService) GetBudgetProgress() (*domain.BudgetProgressResponse, error) {
+	return m.progressResp, nil
+}
+
+func setupBudgetRouter(handler *BudgetHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/budgets", handler.ListBudgets)
+	router.POST("/budgets", handler.CreateBudget)
+	router.GET("/budgets/:id", handler.GetBudget)
+	router.PUT("/budgets/:id", handler.UpdateBudget)
+	router.DELETE("/budgets/:id", handler.DeleteBudget)
+	router.GET("/analytics/budgets", handler.GetBudgetProgress)
+	return router
+}
+
+func TestBudgetHandler_CreateBudget_Success(t *testing.T) {
+	handler := NewBudgetHandler(&mockBudgetService{})
+	router := setupBudgetRouter(handler)
+
+	body, _ := json.Marshal(map[string]interface{}{"category": "Food", "amount": 3000000})
+	req := httptest.NewRequest("POST", "/budgets", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.Budget
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Food", response.Category)
+}
+
+func TestBudgetHandler_CreateBudget_MissingAmount(t *testing.T) {
+	handler := NewBudgetHandler(&mockBudgetService{})
+	router := setupBudgetRouter(handler)
+
+	body, _ := json.Marshal(map[string]interface{}{"category": "Food"})
+	req := httptest.NewRequest("POST", "/budgets", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBudgetHandler_CreateBudget_Duplicate(t *testing.T) {
+	handler := NewBudgetHandler(&mockBudgetService{
+		createFunc: func(req *domain.BudgetRequest) (*domain.Budget, error) {
+			return nil, repository.ErrBudgetAlreadyExists
+		},
+	})
+	router := setupBudgetRouter(handler)
+
+	body, _ := json.Marshal(map[string]interface{}{"category": "Food", "amount": 100})
+	req := httptest.NewRequest("POST", "/budgets", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestBudgetHandler_GetBudget_NotFound(t *testing.T) {
+	handler := NewBudgetHandler(&mockBudgetService{
+		getFunc: func(id int64) (*domain.Budget, error) {
+			return nil, repository.ErrBudgetNotFound
+		},
+	})
+	router := setupBudgetRouter(handler)
+
+	req := httptest.NewRequest("GET", "/budgets/7", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestBudgetHandler_GetBudget_InvalidID(t *testing.T) {
+	handler := NewBudgetHandler(&mockBudgetService{})
+	router := setupBudgetRouter(handler)
+
+	req := httptest.NewRequest("GET", "/budgets/abc", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestBudgetHandler_DeleteBudget_Success(t *testing.T) {
+	var deleted int64
+	handler := NewBudgetHandler(&mockBudgetService{
+		deleteFunc: func(id int64) error {
+			deleted = id
+			return nil
+		},
+	})
+	router := setupBudgetRouter(handler)
+
+	req := httptest.NewRequest("DELETE", "/budgets/3", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, int64(3), deleted)
+}
+
+func TestBudgetHandler_GetBudgetProgress(t *testing.T) {
+	handler := NewBudgetHandler(&mockBudgetService{
+		progressResp: &domain.BudgetProgressResponse{
+			Period: "2026-04",
+			Budgets: []domain.BudgetProgress{
+				{ID: 1, Category: "Food", Amount: 3000, Spent: 1000, Remaining: 2000, Status: domain.BudgetStatusOnTrack},
+			},
+		},
+	})
+	router := setupBudgetRouter(handler)
+
+	req := httptest.NewRequest("GET", "/analytics/budgets", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.BudgetProgressResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-04", response.Period)
+	assert.Len(t, response.Budgets, 1)
+	assert.Equal(t, 2000.0, response.Budgets[0].Remaining)
+}
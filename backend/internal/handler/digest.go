@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// DigestHandler handles digest email preference requests of the signed-in user
+type DigestHandler struct {
+	service service.DigestService
+}
+
+// NewDigestHandler creates a new digest handler
+func NewDigestHandler(service service.DigestService) *DigestHandler {
+	return &DigestHandler{service: service}
+}
+
+// GetPreferences returns which digests the user receives
+// GET /api/v1/digests/preferences
+func (h *DigestHandler) GetPreferences(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.service.GetPreferences(userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// UpdatePreferences opts the user in or out of weekly and monthly digests
+// PUT /api/v1/digests/preferences
+func (h *DigestHandler) UpdatePreferences(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.DigestPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	subscription, err := h.service.UpdatePreferences(userID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *DigestHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
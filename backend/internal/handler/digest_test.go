@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+)
+
+// mockDigestService is a mock implementation of DigestService for testing
+type mockDigestService struct {
+	userID int64
+	err    error
+}
+
+func (m *mockDigestService) GetPreferences(userID int64) (*domain.DigestSubscription, error) {
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.DigestSubscription{UserID: userID, Weekly: true}, nil
+}
+
+func (m *mockDigestService) UpdatePreferences(userID int64, req *domain.DigestPreferencesRequest) (*domain.DigestSubscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	subscription := &domain.DigestSubscription{UserID: userID}
+	if req.Monthly != nil {
+		subscription.Monthly = *req.Monthly
+	}
+	return subscription, nil
+}
+
+func (m *mockDigestService) BuildDigest(ctx context.Context, frequency domain.DigestFrequency) (*domain.Digest, error) {
+	return &domain.Digest{Frequency: frequency}, nil
+}
+
+func (m *mockDigestService) SendDue(ctx context.Context) (*domain.DigestRunResult, error) {
+	return &domain.DigestRunResult{}, nil
+}
+
+// setupDigestRouter signs every request in as userID; zero leaves it anonymous
+func setupDigestRouter(handler *DigestHandler, userID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set(middleware.UserIDContextKey, userID)
+		}
+		c.Next()
+	})
+	router.GET("/digests/preferences", handler.GetPreferences)
+	router.PUT("/digests/preferences", handler.UpdatePreferences)
+	return router
+}
+
+func TestDigestHandler_GetPreferences(t *testing.T) {
+	svc := &mockDigestService{}
+	router := setupDigestRouter(NewDigestHandler(svc), 42)
+
+	req := httptest.NewRequest("GET", "/digests/preferences", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+
+	var response domain.DigestSubscription
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.True(t, response.Weekly)
+}
+
+func TestDigestHandler_GetPreferences_Unauthorized(t *testing.T) {
+	router := setupDigestRouter(NewDigestHandler(&mockDigestService{}), 0)
+
+	req := httptest.NewRequest("GET", "/digests/preferences", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestDigestHandler_UpdatePreferences(t *testing.T) {
+	svc := &mockDigestService{}
+	router := setupDigestRouter(NewDigestHandler(svc), 42)
+
+	req := httptest.NewRequest("PUT", "/digests/preferences", bytes.NewBufferString(`{"monthly": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.DigestSubscription
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, int64(42), response.UserID)
+	assert.True(t, response.Monthly)
+}
+
+func TestDigestHandler_UpdatePreferences_ValidationError(t *testing.T) {
+	router := setupDigestRouter(NewDigestHandler(&mockDigestService{}), 42)
+
+	req := httptest.NewRequest("PUT", "/digests/preferences", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "weekly", response["field"])
+}
+
+func TestDigestHandler_UpdatePreferences_InvalidJSON(t *testing.T) {
+	router := setupDigestRouter(NewDigestHandler(&mockDigestService{}), 42)
+
+	req := httptest.NewRequest("PUT", "/digests/preferences", bytes.NewBufferString(`{"weekly": "yes"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDigestHandler_ServiceError(t *testing.T) {
+	router := setupDigestRouter(NewDigestHandler(&mockDigestService{err: errors.New("connection refused")}), 42)
+
+	req := httptest.NewRequest("GET", "/digests/preferences", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
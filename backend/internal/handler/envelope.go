@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// EnvelopeHandler handles envelope budgeting requests
+type EnvelopeHandler struct {
+	service service.EnvelopeService
+}
+
+// NewEnvelopeHandler creates a new envelope handler
+func NewEnvelopeHandler(service service.EnvelopeService) *EnvelopeHandler {
+	return &EnvelopeHandler{service: service}
+}
+
+// CreateEnvelope creates an envelope for a spending category
+// POST /api/v1/envelopes
+func (h *EnvelopeHandler) CreateEnvelope(c *gin.Context) {
+	var req domain.EnvelopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	envelope, err := h.service.CreateEnvelope(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, envelope)
+}
+
+// ListEnvelopes returns all envelopes
+// GET /api/v1/envelopes
+func (h *EnvelopeHandler) ListEnvelopes(c *gin.Context) {
+	envelopes, err := h.service.ListEnvelopes()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": envelopes,
+	})
+}
+
+// GetEnvelope returns a single envelope by ID
+// GET /api/v1/envelopes/:id
+func (h *EnvelopeHandler) GetEnvelope(c *gin.Context) {
+	id, ok := parseIDParam(c, "envelope")
+	if !ok {
+		return
+	}
+
+	envelope, err := h.service.GetEnvelope(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, envelope)
+}
+
+// UpdateEnvelope replaces an envelope's name and category
+// PUT /api/v1/envelopes/:id
+func (h *EnvelopeHandler) UpdateEnvelope(c *gin.Context) {
+	id, ok := parseIDParam(c, "envelope")
+	if !ok {
+		return
+	}
+
+	var req domain.EnvelopeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	envelope, err := h.service.UpdateEnvelope(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, envelope)
+}
+
+// DeleteEnvelope removes an envelope with its allocations and moves
+// DELETE /api/v1/envelopes/:id
+func (h *EnvelopeHandler) DeleteEnvelope(c *gin.Context) {
+	id, ok := parseIDParam(c, "envelope")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteEnvelope(id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Allocate assigns money to an envelope, optionally from an income transaction
+// POST /api/v1/envelopes/:id/allocations
+func (h *EnvelopeHandler) Allocate(c *gin.Context) {
+	id, ok := parseIDParam(c, "envelope")
+	if !ok {
+		return
+	}
+
+	var req domain.AllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	allocation, err := h.service.Allocate(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, allocation)
+}
+
+// ListAllocations returns the allocations of an envelope, newest month first
+// GET /api/v1/envelopes/:id/allocations
+func (h *EnvelopeHandler) ListAllocations(c *gin.Context) {
+	id, ok := parseIDParam(c, "envelope")
+	if !ok {
+		return
+	}
+
+	allocations, err := h.service.ListAllocations(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": allocations,
+	})
+}
+
+// Move moves available money from this envelope to another
+// POST /api/v1/envelopes/:id/moves
+func (h *EnvelopeHandler) Move(c *gin.Context) {
+	id, ok := parseIDParam(c, "envelope")
+	if !ok {
+		return
+	}
+
+	var req domain.MoveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	move, err := h.service.Move(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, move)
+}
+
+// GetBalances returns monthly envelope balances with rollover
+// GET /api/v1/analytics/envelopes?start_month=2024-01&end_month=2024-06
+func (h *EnvelopeHandler) GetBalances(c *gin.Context) {
+	var params domain.EnvelopeBalancesQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid query parameters",
+		})
+		return
+	}
+
+	balances, err := h.service.GetBalances(params)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *EnvelopeHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, repository.ErrEnvelopeNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "envelope not found",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrEnvelopeAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
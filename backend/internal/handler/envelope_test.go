@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockEnvelopeService is a mock implementation of EnvelopeService for testing
+type mockEnvelopeService struct {
+	getFunc      func(id int64) (*domain.Envelope, error)
+	allocateFunc func(envelopeID int64, req *domain.AllocationRequest) (*domain.EnvelopeAllocation, error)
+	moveFunc     func(fromEnvelopeID int64, req *domain.MoveRequest) (*domain.EnvelopeMove, error)
+	balancesFunc func(params domain.EnvelopeBalancesQueryParams) (*domain.EnvelopeBalancesResponse, error)
+}
+
+func (m *mockEnvelopeService) CreateEnvelope(req *domain.EnvelopeRequest) (*domain.Envelope, error) {
+	return &domain.Envelope{ID: 1, Name: req.Name, Category: req.Category}, nil
+}
+
+func (m *mockEnvelopeService) GetEnvelope(id int64) (*domain.Envelope, error) {
+	if m.getFunc != nil {
+		return m.getFunc(id)
+	}
+	return &domain.Envelope{ID: id}, nil
+}
+
+func (m *mockEnvelopeService) ListEnvelopes() ([]domain.Envelope, error) {
+	return []domain.Envelope{{ID: 1, Name: "Groceries", Category: "Food"}}, nil
+}
+
+func (m *mockEnvelopeService) UpdateEnvelope(id int64, req *domain.EnvelopeRequest) (*domain.Envelope, error) {
+	return &domain.Envelope{ID: id, Name: req.Name, Category: req.Category}, nil
+}
+
+func (m *mockEnvelopeService) DeleteEnvelope(id int64) error {
+	return nil
+}
+
+func (m *mockEnvelopeService) Allocate(envelopeID int64, req *domain.AllocationRequest) (*domain.EnvelopeAllocation, error) {
+	if m.allocateFunc != nil {
+		return m.allocateFunc(envelopeID, req)
+	}
+	return &domain.EnvelopeAllocation{ID: 1, EnvelopeID: envelopeID, Amount: req.Amount}, nil
+}
+
+func (m *mockEnvelopeService) ListAllocations(envelopeID int64) ([]domain.EnvelopeAllocation, error) {
+	return []domain.EnvelopeAllocation{}, nil
+}
+
+func (m *mockEnvelopeService) Move(fromEnvelopeID int64, req *domain.MoveRequest) (*domain.EnvelopeMove, error) {
+	if m.moveFunc != nil {
+		return m.moveFunc(fromEnvelopeID, req)
+	}
+	return &domain.EnvelopeMove{ID: 1, FromEnvelopeID: fromEnvelopeID, ToEnvelopeID: req.ToEnvelopeID, Amount: req.Amount}, nil
+}
+
+func (m *mockEnvelopeService) GetBalances(params domain.EnvelopeBalancesQueryParams) (*domain.EnvelopeBalancesResponse, error) {
+	if m.balancesFunc != nil {
+		return m.balancesFunc(params)
+	}
+	return &domain.EnvelopeBalancesResponse{}, nil
+}
+
+func setupEnvelopeRouter(handler *EnvelopeHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/envelopes", handler.ListEnvelopes)
+	router.POST("/envelopes", handler.CreateEnvelope)
+	router.GET("/envelopes/:id", handler.GetEnvelope)
+	router.PUT("/envelopes/:id", handler.UpdateEnvelope)
+	router.DELETE("/envelopes/:id", handler.DeleteEnvelope)
+	router.GET("/envelopes/:id/allocations", handler.ListAllocations)
+	router.POST("/envelopes/:id/allocations", handler.Allocate)
+	router.POST("/envelopes/:id/moves", handler.Move)
+	router.GET("/analytics/envelopes", handler.GetBalances)
+	return router
+}
+
+func TestEnvelopeHandler_CreateEnvelope_Success(t *testing.T) {
+	router := setupEnvelopeRouter(NewEnvelopeHandler(&mockEnvelopeService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "Groceries", "category": "Food"})
+	req := httptest.NewRequest("POST", "/envelopes", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestEnvelopeHandler_GetEnvelope_NotFound(t *testing.T) {
+	router := setupEnvelopeRouter(NewEnvelopeHandler(&mockEnvelopeService{
+		getFunc: func(id int64) (*domain.Envelope, error) {
+			return nil, repository.ErrEnvelopeNotFound
+		},
+	}))
+
+	req := httptest.NewRequest("GET", "/envelopes/7", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestEnvelopeHandler_Allocate_Success(t *testing.T) {
+	router := setupEnvelopeRouter(NewEnvelopeHandler(&mockEnvelopeService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{"amount": 250, "month": "2026-03"})
+	req := httptest.NewRequest("POST", "/envelopes/2/allocations", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.EnvelopeAllocation
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), response.EnvelopeID)
+}
+
+func TestEnvelopeHandler_Move_InsufficientFunds(t *testing.T) {
+	router := setupEnvelopeRouter(NewEnvelopeHandler(&mockEnvelopeService{
+		moveFunc: func(fromEnvelopeID int64, req *domain.MoveRequest) (*domain.EnvelopeMove, error) {
+			return nil, &domain.ValidationError{Field: "amount", Message: "amount exceeds the available balance"}
+		},
+	}))
+
+	body, _ := json.Marshal(map[string]interface{}{"to_envelope_id": 1, "amount": 50})
+	req := httptest.NewRequest("POST", "/envelopes/2/moves", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"amount"`)
+}
+
+func TestEnvelopeHandler_GetBalances_PassesMonths(t *testing.T) {
+	var received domain.EnvelopeBalancesQueryParams
+	router := setupEnvelopeRouter(NewEnvelopeHandler(&mockEnvelopeService{
+		balancesFunc: func(params domain.EnvelopeBalancesQueryParams) (*domain.EnvelopeBalancesResponse, error) {
+			received = params
+			return &domain.EnvelopeBalancesResponse{Months: []string{"2026-01", "2026-02"}}, nil
+		},
+	}))
+
+	req := httptest.NewRequest("GET", "/analytics/envelopes?start_month=2026-01&end_month=2026-02", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2026-01", received.StartMonth)
+	assert.Equal(t, "2026-02", received.EndMonth)
+}
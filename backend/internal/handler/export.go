@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// exportContentTypes maps export formats to their response content type
+var exportContentTypes = map[domain.ExportFormat]string{
+	domain.ExportFormatCSV:       "text/csv; charset=utf-8",
+	domain.ExportFormatXLSX:      "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	domain.ExportFormatNDJSON:    "application/x-ndjson",
+	domain.ExportFormatBeancount: "text/plain; charset=utf-8",
+	domain.ExportFormatHledger:   "text/plain; charset=utf-8",
+	domain.ExportFormatLedger:    "text/plain; charset=utf-8",
+}
+
+// exportExtensions maps export formats to their download file extension
+var exportExtensions = map[domain.ExportFormat]string{
+	domain.ExportFormatCSV:       "csv",
+	domain.ExportFormatXLSX:      "xlsx",
+	domain.ExportFormatNDJSON:    "ndjson",
+	domain.ExportFormatBeancount: "beancount",
+	domain.ExportFormatHledger:   "journal",
+	domain.ExportFormatLedger:    "ledger",
+}
+
+// exportWriteTimeout bounds every write of an export. It replaces the server's
+// write timeout, which would cut off exports that take longer as a whole.
+const exportWriteTimeout = 30 * time.Second
+
+// ExportHandler handles transaction export requests
+type ExportHandler struct {
+	service service.ExportService
+}
+
+// NewExportHandler creates a new export handler
+func NewExportHandler(service service.ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+// ExportTransactions streams the transactions matching the listing filters as
+// a file download. Query: format (csv, xlsx, ndjson, beancount, hledger,
+// ledger), columns, locale and the type, source, category, start_date and
+// end_date filters. Reading stops when the client disconnects.
+// GET /api/v1/transactions/export
+func (h *ExportHandler) ExportTransactions(c *gin.Context) {
+	var params domain.ExportQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid query parameters",
+		})
+		return
+	}
+
+	w := &exportResponseWriter{c: c, rc: http.NewResponseController(c.Writer), params: &params}
+	if err := h.service.Export(c.Request.Context(), w, &params); err != nil {
+		if w.started {
+			// The download is already under way; the client sees a truncated file
+			_ = c.Error(err)
+			c.Abort()
+			return
+		}
+
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	w.start()
+}
+
+// exportResponseWriter sets the download headers on the first write, so a
+// request that fails before any output still gets a JSON error response, and
+// gives every write its own deadline
+type exportResponseWriter struct {
+	c       *gin.Context
+	rc      *http.ResponseController
+	params  *domain.ExportQueryParams
+	started bool
+}
+
+func (w *exportResponseWriter) Write(p []byte) (int, error) {
+	w.start()
+	if err := w.rc.SetWriteDeadline(time.Now().Add(exportWriteTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return 0, err
+	}
+	return w.c.Writer.Write(p)
+}
+
+func (w *exportResponseWriter) start() {
+	if w.started {
+		return
+	}
+	w.started = true
+
+	filename := fmt.Sprintf("transactions-%s.%s", time.Now().UTC().Format("20060102"), exportExtensions[w.params.Format])
+	w.c.Header("Content-Type", exportContentTypes[w.params.Format])
+	w.c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.c.Header("Cache-Control", "no-store")
+	w.c.Status(http.StatusOK)
+}
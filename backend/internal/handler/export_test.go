@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// mockExportService is a mock implementation of ExportService for testing
+type mockExportService struct {
+	params    *domain.ExportQueryParams
+	body      string
+	chunks    []string      // written one by one after body
+	delay     time.Duration // wait before each chunk
+	streamErr error
+}
+
+func (m *mockExportService) Export(ctx context.Context, w io.Writer, params *domain.ExportQueryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	m.params = params
+	if m.body != "" {
+		if _, err := io.WriteString(w, m.body); err != nil {
+			return err
+		}
+	}
+	for _, chunk := range m.chunks {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.delay):
+		}
+		if _, err := io.WriteString(w, chunk); err != nil {
+			return err
+		}
+	}
+	return m.streamErr
+}
+
+func setupExportRouter(handler *ExportHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/transactions/export", handler.ExportTransactions)
+	return router
+}
+
+func TestExportHandler_ExportTransactions_CSV(t *testing.T) {
+	svc := &mockExportService{body: "id,amount\n1,10.00\n"}
+	router := setupExportRouter(NewExportHandler(svc))
+
+	req := httptest.NewRequest("GET", "/transactions/export?type=out&category=Food&start_date=2026-03-01&columns=id,amount&locale=en-US", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="transactions-\d{8}\.csv"$`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "id,amount\n1,10.00\n", w.Body.String())
+
+	assert.Equal(t, domain.TransactionTypeOut, svc.params.Type)
+	assert.Equal(t, "Food", svc.params.Category)
+	assert.Equal(t, "2026-03-01", svc.params.StartDate)
+	assert.Equal(t, []domain.ExportColumn{domain.ExportColumnID, domain.ExportColumnAmount}, svc.params.SelectedColumns())
+}
+
+func TestExportHandler_ExportTransactions_EmptyNDJSON(t *testing.T) {
+	router := setupExportRouter(NewExportHandler(&mockExportService{}))
+
+	req := httptest.NewRequest("GET", "/transactions/export?format=ndjson", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Body.String())
+}
+
+func TestExportHandler_ExportTransactions_InvalidParams(t *testing.T) {
+	router := setupExportRouter(NewExportHandler(&mockExportService{}))
+
+	req := httptest.NewRequest("GET", "/transactions/export?format=xlsx&columns=amount,password", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+
+	var response map[string]interface{}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "columns", response["field"])
+}
+
+func TestExportHandler_ExportTransactions_ErrorBeforeOutput(t *testing.T) {
+	router := setupExportRouter(NewExportHandler(&mockExportService{streamErr: errors.New("database error")}))
+
+	req := httptest.NewRequest("GET", "/transactions/export", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+}
+
+func TestExportHandler_ExportTransactions_ErrorMidStream(t *testing.T) {
+	svc := &mockExportService{body: "id\n1\n", streamErr: errors.New("database error")}
+	router := setupExportRouter(NewExportHandler(svc))
+
+	req := httptest.NewRequest("GET", "/transactions/export", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	// Headers are already sent, so the download is cut short rather than replaced
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "id\n1\n", w.Body.String())
+}
+
+func TestExportHandler_ExportTransactions_Beancount(t *testing.T) {
+	svc := &mockExportService{body: "option \"operating_currency\" \"USD\"\n"}
+	router := setupExportRouter(NewExportHandler(svc))
+
+	req := httptest.NewRequest("GET", "/transactions/export?format=beancount&source=Vietcombank", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Regexp(t, `filename="transactions-\d{8}\.beancount"$`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "Vietcombank", svc.params.Source)
+}
+
+func TestExportHandler_ExportTransactions_OutlastsWriteTimeout(t *testing.T) {
+	svc := &mockExportService{
+		body:   "id,amount\n",
+		chunks: []string{"1,10.00\n", "2,20.00\n", "3,30.00\n"},
+		delay:  100 * time.Millisecond,
+	}
+	server := httptest.NewUnstartedServer(setupExportRouter(NewExportHandler(svc)))
+	server.Config.WriteTimeout = 150 * time.Millisecond
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/transactions/export")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "id,amount\n1,10.00\n2,20.00\n3,30.00\n", string(body), "the export is not cut off")
+}
+
+func TestExportHandler_ExportTransactions_StopsWhenClientLeaves(t *testing.T) {
+	svc := &mockExportService{chunks: []string{"1,10.00\n"}, delay: time.Second}
+	router := setupExportRouter(NewExportHandler(svc))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/transactions/export", nil).WithContext(ctx))
+
+	assert.Less(t, time.Since(start), time.Second, "the export gets the request's context")
+	assert.NotContains(t, w.Body.String(), "1,10.00")
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// ForecastHandler handles cash-flow forecast requests
+type ForecastHandler struct {
+	service service.ForecastService
+}
+
+// NewForecastHandler creates a new forecast handler
+func NewForecastHandler(service service.ForecastService) *ForecastHandler {
+	return &ForecastHandler{service: service}
+}
+
+// GetForecast returns the projected daily balance for the next 30 to 90 days
+// GET /api/v1/analytics/forecast?days=30
+func (h *ForecastHandler) GetForecast(c *gin.Context) {
+	var params domain.ForecastQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid query parameters",
+		})
+		return
+	}
+
+	forecast, err := h.service.GetForecast(params)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, forecast)
+}
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// mockForecastService is a mock implementation of ForecastService for testing
+type mockForecastService struct {
+	params domain.ForecastQueryParams
+}
+
+func (m *mockForecastService) GetForecast(params domain.ForecastQueryParams) (*domain.ForecastResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	m.params = params
+	return &domain.ForecastResponse{
+		Days: params.Days,
+		Data: []domain.ForecastDataPoint{
+			{
+				TrendDataPoint: domain.TrendDataPoint{Date: "2026-03-02", Expense: 100, Net: -100},
+				Balance:        -50,
+				BelowZero:      true,
+			},
+		},
+	}, nil
+}
+
+func setupForecastRouter(handler *ForecastHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/analytics/forecast", handler.GetForecast)
+	return router
+}
+
+func TestForecastHandler_GetForecast_TrendShape(t *testing.T) {
+	svc := &mockForecastService{}
+	router := setupForecastRouter(NewForecastHandler(svc))
+
+	req := httptest.NewRequest("GET", "/analytics/forecast?days=60", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 60, svc.params.Days)
+
+	// Data points are flat so trend charts can consume them
+	var response struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-03-02", response.Data[0]["date"])
+	assert.Equal(t, -100.0, response.Data[0]["net"])
+	assert.Equal(t, true, response.Data[0]["below_zero"])
+}
+
+func TestForecastHandler_GetForecast_DaysOutOfRange(t *testing.T) {
+	router := setupForecastRouter(NewForecastHandler(&mockForecastService{}))
+
+	req := httptest.NewRequest("GET", "/analytics/forecast?days=7", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
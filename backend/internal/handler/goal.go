@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// GoalHandler handles savings goal requests
+type GoalHandler struct {
+	service service.GoalService
+}
+
+// NewGoalHandler creates a new goal handler
+func NewGoalHandler(service service.GoalService) *GoalHandler {
+	return &GoalHandler{service: service}
+}
+
+// CreateGoal creates a savings goal
+// POST /api/v1/goals
+func (h *GoalHandler) CreateGoal(c *gin.Context) {
+	var req domain.GoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	goal, err := h.service.CreateGoal(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, goal)
+}
+
+// ListGoals returns all goals ordered by target date
+// GET /api/v1/goals
+func (h *GoalHandler) ListGoals(c *gin.Context) {
+	goals, err := h.service.ListGoals()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": goals,
+	})
+}
+
+// GetGoal returns a single goal by ID
+// GET /api/v1/goals/:id
+func (h *GoalHandler) GetGoal(c *gin.Context) {
+	id, ok := parseIDParam(c, "goal")
+	if !ok {
+		return
+	}
+
+	goal, err := h.service.GetGoal(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+// UpdateGoal replaces a goal's target and links
+// PUT /api/v1/goals/:id
+func (h *GoalHandler) UpdateGoal(c *gin.Context) {
+	id, ok := parseIDParam(c, "goal")
+	if !ok {
+		return
+	}
+
+	var req domain.GoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	goal, err := h.service.UpdateGoal(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, goal)
+}
+
+// DeleteGoal removes a goal with its manual contributions
+// DELETE /api/v1/goals/:id
+func (h *GoalHandler) DeleteGoal(c *gin.Context) {
+	id, ok := parseIDParam(c, "goal")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteGoal(id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddContribution records a manual allocation to or withdrawal from a goal
+// POST /api/v1/goals/:id/contributions
+func (h *GoalHandler) AddContribution(c *gin.Context) {
+	id, ok := parseIDParam(c, "goal")
+	if !ok {
+		return
+	}
+
+	var req domain.GoalContributionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	contribution, err := h.service.AddContribution(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, contribution)
+}
+
+// ListContributions returns the manual contributions of a goal, newest first
+// GET /api/v1/goals/:id/contributions
+func (h *GoalHandler) ListContributions(c *gin.Context) {
+	id, ok := parseIDParam(c, "goal")
+	if !ok {
+		return
+	}
+
+	contributions, err := h.service.ListContributions(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": contributions,
+	})
+}
+
+// GetProgress returns a goal's saved amount, required monthly contribution and status
+// GET /api/v1/goals/:id/progress
+func (h *GoalHandler) GetProgress(c *gin.Context) {
+	id, ok := parseIDParam(c, "goal")
+	if !ok {
+		return
+	}
+
+	progress, err := h.service.GetProgress(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
+
+// ListProgress returns the progress of every goal
+// GET /api/v1/analytics/goals
+func (h *GoalHandler) ListProgress(c *gin.Context) {
+	progress, err := h.service.ListProgress()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": progress,
+	})
+}
+
+// handleError maps service errors to HTTP responses
+func (h *GoalHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, repository.ErrGoalNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "goal not found",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrGoalAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockGoalService is a mock implementation of GoalService for testing
+type mockGoalService struct {
+	createFunc   func(req *domain.GoalRequest) (*domain.Goal, error)
+	progressFunc func(id int64) (*domain.GoalProgress, error)
+}
+
+func (m *mockGoalService) CreateGoal(req *domain.GoalRequest) (*domain.Goal, error) {
+	if m.createFunc != nil {
+		return m.createFunc(req)
+	}
+	return &domain.Goal{ID: 1, Name: req.Name, TargetAmount: req.TargetAmount}, nil
+}
+
+func (m *mockGoalService) GetGoal(id int64) (*domain.Goal, error) {
+	return &domain.Goal{ID: id}, nil
+}
+
+func (m *mockGoalService) ListGoals() ([]domain.Goal, error) {
+	return []domain.Goal{{ID: 1, Name: "Vacation"}}, nil
+}
+
+func (m *mockGoalService) UpdateGoal(id int64, req *domain.GoalRequest) (*domain.Goal, error) {
+	return &domain.Goal{ID: id, Name: req.Name, TargetAmount: req.TargetAmount}, nil
+}
+
+func (m *mockGoalService) DeleteGoal(id int64) error {
+	return nil
+}
+
+func (m *mockGoalService) AddContribution(goalID int64, req *domain.GoalContributionRequest) (*domain.GoalContribution, error) {
+	return &domain.GoalContribution{ID: 1, GoalID: goalID, Amount: req.Amount}, nil
+}
+
+func (m *mockGoalService) ListContributions(goalID int64) ([]domain.GoalContribution, error) {
+	return []domain.GoalContribution{}, nil
+}
+
+func (m *mockGoalService) GetProgress(id int64) (*domain.GoalProgress, error) {
+	if m.progressFunc != nil {
+		return m.progressFunc(id)
+	}
+	return &domain.GoalProgress{Goal: domain.Goal{ID: id}}, nil
+}
+
+func (m *mockGoalService) ListProgress() ([]domain.GoalProgress, error) {
+	return []domain.GoalProgress{{Goal: domain.Goal{ID: 1}, Status: domain.GoalStatusOnTrack}}, nil
+}
+
+func setupGoalRouter(handler *GoalHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/goals", handler.ListGoals)
+	router.POST("/goals", handler.CreateGoal)
+	router.GET("/goals/:id", handler.GetGoal)
+	router.PUT("/goals/:id", handler.UpdateGoal)
+	router.DELETE("/goals/:id", handler.DeleteGoal)
+	router.GET("/goals/:id/progress", handler.GetProgress)
+	router.GET("/goals/:id/contributions", handler.ListContributions)
+	router.POST("/goals/:id/contributions", handler.AddContribution)
+	router.GET("/analytics/goals", handler.ListProgress)
+	return router
+}
+
+func TestGoalHandler_CreateGoal_Success(t *testing.T) {
+	router := setupGoalRouter(NewGoalHandler(&mockGoalService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "Vacation", "target_amount": 12000, "target_date": "2026-12-31"})
+	req := httptest.NewRequest("POST", "/goals", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestGoalHandler_CreateGoal_Duplicate(t *testing.T) {
+	router := setupGoalRouter(NewGoalHandler(&mockGoalService{
+		createFunc: func(req *domain.GoalRequest) (*domain.Goal, error) {
+			return nil, repository.ErrGoalAlreadyExists
+		},
+	}))
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "Vacation", "target_amount": 12000, "target_date": "2026-12-31"})
+	req := httptest.NewRequest("POST", "/goals", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestGoalHandler_AddContribution_Withdrawal(t *testing.T) {
+	router := setupGoalRouter(NewGoalHandler(&mockGoalService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{"amount": -250})
+	req := httptest.NewRequest("POST", "/goals/3/contributions", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.GoalContribution
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), response.GoalID)
+	assert.Equal(t, -250.0, response.Amount)
+}
+
+func TestGoalHandler_GetProgress_NotFound(t *testing.T) {
+	router := setupGoalRouter(NewGoalHandler(&mockGoalService{
+		progressFunc: func(id int64) (*domain.GoalProgress, error) {
+			return nil, repository.ErrGoalNotFound
+		},
+	}))
+
+	req := httptest.NewRequest("GET", "/goals/7/progress", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestGoalHandler_GetProgress_InvalidID(t *testing.T) {
+	router := setupGoalRouter(NewGoalHandler(&mockGoalService{}))
+
+	req := httptest.NewRequest("GET", "/goals/abc/progress", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGoalHandler_ListProgress(t *testing.T) {
+	router := setupGoalRouter(NewGoalHandler(&mockGoalService{}))
+
+	req := httptest.NewRequest("GET", "/analytics/goals", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response struct {
+		Data []domain.GoalProgress `json:"data"`
+	}
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, domain.GoalStatusOnTrack, response.Data[0].Status)
+}
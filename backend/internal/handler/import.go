@@ -0,0 +1,292 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// ImportHandler handles statement import and import profile requests
+type ImportHandler struct {
+	service service.ImportService
+}
+
+// NewImportHandler creates a new import handler
+func NewImportHandler(service service.ImportService) *ImportHandler {
+	return &ImportHandler{service: service}
+}
+
+// ImportCSV imports a CSV bank statement uploaded as multipart form data.
+// Form fields: file, profile_id or mapping (JSON), dry_run.
+// POST /api/v1/import/csv
+func (h *ImportHandler) ImportCSV(c *gin.Context) {
+	var err error
+	var ok bool
+	req := domain.CSVImportRequest{}
+	if value := c.PostForm("profile_id"); value != "" {
+		req.ProfileID, err = strconv.ParseInt(value, 10, 64)
+		if err != nil || req.ProfileID <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid import profile ID",
+				"field": "profile_id",
+			})
+			return
+		}
+	}
+	if value := c.PostForm("mapping"); value != "" {
+		req.Mapping = &domain.ImportMapping{}
+		if err := json.Unmarshal([]byte(value), req.Mapping); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "mapping must be a JSON object",
+				"field": "mapping",
+			})
+			return
+		}
+	}
+	if req.DryRun, ok = parseDryRun(c); !ok {
+		return
+	}
+
+	file, ok := openUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportCSV(file, &req)
+	h.respondImport(c, result, err)
+}
+
+// ImportOFX imports an OFX or QFX statement uploaded as multipart form data.
+// Form fields: file, optional source, source_account, dry_run.
+// POST /api/v1/import/ofx
+func (h *ImportHandler) ImportOFX(c *gin.Context) {
+	req, ok := parseStatementForm(c)
+	if !ok {
+		return
+	}
+
+	file, ok := openUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportOFX(file, req)
+	h.respondImport(c, result, err)
+}
+
+// ImportQIF imports a QIF statement uploaded as multipart form data.
+// Form fields: file, source, optional source_account, date_format, dry_run.
+// POST /api/v1/import/qif
+func (h *ImportHandler) ImportQIF(c *gin.Context) {
+	req, ok := parseStatementForm(c)
+	if !ok {
+		return
+	}
+
+	file, ok := openUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	result, err := h.service.ImportQIF(file, req)
+	h.respondImport(c, result, err)
+}
+
+// respondImport writes the import result: 200 for a dry run, 201 once stored
+func (h *ImportHandler) respondImport(c *gin.Context, result *domain.ImportResult, err error) {
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	status := http.StatusCreated
+	if result.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, result)
+}
+
+// parseStatementForm reads the OFX/QIF import options, writing a 400 response if invalid
+func parseStatementForm(c *gin.Context) (*domain.StatementImportRequest, bool) {
+	req := &domain.StatementImportRequest{
+		Source:        c.PostForm("source"),
+		SourceAccount: c.PostForm("source_account"),
+		DateFormat:    c.PostForm("date_format"),
+	}
+
+	var ok bool
+	if req.DryRun, ok = parseDryRun(c); !ok {
+		return nil, false
+	}
+	return req, true
+}
+
+// parseDryRun reads the optional dry_run form field, writing a 400 response if invalid
+func parseDryRun(c *gin.Context) (bool, bool) {
+	value := c.PostForm("dry_run")
+	if value == "" {
+		return false, true
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "dry_run must be true or false",
+			"field": "dry_run",
+		})
+		return false, false
+	}
+	return dryRun, true
+}
+
+// openUpload opens the uploaded file form field, writing a 400 response if missing
+func openUpload(c *gin.Context) (multipart.File, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "file is required",
+			"field": "file",
+		})
+		return nil, false
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "file could not be read",
+			"field": "file",
+		})
+		return nil, false
+	}
+	return file, true
+}
+
+// CreateProfile creates a saved column mapping
+// POST /api/v1/import/profiles
+func (h *ImportHandler) CreateProfile(c *gin.Context) {
+	var req domain.ImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	profile, err := h.service.CreateProfile(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, profile)
+}
+
+// ListProfiles returns all saved column mappings
+// GET /api/v1/import/profiles
+func (h *ImportHandler) ListProfiles(c *gin.Context) {
+	profiles, err := h.service.ListProfiles()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": profiles,
+	})
+}
+
+// GetProfile returns a single saved column mapping by ID
+// GET /api/v1/import/profiles/:id
+func (h *ImportHandler) GetProfile(c *gin.Context) {
+	id, ok := parseIDParam(c, "import profile")
+	if !ok {
+		return
+	}
+
+	profile, err := h.service.GetProfile(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// UpdateProfile replaces a saved column mapping
+// PUT /api/v1/import/profiles/:id
+func (h *ImportHandler) UpdateProfile(c *gin.Context) {
+	id, ok := parseIDParam(c, "import profile")
+	if !ok {
+		return
+	}
+
+	var req domain.ImportProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	profile, err := h.service.UpdateProfile(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// DeleteProfile deletes a saved column mapping
+// DELETE /api/v1/import/profiles/:id
+func (h *ImportHandler) DeleteProfile(c *gin.Context) {
+	id, ok := parseIDParam(c, "import profile")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteProfile(id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *ImportHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, repository.ErrImportProfileNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "import profile not found",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrImportProfileAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
@@ -0,0 +1,302 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockImportService is a mock implementation of ImportService for testing
+type mockImportService struct {
+	createFunc func(req *domain.ImportProfileRequest) (*domain.ImportProfile, error)
+	req        *domain.CSVImportRequest
+	statement  *domain.StatementImportRequest
+	format     string
+	body       string
+}
+
+func (m *mockImportService) CreateProfile(req *domain.ImportProfileRequest) (*domain.ImportProfile, error) {
+	if m.createFunc != nil {
+		return m.createFunc(req)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return req.ToImportProfile(), nil
+}
+
+func (m *mockImportService) GetProfile(id int64) (*domain.ImportProfile, error) {
+	return nil, repository.ErrImportProfileNotFound
+}
+
+func (m *mockImportService) ListProfiles() ([]domain.ImportProfile, error) {
+	return []domain.ImportProfile{{ID: 1, Name: "VCB"}}, nil
+}
+
+func (m *mockImportService) UpdateProfile(id int64, req *domain.ImportProfileRequest) (*domain.ImportProfile, error) {
+	profile := req.ToImportProfile()
+	profile.ID = id
+	return profile, nil
+}
+
+func (m *mockImportService) DeleteProfile(id int64) error {
+	return nil
+}
+
+func (m *mockImportService) ImportCSV(r io.Reader, req *domain.CSVImportRequest) (*domain.ImportResult, error) {
+	body, _ := io.ReadAll(r)
+	m.body = string(body)
+	m.req = req
+	if req.Mapping != nil {
+		if err := req.Mapping.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return &domain.ImportResult{DryRun: req.DryRun, Total: 1, Valid: 1}, nil
+}
+
+func (m *mockImportService) ImportOFX(r io.Reader, req *domain.StatementImportRequest) (*domain.ImportResult, error) {
+	return m.importStatement("ofx", r, req)
+}
+
+func (m *mockImportService) ImportQIF(r io.Reader, req *domain.StatementImportRequest) (*domain.ImportResult, error) {
+	return m.importStatement("qif", r, req)
+}
+
+func (m *mockImportService) importStatement(format string, r io.Reader, req *domain.StatementImportRequest) (*domain.ImportResult, error) {
+	body, _ := io.ReadAll(r)
+	m.body = string(body)
+	m.statement = req
+	m.format = format
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &domain.ImportResult{DryRun: req.DryRun, Total: 1, Imported: 1}, nil
+}
+
+func setupImportRouter(handler *ImportHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/import/csv", handler.ImportCSV)
+	router.POST("/import/ofx", handler.ImportOFX)
+	router.POST("/import/qif", handler.ImportQIF)
+	router.GET("/import/profiles", handler.ListProfiles)
+	router.POST("/import/profiles", handler.CreateProfile)
+	router.GET("/import/profiles/:id", handler.GetProfile)
+	router.PUT("/import/profiles/:id", handler.UpdateProfile)
+	router.DELETE("/import/profiles/:id", handler.DeleteProfile)
+	return router
+}
+
+// newCSVUpload builds a multipart CSV import request with the given form fields
+func newCSVUpload(t *testing.T, content string, fields map[string]string) *http.Request {
+	return newUpload(t, "/import/csv", "statement.csv", content, fields)
+}
+
+// newUpload builds a multipart import request to path with the given form fields
+func newUpload(t *testing.T, path, filename, content string, fields map[string]string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if content != "" {
+		part, err := writer.CreateFormFile("file", filename)
+		assert.NoError(t, err)
+		_, _ = part.Write([]byte(content))
+	}
+	for name, value := range fields {
+		assert.NoError(t, writer.WriteField(name, value))
+	}
+	assert.NoError(t, writer.Close())
+
+	req := httptest.NewRequest("POST", path, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestImportHandler_ImportCSV_DryRunWithProfile(t *testing.T) {
+	svc := &mockImportService{}
+	router := setupImportRouter(NewImportHandler(svc))
+
+	req := newCSVUpload(t, "Date,Amount\n2026-03-05,-10\n", map[string]string{"profile_id": "3", "dry_run": "true"})
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(3), svc.req.ProfileID)
+	assert.True(t, svc.req.DryRun)
+	assert.Equal(t, "Date,Amount\n2026-03-05,-10\n", svc.body)
+}
+
+func TestImportHandler_ImportCSV_CommitWithInlineMapping(t *testing.T) {
+	svc := &mockImportService{}
+	router := setupImportRouter(NewImportHandler(svc))
+
+	mapping := `{"source":"Vietcombank","date_column":"Date","amount_column":"Amount","decimal_separator":","}`
+	req := newCSVUpload(t, "Date,Amount\n", map[string]string{"mapping": mapping})
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "Vietcombank", svc.req.Mapping.Source)
+	assert.Equal(t, ",", svc.req.Mapping.DecimalSeparator)
+	assert.False(t, svc.req.DryRun)
+}
+
+func TestImportHandler_ImportCSV_BadRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		fields  map[string]string
+		field   string
+	}{
+		{"missing file", "", map[string]string{"profile_id": "1"}, "file"},
+		{"invalid profile id", "Date\n", map[string]string{"profile_id": "abc"}, "profile_id"},
+		{"invalid mapping json", "Date\n", map[string]string{"mapping": "{"}, "mapping"},
+		{"invalid dry run", "Date\n", map[string]string{"profile_id": "1", "dry_run": "maybe"}, "dry_run"},
+		{"invalid mapping", "Date\n", map[string]string{"mapping": `{"date_column":"Date","amount_column":"Amount"}`}, "source"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupImportRouter(NewImportHandler(&mockImportService{}))
+
+			req := newCSVUpload(t, tt.content, tt.fields)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.field, response["field"])
+		})
+	}
+}
+
+func TestImportHandler_ImportOFX_Commit(t *testing.T) {
+	svc := &mockImportService{}
+	router := setupImportRouter(NewImportHandler(svc))
+
+	req := newUpload(t, "/import/ofx", "statement.qfx", "<OFX></OFX>", map[string]string{"source_account": "1234"})
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "ofx", svc.format)
+	assert.Equal(t, "1234", svc.statement.SourceAccount)
+	assert.False(t, svc.statement.DryRun)
+	assert.Equal(t, "<OFX></OFX>", svc.body)
+}
+
+func TestImportHandler_ImportQIF_DryRun(t *testing.T) {
+	svc := &mockImportService{}
+	router := setupImportRouter(NewImportHandler(svc))
+
+	fields := map[string]string{"source": "Cash", "date_format": "02/01/2006", "dry_run": "true"}
+	req := newUpload(t, "/import/qif", "statement.qif", "!Type:Cash\n", fields)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "qif", svc.format)
+	assert.Equal(t, "Cash", svc.statement.Source)
+	assert.Equal(t, "02/01/2006", svc.statement.DateFormat)
+	assert.True(t, svc.statement.DryRun)
+}
+
+func TestImportHandler_ImportStatement_BadRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		fields  map[string]string
+		field   string
+	}{
+		{"missing file", "/import/ofx", "", nil, "file"},
+		{"invalid dry run", "/import/qif", "!Type:Bank\n", map[string]string{"dry_run": "maybe"}, "dry_run"},
+		{"invalid date format", "/import/qif", "!Type:Bank\n", map[string]string{"date_format": "dd/mm/yyyy"}, "date_format"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupImportRouter(NewImportHandler(&mockImportService{}))
+
+			req := newUpload(t, tt.path, "statement", tt.content, tt.fields)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+
+			var response map[string]interface{}
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.field, response["field"])
+		})
+	}
+}
+
+func TestImportHandler_CreateProfile_Success(t *testing.T) {
+	router := setupImportRouter(NewImportHandler(&mockImportService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"name": "VCB export", "source": "Vietcombank", "date_column": "Ngày GD",
+		"date_format": "02/01/2006", "debit_column": "Ghi nợ", "credit_column": "Ghi có",
+	})
+	req := httptest.NewRequest("POST", "/import/profiles", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.ImportProfile
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Ghi nợ", response.DebitColumn)
+	assert.Equal(t, domain.AmountSignNegativeOut, response.AmountSign)
+}
+
+func TestImportHandler_CreateProfile_Duplicate(t *testing.T) {
+	router := setupImportRouter(NewImportHandler(&mockImportService{
+		createFunc: func(req *domain.ImportProfileRequest) (*domain.ImportProfile, error) {
+			return nil, repository.ErrImportProfileAlreadyExists
+		},
+	}))
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "VCB export"})
+	req := httptest.NewRequest("POST", "/import/profiles", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestImportHandler_GetProfile_NotFound(t *testing.T) {
+	router := setupImportRouter(NewImportHandler(&mockImportService{}))
+
+	req := httptest.NewRequest("GET", "/import/profiles/9", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// MFAHandler handles two-factor authentication requests of the signed-in user
+type MFAHandler struct {
+	service service.MFAService
+}
+
+// NewMFAHandler creates a new two-factor authentication handler
+func NewMFAHandler(service service.MFAService) *MFAHandler {
+	return &MFAHandler{service: service}
+}
+
+// GetStatus tells whether the user has 2FA enabled
+// GET /api/v1/auth/mfa
+func (h *MFAHandler) GetStatus(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.service.GetStatus(userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Enroll creates a TOTP secret for the user's authenticator app
+// POST /api/v1/auth/mfa/enroll
+func (h *MFAHandler) Enroll(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	enrollment, err := h.service.Enroll(userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// Activate enables 2FA with a first code from the authenticator app and
+// returns the recovery codes
+// POST /api/v1/auth/mfa/activate
+func (h *MFAHandler) Activate(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	codes, err := h.service.Activate(userID, &req, clientInfo(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// Disable turns 2FA off with a TOTP or recovery code
+// POST /api/v1/auth/mfa/disable
+func (h *MFAHandler) Disable(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if err := h.service.Disable(userID, &req, clientInfo(c)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes with a TOTP code
+// POST /api/v1/auth/mfa/recovery-codes
+func (h *MFAHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(userID, &req, clientInfo(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *MFAHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if respondLoginThrottled(c, err) {
+		return
+	}
+
+	if errors.Is(err, service.ErrInvalidMFACode) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+			"field": "code",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrMFANotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, service.ErrMFAAlreadyEnabled) || errors.Is(err, service.ErrMFANotEnabled) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
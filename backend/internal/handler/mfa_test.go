@@ -0,0 +1,227 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// mockMFAService is a mock implementation of MFAService for testing
+type mockMFAService struct {
+	userID int64
+	code   string
+	client domain.ClientInfo
+	err    error
+}
+
+func (m *mockMFAService) GetStatus(userID int64) (*domain.MFAStatus, error) {
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	enabledAt := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	return &domain.MFAStatus{Enabled: true, EnabledAt: &enabledAt, RecoveryCodesRemaining: 8}, nil
+}
+
+func (m *mockMFAService) Enroll(userID int64) (*domain.MFAEnrollment, error) {
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.MFAEnrollment{
+		Secret:     "JBSWY3DPEHPK3PXP",
+		OTPAuthURL: "otpauth://totp/Finance%20Tracker:test@example.com?secret=JBSWY3DPEHPK3PXP",
+		QRCodePNG:  []byte("\x89PNG"),
+	}, nil
+}
+
+func (m *mockMFAService) Activate(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) (*domain.MFARecoveryCodes, error) {
+	if err := m.record(userID, req, client); err != nil {
+		return nil, err
+	}
+	return &domain.MFARecoveryCodes{RecoveryCodes: []string{"k7m2p-x9qrt"}}, nil
+}
+
+func (m *mockMFAService) Disable(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) error {
+	return m.record(userID, req, client)
+}
+
+func (m *mockMFAService) RegenerateRecoveryCodes(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) (*domain.MFARecoveryCodes, error) {
+	if err := m.record(userID, req, client); err != nil {
+		return nil, err
+	}
+	return &domain.MFARecoveryCodes{RecoveryCodes: []string{"a2b3c-d4e5f"}}, nil
+}
+
+func (m *mockMFAService) IsEnabled(userID int64) (bool, error) {
+	return false, nil
+}
+
+func (m *mockMFAService) Verify(user *domain.User, code string, client domain.ClientInfo) error {
+	return nil
+}
+
+func (m *mockMFAService) record(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	m.userID, m.code, m.client = userID, req.Code, client
+	return m.err
+}
+
+func setupMFARouter(handler *MFAHandler, userID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set(middleware.UserIDContextKey, userID)
+		}
+		c.Next()
+	})
+	router.GET("/mfa", handler.GetStatus)
+	router.POST("/mfa/enroll", handler.Enroll)
+	router.POST("/mfa/activate", handler.Activate)
+	router.POST("/mfa/disable", handler.Disable)
+	router.POST("/mfa/recovery-codes", handler.RegenerateRecoveryCodes)
+	return router
+}
+
+func TestMFAHandler_GetStatus(t *testing.T) {
+	svc := &mockMFAService{}
+	router := setupMFARouter(NewMFAHandler(svc), 42)
+
+	req := httptest.NewRequest("GET", "/mfa", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+	assert.JSONEq(t, `{"enabled": true, "enabled_at": "2026-03-10T09:00:00Z", "recovery_codes_remaining": 8}`, w.Body.String())
+}
+
+func TestMFAHandler_Enroll(t *testing.T) {
+	router := setupMFARouter(NewMFAHandler(&mockMFAService{}), 42)
+
+	req := httptest.NewRequest("POST", "/mfa/enroll", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", response["secret"])
+	assert.Contains(t, response["otpauth_url"], "otpauth://totp/")
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("\x89PNG")), response["qr_code_png"])
+}
+
+func TestMFAHandler_Enroll_AlreadyEnabled(t *testing.T) {
+	router := setupMFARouter(NewMFAHandler(&mockMFAService{err: service.ErrMFAAlreadyEnabled}), 42)
+
+	req := httptest.NewRequest("POST", "/mfa/enroll", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestMFAHandler_Activate(t *testing.T) {
+	svc := &mockMFAService{}
+	router := setupMFARouter(NewMFAHandler(svc), 42)
+
+	req := httptest.NewRequest("POST", "/mfa/activate", bytes.NewBufferString(`{"code": "123 456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "203.0.113.7:4711"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "123456", svc.code)
+	assert.Equal(t, "203.0.113.7", svc.client.IPAddress)
+	assert.JSONEq(t, `{"recovery_codes": ["k7m2p-x9qrt"]}`, w.Body.String())
+}
+
+func TestMFAHandler_Activate_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+	}{
+		{"missing code", `{}`, nil, http.StatusBadRequest},
+		{"invalid code", `{"code": "123456"}`, service.ErrInvalidMFACode, http.StatusBadRequest},
+		{"not enrolled", `{"code": "123456"}`, repository.ErrMFANotFound, http.StatusNotFound},
+		{"throttled", `{"code": "123456"}`, &service.LoginThrottledError{RetryAfter: time.Second}, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupMFARouter(NewMFAHandler(&mockMFAService{err: tt.err}), 42)
+
+			req := httptest.NewRequest("POST", "/mfa/activate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestMFAHandler_Disable(t *testing.T) {
+	svc := &mockMFAService{}
+	router := setupMFARouter(NewMFAHandler(svc), 42)
+
+	req := httptest.NewRequest("POST", "/mfa/disable", bytes.NewBufferString(`{"code": "k7m2p-x9qrt"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "k7m2p-x9qrt", svc.code)
+}
+
+func TestMFAHandler_Disable_NotEnabled(t *testing.T) {
+	router := setupMFARouter(NewMFAHandler(&mockMFAService{err: service.ErrMFANotEnabled}), 42)
+
+	req := httptest.NewRequest("POST", "/mfa/disable", bytes.NewBufferString(`{"code": "123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestMFAHandler_RegenerateRecoveryCodes(t *testing.T) {
+	router := setupMFARouter(NewMFAHandler(&mockMFAService{}), 42)
+
+	req := httptest.NewRequest("POST", "/mfa/recovery-codes", bytes.NewBufferString(`{"code": "123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"recovery_codes": ["a2b3c-d4e5f"]}`, w.Body.String())
+}
+
+func TestMFAHandler_Unauthenticated(t *testing.T) {
+	router := setupMFARouter(NewMFAHandler(&mockMFAService{}), 0)
+
+	req := httptest.NewRequest("GET", "/mfa", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// OutgoingWebhookHandler handles the webhook endpoints of the signed-in user
+// and their delivery log
+type OutgoingWebhookHandler struct {
+	service service.OutgoingWebhookService
+}
+
+// NewOutgoingWebhookHandler creates a new outgoing webhook handler
+func NewOutgoingWebhookHandler(service service.OutgoingWebhookService) *OutgoingWebhookHandler {
+	return &OutgoingWebhookHandler{service: service}
+}
+
+// CreateEndpoint registers a webhook endpoint; the response holds the signing secret
+// POST /api/v1/webhook-endpoints
+func (h *OutgoingWebhookHandler) CreateEndpoint(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	endpoint, err := h.service.CreateEndpoint(userID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, endpoint)
+}
+
+// ListEndpoints returns the user's webhook endpoints
+// GET /api/v1/webhook-endpoints
+func (h *OutgoingWebhookHandler) ListEndpoints(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	endpoints, err := h.service.ListEndpoints(userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": endpoints,
+	})
+}
+
+// GetEndpoint returns a single webhook endpoint
+// GET /api/v1/webhook-endpoints/:id
+func (h *OutgoingWebhookHandler) GetEndpoint(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(c, "webhook endpoint")
+	if !ok {
+		return
+	}
+
+	endpoint, err := h.service.GetEndpoint(userID, id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// UpdateEndpoint changes the URL, events or state of a webhook endpoint
+// PUT /api/v1/webhook-endpoints/:id
+func (h *OutgoingWebhookHandler) UpdateEndpoint(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(c, "webhook endpoint")
+	if !ok {
+		return
+	}
+
+	var req domain.WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	endpoint, err := h.service.UpdateEndpoint(userID, id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, endpoint)
+}
+
+// DeleteEndpoint deletes a webhook endpoint and its delivery log
+// DELETE /api/v1/webhook-endpoints/:id
+func (h *OutgoingWebhookHandler) DeleteEndpoint(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(c, "webhook endpoint")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteEndpoint(userID, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries returns the delivery log of a webhook endpoint, newest first
+// GET /api/v1/webhook-endpoints/:id/deliveries
+func (h *OutgoingWebhookHandler) ListDeliveries(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(c, "webhook endpoint")
+	if !ok {
+		return
+	}
+
+	var params domain.WebhookDeliveryQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	deliveries, err := h.service.ListDeliveries(userID, id, params)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": deliveries,
+	})
+}
+
+// Redeliver queues the event of a past delivery again
+// POST /api/v1/webhook-endpoints/:id/deliveries/:delivery_id/redeliver
+func (h *OutgoingWebhookHandler) Redeliver(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(c, "webhook endpoint")
+	if !ok {
+		return
+	}
+	deliveryID, ok := parseNamedIDParam(c, "delivery_id", "delivery")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.service.Redeliver(userID, id, deliveryID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *OutgoingWebhookHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, repository.ErrWebhookEndpointNotFound) || errors.Is(err, repository.ErrWebhookDeliveryNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockOutgoingWebhookService is a mock implementation of OutgoingWebhookService for testing
+type mockOutgoingWebhookService struct {
+	userID     int64
+	endpointID int64
+	deliveryID int64
+	params     domain.WebhookDeliveryQueryParams
+	err        error
+}
+
+func (m *mockOutgoingWebhookService) CreateEndpoint(userID int64, req *domain.WebhookEndpointRequest) (*domain.WebhookEndpointCreated, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.WebhookEndpointCreated{
+		WebhookEndpoint: domain.WebhookEndpoint{ID: 1, UserID: userID, URL: req.URL, Events: req.Events, Active: true},
+		Secret:          "whsec_test",
+	}, nil
+}
+
+func (m *mockOutgoingWebhookService) ListEndpoints(userID int64) ([]domain.WebhookEndpoint, error) {
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.WebhookEndpoint{{ID: 1, UserID: userID, Secret: "whsec_test"}}, nil
+}
+
+func (m *mockOutgoingWebhookService) GetEndpoint(userID, id int64) (*domain.WebhookEndpoint, error) {
+	m.userID, m.endpointID = userID, id
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.WebhookEndpoint{ID: id, UserID: userID}, nil
+}
+
+func (m *mockOutgoingWebhookService) UpdateEndpoint(userID, id int64, req *domain.WebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	m.userID, m.endpointID = userID, id
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.WebhookEndpoint{ID: id, UserID: userID, URL: req.URL}, nil
+}
+
+func (m *mockOutgoingWebhookService) DeleteEndpoint(userID, id int64) error {
+	m.userID, m.endpointID = userID, id
+	return m.err
+}
+
+func (m *mockOutgoingWebhookService) ListDeliveries(userID, endpointID int64, params domain.WebhookDeliveryQueryParams) ([]domain.WebhookDelivery, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	m.userID, m.endpointID, m.params = userID, endpointID, params
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.WebhookDelivery{{ID: 3, EndpointID: endpointID}}, nil
+}
+
+func (m *mockOutgoingWebhookService) Redeliver(userID, endpointID, deliveryID int64) (*domain.WebhookDelivery, error) {
+	m.userID, m.endpointID, m.deliveryID = userID, endpointID, deliveryID
+	if m.err != nil {
+		return nil, m.err
+	}
+	redeliveryOf := deliveryID
+	return &domain.WebhookDelivery{ID: 9, EndpointID: endpointID, Status: domain.WebhookDeliveryPending, RedeliveryOf: &redeliveryOf}, nil
+}
+
+func (m *mockOutgoingWebhookService) DeliverDue(ctx context.Context) (*domain.WebhookRunResult, error) {
+	return &domain.WebhookRunResult{}, nil
+}
+
+func (m *mockOutgoingWebhookService) OnTransactionsCreated(transactions []domain.Transaction) {}
+
+func (m *mockOutgoingWebhookService) OnTransactionUpdated(previous, updated domain.Transaction) {}
+
+// setupOutgoingWebhookRouter signs every request in as userID; zero leaves it anonymous
+func setupOutgoingWebhookRouter(handler *OutgoingWebhookHandler, userID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set(middleware.UserIDContextKey, userID)
+		}
+		c.Next()
+	})
+	router.POST("/webhook-endpoints", handler.CreateEndpoint)
+	router.GET("/webhook-endpoints", handler.ListEndpoints)
+	router.GET("/webhook-endpoints/:id", handler.GetEndpoint)
+	router.PUT("/webhook-endpoints/:id", handler.UpdateEndpoint)
+	router.DELETE("/webhook-endpoints/:id", handler.DeleteEndpoint)
+	router.GET("/webhook-endpoints/:id/deliveries", handler.ListDeliveries)
+	router.POST("/webhook-endpoints/:id/deliveries/:delivery_id/redeliver", handler.Redeliver)
+	return router
+}
+
+func TestOutgoingWebhookHandler_CreateEndpoint(t *testing.T) {
+	svc := &mockOutgoingWebhookService{}
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(svc), 42)
+
+	body := `{"url": "https://example.com/hooks", "events": ["transaction.created"]}`
+	req := httptest.NewRequest("POST", "/webhook-endpoints", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "whsec_test", response["secret"])
+	assert.Equal(t, "https://example.com/hooks", response["url"])
+}
+
+func TestOutgoingWebhookHandler_CreateEndpoint_ValidationError(t *testing.T) {
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(&mockOutgoingWebhookService{}), 42)
+
+	body := `{"url": "https://example.com/hooks", "events": ["transaction.deleted"]}`
+	req := httptest.NewRequest("POST", "/webhook-endpoints", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, "events", response["field"])
+}
+
+func TestOutgoingWebhookHandler_ListEndpoints_HidesSecret(t *testing.T) {
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(&mockOutgoingWebhookService{}), 42)
+
+	req := httptest.NewRequest("GET", "/webhook-endpoints", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "whsec_test")
+}
+
+func TestOutgoingWebhookHandler_Unauthorized(t *testing.T) {
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(&mockOutgoingWebhookService{}), 0)
+
+	req := httptest.NewRequest("GET", "/webhook-endpoints", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestOutgoingWebhookHandler_GetEndpoint_NotFound(t *testing.T) {
+	svc := &mockOutgoingWebhookService{err: repository.ErrWebhookEndpointNotFound}
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(svc), 42)
+
+	req := httptest.NewRequest("GET", "/webhook-endpoints/5", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, int64(5), svc.endpointID)
+}
+
+func TestOutgoingWebhookHandler_DeleteEndpoint(t *testing.T) {
+	svc := &mockOutgoingWebhookService{}
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(svc), 42)
+
+	req := httptest.NewRequest("DELETE", "/webhook-endpoints/5", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, int64(5), svc.endpointID)
+}
+
+func TestOutgoingWebhookHandler_ListDeliveries(t *testing.T) {
+	svc := &mockOutgoingWebhookService{}
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(svc), 42)
+
+	req := httptest.NewRequest("GET", "/webhook-endpoints/5/deliveries?status=failed&limit=10", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.WebhookDeliveryFailed, svc.params.Status)
+	assert.Equal(t, 10, svc.params.Limit)
+}
+
+func TestOutgoingWebhookHandler_ListDeliveries_InvalidStatus(t *testing.T) {
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(&mockOutgoingWebhookService{}), 42)
+
+	req := httptest.NewRequest("GET", "/webhook-endpoints/5/deliveries?status=lost", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestOutgoingWebhookHandler_Redeliver(t *testing.T) {
+	svc := &mockOutgoingWebhookService{}
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(svc), 42)
+
+	req := httptest.NewRequest("POST", "/webhook-endpoints/5/deliveries/3/redeliver", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, int64(5), svc.endpointID)
+	assert.Equal(t, int64(3), svc.deliveryID)
+
+	var response domain.WebhookDelivery
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, domain.WebhookDeliveryPending, response.Status)
+}
+
+func TestOutgoingWebhookHandler_Redeliver_InvalidDeliveryID(t *testing.T) {
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(&mockOutgoingWebhookService{}), 42)
+
+	req := httptest.NewRequest("POST", "/webhook-endpoints/5/deliveries/abc/redeliver", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestOutgoingWebhookHandler_ServiceError(t *testing.T) {
+	router := setupOutgoingWebhookRouter(NewOutgoingWebhookHandler(&mockOutgoingWebhookService{err: errors.New("connection refused")}), 42)
+
+	req := httptest.NewRequest("GET", "/webhook-endpoints", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/metrics"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+)
+
+// parseIDParam reads a positive :id path parameter, writing a 400 response if invalid
+func parseIDParam(c *gin.Context, entity string) (int64, bool) {
+	return parseNamedIDParam(c, "id", entity)
+}
+
+// parseNamedIDParam reads a positive ID from the named path parameter, writing a 400 response if invalid
+func parseNamedIDParam(c *gin.Context, param, entity string) (int64, bool) {
+	var id int64
+	if _, err := fmt.Sscanf(c.Param(param), "%d", &id); err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid " + entity + " ID",
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// requireUserID returns the signed-in user, writing a 401 response if there is none
+func requireUserID(c *gin.Context) (int64, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "unauthorized",
+		})
+	}
+	return userID, ok
+}
+
+// clientInfo returns the IP address and user agent of the request
+func clientInfo(c *gin.Context) domain.ClientInfo {
+	return domain.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// respondValidationError writes a 400 response naming the invalid field and
+// counts the failure in the metrics
+func respondValidationError(c *gin.Context, err *domain.ValidationError) {
+	metrics.CountValidationFailure(err.Field)
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error": err.Message,
+		"field": err.Field,
+	})
+}
@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// PasswordHandler handles password change and reset requests
+type PasswordHandler struct {
+	service service.PasswordService
+}
+
+// NewPasswordHandler creates a new password handler
+func NewPasswordHandler(service service.PasswordService) *PasswordHandler {
+	return &PasswordHandler{service: service}
+}
+
+// ChangePassword sets a new password for the signed-in user and returns a
+// new token, since the other sessions are revoked
+// POST /api/v1/auth/password
+func (h *PasswordHandler) ChangePassword(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var req domain.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	req.Client = clientInfo(c)
+
+	response, err := h.service.ChangePassword(userID, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ForgotPassword sends a reset link if the email belongs to an account. The
+// response is the same either way.
+// POST /api/v1/auth/password/forgot
+func (h *PasswordHandler) ForgotPassword(c *gin.Context) {
+	var req domain.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	req.Client = clientInfo(c)
+
+	if err := h.service.ForgotPassword(c.Request.Context(), &req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "if the email belongs to an account, a reset link has been sent",
+	})
+}
+
+// ResetPassword sets a new password with the token of a reset link
+// POST /api/v1/auth/password/reset
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var req domain.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+	req.Client = clientInfo(c)
+
+	if err := h.service.ResetPassword(&req); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *PasswordHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if respondLoginThrottled(c, err) {
+		return
+	}
+
+	if errors.Is(err, service.ErrWrongPassword) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+			"field": "current_password",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrPasswordResetTokenNotFound) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+			"field": "token",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, service.ErrPasswordResetDisabled) {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
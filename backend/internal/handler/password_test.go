@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// mockPasswordService is a mock implementation of PasswordService for testing
+type mockPasswordService struct {
+	userID    int64
+	changeReq *domain.ChangePasswordRequest
+	forgotReq *domain.ForgotPasswordRequest
+	resetReq  *domain.ResetPasswordRequest
+	err       error
+}
+
+func (m *mockPasswordService) ChangePassword(userID int64, req *domain.ChangePasswordRequest) (*domain.AuthResponse, error) {
+	m.userID, m.changeReq = userID, req
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.AuthResponse{Token: "new-token", User: domain.UserResponse{ID: userID}}, nil
+}
+
+func (m *mockPasswordService) ForgotPassword(ctx context.Context, req *domain.ForgotPasswordRequest) error {
+	m.forgotReq = req
+	return m.err
+}
+
+func (m *mockPasswordService) ResetPassword(req *domain.ResetPasswordRequest) error {
+	m.resetReq = req
+	return m.err
+}
+
+func (m *mockPasswordService) DeleteExpiredResetTokens() (int64, error) {
+	return 0, nil
+}
+
+func setupPasswordRouter(handler *PasswordHandler, userID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set(middleware.UserIDContextKey, userID)
+		}
+		c.Next()
+	})
+	router.POST("/auth/password", handler.ChangePassword)
+	router.POST("/auth/password/forgot", handler.ForgotPassword)
+	router.POST("/auth/password/reset", handler.ResetPassword)
+	return router
+}
+
+func postPasswordRequest(router *gin.Engine, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = "203.0.113.7:4711"
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestPasswordHandler_ChangePassword(t *testing.T) {
+	svc := &mockPasswordService{}
+	router := setupPasswordRouter(NewPasswordHandler(svc), 42)
+
+	w := postPasswordRequest(router, "/auth/password", `{"current_password": "Password123", "new_password": "NewPassword456"}`)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+	assert.Equal(t, "NewPassword456", svc.changeReq.NewPassword)
+	assert.Equal(t, "203.0.113.7", svc.changeReq.Client.IPAddress)
+	assert.Contains(t, w.Body.String(), `"token":"new-token"`)
+}
+
+func TestPasswordHandler_ChangePassword_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		err        error
+		wantStatus int
+		wantField  string
+	}{
+		{"missing fields", `{}`, nil, http.StatusBadRequest, ""},
+		{"wrong password", `{"current_password": "Wrong1234567", "new_password": "NewPassword456"}`, service.ErrWrongPassword, http.StatusBadRequest, "current_password"},
+		{"weak password", `{"current_password": "Password123", "new_password": "alllowercase"}`, &domain.ValidationError{Field: "new_password", Message: "too weak"}, http.StatusBadRequest, "new_password"},
+		{"throttled", `{"current_password": "Wrong1234567", "new_password": "NewPassword456"}`, &service.LoginThrottledError{RetryAfter: time.Second}, http.StatusTooManyRequests, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupPasswordRouter(NewPasswordHandler(&mockPasswordService{err: tt.err}), 42)
+
+			w := postPasswordRequest(router, "/auth/password", tt.body)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantField != "" {
+				assert.Contains(t, w.Body.String(), `"field":"`+tt.wantField+`"`)
+			}
+		})
+	}
+}
+
+func TestPasswordHandler_ChangePassword_Unauthenticated(t *testing.T) {
+	router := setupPasswordRouter(NewPasswordHandler(&mockPasswordService{}), 0)
+
+	w := postPasswordRequest(router, "/auth/password", `{"current_password": "Password123", "new_password": "NewPassword456"}`)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestPasswordHandler_ForgotPassword(t *testing.T) {
+	svc := &mockPasswordService{}
+	router := setupPasswordRouter(NewPasswordHandler(svc), 0)
+
+	w := postPasswordRequest(router, "/auth/password/forgot", `{"email": "test@example.com"}`)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.Equal(t, "test@example.com", svc.forgotReq.Email)
+	assert.Equal(t, "203.0.113.7", svc.forgotReq.Client.IPAddress)
+}
+
+func TestPasswordHandler_ForgotPassword_Disabled(t *testing.T) {
+	router := setupPasswordRouter(NewPasswordHandler(&mockPasswordService{err: service.ErrPasswordResetDisabled}), 0)
+
+	w := postPasswordRequest(router, "/auth/password/forgot", `{"email": "test@example.com"}`)
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+}
+
+func TestPasswordHandler_ResetPassword(t *testing.T) {
+	svc := &mockPasswordService{}
+	router := setupPasswordRouter(NewPasswordHandler(svc), 0)
+
+	w := postPasswordRequest(router, "/auth/password/reset", `{"token": "abc", "new_password": "NewPassword456"}`)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "abc", svc.resetReq.Token)
+}
+
+func TestPasswordHandler_ResetPassword_InvalidToken(t *testing.T) {
+	router := setupPasswordRouter(NewPasswordHandler(&mockPasswordService{err: repository.ErrPasswordResetTokenNotFound}), 0)
+
+	w := postPasswordRequest(router, "/auth/password/reset", `{"token": "abc", "new_password": "NewPassword456"}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"token"`)
+}
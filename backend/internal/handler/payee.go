@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// PayeeHandler handles payee directory requests
+type PayeeHandler struct {
+	service service.PayeeService
+}
+
+// NewPayeeHandler creates a new payee handler
+func NewPayeeHandler(service service.PayeeService) *PayeeHandler {
+	return &PayeeHandler{service: service}
+}
+
+// CreatePayee creates a payee and links matching historical transactions
+// POST /api/v1/payees
+func (h *PayeeHandler) CreatePayee(c *gin.Context) {
+	var req domain.PayeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	payee, err := h.service.CreatePayee(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, payee)
+}
+
+// ListPayees returns all payees with their aliases
+// GET /api/v1/payees
+func (h *PayeeHandler) ListPayees(c *gin.Context) {
+	payees, err := h.service.ListPayees()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": payees,
+	})
+}
+
+// GetPayee returns a single payee by ID
+// GET /api/v1/payees/:id
+func (h *PayeeHandler) GetPayee(c *gin.Context) {
+	id, ok := parseIDParam(c, "payee")
+	if !ok {
+		return
+	}
+
+	payee, err := h.service.GetPayee(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+// UpdatePayee replaces a payee's name, default category and aliases
+// PUT /api/v1/payees/:id
+func (h *PayeeHandler) UpdatePayee(c *gin.Context) {
+	id, ok := parseIDParam(c, "payee")
+	if !ok {
+		return
+	}
+
+	var req domain.PayeeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	payee, err := h.service.UpdatePayee(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, payee)
+}
+
+// DeletePayee removes a payee; its transactions are kept but unlinked
+// DELETE /api/v1/payees/:id
+func (h *PayeeHandler) DeletePayee(c *gin.Context) {
+	id, ok := parseIDParam(c, "payee")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeletePayee(id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MergePayees merges other payees into this one and re-links their transactions
+// POST /api/v1/payees/:id/merge
+func (h *PayeeHandler) MergePayees(c *gin.Context) {
+	id, ok := parseIDParam(c, "payee")
+	if !ok {
+		return
+	}
+
+	var req domain.MergePayeesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	result, err := h.service.MergePayees(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetPayeeBreakdown returns the top payees by amount
+// GET /api/v1/analytics/by-payee?type=out&limit=10&start_date=2026-01-01
+func (h *PayeeHandler) GetPayeeBreakdown(c *gin.Context) {
+	var params domain.PayeeBreakdownQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid query parameters",
+		})
+		return
+	}
+
+	breakdown, err := h.service.GetPayeeBreakdown(params)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, breakdown)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *PayeeHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, repository.ErrPayeeNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "payee not found",
+		})
+		return
+	}
+
+	if errors.Is(err, repository.ErrPayeeAlreadyExists) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
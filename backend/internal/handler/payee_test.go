@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockPayeeService is a mock implementation of PayeeService for testing
+type mockPayeeService struct {
+	createFunc func(req *domain.PayeeRequest) (*domain.Payee, error)
+	mergeFunc  func(targetID int64, req *domain.MergePayeesRequest) (*domain.MergePayeesResponse, error)
+	params     domain.PayeeBreakdownQueryParams
+}
+
+func (m *mockPayeeService) CreatePayee(req *domain.PayeeRequest) (*domain.Payee, error) {
+	if m.createFunc != nil {
+		return m.createFunc(req)
+	}
+	return req.ToPayee(), nil
+}
+
+func (m *mockPayeeService) GetPayee(id int64) (*domain.Payee, error) {
+	return &domain.Payee{ID: id, Name: "Grab"}, nil
+}
+
+func (m *mockPayeeService) ListPayees() ([]domain.Payee, error) {
+	return []domain.Payee{{ID: 1, Name: "Grab"}}, nil
+}
+
+func (m *mockPayeeService) UpdatePayee(id int64, req *domain.PayeeRequest) (*domain.Payee, error) {
+	payee := req.ToPayee()
+	payee.ID = id
+	return payee, nil
+}
+
+func (m *mockPayeeService) DeletePayee(id int64) error {
+	return nil
+}
+
+func (m *mockPayeeService) MergePayees(targetID int64, req *domain.MergePayeesRequest) (*domain.MergePayeesResponse, error) {
+	if m.mergeFunc != nil {
+		return m.mergeFunc(targetID, req)
+	}
+	return &domain.MergePayeesResponse{
+		Payee:                &domain.Payee{ID: targetID},
+		MergedPayees:         len(req.SourcePayeeIDs),
+		RelinkedTransactions: 12,
+	}, nil
+}
+
+func (m *mockPayeeService) GetPayeeBreakdown(params domain.PayeeBreakdownQueryParams) ([]domain.PayeeBreakdown, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	m.params = params
+	return []domain.PayeeBreakdown{{PayeeID: 1, Name: "Grab", Amount: 300000}}, nil
+}
+
+func (m *mockPayeeService) PrepareTransactions(transactions []domain.Transaction) {}
+
+func (m *mockPayeeService) OnTransactionsCreated(transactions []domain.Transaction) {}
+
+func setupPayeeRouter(handler *PayeeHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/payees", handler.ListPayees)
+	router.POST("/payees", handler.CreatePayee)
+	router.GET("/payees/:id", handler.GetPayee)
+	router.PUT("/payees/:id", handler.UpdatePayee)
+	router.DELETE("/payees/:id", handler.DeletePayee)
+	router.POST("/payees/:id/merge", handler.MergePayees)
+	router.GET("/analytics/by-payee", handler.GetPayeeBreakdown)
+	return router
+}
+
+func TestPayeeHandler_CreatePayee_Success(t *testing.T) {
+	router := setupPayeeRouter(NewPayeeHandler(&mockPayeeService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "Grab", "default_category": "Transportation", "aliases": []string{"GRAB*"}})
+	req := httptest.NewRequest("POST", "/payees", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.Payee
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "GRAB*", response.Aliases[0].Pattern)
+}
+
+func TestPayeeHandler_CreatePayee_Duplicate(t *testing.T) {
+	router := setupPayeeRouter(NewPayeeHandler(&mockPayeeService{
+		createFunc: func(req *domain.PayeeRequest) (*domain.Payee, error) {
+			return nil, repository.ErrPayeeAlreadyExists
+		},
+	}))
+
+	body, _ := json.Marshal(map[string]interface{}{"name": "Grab"})
+	req := httptest.NewRequest("POST", "/payees", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestPayeeHandler_MergePayees_Success(t *testing.T) {
+	router := setupPayeeRouter(NewPayeeHandler(&mockPayeeService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{"source_payee_ids": []int64{2, 3}})
+	req := httptest.NewRequest("POST", "/payees/1/merge", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response domain.MergePayeesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.MergedPayees)
+	assert.Equal(t, int64(12), response.RelinkedTransactions)
+}
+
+func TestPayeeHandler_MergePayees_MissingSources(t *testing.T) {
+	router := setupPayeeRouter(NewPayeeHandler(&mockPayeeService{}))
+
+	req := httptest.NewRequest("POST", "/payees/1/merge", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPayeeHandler_MergePayees_NotFound(t *testing.T) {
+	router := setupPayeeRouter(NewPayeeHandler(&mockPayeeService{
+		mergeFunc: func(targetID int64, req *domain.MergePayeesRequest) (*domain.MergePayeesResponse, error) {
+			return nil, repository.ErrPayeeNotFound
+		},
+	}))
+
+	body, _ := json.Marshal(map[string]interface{}{"source_payee_ids": []int64{2}})
+	req := httptest.NewRequest("POST", "/payees/99/merge", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPayeeHandler_GetPayeeBreakdown_Defaults(t *testing.T) {
+	svc := &mockPayeeService{}
+	router := setupPayeeRouter(NewPayeeHandler(svc))
+
+	req := httptest.NewRequest("GET", "/analytics/by-payee", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.TransactionTypeOut, svc.params.Type)
+	assert.Equal(t, 10, svc.params.Limit)
+}
+
+func TestPayeeHandler_GetPayeeBreakdown_InvalidLimit(t *testing.T) {
+	router := setupPayeeRouter(NewPayeeHandler(&mockPayeeService{}))
+
+	req := httptest.NewRequest("GET", "/analytics/by-payee?limit=500", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
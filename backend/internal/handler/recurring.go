@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// RecurringHandler handles recurring transaction requests
+type RecurringHandler struct {
+	service service.RecurringService
+}
+
+// NewRecurringHandler creates a new recurring handler
+func NewRecurringHandler(service service.RecurringService) *RecurringHandler {
+	return &RecurringHandler{service: service}
+}
+
+// GetRecurring returns detected subscriptions and bills with their next expected date
+// GET /api/v1/analytics/recurring?type=out
+func (h *RecurringHandler) GetRecurring(c *gin.Context) {
+	var params domain.RecurringQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid query parameters",
+		})
+		return
+	}
+
+	recurring, err := h.service.GetRecurring(params)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, recurring)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// mockRecurringService is a mock implementation of RecurringService for testing
+type mockRecurringService struct {
+	params domain.RecurringQueryParams
+}
+
+func (m *mockRecurringService) GetRecurring(params domain.RecurringQueryParams) (*domain.RecurringResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	m.params = params
+	return &domain.RecurringResponse{
+		Series: []domain.RecurringSeries{
+			{Payee: "Netflix", Interval: domain.RecurringIntervalMonthly, AverageAmount: 260000, PriceChanged: true},
+		},
+	}, nil
+}
+
+func (m *mockRecurringService) Analyze() (*domain.RecurringResponse, error) {
+	return &domain.RecurringResponse{}, nil
+}
+
+func (m *mockRecurringService) Start(ctx context.Context, interval time.Duration) {}
+
+func setupRecurringRouter(handler *RecurringHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/analytics/recurring", handler.GetRecurring)
+	return router
+}
+
+func TestRecurringHandler_GetRecurring_Success(t *testing.T) {
+	svc := &mockRecurringService{}
+	router := setupRecurringRouter(NewRecurringHandler(svc))
+
+	req := httptest.NewRequest("GET", "/analytics/recurring?type=out", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, domain.TransactionTypeOut, svc.params.Type)
+
+	var response domain.RecurringResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Series, 1)
+	assert.True(t, response.Series[0].PriceChanged)
+}
+
+func TestRecurringHandler_GetRecurring_InvalidType(t *testing.T) {
+	router := setupRecurringRouter(NewRecurringHandler(&mockRecurringService{}))
+
+	req := httptest.NewRequest("GET", "/analytics/recurring?type=sideways", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// reportContentTypes maps report formats to their response content type
+var reportContentTypes = map[domain.ReportFormat]string{
+	domain.ReportFormatHTML: "text/html; charset=utf-8",
+	domain.ReportFormatPDF:  "application/pdf",
+}
+
+// ReportHandler handles report requests
+type ReportHandler struct {
+	service service.ReportService
+}
+
+// NewReportHandler creates a new report handler
+func NewReportHandler(service service.ReportService) *ReportHandler {
+	return &ReportHandler{service: service}
+}
+
+// GetMonthlyReport renders the monthly statement. Query: month (YYYY-MM,
+// defaults to the current month) and format (html or pdf).
+// GET /api/v1/reports/monthly?month=2024-05&format=pdf
+func (h *ReportHandler) GetMonthlyReport(c *gin.Context) {
+	var params domain.MonthlyReportQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid query parameters",
+		})
+		return
+	}
+
+	report, err := h.service.GetMonthlyReport(c.Request.Context(), &params)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	// Render into a buffer so a failure still gets a JSON error response
+	var body bytes.Buffer
+	if err := h.service.RenderMonthlyReport(&body, report, params.Format); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	if params.Format == domain.ReportFormatPDF {
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", "statement-"+report.Month+".pdf"))
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, reportContentTypes[params.Format], body.Bytes())
+}
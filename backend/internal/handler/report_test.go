@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// mockReportService is a mock implementation of ReportService for testing
+type mockReportService struct {
+	params    *domain.MonthlyReportQueryParams
+	getErr    error
+	renderErr error
+}
+
+func (m *mockReportService) GetMonthlyReport(ctx context.Context, params *domain.MonthlyReportQueryParams) (*domain.MonthlyReport, error) {
+	if err := params.Validate(time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)); err != nil {
+		return nil, err
+	}
+	m.params = params
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	return &domain.MonthlyReport{Month: params.Month}, nil
+}
+
+func (m *mockReportService) RenderMonthlyReport(w io.Writer, report *domain.MonthlyReport, format domain.ReportFormat) error {
+	if m.renderErr != nil {
+		return m.renderErr
+	}
+	_, err := io.WriteString(w, string(format)+" statement "+report.Month)
+	return err
+}
+
+func setupReportRouter(handler *ReportHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/reports/monthly", handler.GetMonthlyReport)
+	return router
+}
+
+func TestReportHandler_GetMonthlyReport_HTML(t *testing.T) {
+	svc := &mockReportService{}
+	router := setupReportRouter(NewReportHandler(svc))
+
+	req := httptest.NewRequest("GET", "/reports/monthly", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Empty(t, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "html statement 2026-03", w.Body.String())
+}
+
+func TestReportHandler_GetMonthlyReport_PDF(t *testing.T) {
+	svc := &mockReportService{}
+	router := setupReportRouter(NewReportHandler(svc))
+
+	req := httptest.NewRequest("GET", "/reports/monthly?month=2026-01&format=pdf", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `inline; filename="statement-2026-01.pdf"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "pdf statement 2026-01", w.Body.String())
+	assert.Equal(t, "2026-01", svc.params.Month)
+}
+
+func TestReportHandler_GetMonthlyReport_InvalidMonth(t *testing.T) {
+	router := setupReportRouter(NewReportHandler(&mockReportService{}))
+
+	req := httptest.NewRequest("GET", "/reports/monthly?month=2030-01", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "month", response["field"])
+}
+
+func TestReportHandler_GetMonthlyReport_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		svc  *mockReportService
+	}{
+		{"service error", &mockReportService{getErr: errors.New("database error")}},
+		{"render error", &mockReportService{renderErr: errors.New("font not found")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupReportRouter(NewReportHandler(tt.svc))
+
+			req := httptest.NewRequest("GET", "/reports/monthly?format=pdf", nil)
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			assert.Contains(t, w.Header().Get("Content-Type"), "application/json")
+			assert.Contains(t, w.Body.String(), "internal server error")
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// ScheduledHandler handles scheduled transaction and upcoming bill requests
+type ScheduledHandler struct {
+	service service.ScheduledService
+}
+
+// NewScheduledHandler creates a new scheduled transaction handler
+func NewScheduledHandler(service service.ScheduledService) *ScheduledHandler {
+	return &ScheduledHandler{service: service}
+}
+
+// CreateScheduled creates an expected future transaction with a recurrence rule
+// POST /api/v1/scheduled
+func (h *ScheduledHandler) CreateScheduled(c *gin.Context) {
+	var req domain.ScheduledTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	scheduled, err := h.service.CreateScheduled(&req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, scheduled)
+}
+
+// ListScheduled returns all scheduled transactions
+// GET /api/v1/scheduled
+func (h *ScheduledHandler) ListScheduled(c *gin.Context) {
+	scheduled, err := h.service.ListScheduled()
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": scheduled,
+	})
+}
+
+// GetScheduled returns a single scheduled transaction by ID
+// GET /api/v1/scheduled/:id
+func (h *ScheduledHandler) GetScheduled(c *gin.Context) {
+	id, ok := parseIDParam(c, "scheduled transaction")
+	if !ok {
+		return
+	}
+
+	scheduled, err := h.service.GetScheduled(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scheduled)
+}
+
+// UpdateScheduled replaces a scheduled transaction's recurrence rule
+// PUT /api/v1/scheduled/:id
+func (h *ScheduledHandler) UpdateScheduled(c *gin.Context) {
+	id, ok := parseIDParam(c, "scheduled transaction")
+	if !ok {
+		return
+	}
+
+	var req domain.ScheduledTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	scheduled, err := h.service.UpdateScheduled(id, &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, scheduled)
+}
+
+// DeleteScheduled removes a scheduled transaction and its payment history
+// DELETE /api/v1/scheduled/:id
+func (h *ScheduledHandler) DeleteScheduled(c *gin.Context) {
+	id, ok := parseIDParam(c, "scheduled transaction")
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteScheduled(id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListPayments returns the transactions matched to a scheduled transaction
+// GET /api/v1/scheduled/:id/payments
+func (h *ScheduledHandler) ListPayments(c *gin.Context) {
+	id, ok := parseIDParam(c, "scheduled transaction")
+	if !ok {
+		return
+	}
+
+	payments, err := h.service.ListPayments(id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": payments,
+	})
+}
+
+// ListUpcoming returns unpaid scheduled transactions due in the next N days, including overdue ones
+// GET /api/v1/transactions/upcoming?days=30
+func (h *ScheduledHandler) ListUpcoming(c *gin.Context) {
+	var params domain.UpcomingQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "invalid query parameters",
+		})
+		return
+	}
+
+	upcoming, err := h.service.ListUpcoming(params)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, upcoming)
+}
+
+// handleError maps service errors to HTTP responses
+func (h *ScheduledHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, repository.ErrScheduledTransactionNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": "scheduled transaction not found",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockScheduledService is a mock implementation of ScheduledService for testing
+type mockScheduledService struct {
+	getFunc        func(id int64) (*domain.ScheduledTransaction, error)
+	upcomingParams domain.UpcomingQueryParams
+}
+
+func (m *mockScheduledService) CreateScheduled(req *domain.ScheduledTransactionRequest) (*domain.ScheduledTransaction, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	scheduled := req.ToScheduledTransaction()
+	scheduled.ID = 1
+	return scheduled, nil
+}
+
+func (m *mockScheduledService) GetScheduled(id int64) (*domain.ScheduledTransaction, error) {
+	if m.getFunc != nil {
+		return m.getFunc(id)
+	}
+	return &domain.ScheduledTransaction{ID: id}, nil
+}
+
+func (m *mockScheduledService) ListScheduled() ([]domain.ScheduledTransaction, error) {
+	return []domain.ScheduledTransaction{}, nil
+}
+
+func (m *mockScheduledService) UpdateScheduled(id int64, req *domain.ScheduledTransactionRequest) (*domain.ScheduledTransaction, error) {
+	scheduled := req.ToScheduledTransaction()
+	scheduled.ID = id
+	return scheduled, nil
+}
+
+func (m *mockScheduledService) DeleteScheduled(id int64) error {
+	return nil
+}
+
+func (m *mockScheduledService) ListPayments(id int64) ([]domain.ScheduledPayment, error) {
+	return []domain.ScheduledPayment{}, nil
+}
+
+func (m *mockScheduledService) ListUpcoming(params domain.UpcomingQueryParams) (*domain.UpcomingResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	m.upcomingParams = params
+	return &domain.UpcomingResponse{
+		Days: params.Days,
+		Data: []domain.UpcomingTransaction{
+			{ScheduledTransactionID: 1, Name: "Rent", Status: domain.UpcomingStatusOverdue, DaysUntilDue: -3},
+		},
+		Overdue: 1,
+	}, nil
+}
+
+func (m *mockScheduledService) MatchTransaction(tx *domain.Transaction) (*domain.ScheduledPayment, error) {
+	return nil, nil
+}
+
+func (m *mockScheduledService) OnTransactionsCreated(transactions []domain.Transaction) {}
+
+func setupScheduledRouter(handler *ScheduledHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/scheduled", handler.ListScheduled)
+	router.POST("/scheduled", handler.CreateScheduled)
+	router.GET("/scheduled/:id", handler.GetScheduled)
+	router.PUT("/scheduled/:id", handler.UpdateScheduled)
+	router.DELETE("/scheduled/:id", handler.DeleteScheduled)
+	router.GET("/scheduled/:id/payments", handler.ListPayments)
+	router.GET("/transactions/upcoming", handler.ListUpcoming)
+	return router
+}
+
+func TestScheduledHandler_CreateScheduled_Success(t *testing.T) {
+	router := setupScheduledRouter(NewScheduledHandler(&mockScheduledService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":       "Rent",
+		"type":       "out",
+		"frequency":  "monthly",
+		"start_date": "2026-01-05",
+		"recipient":  "Nguyen Van A",
+		"amount":     5000000,
+	})
+	req := httptest.NewRequest("POST", "/scheduled", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response domain.ScheduledTransaction
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ScheduleFrequencyMonthly, response.Frequency)
+	assert.True(t, response.Active)
+}
+
+func TestScheduledHandler_CreateScheduled_InvalidFrequency(t *testing.T) {
+	router := setupScheduledRouter(NewScheduledHandler(&mockScheduledService{}))
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":       "Rent",
+		"type":       "out",
+		"frequency":  "hourly",
+		"start_date": "2026-01-05",
+		"amount":     5000000,
+	})
+	req := httptest.NewRequest("POST", "/scheduled", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"frequency"`)
+}
+
+func TestScheduledHandler_GetScheduled_NotFound(t *testing.T) {
+	router := setupScheduledRouter(NewScheduledHandler(&mockScheduledService{
+		getFunc: func(id int64) (*domain.ScheduledTransaction, error) {
+			return nil, repository.ErrScheduledTransactionNotFound
+		},
+	}))
+
+	req := httptest.NewRequest("GET", "/scheduled/5", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestScheduledHandler_ListUpcoming(t *testing.T) {
+	svc := &mockScheduledService{}
+	router := setupScheduledRouter(NewScheduledHandler(svc))
+
+	req := httptest.NewRequest("GET", "/transactions/upcoming?days=14", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 14, svc.upcomingParams.Days)
+
+	var response domain.UpcomingResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.Overdue)
+	assert.Equal(t, domain.UpcomingStatusOverdue, response.Data[0].Status)
+}
+
+func TestScheduledHandler_ListUpcoming_DefaultsTo30Days(t *testing.T) {
+	svc := &mockScheduledService{}
+	router := setupScheduledRouter(NewScheduledHandler(svc))
+
+	req := httptest.NewRequest("GET", "/transactions/upcoming", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 30, svc.upcomingParams.Days)
+}
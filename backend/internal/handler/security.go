@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// SecurityHandler handles security log requests of the signed-in user
+type SecurityHandler struct {
+	service service.SecurityService
+}
+
+// NewSecurityHandler creates a new security handler
+func NewSecurityHandler(service service.SecurityService) *SecurityHandler {
+	return &SecurityHandler{service: service}
+}
+
+// ListEvents returns the security log of the user, newest first
+// GET /api/v1/security-events
+func (h *SecurityHandler) ListEvents(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	var params domain.SecurityEventQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	events, err := h.service.ListEvents(userID, params)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"data": events,
+	})
+}
+
+// handleError maps service errors to HTTP responses
+func (h *SecurityHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+)
+
+// mockSecurityService is a mock implementation of SecurityService for testing
+type mockSecurityService struct {
+	userID int64
+	params domain.SecurityEventQueryParams
+	err    error
+}
+
+func (m *mockSecurityService) CheckLogin(email string, client domain.ClientInfo) error {
+	return nil
+}
+
+func (m *mockSecurityService) LoginFailed(user *domain.User, email string, client domain.ClientInfo) error {
+	return nil
+}
+
+func (m *mockSecurityService) LoginSucceeded(user *domain.User, client domain.ClientInfo) error {
+	return nil
+}
+
+func (m *mockSecurityService) MFAFailed(user *domain.User, client domain.ClientInfo) error {
+	return nil
+}
+
+func (m *mockSecurityService) RecordEvent(userID int64, eventType string, client domain.ClientInfo, details string) error {
+	return nil
+}
+
+func (m *mockSecurityService) ListEvents(userID int64, params domain.SecurityEventQueryParams) ([]domain.SecurityEvent, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	m.userID, m.params = userID, params
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.SecurityEvent{{ID: 3, UserID: userID, Type: domain.SecurityEventAccountLocked, IPAddress: "203.0.113.7"}}, nil
+}
+
+func (m *mockSecurityService) Unlock(email string) error {
+	return nil
+}
+
+func (m *mockSecurityService) UnlockIP(ip string) error {
+	return nil
+}
+
+func (m *mockSecurityService) DeleteExpiredThrottles() (int64, error) {
+	return 0, nil
+}
+
+func setupSecurityRouter(handler *SecurityHandler, userID int64) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if userID != 0 {
+			c.Set(middleware.UserIDContextKey, userID)
+		}
+		c.Next()
+	})
+	router.GET("/security-events", handler.ListEvents)
+	return router
+}
+
+func TestSecurityHandler_ListEvents(t *testing.T) {
+	svc := &mockSecurityService{}
+	router := setupSecurityRouter(NewSecurityHandler(svc), 42)
+
+	req := httptest.NewRequest("GET", "/security-events?type=account.locked&limit=10", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+	assert.Equal(t, domain.SecurityEventQueryParams{Type: domain.SecurityEventAccountLocked, Limit: 10}, svc.params)
+
+	var response struct {
+		Data []domain.SecurityEvent `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, "203.0.113.7", response.Data[0].IPAddress)
+}
+
+func TestSecurityHandler_ListEvents_InvalidType(t *testing.T) {
+	router := setupSecurityRouter(NewSecurityHandler(&mockSecurityService{}), 42)
+
+	req := httptest.NewRequest("GET", "/security-events?type=logout", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"type"`)
+}
+
+func TestSecurityHandler_ListEvents_InvalidLimit(t *testing.T) {
+	router := setupSecurityRouter(NewSecurityHandler(&mockSecurityService{}), 42)
+
+	req := httptest.NewRequest("GET", "/security-events?limit=abc", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSecurityHandler_ListEvents_Unauthorized(t *testing.T) {
+	router := setupSecurityRouter(NewSecurityHandler(&mockSecurityService{}), 0)
+
+	req := httptest.NewRequest("GET", "/security-events", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestSecurityHandler_ListEvents_ServiceError(t *testing.T) {
+	router := setupSecurityRouter(NewSecurityHandler(&mockSecurityService{err: errors.New("connection refused")}), 42)
+
+	req := httptest.NewRequest("GET", "/security-events", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
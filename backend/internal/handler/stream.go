@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// streamWriteGrace is how long a write may block beyond the heartbeat
+// interval before the client is considered gone
+const streamWriteGrace = 10 * time.Second
+
+// StreamHandler handles the live transaction stream
+type StreamHandler struct {
+	service   service.StreamService
+	heartbeat time.Duration
+}
+
+// NewStreamHandler creates a new stream handler that sends a heartbeat
+// comment whenever the stream was idle for the heartbeat interval
+func NewStreamHandler(service service.StreamService, heartbeat time.Duration) *StreamHandler {
+	return &StreamHandler{service: service, heartbeat: heartbeat}
+}
+
+// Stream pushes new transactions and summary totals as Server-Sent Events.
+// A Last-Event-ID header, or the last_event_id query for the first
+// connection, replays the transactions stored after that ID.
+// GET /api/v1/stream
+func (h *StreamHandler) Stream(c *gin.Context) {
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var after int64
+	if lastEventID != "" {
+		var err error
+		if after, err = strconv.ParseInt(lastEventID, 10, 64); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error": "Last-Event-ID must be a transaction ID",
+			})
+			return
+		}
+	}
+
+	subscription, err := h.service.Subscribe(after)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+	defer subscription.Close()
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // disable proxy buffering in nginx
+	c.Status(http.StatusOK)
+
+	// The server's write timeout would end the stream, so every write gets its own deadline
+	rc := http.NewResponseController(c.Writer)
+	write := func(fn func(w io.Writer) error) bool {
+		if err := rc.SetWriteDeadline(time.Now().Add(h.heartbeat + streamWriteGrace)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return false
+		}
+		if err := fn(c.Writer); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	replayed := make(map[int64]bool, len(subscription.Backlog))
+	ok := write(func(w io.Writer) error {
+		for _, event := range subscription.Backlog {
+			if _, err := event.WriteTo(w); err != nil {
+				return err
+			}
+			if event.ID != 0 {
+				replayed[event.ID] = true
+			}
+		}
+		return nil
+	})
+	if !ok {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, open := <-subscription.Events:
+			if !open {
+				return
+			}
+			if event.ID != 0 && replayed[event.ID] {
+				continue
+			}
+			if !write(func(w io.Writer) error {
+				_, err := event.WriteTo(w)
+				return err
+			}) {
+				return
+			}
+			heartbeat.Reset(h.heartbeat)
+		case <-heartbeat.C:
+			if !write(func(w io.Writer) error {
+				_, err := io.WriteString(w, ": heartbeat\n\n")
+				return err
+			}) {
+				return
+			}
+		}
+	}
+}
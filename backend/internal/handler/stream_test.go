@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// mockStreamService is a mock implementation of StreamService for testing.
+// Subscriptions replay backlog and then the events queued on live.
+type mockStreamService struct {
+	backlog     []domain.StreamEvent
+	live        chan domain.StreamEvent
+	lastEventID int64
+	closed      bool
+	err         error
+}
+
+func (m *mockStreamService) Subscribe(lastEventID int64) (*service.StreamSubscription, error) {
+	m.lastEventID = lastEventID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &service.StreamSubscription{
+		Backlog: m.backlog,
+		Events:  m.live,
+		Close:   func() { m.closed = true },
+	}, nil
+}
+
+func (m *mockStreamService) Start(ctx context.Context) {}
+
+func (m *mockStreamService) OnTransactionsCreated(transactions []domain.Transaction) {}
+
+func (m *mockStreamService) OnTransactionUpdated(previous, updated domain.Transaction) {}
+
+func setupStreamRouter(handler *StreamHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/stream", handler.Stream)
+	return router
+}
+
+func TestStreamHandler_Stream_ReplaysThenStreams(t *testing.T) {
+	svc := &mockStreamService{
+		backlog: []domain.StreamEvent{
+			domain.NewTransactionCreatedEvent(domain.Transaction{ID: 5}),
+			{Event: domain.StreamEventSummary, Data: domain.SummaryResponse{TotalIncome: 100}},
+		},
+		live: make(chan domain.StreamEvent, 3),
+	}
+	svc.live <- domain.NewTransactionCreatedEvent(domain.Transaction{ID: 5})
+	svc.live <- domain.NewTransactionCreatedEvent(domain.Transaction{ID: 6})
+	svc.live <- domain.StreamEvent{Event: domain.StreamEventSummary, Data: domain.SummaryResponse{TotalIncome: 200}}
+	close(svc.live)
+	router := setupStreamRouter(NewStreamHandler(svc, time.Minute))
+
+	req := httptest.NewRequest("GET", "/stream", nil)
+	req.Header.Set("Last-Event-ID", "4")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, int64(4), svc.lastEventID)
+	assert.True(t, svc.closed)
+
+	body := w.Body.String()
+	assert.Equal(t, 1, strings.Count(body, "id: 5\n"), "replayed transactions must not be sent twice")
+	assert.Contains(t, body, "id: 6\nevent: transaction.created\n")
+	assert.Less(t, strings.Index(body, `"total_income":100`), strings.Index(body, `"total_income":200`))
+}
+
+func TestStreamHandler_Stream_Heartbeat(t *testing.T) {
+	svc := &mockStreamService{live: make(chan domain.StreamEvent)}
+	router := setupStreamRouter(NewStreamHandler(svc, 10*time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest("GET", "/stream", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), ": heartbeat\n\n")
+	assert.True(t, svc.closed)
+}
+
+func TestStreamHandler_Stream_LastEventIDQuery(t *testing.T) {
+	svc := &mockStreamService{live: make(chan domain.StreamEvent)}
+	close(svc.live)
+	router := setupStreamRouter(NewStreamHandler(svc, time.Minute))
+
+	req := httptest.NewRequest("GET", "/stream?last_event_id=42", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(42), svc.lastEventID)
+}
+
+func TestStreamHandler_Stream_InvalidLastEventID(t *testing.T) {
+	router := setupStreamRouter(NewStreamHandler(&mockStreamService{}, time.Minute))
+
+	req := httptest.NewRequest("GET", "/stream", nil)
+	req.Header.Set("Last-Event-ID", "abc")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestStreamHandler_Stream_ServiceError(t *testing.T) {
+	router := setupStreamRouter(NewStreamHandler(&mockStreamService{err: errors.New("connection refused")}, time.Minute))
+
+	req := httptest.NewRequest("GET", "/stream", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
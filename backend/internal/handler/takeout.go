@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// TakeoutHandler handles takeout archives of the signed-in user
+type TakeoutHandler struct {
+	service service.TakeoutService
+}
+
+// NewTakeoutHandler creates a new takeout handler
+func NewTakeoutHandler(service service.TakeoutService) *TakeoutHandler {
+	return &TakeoutHandler{service: service}
+}
+
+// RequestTakeout starts an archive of the user's data; small ledgers are
+// archived at once (201), larger ones in the background (202)
+// POST /api/v1/me/takeouts
+func (h *TakeoutHandler) RequestTakeout(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	takeout, err := h.service.Request(userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	status := http.StatusAccepted
+	if takeout.Status == domain.TakeoutStatusReady {
+		status = http.StatusCreated
+	}
+	c.JSON(status, withDownloadURL(takeout))
+}
+
+// ListTakeouts returns the takeouts of the user that have not expired
+// GET /api/v1/me/takeouts
+func (h *TakeoutHandler) ListTakeouts(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	takeouts, err := h.service.List(userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	for i := range takeouts {
+		withDownloadURL(&takeouts[i])
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data": takeouts,
+	})
+}
+
+// GetTakeout returns a takeout of the user
+// GET /api/v1/me/takeouts/:id
+func (h *TakeoutHandler) GetTakeout(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(c, "takeout")
+	if !ok {
+		return
+	}
+
+	takeout, err := h.service.Get(userID, id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, withDownloadURL(takeout))
+}
+
+// DownloadTakeout sends the zip archive of a ready takeout
+// GET /api/v1/me/takeouts/:id/download
+func (h *TakeoutHandler) DownloadTakeout(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+	id, ok := parseIDParam(c, "takeout")
+	if !ok {
+		return
+	}
+
+	takeout, err := h.service.Download(userID, id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	filename := fmt.Sprintf("takeout-%s.zip", takeout.CompletedAt.UTC().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Header("Cache-Control", "no-store")
+	c.Data(http.StatusOK, "application/zip", takeout.Archive)
+}
+
+// RestoreTakeout brings a takeout archive into the account and the empty ledger
+// POST /api/v1/me/takeouts/restore
+func (h *TakeoutHandler) RestoreTakeout(c *gin.Context) {
+	userID, ok := requireUserID(c)
+	if !ok {
+		return
+	}
+
+	file, ok := openUpload(c)
+	if !ok {
+		return
+	}
+	defer file.Close()
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "file could not be read",
+			"field": "file",
+		})
+		return
+	}
+
+	result, err := h.service.Restore(userID, file, size)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// withDownloadURL sets the download URL of a ready takeout
+func withDownloadURL(takeout *domain.Takeout) *domain.Takeout {
+	if takeout.Status == domain.TakeoutStatusReady {
+		takeout.DownloadURL = fmt.Sprintf("/api/v1/me/takeouts/%d/download", takeout.ID)
+	}
+	return takeout
+}
+
+// handleError maps service errors to HTTP responses
+func (h *TakeoutHandler) handleError(c *gin.Context, err error) {
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		respondValidationError(c, validationErr)
+		return
+	}
+
+	if errors.Is(err, repository.ErrTakeoutNotFound) || errors.Is(err, repository.ErrUserNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if errors.Is(err, service.ErrTakeoutInProgress) || errors.Is(err, service.ErrTakeoutNotReady) ||
+		errors.Is(err, repository.ErrLedgerNotEmpty) {
+		c.JSON(http.StatusConflict, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"error": "internal server error",
+	})
+}
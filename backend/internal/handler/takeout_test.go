@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/middleware"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/service"
+)
+
+// mockTakeoutService is a mock implementation of TakeoutService for testing
+type mockTakeoutService struct {
+	userID   int64
+	status   domain.TakeoutStatus
+	restored string
+	err      error
+}
+
+func (m *mockTakeoutService) takeout(id int64) *domain.Takeout {
+	completedAt := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+	return &domain.Takeout{ID: id, UserID: m.userID, Status: m.status, CompletedAt: &completedAt, Archive: []byte("PK zip")}
+}
+
+func (m *mockTakeoutService) Request(userID int64) (*domain.Takeout, error) {
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.takeout(1), nil
+}
+
+func (m *mockTakeoutService) List(userID int64) ([]domain.Takeout, error) {
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []domain.Takeout{*m.takeout(2), *m.takeout(1)}, nil
+}
+
+func (m *mockTakeoutService) Get(userID, id int64) (*domain.Takeout, error) {
+	m.userID = userID
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.takeout(id), nil
+}
+
+func (m *mockTakeoutService) Download(userID, id int64) (*domain.Takeout, error) {
+	return m.Get(userID, id)
+}
+
+func (m *mockTakeoutService) BuildDue(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *mockTakeoutService) DeleteExpired() (int64, error) {
+	return 0, nil
+}
+
+func (m *mockTakeoutService) Restore(userID int64, archive io.ReaderAt, size int64) (*domain.TakeoutRestoreResult, error) {
+	m.userID = userID
+	content := make([]byte, size)
+	if _, err := archive.ReadAt(content, 0); err != nil {
+		return nil, err
+	}
+	m.restored = string(content)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.TakeoutRestoreResult{Transactions: 12, Payees: 2, Budgets: 1}, nil
+}
+
+func setupTakeoutRouter(handler *TakeoutHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDContextKey, int64(42))
+		c.Next()
+	})
+	router.POST("/me/takeouts", handler.RequestTakeout)
+	router.GET("/me/takeouts", handler.ListTakeouts)
+	router.POST("/me/takeouts/restore", handler.RestoreTakeout)
+	router.GET("/me/takeouts/:id", handler.GetTakeout)
+	router.GET("/me/takeouts/:id/download", handler.DownloadTakeout)
+	return router
+}
+
+func TestTakeoutHandler_RequestTakeout(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     domain.TakeoutStatus
+		wantStatus int
+		wantURL    bool
+	}{
+		{"built at once", domain.TakeoutStatusReady, http.StatusCreated, true},
+		{"built later", domain.TakeoutStatusPending, http.StatusAccepted, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &mockTakeoutService{status: tt.status}
+			router := setupTakeoutRouter(NewTakeoutHandler(svc))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", "/me/takeouts", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, int64(42), svc.userID)
+			if tt.wantURL {
+				assert.Contains(t, w.Body.String(), `"download_url":"/api/v1/me/takeouts/1/download"`)
+			} else {
+				assert.NotContains(t, w.Body.String(), "download_url")
+			}
+			assert.NotContains(t, w.Body.String(), "archive")
+		})
+	}
+}
+
+func TestTakeoutHandler_ListTakeouts(t *testing.T) {
+	router := setupTakeoutRouter(NewTakeoutHandler(&mockTakeoutService{status: domain.TakeoutStatusReady}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/me/takeouts", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"download_url":"/api/v1/me/takeouts/2/download"`)
+	assert.Contains(t, w.Body.String(), `"download_url":"/api/v1/me/takeouts/1/download"`)
+}
+
+func TestTakeoutHandler_DownloadTakeout(t *testing.T) {
+	router := setupTakeoutRouter(NewTakeoutHandler(&mockTakeoutService{status: domain.TakeoutStatusReady}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/me/takeouts/3/download", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="takeout-20260502.zip"`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Equal(t, "PK zip", w.Body.String())
+}
+
+func TestTakeoutHandler_RestoreTakeout(t *testing.T) {
+	svc := &mockTakeoutService{}
+	router := setupTakeoutRouter(NewTakeoutHandler(svc))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpload(t, "/me/takeouts/restore", "takeout.zip", "PK archive", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(42), svc.userID)
+	assert.Equal(t, "PK archive", svc.restored)
+	assert.JSONEq(t, `{"transactions": 12, "payees": 2, "budgets": 1}`, w.Body.String())
+}
+
+func TestTakeoutHandler_RestoreTakeout_MissingFile(t *testing.T) {
+	router := setupTakeoutRouter(NewTakeoutHandler(&mockTakeoutService{}))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, newUpload(t, "/me/takeouts/restore", "takeout.zip", "", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"file"`)
+}
+
+func TestTakeoutHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		err        error
+		wantStatus int
+	}{
+		{"in progress", "POST", "/me/takeouts", service.ErrTakeoutInProgress, http.StatusConflict},
+		{"invalid id", "GET", "/me/takeouts/abc", nil, http.StatusBadRequest},
+		{"not found", "GET", "/me/takeouts/9", repository.ErrTakeoutNotFound, http.StatusNotFound},
+		{"not ready", "GET", "/me/takeouts/9/download", service.ErrTakeoutNotReady, http.StatusConflict},
+		{"deleted user", "POST", "/me/takeouts", repository.ErrUserNotFound, http.StatusNotFound},
+		{"internal error", "GET", "/me/takeouts", assert.AnError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTakeoutRouter(NewTakeoutHandler(&mockTakeoutService{err: tt.err}))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestTakeoutHandler_RestoreTakeout_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid archive", &domain.ValidationError{Field: "file", Message: "file is not a zip archive"}, http.StatusBadRequest},
+		{"ledger not empty", repository.ErrLedgerNotEmpty, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTakeoutRouter(NewTakeoutHandler(&mockTakeoutService{err: tt.err}))
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newUpload(t, "/me/takeouts/restore", "takeout.zip", "PK archive", nil))
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
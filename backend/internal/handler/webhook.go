@@ -3,10 +3,13 @@ package handler
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/metrics"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
 	"github.com/dev/personal-finance-tracker/backend/internal/service"
 )
 
@@ -24,6 +27,7 @@ func NewWebhookHandler(service service.TransactionService) *WebhookHandler {
 func (h *WebhookHandler) CreateTransaction(c *gin.Context) {
 	var req domain.CreateTransactionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		metrics.CountWebhookTransactions(metrics.UnknownSource, metrics.WebhookResultInvalid, 1)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -35,20 +39,20 @@ func (h *WebhookHandler) CreateTransaction(c *gin.Context) {
 		// Check if it's a validation error
 		var validationErr *domain.ValidationError
 		if errors.As(err, &validationErr) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": validationErr.Message,
-				"field": validationErr.Field,
-			})
+			countWebhookTransactions([]domain.CreateTransactionRequest{req}, metrics.WebhookResultInvalid)
+			respondValidationError(c, validationErr)
 			return
 		}
 
 		// All other errors are internal server errors
+		countWebhookTransactions([]domain.CreateTransactionRequest{req}, metrics.WebhookResultFailed)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "internal server error",
 		})
 		return
 	}
 
+	metrics.CountWebhookTransactions(transaction.Source, metrics.WebhookResultCreated, 1)
 	c.JSON(http.StatusCreated, transaction)
 }
 
@@ -56,6 +60,7 @@ func (h *WebhookHandler) CreateTransaction(c *gin.Context) {
 func (h *WebhookHandler) CreateBatchTransaction(c *gin.Context) {
 	var req domain.BatchTransactionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
+		metrics.CountWebhookTransactions(metrics.UnknownSource, metrics.WebhookResultInvalid, 1)
 		c.JSON(http.StatusBadRequest, gin.H{
 			"error": err.Error(),
 		})
@@ -67,22 +72,77 @@ func (h *WebhookHandler) CreateBatchTransaction(c *gin.Context) {
 		// Check if it's a validation error
 		var validationErr *domain.ValidationError
 		if errors.As(err, &validationErr) {
-			c.JSON(http.StatusBadRequest, gin.H{
-				"error": validationErr.Message,
-				"field": validationErr.Field,
-			})
+			countWebhookTransactions(req.Transactions, metrics.WebhookResultInvalid)
+			respondValidationError(c, validationErr)
 			return
 		}
 
 		// All other errors are internal server errors
+		countWebhookTransactions(req.Transactions, metrics.WebhookResultFailed)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "internal server error",
 		})
 		return
 	}
 
+	for _, transaction := range transactions {
+		metrics.CountWebhookTransactions(transaction.Source, metrics.WebhookResultCreated, 1)
+	}
+
 	c.JSON(http.StatusCreated, gin.H{
 		"created":      len(transactions),
 		"transactions": transactions,
 	})
 }
+
+// UpdateTransaction replaces the fields of a transaction
+// PUT /api/v1/transactions/:id
+func (h *WebhookHandler) UpdateTransaction(c *gin.Context) {
+	transactionID, ok := parseIDParam(c, "transaction")
+	if !ok {
+		return
+	}
+
+	var req domain.CreateTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	transaction, err := h.service.UpdateTransaction(transactionID, &req)
+	if err != nil {
+		var validationErr *domain.ValidationError
+		if errors.As(err, &validationErr) {
+			respondValidationError(c, validationErr)
+			return
+		}
+
+		if errors.Is(err, repository.ErrTransactionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "transaction not found",
+			})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "internal server error",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, transaction)
+}
+
+// countWebhookTransactions counts rejected webhook transactions by source;
+// sources longer than validation allows are counted as unknown
+func countWebhookTransactions(reqs []domain.CreateTransactionRequest, result string) {
+	for _, req := range reqs {
+		source := strings.TrimSpace(req.Source)
+		if len(source) > domain.MaxSourceLength {
+			source = metrics.UnknownSource
+		}
+		metrics.CountWebhookTransactions(source, result, 1)
+	}
+}
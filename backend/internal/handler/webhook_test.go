@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/metrics"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
 )
 
 // Mock service for testing
@@ -25,6 +27,9 @@ type mockTransactionService struct {
 	getTrendsFunc        func(period string) (*domain.TrendsResponse, error)
 	getBreakdownSource   func() ([]domain.BreakdownResponse, error)
 	getBreakdownCategory func() ([]domain.BreakdownResponse, error)
+	getBreakdownFunc     func(params domain.BreakdownQueryParams) ([]domain.BreakdownResponse, error)
+	getPivotFunc         func(params domain.PivotQueryParams) (*domain.PivotResponse, error)
+	updateFunc           func(id int64, req *domain.CreateTransactionRequest) (*domain.Transaction, error)
 }
 
 func (m *mockTransactionService) CreateTransaction(req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
@@ -41,6 +46,13 @@ func (m *mockTransactionService) CreateBatchTransaction(req *domain.BatchTransac
 	return []domain.Transaction{{ID: 1}}, nil
 }
 
+func (m *mockTransactionService) UpdateTransaction(id int64, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+	if m.updateFunc != nil {
+		return m.updateFunc(id, req)
+	}
+	return &domain.Transaction{ID: id}, nil
+}
+
 func (m *mockTransactionService) GetTransactionByID(id int64) (*domain.Transaction, error) {
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
@@ -83,11 +95,26 @@ func (m *mockTransactionService) GetBreakdownByCategory() ([]domain.BreakdownRes
 	return []domain.BreakdownResponse{}, nil
 }
 
+func (m *mockTransactionService) GetBreakdown(params domain.BreakdownQueryParams) ([]domain.BreakdownResponse, error) {
+	if m.getBreakdownFunc != nil {
+		return m.getBreakdownFunc(params)
+	}
+	return []domain.BreakdownResponse{}, nil
+}
+
+func (m *mockTransactionService) GetPivot(params domain.PivotQueryParams) (*domain.PivotResponse, error) {
+	if m.getPivotFunc != nil {
+		return m.getPivotFunc(params)
+	}
+	return &domain.PivotResponse{}, nil
+}
+
 func setupTestRouter(handler *WebhookHandler) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 	router.POST("/webhook/transaction", handler.CreateTransaction)
 	router.POST("/webhook/batch", handler.CreateBatchTransaction)
+	router.PUT("/transactions/:id", handler.UpdateTransaction)
 	return router
 }
 
@@ -499,3 +526,111 @@ func TestWebhookHandler_CreateBatchTransaction_ServiceError(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+// Test WebhookHandler UpdateTransaction
+
+func TestWebhookHandler_UpdateTransaction_Success(t *testing.T) {
+	var updatedID int64
+	mockService := &mockTransactionService{
+		updateFunc: func(id int64, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+			updatedID = id
+			return &domain.Transaction{ID: id, Amount: req.Amount, Type: req.Type}, nil
+		},
+	}
+
+	router := setupTestRouter(NewWebhookHandler(mockService))
+
+	body := `{"amount": 250, "type": "out", "source": "Bank ABC", "transaction_date": "2026-01-15T12:00:00Z"}`
+	req := httptest.NewRequest("PUT", "/transactions/4", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(4), updatedID)
+
+	var response domain.Transaction
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, 250.0, response.Amount)
+}
+
+func TestWebhookHandler_UpdateTransaction_NotFound(t *testing.T) {
+	mockService := &mockTransactionService{
+		updateFunc: func(id int64, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+			return nil, repository.ErrTransactionNotFound
+		},
+	}
+
+	router := setupTestRouter(NewWebhookHandler(mockService))
+
+	body := `{"amount": 250, "type": "out", "source": "Bank ABC", "transaction_date": "2026-01-15T12:00:00Z"}`
+	req := httptest.NewRequest("PUT", "/transactions/4", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestWebhookHandler_UpdateTransaction_InvalidID(t *testing.T) {
+	router := setupTestRouter(NewWebhookHandler(&mockTransactionService{}))
+
+	body := `{"amount": 250, "type": "out", "source": "Bank ABC", "transaction_date": "2026-01-15T12:00:00Z"}`
+	req := httptest.NewRequest("PUT", "/transactions/abc", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// Test webhook metrics
+
+// scrapeMetrics returns what the metrics endpoint serves
+func scrapeMetrics() string {
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	return w.Body.String()
+}
+
+func TestWebhookHandler_CountsTransactionsBySourceAndResult(t *testing.T) {
+	mockService := &mockTransactionService{
+		createFunc: func(req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+			switch req.Source {
+			case "Metrics Invalid Bank":
+				return nil, &domain.ValidationError{Field: "metrics_test_category", Message: "invalid category"}
+			case "Metrics Failing Bank":
+				return nil, errors.New("database error")
+			}
+			return &domain.Transaction{ID: 1, Source: req.Source}, nil
+		},
+		createBatchFunc: func(req *domain.BatchTransactionRequest) ([]domain.Transaction, error) {
+			return []domain.Transaction{{ID: 1, Source: "Metrics Batch Bank"}, {ID: 2, Source: "Metrics Batch Bank"}}, nil
+		},
+	}
+	router := setupTestRouter(NewWebhookHandler(mockService))
+
+	send := func(path, body string) {
+		req := httptest.NewRequest("POST", path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	transaction := func(source string) string {
+		return `{"amount": 10, "type": "out", "source": "` + source + `", "transaction_date": "2026-01-15T12:00:00Z"}`
+	}
+	send("/webhook/transaction", transaction("Metrics Created Bank"))
+	send("/webhook/transaction", transaction("Metrics Invalid Bank"))
+	send("/webhook/transaction", transaction("Metrics Failing Bank"))
+	send("/webhook/batch", `{"transactions": [`+transaction("Metrics Batch Bank")+`, `+transaction("Metrics Batch Bank")+`]}`)
+
+	body := scrapeMetrics()
+	assert.Contains(t, body, `finance_tracker_webhook_transactions_total{result="created",source="Metrics Created Bank"} 1`)
+	assert.Contains(t, body, `finance_tracker_webhook_transactions_total{result="invalid",source="Metrics Invalid Bank"} 1`)
+	assert.Contains(t, body, `finance_tracker_webhook_transactions_total{result="failed",source="Metrics Failing Bank"} 1`)
+	assert.Contains(t, body, `finance_tracker_webhook_transactions_total{result="created",source="Metrics Batch Bank"} 2`)
+	assert.Contains(t, body, `finance_tracker_validation_failures_total{field="metrics_test_category"} 1`)
+}
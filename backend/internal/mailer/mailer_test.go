@@ -0,0 +1,211 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// catchAll is a minimal SMTP server that accepts every message, like a local
+// development catch-all
+type catchAll struct {
+	listener net.Listener
+	messages chan capturedMessage
+}
+
+type capturedMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func startCatchAll(t *testing.T) *catchAll {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	server := &catchAll{listener: listener, messages: make(chan capturedMessage, 10)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *catchAll) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *catchAll) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+	reply("220 localhost catch-all")
+	var msg capturedMessage
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			msg.from = smtpPath(line[len("MAIL FROM:"):])
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			msg.to = append(msg.to, smtpPath(line[len("RCPT TO:"):]))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			msg.data = data.String()
+			s.messages <- msg
+			msg = capturedMessage{}
+			reply("250 OK: queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// smtpPath returns the address of a MAIL FROM or RCPT TO argument such as
+// "<an@example.com> BODY=8BITMIME"
+func smtpPath(arg string) string {
+	arg = strings.TrimPrefix(strings.TrimSpace(arg), "<")
+	if end := strings.Index(arg, ">"); end >= 0 {
+		arg = arg[:end]
+	}
+	return arg
+}
+
+func TestSMTPConfig_Validate(t *testing.T) {
+	valid := SMTPConfig{Host: "localhost", Port: 1025, From: "Tracker <no-reply@example.com>", TLS: TLSNone}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*SMTPConfig)
+	}{
+		{"missing host", func(c *SMTPConfig) { c.Host = "" }},
+		{"invalid port", func(c *SMTPConfig) { c.Port = 0 }},
+		{"invalid from", func(c *SMTPConfig) { c.From = "not an address" }},
+		{"unknown tls mode", func(c *SMTPConfig) { c.TLS = "ssl" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			tt.modify(&cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	server := startCatchAll(t)
+	m, err := NewSMTPMailer(SMTPConfig{
+		Host: "127.0.0.1",
+		Port: server.port(),
+		From: "Finance Tracker <no-reply@example.com>",
+		TLS:  TLSNone,
+	})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), &Message{
+		To:      []string{"an@example.com"},
+		Subject: "Tóm tắt tuần 2026-W10",
+		HTML:    "<p>Spent <b>1,200.00</b></p>",
+		Text:    "Spent 1,200.00",
+	})
+	require.NoError(t, err)
+
+	var captured capturedMessage
+	select {
+	case captured = <-server.messages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+	assert.Equal(t, "no-reply@example.com", captured.from)
+	assert.Equal(t, []string{"an@example.com"}, captured.to)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(captured.data))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "Tóm tắt tuần 2026-W10", subject)
+	assert.Contains(t, parsed.Header.Get("Message-ID"), "@example.com>")
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		bodies = append(bodies, part.Header.Get("Content-Type")+": "+string(body))
+	}
+	assert.Equal(t, []string{
+		"text/plain; charset=utf-8: Spent 1,200.00",
+		"text/html; charset=utf-8: <p>Spent <b>1,200.00</b></p>",
+	}, bodies)
+}
+
+func TestSMTPMailer_Send_NoRecipients(t *testing.T) {
+	m, err := NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: 1025, From: "no-reply@example.com", TLS: TLSNone})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, m.Send(context.Background(), &Message{Subject: "Hi"}), ErrNoRecipients)
+}
+
+func TestSMTPMailer_Send_ConnectionRefused(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	m, err := NewSMTPMailer(SMTPConfig{Host: "127.0.0.1", Port: port, From: "no-reply@example.com", TLS: TLSNone})
+	require.NoError(t, err)
+
+	err = m.Send(context.Background(), &Message{To: []string{"an@example.com"}, Subject: "Hi", Text: "Hi"})
+	assert.ErrorContains(t, err, "connect to smtp server")
+}
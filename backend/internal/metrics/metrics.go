@@ -0,0 +1,115 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes the names of the application's metrics
+const Namespace = "finance_tracker"
+
+// Results of ingesting a webhook transaction
+const (
+	WebhookResultCreated = "created" // stored
+	WebhookResultInvalid = "invalid" // rejected by validation or malformed JSON
+	WebhookResultFailed  = "failed"  // could not be stored
+)
+
+// UnknownSource labels webhook transactions without a usable source
+const UnknownSource = "unknown"
+
+// registry holds the metrics served on /metrics. It is separate from the
+// default registry so libraries cannot add metrics unnoticed.
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests by method, route and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	webhookTransactions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "webhook_transactions_total",
+		Help:      "Transactions received by the webhook by source and result.",
+	}, []string{"source", "result"})
+
+	validationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "validation_failures_total",
+		Help:      "Requests rejected by validation by field.",
+	}, []string{"field"})
+
+	analyticsQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "analytics_query_duration_seconds",
+		Help:      "Duration of analytics database queries by query.",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"query"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		webhookTransactions,
+		validationFailures,
+		analyticsQueryDuration,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// RegisterDB exports the connection pool statistics of db as go_sql_* metrics
+// labelled with name
+func RegisterDB(db *sql.DB, name string) error {
+	return registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// ObserveRequest records a finished HTTP request. route is the route
+// template, such as /api/v1/transactions/:id, to keep the number of series
+// bounded.
+func ObserveRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpRequestDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+}
+
+// CountWebhookTransactions records count transactions of source received by
+// the webhook with result
+func CountWebhookTransactions(source, result string, count int) {
+	if source == "" {
+		source = UnknownSource
+	}
+	webhookTransactions.WithLabelValues(source, result).Add(float64(count))
+}
+
+// CountValidationFailure records a request rejected because of field
+func CountValidationFailure(field string) {
+	validationFailures.WithLabelValues(field).Inc()
+}
+
+// ObserveAnalyticsQuery records the duration of an analytics query that
+// started at start; call it deferred
+func ObserveAnalyticsQuery(query string, start time.Time) {
+	analyticsQueryDuration.WithLabelValues(query).Observe(time.Since(start).Seconds())
+}
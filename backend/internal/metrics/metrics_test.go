@@ -0,0 +1,66 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scrape returns what Handler serves
+func scrape(t *testing.T) string {
+	t.Helper()
+	w := httptest.NewRecorder()
+	Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	return w.Body.String()
+}
+
+func TestObserveRequest(t *testing.T) {
+	ObserveRequest("GET", "/api/v1/transactions/:id", http.StatusNotFound, 30*time.Millisecond)
+	ObserveRequest("GET", "/api/v1/transactions/:id", http.StatusNotFound, 2*time.Second)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/api/v1/transactions/:id", "404")))
+	body := scrape(t)
+	assert.Contains(t, body, `finance_tracker_http_request_duration_seconds_bucket{method="GET",route="/api/v1/transactions/:id",status="404",le="0.05"} 1`)
+	assert.Contains(t, body, `finance_tracker_http_request_duration_seconds_count{method="GET",route="/api/v1/transactions/:id",status="404"} 2`)
+}
+
+func TestCountWebhookTransactions(t *testing.T) {
+	CountWebhookTransactions("Bank ABC", WebhookResultCreated, 3)
+	CountWebhookTransactions("", WebhookResultInvalid, 1)
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(webhookTransactions.WithLabelValues("Bank ABC", WebhookResultCreated)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(webhookTransactions.WithLabelValues(UnknownSource, WebhookResultInvalid)))
+}
+
+func TestCountValidationFailure(t *testing.T) {
+	CountValidationFailure("amount")
+
+	assert.Contains(t, scrape(t), `finance_tracker_validation_failures_total{field="amount"} 1`)
+}
+
+func TestObserveAnalyticsQuery(t *testing.T) {
+	ObserveAnalyticsQuery("summary", time.Now().Add(-20*time.Millisecond))
+
+	assert.Contains(t, scrape(t), `finance_tracker_analytics_query_duration_seconds_bucket{query="summary",le="0.025"} 1`)
+}
+
+func TestRegisterDB(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(7)
+
+	require.NoError(t, RegisterDB(db, "metrics_test"))
+
+	body := scrape(t)
+	assert.Contains(t, body, `go_sql_max_open_connections{db_name="metrics_test"} 7`)
+	assert.Contains(t, body, `go_sql_in_use_connections{db_name="metrics_test"}`)
+	assert.Error(t, RegisterDB(db, "metrics_test"), "a database is registered once")
+}
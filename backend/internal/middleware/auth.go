@@ -19,8 +19,15 @@ const (
 	UserUUIDContextKey = "user_uuid"
 )
 
+// TokenValidator validates access tokens. *security.JWTManager checks the
+// signature and expiry; the auth service also refuses tokens of inactive
+// users and of sessions revoked by a password change.
+type TokenValidator interface {
+	ValidateToken(token string) (*security.Claims, error)
+}
+
 // JWTAuth validates JWT tokens and adds user info to context
-func JWTAuth(jwtManager *security.JWTManager) gin.HandlerFunc {
+func JWTAuth(validator TokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Get Authorization header
 		authHeader := c.GetHeader("Authorization")
@@ -43,7 +50,7 @@ func JWTAuth(jwtManager *security.JWTManager) gin.HandlerFunc {
 		}
 
 		// Validate token
-		claims, err := jwtManager.ValidateToken(token)
+		claims, err := validator.ValidateToken(token)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{
 				"error": "invalid or expired token",
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -141,6 +142,42 @@ func TestJWTAuth_WrongSecret(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
+// revokingValidator refuses tokens that are valid JWTs, like the auth
+// service does for revoked sessions
+type revokingValidator struct {
+	jwtManager *security.JWTManager
+}
+
+func (v revokingValidator) ValidateToken(token string) (*security.Claims, error) {
+	if _, err := v.jwtManager.ValidateToken(token); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("session has been revoked")
+}
+
+func TestJWTAuth_ValidatorRefusesToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	jwtManager := security.NewJWTManager("test-jwt-secret-minimum-32-chars")
+	userUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	token, _ := jwtManager.GenerateToken(123, "test@example.com", userUUID)
+
+	router.Use(JWTAuth(revokingValidator{jwtManager: jwtManager}))
+	router.GET("/protected", func(c *gin.Context) {
+		c.String(http.StatusOK, "protected")
+	})
+
+	req, _ := http.NewRequest("GET", "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid or expired token")
+}
+
 // Test context helpers
 
 func TestJWTAuth_SetsUserContext(t *testing.T) {
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/metrics"
+)
+
+// unmatchedRoute labels requests that matched no route, so unknown paths
+// do not each add a series
+const unmatchedRoute = "unmatched"
+
+// standardMethods are the methods kept as labels of unmatched requests;
+// other methods are labelled OTHER for the same reason
+var standardMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// Metrics records the count and latency of requests by method, route
+// template and status code
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		method, route := c.Request.Method, c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+			if !standardMethods[method] {
+				method = "OTHER"
+			}
+		}
+		metrics.ObserveRequest(method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/metrics"
+)
+
+// scrapeMetrics returns what the metrics endpoint serves
+func scrapeMetrics() string {
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	return w.Body.String()
+}
+
+func TestMetrics_LabelsByRouteTemplate(t *testing.T) {
+	router := gin.New()
+	router.Use(Metrics())
+	router.GET("/metrics-test/items/:id", func(c *gin.Context) {
+		c.Status(http.StatusTeapot)
+	})
+
+	for _, path := range []string{"/metrics-test/items/1", "/metrics-test/items/2"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	body := scrapeMetrics()
+	assert.Contains(t, body, `finance_tracker_http_requests_total{method="GET",route="/metrics-test/items/:id",status="418"} 2`)
+	assert.Contains(t, body, `finance_tracker_http_request_duration_seconds_count{method="GET",route="/metrics-test/items/:id",status="418"} 2`)
+	assert.NotContains(t, body, "/metrics-test/items/1")
+}
+
+func TestMetrics_UnmatchedRequests(t *testing.T) {
+	router := gin.New()
+	router.Use(Metrics())
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("DELETE", "/metrics-test/nowhere", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("BREW", "/metrics-test/nowhere", nil))
+
+	body := scrapeMetrics()
+	assert.Contains(t, body, `finance_tracker_http_requests_total{method="DELETE",route="unmatched",status="404"}`)
+	assert.Contains(t, body, `finance_tracker_http_requests_total{method="OTHER",route="unmatched",status="404"}`)
+	assert.NotContains(t, body, "/metrics-test/nowhere")
+	assert.NotContains(t, body, "BREW")
+}
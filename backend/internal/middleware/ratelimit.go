@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// RateLimitStore takes tokens from the token bucket of a key
+type RateLimitStore interface {
+	Take(key string, policy domain.RateLimitPolicy) (domain.RateLimitResult, error)
+}
+
+// RateLimitKeyFunc returns the bucket key of a request; an empty key skips the limit
+type RateLimitKeyFunc func(c *gin.Context) string
+
+// ClientIPKey limits requests per client IP
+func ClientIPKey(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// UserKey limits requests per signed-in user; it must run after JWTAuth
+func UserKey(c *gin.Context) string {
+	userID, ok := GetUserID(c)
+	if !ok {
+		return ""
+	}
+	return strconv.FormatInt(userID, 10)
+}
+
+// APIKeyKey limits requests carrying the valid API key. Requests with a
+// missing or wrong key are left to the other limits, so guessing keys
+// cannot create buckets. The key is hashed before it is stored.
+func APIKeyKey(validAPIKey string) RateLimitKeyFunc {
+	return func(c *gin.Context) string {
+		apiKey := c.GetHeader(APIKeyHeader)
+		if apiKey == "" || subtle.ConstantTimeCompare([]byte(apiKey), []byte(validAPIKey)) != 1 {
+			return ""
+		}
+		sum := sha256.Sum256([]byte(apiKey))
+		return hex.EncodeToString(sum[:8])
+	}
+}
+
+// RateLimit limits requests to the token bucket policy per key and sets the
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers.
+// Denied requests get 429 with Retry-After. When the store fails, requests
+// are let through rather than taking the API down with it.
+func RateLimit(store RateLimitStore, policy domain.RateLimitPolicy, key RateLimitKeyFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !policy.Enabled() {
+			c.Next()
+			return
+		}
+		k := key(c)
+		if k == "" {
+			c.Next()
+			return
+		}
+
+		result, err := store.Take(policy.Name+":"+k, policy)
+		if err != nil {
+			log := GetLogger(c)
+			log.Error().Err(err).Str("policy", policy.Name).Msg("Rate limit check failed")
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.Itoa(ceilSeconds(result.Reset)))
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			c.JSON(http.StatusTooManyRequests, gin.H{
+				"error": "rate limit exceeded",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// ceilSeconds rounds d up to whole seconds, at least 1 for any wait
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// memorySweepInterval is how often the memory store drops full buckets
+const memorySweepInterval = time.Minute
+
+type memoryBucket struct {
+	tokens  float64
+	updated time.Time
+	full    time.Time // When the bucket has refilled completely
+}
+
+// MemoryRateLimitStore keeps token buckets in process memory, so every API
+// replica limits requests on its own
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryRateLimitStore creates an empty in-memory rate limit store
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets: make(map[string]*memoryBucket),
+		now:     time.Now,
+	}
+}
+
+// Take takes a token from the bucket of key, starting with a full bucket
+func (s *MemoryRateLimitStore) Take(key string, policy domain.RateLimitPolicy) (domain.RateLimitResult, error) {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: policy.Capacity(), updated: now}
+		s.buckets[key] = bucket
+	}
+
+	tokens, allowed := policy.Take(bucket.tokens, now.Sub(bucket.updated))
+	result := policy.Result(tokens, allowed)
+	bucket.tokens = tokens
+	bucket.updated = now
+	bucket.full = now.Add(result.Reset)
+
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely, as a missing bucket
+// starts full anyway
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		if !now.Before(bucket.full) {
+			delete(s.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// failingRateLimitStore fails every check
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(key string, policy domain.RateLimitPolicy) (domain.RateLimitResult, error) {
+	return domain.RateLimitResult{}, errors.New("connection refused")
+}
+
+func setupRateLimitRouter(store RateLimitStore, policy domain.RateLimitPolicy, key RateLimitKeyFunc) *gin.Engine {
+	router := gin.New()
+	router.Use(RateLimit(store, policy, key))
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func serveFrom(router *gin.Engine, remoteAddr string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.RemoteAddr = remoteAddr
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+// Test RateLimit
+
+func TestRateLimit_AllowsBurstThenDenies(t *testing.T) {
+	policy := domain.RateLimitPolicy{Name: "auth", Requests: 10, Period: time.Minute, Burst: 2}
+	router := setupRateLimitRouter(NewMemoryRateLimitStore(), policy, ClientIPKey)
+
+	for i := 0; i < 2; i++ {
+		w := serveFrom(router, "203.0.113.7:1234", nil)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: expected status 200, got %d", i+1, w.Code)
+		}
+	}
+	w := serveFrom(router, "203.0.113.7:1234", nil)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status 429, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "6" {
+		t.Errorf("expected Retry-After 6, got %q", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Limit"); got != "2" {
+		t.Errorf("expected X-RateLimit-Limit 2, got %q", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Remaining"); got != "0" {
+		t.Errorf("expected X-RateLimit-Remaining 0, got %q", got)
+	}
+	if got := w.Header().Get("X-RateLimit-Reset"); got != "12" {
+		t.Errorf("expected X-RateLimit-Reset 12, got %q", got)
+	}
+
+	// Another client has its own bucket
+	if w := serveFrom(router, "198.51.100.1:1234", nil); w.Code != http.StatusOK {
+		t.Errorf("expected other client to get status 200, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	router := setupRateLimitRouter(failingRateLimitStore{}, domain.RateLimitPolicy{Name: "public"}, ClientIPKey)
+
+	w := serveFrom(router, "203.0.113.7:1234", nil)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+	if w.Header().Get("X-RateLimit-Limit") != "" {
+		t.Error("expected no rate limit headers for a disabled policy")
+	}
+}
+
+func TestRateLimit_StoreErrorLetsRequestsThrough(t *testing.T) {
+	policy := domain.RateLimitPolicy{Name: "public", Requests: 1, Period: time.Minute}
+	router := setupRateLimitRouter(failingRateLimitStore{}, policy, ClientIPKey)
+
+	w := serveFrom(router, "203.0.113.7:1234", nil)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_APIKeyKeyIgnoresInvalidKeys(t *testing.T) {
+	policy := domain.RateLimitPolicy{Name: "api_key", Requests: 1, Period: time.Minute}
+	router := setupRateLimitRouter(NewMemoryRateLimitStore(), policy, APIKeyKey("test-api-key"))
+
+	for i := 0; i < 3; i++ {
+		w := serveFrom(router, "203.0.113.7:1234", map[string]string{APIKeyHeader: "wrong-key"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected requests with a wrong key to skip the limit, got %d", w.Code)
+		}
+	}
+
+	valid := map[string]string{APIKeyHeader: "test-api-key"}
+	if w := serveFrom(router, "203.0.113.7:1234", valid); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	// The same key from another address shares the bucket
+	if w := serveFrom(router, "198.51.100.1:1234", valid); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429, got %d", w.Code)
+	}
+}
+
+func TestRateLimit_UserKey(t *testing.T) {
+	policy := domain.RateLimitPolicy{Name: "user", Requests: 1, Period: time.Minute}
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-Test-User"); user != "" {
+			c.Set(UserIDContextKey, int64(len(user)))
+		}
+		c.Next()
+	})
+	router.Use(RateLimit(NewMemoryRateLimitStore(), policy, UserKey))
+	router.GET("/test", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	serveFrom(router, "203.0.113.7:1234", map[string]string{"X-Test-User": "a"})
+	if w := serveFrom(router, "198.51.100.1:1234", map[string]string{"X-Test-User": "a"}); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected the user's second request to get status 429, got %d", w.Code)
+	}
+	if w := serveFrom(router, "203.0.113.7:1234", map[string]string{"X-Test-User": "bb"}); w.Code != http.StatusOK {
+		t.Errorf("expected another user to get status 200, got %d", w.Code)
+	}
+}
+
+// Test MemoryRateLimitStore
+
+func TestMemoryRateLimitStore_RefillsAndSweeps(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryRateLimitStore()
+	store.now = func() time.Time { return now }
+	policy := domain.RateLimitPolicy{Name: "auth", Requests: 1, Period: time.Second}
+
+	if result, _ := store.Take("auth:a", policy); !result.Allowed {
+		t.Fatal("expected the first request to be allowed")
+	}
+	if result, _ := store.Take("auth:a", policy); result.Allowed || result.RetryAfter != time.Second {
+		t.Fatalf("expected the second request to wait 1s, got %+v", result)
+	}
+
+	now = now.Add(time.Second)
+	if result, _ := store.Take("auth:a", policy); !result.Allowed {
+		t.Fatal("expected a token to be refilled after 1s")
+	}
+
+	now = now.Add(2 * memorySweepInterval)
+	store.Take("auth:b", policy)
+	if _, ok := store.buckets["auth:a"]; ok {
+		t.Error("expected the refilled bucket to be swept")
+	}
+}
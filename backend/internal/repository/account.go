@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// AccountRepository handles deactivation and removal of user accounts
+type AccountRepository interface {
+	Deactivate(userID int64, now time.Time, deleteAt *time.Time) error
+	Reactivate(userID int64) error
+	FindDueForDeletion(now time.Time, limit int) ([]domain.User, error)
+	Delete(user *domain.User) error
+}
+
+type accountRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountRepository creates a new account repository
+func NewAccountRepository(db *gorm.DB) AccountRepository {
+	return &accountRepository{db: db}
+}
+
+// Deactivate marks an active account inactive at now and revokes its
+// sessions. A non-nil deleteAt schedules the removal of its sign-in account.
+func (r *accountRepository) Deactivate(userID int64, now time.Time, deleteAt *time.Time) error {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND is_active", userID).
+		Updates(map[string]interface{}{
+			"is_active":             false,
+			"deactivated_at":        now,
+			"sessions_revoked_at":   now,
+			"deletion_scheduled_at": deleteAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// Reactivate marks an account active again and cancels its removal
+func (r *accountRepository) Reactivate(userID int64) error {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"is_active":             true,
+			"deactivated_at":        nil,
+			"deletion_scheduled_at": nil,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// FindDueForDeletion returns up to limit inactive accounts whose removal is
+// due at now, oldest first
+func (r *accountRepository) FindDueForDeletion(now time.Time, limit int) ([]domain.User, error) {
+	var users []domain.User
+	err := r.db.Where("NOT is_active AND deletion_scheduled_at <= ?", now).
+		Order("deletion_scheduled_at ASC").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+// Delete removes the sign-in account and everything that belongs to it: its API key, digest subscription, webhook endpoints and deliveries,
+// security log, two-factor secrets, tokens, takeout archives and failed
+// login records. Ledger data is shared by all users and kept. The tables
+// are cleared one by one rather than relying on ON DELETE CASCADE, which
+// schemas created by AutoMigrate lack.
+func (r *accountRepository) Delete(user *domain.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		endpoints := tx.Model(&domain.WebhookEndpoint{}).Select("id").Where("user_id = ?", user.ID)
+		if err := tx.Where("endpoint_id IN (?)", endpoints).Delete(&domain.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+
+		owned := []interface{}{
+			&domain.WebhookEndpoint{},
+			&domain.DigestSubscription{},
+			&domain.SecurityEvent{},
+			&domain.MFARecoveryCode{},
+			&domain.UserMFA{},
+			&domain.PasswordResetToken{},
+			&domain.EmailChangeToken{},
+			&domain.Takeout{},
+		}
+		for _, model := range owned {
+			if err := tx.Where("user_id = ?", user.ID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Where("key = ?", domain.LoginAccountKey(user.Email)).Delete(&domain.LoginThrottle{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.User{}, user.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+func TestAccountRepository_Deactivate(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAccountRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	deleteAt := now.Add(30 * 24 * time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "deactivated_at"=$1,"deletion_scheduled_at"=$2,"is_active"=$3,"sessions_revoked_at"=$4,"updated_at"=$5 WHERE id = $6 AND is_active`)).
+		WithArgs(now, &deleteAt, false, now, sqlmock.AnyArg(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Deactivate(1, now, &deleteAt)
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountRepository_Deactivate_AlreadyInactive(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Deactivate(1, time.Now(), nil)
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountRepository_FindDueForDeletion(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAccountRepository(db)
+	now := time.Date(2026, 4, 9, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE NOT is_active AND deletion_scheduled_at <= $1 ORDER BY deletion_scheduled_at ASC LIMIT $2`)).
+		WithArgs(now, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email"}).AddRow(7, "gone@example.com"))
+
+	users, err := repo.FindDueForDeletion(now, 100)
+
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Equal(t, int64(7), users[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountRepository_Delete(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "webhook_deliveries" WHERE endpoint_id IN (SELECT "id" FROM "webhook_endpoints" WHERE user_id = $1)`)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 12))
+	for _, table := range []string{"webhook_endpoints", "digest_subscriptions", "security_events",
+		"mfa_recovery_codes", "user_mfa", "password_reset_tokens", "email_change_tokens", "takeouts"} {
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "` + table + `" WHERE user_id = $1`)).
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "login_throttles" WHERE key = $1`)).
+		WithArgs("account:gone@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "users" WHERE "users"."id" = $1`)).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Delete(&domain.User{ID: 7, Email: "gone@example.com"})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAccountRepository_Delete_RollsBackOnError(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAccountRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "webhook_deliveries"`)).
+		WillReturnError(assert.AnError)
+	mock.ExpectRollback()
+
+	err := repo.Delete(&domain.User{ID: 7, Email: "gone@example.com"})
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
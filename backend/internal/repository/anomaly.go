@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// AnomalyRepository handles database operations for transaction anomalies
+type AnomalyRepository interface {
+	ListExpensesBetween(since, until time.Time) ([]domain.Transaction, error)
+	Save(anomalies []domain.TransactionAnomaly) error
+	ListSince(since time.Time, reason domain.AnomalyReason) ([]domain.TransactionAnomaly, error)
+	ListTransactions(ids []int64) ([]domain.Transaction, error)
+}
+
+type anomalyRepository struct {
+	db *gorm.DB
+}
+
+// NewAnomalyRepository creates a new anomaly repository
+func NewAnomalyRepository(db *gorm.DB) AnomalyRepository {
+	return &anomalyRepository{db: db}
+}
+
+// ListExpensesBetween returns out transactions dated within [since, until], oldest first
+func (r *anomalyRepository) ListExpensesBetween(since, until time.Time) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.Where("type = ? AND transaction_date >= ? AND transaction_date <= ?", domain.TransactionTypeOut, since, until).
+		Order("transaction_date ASC, id ASC").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// Save stores anomalies and flags their transactions atomically
+func (r *anomalyRepository) Save(anomalies []domain.TransactionAnomaly) error {
+	if len(anomalies) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(anomalies))
+	for _, anomaly := range anomalies {
+		ids = append(ids, anomaly.TransactionID)
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&anomalies).Error; err != nil {
+			return err
+		}
+		return tx.Model(&domain.Transaction{}).Where("id IN ?", ids).UpdateColumn("anomalous", true).Error
+	})
+}
+
+// ListSince returns anomalies of transactions dated on or after since, newest first.
+// An empty reason returns all reasons.
+func (r *anomalyRepository) ListSince(since time.Time, reason domain.AnomalyReason) ([]domain.TransactionAnomaly, error) {
+	var anomalies []domain.TransactionAnomaly
+	query := r.db.Joins("JOIN transactions ON transactions.id = transaction_anomalies.transaction_id").
+		Where("transactions.transaction_date >= ?", since)
+	if reason != "" {
+		query = query.Where("transaction_anomalies.reason = ?", reason)
+	}
+	err := query.Order("transactions.transaction_date DESC, transaction_anomalies.id ASC").
+		Find(&anomalies).Error
+	return anomalies, err
+}
+
+// ListTransactions returns the transactions with the given IDs
+func (r *anomalyRepository) ListTransactions(ids []int64) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	if len(ids) == 0 {
+		return transactions, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&transactions).Error
+	return transactions, err
+}
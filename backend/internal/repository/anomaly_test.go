@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test ListExpensesBetween
+
+func TestAnomalyRepository_ListExpensesBetween(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAnomalyRepository(db)
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "type", "amount", "transaction_date"}).
+		AddRow(1, "out", 50.00, since.AddDate(0, 1, 0))
+
+	mock.ExpectQuery(regexp.QuoteMeta("type = $1 AND transaction_date >= $2 AND transaction_date <= $3")).
+		WithArgs(domain.TransactionTypeOut, since, until).
+		WillReturnRows(rows)
+
+	transactions, err := repo.ListExpensesBetween(since, until)
+
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test Save
+
+func TestAnomalyRepository_Save_FlagsTransactions(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAnomalyRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "transaction_anomalies"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "anomalous"=$1`)).
+		WithArgs(true, int64(7), int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Save([]domain.TransactionAnomaly{
+		{TransactionID: 7, Reason: domain.AnomalyReasonCategoryAmount},
+		{TransactionID: 7, Reason: domain.AnomalyReasonNewRecipient},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAnomalyRepository_Save_Empty(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAnomalyRepository(db)
+
+	err := repo.Save(nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test ListSince
+
+func TestAnomalyRepository_ListSince_FiltersReason(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewAnomalyRepository(db)
+
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "transaction_id", "reason"}).
+		AddRow(1, 7, "burst")
+
+	mock.ExpectQuery(regexp.QuoteMeta("JOIN transactions ON transactions.id = transaction_anomalies.transaction_id")).
+		WithArgs(since, domain.AnomalyReasonBurst).
+		WillReturnRows(rows)
+
+	anomalies, err := repo.ListSince(since, domain.AnomalyReasonBurst)
+
+	assert.NoError(t, err)
+	assert.Len(t, anomalies, 1)
+	assert.Equal(t, int64(7), anomalies[0].TransactionID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrBudgetNotFound is returned when a budget is not found
+	ErrBudgetNotFound = errors.New("budget not found")
+	// ErrBudgetAlreadyExists is returned when a budget for the category and source already exists
+	ErrBudgetAlreadyExists = errors.New("budget for this category and source already exists")
+)
+
+// BudgetRepository handles database operations for budgets
+type BudgetRepository interface {
+	Create(budget *domain.Budget) error
+	FindByID(id int64) (*domain.Budget, error)
+	List() ([]domain.Budget, error)
+	Update(budget *domain.Budget) error
+	Delete(id int64) error
+	GetCategorySpending(start, end time.Time) ([]domain.CategorySpending, error)
+}
+
+type budgetRepository struct {
+	db        *gorm.DB
+	sanitizer *security.Sanitizer
+}
+
+// NewBudgetRepository creates a new budget repository
+func NewBudgetRepository(db *gorm.DB) BudgetRepository {
+	return &budgetRepository{
+		db:        db,
+		sanitizer: security.NewSanitizer(),
+	}
+}
+
+func (r *budgetRepository) Create(budget *domain.Budget) error {
+	budget.Source = r.sanitizer.CleanInput(budget.Source, domain.MaxSourceLength)
+
+	// Check if a budget for this category and source already exists
+	var existing domain.Budget
+	err := r.db.Where("category = ? AND source = ?", budget.Category, budget.Source).First(&existing).Error
+	if err == nil {
+		return ErrBudgetAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return r.db.Create(budget).Error
+}
+
+func (r *budgetRepository) FindByID(id int64) (*domain.Budget, error) {
+	var budget domain.Budget
+	err := r.db.First(&budget, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBudgetNotFound
+		}
+		return nil, err
+	}
+
+	return &budget, nil
+}
+
+func (r *budgetRepository) List() ([]domain.Budget, error) {
+	var budgets []domain.Budget
+	err := r.db.Order("category ASC, source ASC").Find(&budgets).Error
+	return budgets, err
+}
+
+func (r *budgetRepository) Update(budget *domain.Budget) error {
+	budget.Source = r.sanitizer.CleanInput(budget.Source, domain.MaxSourceLength)
+
+	// Another budget may already own the new category and source
+	var existing domain.Budget
+	err := r.db.Where("category = ? AND source = ? AND id <> ?", budget.Category, budget.Source, budget.ID).
+		First(&existing).Error
+	if err == nil {
+		return ErrBudgetAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	return r.db.Save(budget).Error
+}
+
+func (r *budgetRepository) Delete(id int64) error {
+	result := r.db.Delete(&domain.Budget{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBudgetNotFound
+	}
+	return nil
+}
+
+// GetCategorySpending returns expenses in the period grouped by category and source.
+// Category labels match the category breakdown, so empty categories are "Uncategorized".
+func (r *budgetRepository) GetCategorySpending(start, end time.Time) ([]domain.CategorySpending, error) {
+	var results []domain.CategorySpending
+
+	// Hardcoded SQL; the period bounds are bound parameters
+	query := `
+		SELECT
+			COALESCE(NULLIF(category, ''), 'Uncategorized') as category,
+			source,
+			COALESCE(SUM(amount), 0) as amount
+		FROM transactions
+		WHERE type = 'out' AND transaction_date >= ? AND transaction_date <= ?
+		GROUP BY 1, source
+	`
+
+	err := r.db.Raw(query, start, end).Scan(&results).Error
+	return results, err
+}
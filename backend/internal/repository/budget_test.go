@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test Create
+
+func TestBudgetRepository_Create_Success(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewBudgetRepository(db)
+
+	budget := &domain.Budget{Category: "Food", Amount: 5000000}
+
+	// Mock check for existing budget
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.Create(budget)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), budget.ID)
+}
+
+func TestBudgetRepository_Create_Duplicate(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewBudgetRepository(db)
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "category"}).AddRow(1, "Food"))
+
+	err := repo.Create(&domain.Budget{Category: "Food", Amount: 100})
+
+	assert.ErrorIs(t, err, ErrBudgetAlreadyExists)
+}
+
+// Test FindByID
+
+func TestBudgetRepository_FindByID_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewBudgetRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	budget, err := repo.FindByID(99)
+
+	assert.ErrorIs(t, err, ErrBudgetNotFound)
+	assert.Nil(t, budget)
+}
+
+// Test Delete
+
+func TestBudgetRepository_Delete_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewBudgetRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.Delete(99)
+
+	assert.ErrorIs(t, err, ErrBudgetNotFound)
+}
+
+// Test GetCategorySpending
+
+func TestBudgetRepository_GetCategorySpending(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewBudgetRepository(db)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0).Add(-time.Nanosecond)
+
+	rows := sqlmock.NewRows([]string{"category", "source", "amount"}).
+		AddRow("Food", "Bank ABC", 120.00).
+		AddRow("Uncategorized", "MoMo", 30.00)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE type = 'out'")).
+		WithArgs(start, end).
+		WillReturnRows(rows)
+
+	spending, err := repo.GetCategorySpending(start, end)
+
+	assert.NoError(t, err)
+	assert.Len(t, spending, 2)
+	assert.Equal(t, "Food", spending[0].Category)
+	assert.Equal(t, 120.00, spending[0].Amount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBudgetRepository_GetCategorySpending_DatabaseError(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewBudgetRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.GetCategorySpending(time.Now(), time.Now())
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// ErrDigestSubscriptionNotFound is returned when a user has no digest subscription
+var ErrDigestSubscriptionNotFound = errors.New("digest subscription not found")
+
+// DigestRepository handles database operations for digest subscriptions
+type DigestRepository interface {
+	FindByUserID(userID int64) (*domain.DigestSubscription, error)
+	Save(subscription *domain.DigestSubscription) error
+	ListSubscribed() ([]domain.DigestSubscription, error)
+	ClaimPeriod(userID int64, frequency domain.DigestFrequency, period string) (bool, error)
+	ReleasePeriod(userID int64, frequency domain.DigestFrequency, period, previous string) error
+	MarkSent(userID int64, frequency domain.DigestFrequency, period string, sentAt time.Time) error
+}
+
+type digestRepository struct {
+	db *gorm.DB
+}
+
+// NewDigestRepository creates a new digest repository
+func NewDigestRepository(db *gorm.DB) DigestRepository {
+	return &digestRepository{db: db}
+}
+
+func (r *digestRepository) FindByUserID(userID int64) (*domain.DigestSubscription, error) {
+	var subscription domain.DigestSubscription
+	err := r.db.Where("user_id = ?", userID).First(&subscription).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDigestSubscriptionNotFound
+		}
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// Save creates or updates a subscription's choices and last periods
+func (r *digestRepository) Save(subscription *domain.DigestSubscription) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"weekly", "monthly", "last_weekly_period", "last_monthly_period", "updated_at"}),
+	}).Create(subscription).Error
+}
+
+// ListSubscribed returns the subscriptions of active users receiving any digest
+func (r *digestRepository) ListSubscribed() ([]domain.DigestSubscription, error) {
+	var subscriptions []domain.DigestSubscription
+	err := r.db.Joins("JOIN users ON users.id = digest_subscriptions.user_id").
+		Where("users.is_active AND (digest_subscriptions.weekly OR digest_subscriptions.monthly)").
+		Order("digest_subscriptions.user_id ASC").
+		Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// ClaimPeriod records the period as the user's last one before its digest is
+// sent, unless it is recorded already. Only one of the runs of several
+// replicas gets the claim, so each digest is sent once; it reports whether
+// this one did.
+func (r *digestRepository) ClaimPeriod(userID int64, frequency domain.DigestFrequency, period string) (bool, error) {
+	column := digestPeriodColumn(frequency)
+	result := r.db.Model(&domain.DigestSubscription{}).
+		Where("user_id = ? AND "+column+" < ?", userID, period).
+		UpdateColumn(column, period)
+	return result.RowsAffected > 0, result.Error
+}
+
+// ReleasePeriod puts back the previous period of a claim whose digest could
+// not be sent, so the next run retries it
+func (r *digestRepository) ReleasePeriod(userID int64, frequency domain.DigestFrequency, period, previous string) error {
+	column := digestPeriodColumn(frequency)
+	return r.db.Model(&domain.DigestSubscription{}).
+		Where("user_id = ? AND "+column+" = ?", userID, period).
+		UpdateColumn(column, previous).Error
+}
+
+// MarkSent records that the digest of a period has been sent
+func (r *digestRepository) MarkSent(userID int64, frequency domain.DigestFrequency, period string, sentAt time.Time) error {
+	column := digestPeriodColumn(frequency)
+
+	return r.db.Model(&domain.DigestSubscription{}).
+		Where("user_id = ?", userID).
+		UpdateColumns(map[string]interface{}{column: period, "last_sent_at": sentAt}).Error
+}
+
+// digestPeriodColumn names the column holding the last period of frequency
+func digestPeriodColumn(frequency domain.DigestFrequency) string {
+	if frequency == domain.DigestWeekly {
+		return "last_weekly_period"
+	}
+	return "last_monthly_period"
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test FindByUserID
+
+func TestDigestRepository_FindByUserID_Success(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewDigestRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "digest_subscriptions" WHERE user_id = $1`)).
+		WithArgs(int64(7), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "weekly", "monthly", "last_weekly_period"}).
+			AddRow(7, true, false, "2026-W10"))
+
+	subscription, err := repo.FindByUserID(7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), subscription.UserID)
+	assert.True(t, subscription.Weekly)
+	assert.Equal(t, "2026-W10", subscription.LastWeeklyPeriod)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDigestRepository_FindByUserID_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewDigestRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	subscription, err := repo.FindByUserID(7)
+
+	assert.ErrorIs(t, err, ErrDigestSubscriptionNotFound)
+	assert.Nil(t, subscription)
+}
+
+// Test Save
+
+func TestDigestRepository_Save_Upsert(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewDigestRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "digest_subscriptions"`) + `.*` +
+		regexp.QuoteMeta(`ON CONFLICT ("user_id") DO UPDATE SET "weekly"="excluded"."weekly"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Save(&domain.DigestSubscription{UserID: 7, Weekly: true, LastWeeklyPeriod: "2026-W10"})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test ListSubscribed
+
+func TestDigestRepository_ListSubscribed(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewDigestRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`JOIN users ON users.id = digest_subscriptions.user_id WHERE users.is_active AND (digest_subscriptions.weekly OR digest_subscriptions.monthly)`)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "weekly", "monthly"}).
+			AddRow(1, true, false).
+			AddRow(2, false, true))
+
+	subscriptions, err := repo.ListSubscribed()
+
+	assert.NoError(t, err)
+	assert.Len(t, subscriptions, 2)
+	assert.True(t, subscriptions[1].Monthly)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test ClaimPeriod
+
+func TestDigestRepository_ClaimPeriod(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		want     bool
+	}{
+		{"claimed", 1, true},
+		{"claimed by another run", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, sqlDB := setupMockDB(t)
+			defer sqlDB.Close()
+
+			repo := NewDigestRepository(db)
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta(`UPDATE "digest_subscriptions" SET "last_monthly_period"=$1 WHERE user_id = $2 AND last_monthly_period < $3`)).
+				WithArgs("2026-02", int64(7), "2026-02").
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+			mock.ExpectCommit()
+
+			claimed, err := repo.ClaimPeriod(7, domain.DigestMonthly, "2026-02")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, claimed)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDigestRepository_ReleasePeriod(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewDigestRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "digest_subscriptions" SET "last_weekly_period"=$1 WHERE user_id = $2 AND last_weekly_period = $3`)).
+		WithArgs("2026-W09", int64(7), "2026-W10").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.ReleasePeriod(7, domain.DigestWeekly, "2026-W10", "2026-W09")
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test MarkSent
+
+func TestDigestRepository_MarkSent(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewDigestRepository(db)
+	sentAt := time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "digest_subscriptions" SET "last_sent_at"=$1,"last_weekly_period"=$2 WHERE user_id = $3`)).
+		WithArgs(sentAt, "2026-W10", int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.MarkSent(7, domain.DigestWeekly, "2026-W10", sentAt)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
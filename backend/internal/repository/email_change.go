@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+var (
+	// ErrEmailChangeTokenNotFound is returned when a confirmation token is unknown, used or expired
+	ErrEmailChangeTokenNotFound = errors.New("invalid or expired confirmation token")
+)
+
+// consumeEmailChangeTokenSQL marks a valid token as used in one statement,
+// so concurrent requests cannot both use it
+const consumeEmailChangeTokenSQL = `
+UPDATE email_change_tokens SET used_at = @now
+WHERE token_hash = @hash AND used_at IS NULL AND expires_at > @now
+RETURNING id, user_id, new_email, token_hash, expires_at, used_at, created_at`
+
+// EmailChangeRepository handles database operations for email change confirmations
+type EmailChangeRepository interface {
+	Create(token *domain.EmailChangeToken) error
+	FindPending(userID int64, now time.Time) (*domain.EmailChangeToken, error)
+	Consume(tokenHash string, now time.Time) (*domain.EmailChangeToken, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type emailChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailChangeRepository creates a new email change repository
+func NewEmailChangeRepository(db *gorm.DB) EmailChangeRepository {
+	return &emailChangeRepository{db: db}
+}
+
+// Create stores a new token, invalidating the unused tokens the user was sent before
+func (r *emailChangeRepository) Create(token *domain.EmailChangeToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND used_at IS NULL", token.UserID).Delete(&domain.EmailChangeToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// FindPending returns the unused token of the user that is still valid at now
+func (r *emailChangeRepository) FindPending(userID int64, now time.Time) (*domain.EmailChangeToken, error) {
+	var token domain.EmailChangeToken
+	err := r.db.Where("user_id = ? AND used_at IS NULL AND expires_at > ?", userID, now).
+		Order("created_at DESC").
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEmailChangeTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Consume marks the token with tokenHash as used and returns it, unless it
+// was used before or has expired at now
+func (r *emailChangeRepository) Consume(tokenHash string, now time.Time) (*domain.EmailChangeToken, error) {
+	var token domain.EmailChangeToken
+	err := r.db.Raw(consumeEmailChangeTokenSQL, map[string]interface{}{
+		"hash": tokenHash,
+		"now":  now,
+	}).Scan(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	if token.ID == 0 {
+		return nil, ErrEmailChangeTokenNotFound
+	}
+	return &token, nil
+}
+
+// DeleteExpired deletes the tokens that can no longer be used
+func (r *emailChangeRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&domain.EmailChangeToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmailChangeRepository_FindPending(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEmailChangeRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "email_change_tokens" WHERE user_id = $1 AND used_at IS NULL AND expires_at > $2 ORDER BY created_at DESC`)).
+		WithArgs(int64(1), now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "new_email"}).AddRow(3, 1, "new@example.com"))
+
+	token, err := repo.FindPending(1, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", token.NewEmail)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmailChangeRepository_FindPending_None(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEmailChangeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "email_change_tokens"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	token, err := repo.FindPending(1, time.Now())
+
+	assert.Nil(t, token)
+	assert.ErrorIs(t, err, ErrEmailChangeTokenNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmailChangeRepository_Consume(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEmailChangeRepository(db)
+	now := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE email_change_tokens SET used_at = $1`)).
+		WithArgs(now, "hash", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "new_email", "token_hash", "expires_at", "used_at"}).
+			AddRow(3, 1, "new@example.com", "hash", now.Add(time.Hour), now))
+
+	token, err := repo.Consume("hash", now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), token.UserID)
+	assert.Equal(t, "new@example.com", token.NewEmail)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEmailChangeRepository_Consume_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEmailChangeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE email_change_tokens SET used_at = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "new_email", "token_hash", "expires_at", "used_at"}))
+
+	token, err := repo.Consume("hash", time.Now())
+
+	assert.Nil(t, token)
+	assert.ErrorIs(t, err, ErrEmailChangeTokenNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
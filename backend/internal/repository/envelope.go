@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrEnvelopeNotFound is returned when an envelope is not found
+	ErrEnvelopeNotFound = errors.New("envelope not found")
+	// ErrEnvelopeAlreadyExists is returned when an envelope with the same name or category already exists
+	ErrEnvelopeAlreadyExists = errors.New("envelope with this name or category already exists")
+)
+
+// EnvelopeRepository handles database operations for envelopes, allocations and moves
+type EnvelopeRepository interface {
+	Create(envelope *domain.Envelope) error
+	FindByID(id int64) (*domain.Envelope, error)
+	List() ([]domain.Envelope, error)
+	Update(envelope *domain.Envelope) error
+	Delete(id int64) error
+	CreateAllocation(allocation *domain.EnvelopeAllocation) error
+	ListAllocations(envelopeID int64) ([]domain.EnvelopeAllocation, error)
+	SumAllocationsForTransaction(transactionID int64) (float64, error)
+	CreateMove(move *domain.EnvelopeMove) error
+	GetMonthlyAllocations() ([]domain.EnvelopeMonthAmount, error)
+	GetMonthlyMoves() ([]domain.EnvelopeMoveTotal, error)
+	GetMonthlyCategorySpending() ([]domain.CategoryMonthAmount, error)
+	GetMonthlyIncome() ([]domain.MonthAmount, error)
+}
+
+type envelopeRepository struct {
+	db        *gorm.DB
+	sanitizer *security.Sanitizer
+}
+
+// NewEnvelopeRepository creates a new envelope repository
+func NewEnvelopeRepository(db *gorm.DB) EnvelopeRepository {
+	return &envelopeRepository{
+		db:        db,
+		sanitizer: security.NewSanitizer(),
+	}
+}
+
+func (r *envelopeRepository) Create(envelope *domain.Envelope) error {
+	envelope.Name = r.sanitizer.CleanInput(envelope.Name, domain.MaxEnvelopeNameLength)
+
+	if err := r.checkDuplicate(envelope); err != nil {
+		return err
+	}
+
+	return r.db.Create(envelope).Error
+}
+
+func (r *envelopeRepository) FindByID(id int64) (*domain.Envelope, error) {
+	var envelope domain.Envelope
+	err := r.db.First(&envelope, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrEnvelopeNotFound
+		}
+		return nil, err
+	}
+
+	return &envelope, nil
+}
+
+func (r *envelopeRepository) List() ([]domain.Envelope, error) {
+	var envelopes []domain.Envelope
+	err := r.db.Order("name ASC").Find(&envelopes).Error
+	return envelopes, err
+}
+
+func (r *envelopeRepository) Update(envelope *domain.Envelope) error {
+	envelope.Name = r.sanitizer.CleanInput(envelope.Name, domain.MaxEnvelopeNameLength)
+
+	if err := r.checkDuplicate(envelope); err != nil {
+		return err
+	}
+
+	return r.db.Save(envelope).Error
+}
+
+// Delete removes an envelope together with its allocations and moves
+func (r *envelopeRepository) Delete(id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("envelope_id = ?", id).Delete(&domain.EnvelopeAllocation{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("from_envelope_id = ? OR to_envelope_id = ?", id, id).Delete(&domain.EnvelopeMove{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.Envelope{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrEnvelopeNotFound
+		}
+		return nil
+	})
+}
+
+func (r *envelopeRepository) CreateAllocation(allocation *domain.EnvelopeAllocation) error {
+	allocation.Note = r.sanitizer.CleanInput(allocation.Note, domain.MaxNoteLength)
+	return r.db.Create(allocation).Error
+}
+
+func (r *envelopeRepository) ListAllocations(envelopeID int64) ([]domain.EnvelopeAllocation, error) {
+	var allocations []domain.EnvelopeAllocation
+	err := r.db.Where("envelope_id = ?", envelopeID).
+		Order("month DESC, id DESC").
+		Find(&allocations).Error
+	return allocations, err
+}
+
+// SumAllocationsForTransaction returns how much of an income transaction is already allocated
+func (r *envelopeRepository) SumAllocationsForTransaction(transactionID int64) (float64, error) {
+	var total float64
+	err := r.db.Model(&domain.EnvelopeAllocation{}).
+		Where("transaction_id = ?", transactionID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+func (r *envelopeRepository) CreateMove(move *domain.EnvelopeMove) error {
+	move.Note = r.sanitizer.CleanInput(move.Note, domain.MaxNoteLength)
+	return r.db.Create(move).Error
+}
+
+func (r *envelopeRepository) GetMonthlyAllocations() ([]domain.EnvelopeMonthAmount, error) {
+	var results []domain.EnvelopeMonthAmount
+
+	// Raw SQL is safe here - no user input, hardcoded query
+	query := `
+		SELECT
+			month,
+			envelope_id,
+			COALESCE(SUM(amount), 0) as amount
+		FROM envelope_allocations
+		GROUP BY month, envelope_id
+		ORDER BY month
+	`
+
+	err := r.db.Raw(query).Scan(&results).Error
+	return results, err
+}
+
+func (r *envelopeRepository) GetMonthlyMoves() ([]domain.EnvelopeMoveTotal, error) {
+	var results []domain.EnvelopeMoveTotal
+
+	query := `
+		SELECT
+			month,
+			from_envelope_id,
+			to_envelope_id,
+			COALESCE(SUM(amount), 0) as amount
+		FROM envelope_moves
+		GROUP BY month, from_envelope_id, to_envelope_id
+		ORDER BY month
+	`
+
+	err := r.db.Raw(query).Scan(&results).Error
+	return results, err
+}
+
+// GetMonthlyCategorySpending returns expenses per category and month from the transactions table
+func (r *envelopeRepository) GetMonthlyCategorySpending() ([]domain.CategoryMonthAmount, error) {
+	var results []domain.CategoryMonthAmount
+
+	query := `
+		SELECT
+			TO_CHAR(transaction_date, 'YYYY-MM') as month,
+			category,
+			COALESCE(SUM(amount), 0) as amount
+		FROM transactions
+		WHERE type = 'out' AND category IN (SELECT category FROM envelopes)
+		GROUP BY month, category
+		ORDER BY month
+	`
+
+	err := r.db.Raw(query).Scan(&results).Error
+	return results, err
+}
+
+// GetMonthlyIncome returns total income per month from the transactions table
+func (r *envelopeRepository) GetMonthlyIncome() ([]domain.MonthAmount, error) {
+	var results []domain.MonthAmount
+
+	query := `
+		SELECT
+			TO_CHAR(transaction_date, 'YYYY-MM') as month,
+			COALESCE(SUM(amount), 0) as amount
+		FROM transactions
+		WHERE type = 'in'
+		GROUP BY month
+		ORDER BY month
+	`
+
+	err := r.db.Raw(query).Scan(&results).Error
+	return results, err
+}
+
+// checkDuplicate ensures no other envelope uses the same name or category
+func (r *envelopeRepository) checkDuplicate(envelope *domain.Envelope) error {
+	var existing domain.Envelope
+	err := r.db.Where("(name = ? OR category = ?) AND id <> ?", envelope.Name, envelope.Category, envelope.ID).
+		First(&existing).Error
+	if err == nil {
+		return ErrEnvelopeAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test Create
+
+func TestEnvelopeRepository_Create_Success(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEnvelopeRepository(db)
+
+	envelope := &domain.Envelope{Name: "Groceries", Category: "Food"}
+
+	// Mock check for existing envelope
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.Create(envelope)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), envelope.ID)
+}
+
+func TestEnvelopeRepository_Create_Duplicate(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEnvelopeRepository(db)
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "category"}).AddRow(1, "Groceries", "Food"))
+
+	err := repo.Create(&domain.Envelope{Name: "Eating out", Category: "Food"})
+
+	assert.ErrorIs(t, err, ErrEnvelopeAlreadyExists)
+}
+
+// Test FindByID
+
+func TestEnvelopeRepository_FindByID_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEnvelopeRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	envelope, err := repo.FindByID(99)
+
+	assert.ErrorIs(t, err, ErrEnvelopeNotFound)
+	assert.Nil(t, envelope)
+}
+
+// Test Delete
+
+func TestEnvelopeRepository_Delete_RemovesAllocationsAndMoves(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEnvelopeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "envelope_allocations"`)).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "envelope_moves"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "envelopes"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Delete(3)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnvelopeRepository_Delete_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEnvelopeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Delete(99)
+
+	assert.ErrorIs(t, err, ErrEnvelopeNotFound)
+}
+
+// Test SumAllocationsForTransaction
+
+func TestEnvelopeRepository_SumAllocationsForTransaction(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEnvelopeRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta("COALESCE(SUM(amount), 0)")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(750.00))
+
+	total, err := repo.SumAllocationsForTransaction(5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 750.00, total)
+}
+
+// Test GetMonthlyCategorySpending
+
+func TestEnvelopeRepository_GetMonthlyCategorySpending(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEnvelopeRepository(db)
+
+	rows := sqlmock.NewRows([]string{"month", "category", "amount"}).
+		AddRow("2026-01", "Food", 320.00).
+		AddRow("2026-02", "Food", 410.00)
+
+	mock.ExpectQuery(regexp.QuoteMeta("category IN (SELECT category FROM envelopes)")).
+		WillReturnRows(rows)
+
+	spending, err := repo.GetMonthlyCategorySpending()
+
+	assert.NoError(t, err)
+	assert.Len(t, spending, 2)
+	assert.Equal(t, "2026-02", spending[1].Month)
+	assert.Equal(t, 410.00, spending[1].Amount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnvelopeRepository_GetMonthlyIncome_DatabaseError(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewEnvelopeRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.GetMonthlyIncome()
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"errors"
+	"regexp"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrGoalNotFound is returned when a goal is not found
+	ErrGoalNotFound = errors.New("goal not found")
+	// ErrGoalAlreadyExists is returned when a goal with the same name already exists
+	ErrGoalAlreadyExists = errors.New("goal with this name already exists")
+)
+
+// GoalRepository handles database operations for savings goals and their contributions
+type GoalRepository interface {
+	Create(goal *domain.Goal) error
+	FindByID(id int64) (*domain.Goal, error)
+	List() ([]domain.Goal, error)
+	Update(goal *domain.Goal) error
+	Delete(id int64) error
+	CreateContribution(contribution *domain.GoalContribution) error
+	ListContributions(goalID int64) ([]domain.GoalContribution, error)
+	SumContributions(goalID int64) (float64, error)
+	SumTransactionContributions(goal *domain.Goal) (float64, error)
+}
+
+type goalRepository struct {
+	db        *gorm.DB
+	sanitizer *security.Sanitizer
+}
+
+// NewGoalRepository creates a new goal repository
+func NewGoalRepository(db *gorm.DB) GoalRepository {
+	return &goalRepository{
+		db:        db,
+		sanitizer: security.NewSanitizer(),
+	}
+}
+
+func (r *goalRepository) Create(goal *domain.Goal) error {
+	r.sanitize(goal)
+
+	if err := r.checkDuplicate(goal); err != nil {
+		return err
+	}
+
+	return r.db.Create(goal).Error
+}
+
+func (r *goalRepository) FindByID(id int64) (*domain.Goal, error) {
+	var goal domain.Goal
+	err := r.db.First(&goal, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGoalNotFound
+		}
+		return nil, err
+	}
+
+	return &goal, nil
+}
+
+func (r *goalRepository) List() ([]domain.Goal, error) {
+	var goals []domain.Goal
+	err := r.db.Order("target_date ASC, id ASC").Find(&goals).Error
+	return goals, err
+}
+
+func (r *goalRepository) Update(goal *domain.Goal) error {
+	r.sanitize(goal)
+
+	if err := r.checkDuplicate(goal); err != nil {
+		return err
+	}
+
+	return r.db.Save(goal).Error
+}
+
+// Delete removes a goal together with its manual contributions
+func (r *goalRepository) Delete(id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("goal_id = ?", id).Delete(&domain.GoalContribution{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.Goal{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrGoalNotFound
+		}
+		return nil
+	})
+}
+
+func (r *goalRepository) CreateContribution(contribution *domain.GoalContribution) error {
+	contribution.Note = r.sanitizer.CleanInput(contribution.Note, domain.MaxNoteLength)
+	return r.db.Create(contribution).Error
+}
+
+func (r *goalRepository) ListContributions(goalID int64) ([]domain.GoalContribution, error) {
+	var contributions []domain.GoalContribution
+	err := r.db.Where("goal_id = ?", goalID).
+		Order("contribution_date DESC, id DESC").
+		Find(&contributions).Error
+	return contributions, err
+}
+
+// SumContributions returns the net manual contributions to a goal
+func (r *goalRepository) SumContributions(goalID int64) (float64, error) {
+	var total float64
+	err := r.db.Model(&domain.GoalContribution{}).
+		Where("goal_id = ?", goalID).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
+
+// SumTransactionContributions returns the net amount transactions have added to a
+// goal since its start date. Money received by the linked account counts as saved
+// and money leaving it as withdrawn; for transactions matched by tag or category
+// only, expenses count as saved (money set aside) and income as withdrawn.
+func (r *goalRepository) SumTransactionContributions(goal *domain.Goal) (float64, error) {
+	if goal.SourceAccount == "" && goal.Tag == "" && goal.Category == "" {
+		return 0, nil
+	}
+
+	query := `
+		SELECT COALESCE(SUM(
+			CASE
+				WHEN ? <> '' AND source_account = ? THEN
+					CASE WHEN type = 'in' THEN amount ELSE -amount END
+				ELSE
+					CASE WHEN type = 'out' THEN amount ELSE -amount END
+			END
+		), 0) as total
+		FROM transactions
+		WHERE transaction_date >= ?
+			AND (
+				(? <> '' AND source_account = ?)
+				OR (? <> '' AND description ~* ?)
+				OR (? <> '' AND category = ?)
+			)
+	`
+
+	var total float64
+	err := r.db.Raw(query,
+		goal.SourceAccount, goal.SourceAccount,
+		goal.StartDate,
+		goal.SourceAccount, goal.SourceAccount,
+		goal.Tag, goalTagRegexp(goal.Tag),
+		goal.Category, goal.Category,
+	).Scan(&total).Error
+	return total, err
+}
+
+// sanitize cleans free-text fields before they are stored
+func (r *goalRepository) sanitize(goal *domain.Goal) {
+	goal.Name = r.sanitizer.CleanInput(goal.Name, domain.MaxGoalNameLength)
+	goal.SourceAccount = r.sanitizer.CleanInput(goal.SourceAccount, domain.MaxAccountLength)
+}
+
+// checkDuplicate ensures no other goal uses the same name
+func (r *goalRepository) checkDuplicate(goal *domain.Goal) error {
+	var existing domain.Goal
+	err := r.db.Where("name = ? AND id <> ?", goal.Name, goal.ID).First(&existing).Error
+	if err == nil {
+		return ErrGoalAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+// goalTagRegexp returns the case-insensitive pattern matching #tag in a
+// description, followed by a character that cannot be part of a tag or by the
+// end, so #car does not match #carpool or #car_loan
+func goalTagRegexp(tag string) string {
+	return "#" + regexp.QuoteMeta(tag) + "([^A-Za-z0-9_-]|$)"
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test Create
+
+func TestGoalRepository_Create_Success(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewGoalRepository(db)
+
+	goal := &domain.Goal{Name: "Vacation", TargetAmount: 1000}
+
+	// Mock check for existing goal
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.Create(goal)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), goal.ID)
+}
+
+func TestGoalRepository_Create_Duplicate(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewGoalRepository(db)
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "Vacation"))
+
+	err := repo.Create(&domain.Goal{Name: "Vacation", TargetAmount: 1000})
+
+	assert.ErrorIs(t, err, ErrGoalAlreadyExists)
+}
+
+// Test FindByID
+
+func TestGoalRepository_FindByID_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewGoalRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	goal, err := repo.FindByID(99)
+
+	assert.ErrorIs(t, err, ErrGoalNotFound)
+	assert.Nil(t, goal)
+}
+
+// Test Delete
+
+func TestGoalRepository_Delete_RemovesContributions(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewGoalRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "goal_contributions"`)).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "goals"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Delete(3)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGoalRepository_Delete_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewGoalRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Delete(99)
+
+	assert.ErrorIs(t, err, ErrGoalNotFound)
+}
+
+// Test SumTransactionContributions
+
+func TestGoalRepository_SumTransactionContributions(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewGoalRepository(db)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	goal := &domain.Goal{ID: 1, StartDate: start, SourceAccount: "SAVINGS-01", Tag: "Vacation"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("description ~* $7")).
+		WithArgs("SAVINGS-01", "SAVINGS-01", start, "SAVINGS-01", "SAVINGS-01", "Vacation", "#Vacation([^A-Za-z0-9_-]|$)", "", "").
+		WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(4500.00))
+
+	total, err := repo.SumTransactionContributions(goal)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4500.00, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGoalTagRegexp(t *testing.T) {
+	tests := []struct {
+		tag         string
+		description string
+		want        bool
+	}{
+		{"car", "Savings #car", true},
+		{"car", "#CAR, march", true},
+		{"car", "#car-fund top up", false},
+		{"car", "#carpool to work", false},
+		{"car", "#car_loan repayment", false},
+		{"car_loan", "#car_loan repayment", true},
+		{"car_loan", "#carXloan repayment", false},
+		{"car_loan", "#car_loans", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag+" in "+tt.description, func(t *testing.T) {
+			// Postgres ~* matches like a case-insensitive Go regexp for these patterns
+			pattern := regexp.MustCompile("(?i)" + goalTagRegexp(tt.tag))
+			assert.Equal(t, tt.want, pattern.MatchString(tt.description))
+		})
+	}
+}
+
+func TestGoalRepository_SumTransactionContributions_Unlinked(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewGoalRepository(db)
+
+	total, err := repo.SumTransactionContributions(&domain.Goal{ID: 1})
+
+	assert.NoError(t, err)
+	assert.Zero(t, total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGoalRepository_SumContributions_DatabaseError(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewGoalRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnError(sql.ErrConnDone)
+
+	_, err := repo.SumContributions(1)
+
+	assert.Error(t, err)
+}
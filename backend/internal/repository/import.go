@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrImportProfileNotFound is returned when an import profile is not found
+	ErrImportProfileNotFound = errors.New("import profile not found")
+	// ErrImportProfileAlreadyExists is returned when an import profile with the same name already exists
+	ErrImportProfileAlreadyExists = errors.New("import profile with this name already exists")
+)
+
+// ImportRepository handles database operations for import profiles and
+// duplicate detection of imported transactions
+type ImportRepository interface {
+	CreateProfile(profile *domain.ImportProfile) error
+	FindProfileByID(id int64) (*domain.ImportProfile, error)
+	ListProfiles() ([]domain.ImportProfile, error)
+	UpdateProfile(profile *domain.ImportProfile) error
+	DeleteProfile(id int64) error
+	ListTransactionsBetween(source string, start, end time.Time) ([]domain.Transaction, error)
+	FindByExternalIDs(source string, externalIDs []string) ([]domain.Transaction, error)
+}
+
+type importRepository struct {
+	db        *gorm.DB
+	sanitizer *security.Sanitizer
+}
+
+// NewImportRepository creates a new import repository
+func NewImportRepository(db *gorm.DB) ImportRepository {
+	return &importRepository{
+		db:        db,
+		sanitizer: security.NewSanitizer(),
+	}
+}
+
+func (r *importRepository) CreateProfile(profile *domain.ImportProfile) error {
+	r.sanitize(profile)
+
+	if err := r.checkDuplicate(profile); err != nil {
+		return err
+	}
+
+	return r.db.Create(profile).Error
+}
+
+func (r *importRepository) FindProfileByID(id int64) (*domain.ImportProfile, error) {
+	var profile domain.ImportProfile
+	err := r.db.First(&profile, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrImportProfileNotFound
+		}
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *importRepository) ListProfiles() ([]domain.ImportProfile, error) {
+	var profiles []domain.ImportProfile
+	err := r.db.Order("name ASC").Find(&profiles).Error
+	return profiles, err
+}
+
+func (r *importRepository) UpdateProfile(profile *domain.ImportProfile) error {
+	r.sanitize(profile)
+
+	if err := r.checkDuplicate(profile); err != nil {
+		return err
+	}
+
+	return r.db.Save(profile).Error
+}
+
+func (r *importRepository) DeleteProfile(id int64) error {
+	result := r.db.Delete(&domain.ImportProfile{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrImportProfileNotFound
+	}
+	return nil
+}
+
+// ListTransactionsBetween returns transactions of a source (ignoring case) dated within [start, end]
+func (r *importRepository) ListTransactionsBetween(source string, start, end time.Time) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.Where("LOWER(source) = LOWER(?) AND transaction_date >= ? AND transaction_date <= ?", source, start, end).
+		Order("transaction_date ASC, id ASC").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// FindByExternalIDs returns transactions of a source (ignoring case) with any of the external IDs
+func (r *importRepository) FindByExternalIDs(source string, externalIDs []string) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	if len(externalIDs) == 0 {
+		return transactions, nil
+	}
+	err := r.db.Where("LOWER(source) = LOWER(?) AND external_id IN ?", source, externalIDs).
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// sanitize cleans free-text fields before they are stored
+func (r *importRepository) sanitize(profile *domain.ImportProfile) {
+	profile.Name = r.sanitizer.CleanInput(profile.Name, domain.MaxImportProfileNameLength)
+	profile.Source = r.sanitizer.CleanInput(profile.Source, domain.MaxSourceLength)
+}
+
+// checkDuplicate ensures no other profile uses the same name
+func (r *importRepository) checkDuplicate(profile *domain.ImportProfile) error {
+	var existing domain.ImportProfile
+	err := r.db.Where("name = ? AND id <> ?", profile.Name, profile.ID).First(&existing).Error
+	if err == nil {
+		return ErrImportProfileAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
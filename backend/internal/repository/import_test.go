@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test CreateProfile
+
+func TestImportRepository_CreateProfile_Success(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewImportRepository(db)
+
+	profile := &domain.ImportProfile{
+		Name:          "VCB export",
+		ImportMapping: domain.ImportMapping{Source: "Vietcombank", DateColumn: "Date", AmountColumn: "Amount"},
+	}
+
+	// Mock check for existing profile
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "import_profiles"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.CreateProfile(profile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), profile.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportRepository_CreateProfile_Duplicate(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewImportRepository(db)
+
+	mock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "VCB export"))
+
+	err := repo.CreateProfile(&domain.ImportProfile{Name: "VCB export"})
+
+	assert.ErrorIs(t, err, ErrImportProfileAlreadyExists)
+}
+
+// Test FindProfileByID
+
+func TestImportRepository_FindProfileByID_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewImportRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindProfileByID(99)
+
+	assert.ErrorIs(t, err, ErrImportProfileNotFound)
+}
+
+// Test DeleteProfile
+
+func TestImportRepository_DeleteProfile_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewImportRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "import_profiles"`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.DeleteProfile(99)
+
+	assert.ErrorIs(t, err, ErrImportProfileNotFound)
+}
+
+// Test ListTransactionsBetween
+
+func TestImportRepository_ListTransactionsBetween(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewImportRepository(db)
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "source", "type", "amount", "transaction_date"}).
+		AddRow(4, "Vietcombank", "out", 45000.0, start.Add(10*time.Hour))
+
+	mock.ExpectQuery(regexp.QuoteMeta("LOWER(source) = LOWER($1) AND transaction_date >= $2 AND transaction_date <= $3")).
+		WithArgs("vietcombank", start, end).
+		WillReturnRows(rows)
+
+	transactions, err := repo.ListTransactionsBetween("vietcombank", start, end)
+
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test FindByExternalIDs
+
+func TestImportRepository_FindByExternalIDs(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewImportRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id", "source", "external_id"}).
+		AddRow(7, "Chase Bank", "2026030501")
+
+	mock.ExpectQuery(regexp.QuoteMeta("LOWER(source) = LOWER($1) AND external_id IN ($2,$3)")).
+		WithArgs("chase bank", "2026030501", "2026031001").
+		WillReturnRows(rows)
+
+	transactions, err := repo.FindByExternalIDs("chase bank", []string{"2026030501", "2026031001"})
+
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 1)
+	assert.Equal(t, "2026030501", transactions[0].ExternalID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportRepository_FindByExternalIDs_NoIDs(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewImportRepository(db)
+
+	transactions, err := repo.FindByExternalIDs("Chase Bank", nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+var (
+	// ErrMFANotFound is returned when a user has not enrolled in two-factor authentication
+	ErrMFANotFound = errors.New("two-factor authentication is not set up")
+)
+
+// MFARepository handles database operations for two-factor authentication
+type MFARepository interface {
+	Find(userID int64) (*domain.UserMFA, error)
+	SaveEnrollment(mfa *domain.UserMFA) error
+	Activate(userID, step int64, codeHashes []string, now time.Time) error
+	UseStep(userID, step int64) (bool, error)
+	UseRecoveryCode(userID int64, codeHash string, now time.Time) (bool, error)
+	CountRecoveryCodes(userID int64) (int64, error)
+	ReplaceRecoveryCodes(userID int64, codeHashes []string) error
+	Delete(userID int64) error
+}
+
+type mfaRepository struct {
+	db *gorm.DB
+}
+
+// NewMFARepository creates a new two-factor authentication repository
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) Find(userID int64) (*domain.UserMFA, error) {
+	var mfa domain.UserMFA
+	err := r.db.Where("user_id = ?", userID).First(&mfa).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMFANotFound
+		}
+		return nil, err
+	}
+
+	return &mfa, nil
+}
+
+// SaveEnrollment stores the secret of a pending enrolment, replacing any
+// earlier one that was never activated
+func (r *mfaRepository) SaveEnrollment(mfa *domain.UserMFA) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"secret", "last_used_step", "updated_at"}),
+	}).Create(mfa).Error
+}
+
+// Activate enables a pending enrolment whose first code was of step, and
+// stores its recovery codes
+func (r *mfaRepository) Activate(userID, step int64, codeHashes []string, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&domain.UserMFA{}).
+			Where("user_id = ? AND NOT enabled", userID).
+			Updates(map[string]interface{}{
+				"enabled":        true,
+				"enabled_at":     now,
+				"last_used_step": step,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrMFANotFound
+		}
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+// UseStep records that a code of step was accepted. It returns false when
+// a code of that step or a later one was accepted first, so a code cannot
+// be replayed even by concurrent requests.
+func (r *mfaRepository) UseStep(userID, step int64) (bool, error) {
+	result := r.db.Model(&domain.UserMFA{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		UpdateColumn("last_used_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+// UseRecoveryCode marks an unused recovery code as used. It returns false
+// when the user has no such unused code.
+func (r *mfaRepository) UseRecoveryCode(userID int64, codeHash string, now time.Time) (bool, error) {
+	result := r.db.Model(&domain.MFARecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		UpdateColumn("used_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountRecoveryCodes returns the number of unused recovery codes of a user
+func (r *mfaRepository) CountRecoveryCodes(userID int64) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.MFARecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+// ReplaceRecoveryCodes invalidates all recovery codes of a user in favour of new ones
+func (r *mfaRepository) ReplaceRecoveryCodes(userID int64, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codeHashes)
+	})
+}
+
+// Delete removes the two-factor authentication of a user with its recovery codes
+func (r *mfaRepository) Delete(userID int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("user_id = ?", userID).Delete(&domain.UserMFA{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrMFANotFound
+		}
+		return nil
+	})
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID int64, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&domain.MFARecoveryCode{}).Error; err != nil {
+		return err
+	}
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	codes := make([]domain.MFARecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = domain.MFARecoveryCode{UserID: userID, CodeHash: hash}
+	}
+	return tx.Create(&codes).Error
+}
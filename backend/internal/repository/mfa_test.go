@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMFARepository_Find_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_mfa" WHERE user_id = $1 ORDER BY "user_mfa"."user_id" LIMIT $2`)).
+		WithArgs(int64(1), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+	mfa, err := repo.Find(1)
+
+	assert.Nil(t, mfa)
+	assert.ErrorIs(t, err, ErrMFANotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_Activate(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_mfa" SET "enabled"=$1,"enabled_at"=$2,"last_used_step"=$3,"updated_at"=$4 WHERE user_id = $5 AND NOT enabled`)).
+		WithArgs(true, now, int64(59158080), sqlmock.AnyArg(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "mfa_recovery_codes" WHERE user_id = $1`)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "mfa_recovery_codes" ("used_at","created_at","code_hash","user_id") VALUES ($1,$2,$3,$4),($5,$6,$7,$8) RETURNING "id"`)).
+		WithArgs(nil, sqlmock.AnyArg(), "hash1", int64(1), nil, sqlmock.AnyArg(), "hash2", int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	err := repo.Activate(1, 59158080, []string{"hash1", "hash2"}, now)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_Activate_NotEnrolled(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_mfa" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Activate(1, 59158080, []string{"hash1"}, time.Now())
+
+	assert.ErrorIs(t, err, ErrMFANotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_UseStep(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "user_mfa" SET "last_used_step"=$1 WHERE user_id = $2 AND last_used_step < $3`)).
+		WithArgs(int64(59158081), int64(1), int64(59158081)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	used, err := repo.UseStep(1, 59158081)
+
+	require.NoError(t, err)
+	assert.False(t, used, "a code of the step was accepted before")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_UseRecoveryCode(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "mfa_recovery_codes" SET "used_at"=$1 WHERE user_id = $2 AND code_hash = $3 AND used_at IS NULL`)).
+		WithArgs(now, int64(1), "hash1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	used, err := repo.UseRecoveryCode(1, "hash1", now)
+
+	require.NoError(t, err)
+	assert.True(t, used)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_CountRecoveryCodes(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "mfa_recovery_codes" WHERE user_id = $1 AND used_at IS NULL`)).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	count, err := repo.CountRecoveryCodes(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_Delete(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewMFARepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "mfa_recovery_codes" WHERE user_id = $1`)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "user_mfa" WHERE user_id = $1`)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Delete(1)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
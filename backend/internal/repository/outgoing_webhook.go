@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+var (
+	// ErrWebhookEndpointNotFound is returned when a webhook endpoint is not found
+	ErrWebhookEndpointNotFound = errors.New("webhook endpoint not found")
+	// ErrWebhookDeliveryNotFound is returned when a webhook delivery is not found
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+)
+
+// OutgoingWebhookRepository handles database operations for webhook endpoints and their deliveries
+type OutgoingWebhookRepository interface {
+	CreateEndpoint(endpoint *domain.WebhookEndpoint) error
+	FindEndpoint(userID, id int64) (*domain.WebhookEndpoint, error)
+	ListEndpoints(userID int64) ([]domain.WebhookEndpoint, error)
+	UpdateEndpoint(endpoint *domain.WebhookEndpoint) error
+	DeleteEndpoint(userID, id int64) error
+	ListActiveEndpoints() ([]domain.WebhookEndpoint, error)
+	CreateDeliveries(deliveries []domain.WebhookDelivery) error
+	ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	SaveDelivery(delivery *domain.WebhookDelivery) error
+	ListDeliveries(endpointID int64, params domain.WebhookDeliveryQueryParams) ([]domain.WebhookDelivery, error)
+	FindDelivery(endpointID, id int64) (*domain.WebhookDelivery, error)
+}
+
+type outgoingWebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewOutgoingWebhookRepository creates a new outgoing webhook repository
+func NewOutgoingWebhookRepository(db *gorm.DB) OutgoingWebhookRepository {
+	return &outgoingWebhookRepository{db: db}
+}
+
+func (r *outgoingWebhookRepository) CreateEndpoint(endpoint *domain.WebhookEndpoint) error {
+	return r.db.Create(endpoint).Error
+}
+
+// FindEndpoint returns an endpoint owned by userID
+func (r *outgoingWebhookRepository) FindEndpoint(userID, id int64) (*domain.WebhookEndpoint, error) {
+	var endpoint domain.WebhookEndpoint
+	err := r.db.Where("id = ? AND user_id = ?", id, userID).First(&endpoint).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookEndpointNotFound
+		}
+		return nil, err
+	}
+
+	return &endpoint, nil
+}
+
+// ListEndpoints returns the endpoints of a user, oldest first
+func (r *outgoingWebhookRepository) ListEndpoints(userID int64) ([]domain.WebhookEndpoint, error) {
+	var endpoints []domain.WebhookEndpoint
+	err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *outgoingWebhookRepository) UpdateEndpoint(endpoint *domain.WebhookEndpoint) error {
+	return r.db.Save(endpoint).Error
+}
+
+// DeleteEndpoint deletes an endpoint owned by userID along with its delivery log
+func (r *outgoingWebhookRepository) DeleteEndpoint(userID, id int64) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&domain.WebhookEndpoint{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrWebhookEndpointNotFound
+	}
+	return nil
+}
+
+// ListActiveEndpoints returns the active endpoints of active users
+func (r *outgoingWebhookRepository) ListActiveEndpoints() ([]domain.WebhookEndpoint, error) {
+	var endpoints []domain.WebhookEndpoint
+	err := r.db.Joins("JOIN users ON users.id = webhook_endpoints.user_id").
+		Where("webhook_endpoints.active AND users.is_active").
+		Order("webhook_endpoints.id ASC").
+		Find(&endpoints).Error
+	return endpoints, err
+}
+
+func (r *outgoingWebhookRepository) CreateDeliveries(deliveries []domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.db.Omit(clause.Associations).CreateInBatches(deliveries, 100).Error
+}
+
+// ClaimDueDeliveries locks pending deliveries whose next attempt is due and
+// moves that attempt lease into the future, so concurrent workers skip them
+// while they are being sent. Each delivery is returned with its endpoint.
+func (r *outgoingWebhookRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.WebhookDeliveryPending, now).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&deliveries).Error
+		if err != nil || len(deliveries) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(deliveries))
+		for i := range deliveries {
+			ids[i] = deliveries[i].ID
+		}
+		return tx.Model(&domain.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			UpdateColumn("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+
+	endpointIDs := make([]int64, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		endpointIDs = append(endpointIDs, delivery.EndpointID)
+	}
+	var endpoints []domain.WebhookEndpoint
+	if err := r.db.Where("id IN ?", endpointIDs).Find(&endpoints).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*domain.WebhookEndpoint, len(endpoints))
+	for i := range endpoints {
+		byID[endpoints[i].ID] = &endpoints[i]
+	}
+	for i := range deliveries {
+		deliveries[i].Endpoint = byID[deliveries[i].EndpointID]
+	}
+
+	return deliveries, nil
+}
+
+// SaveDelivery stores the outcome of an attempt
+func (r *outgoingWebhookRepository) SaveDelivery(delivery *domain.WebhookDelivery) error {
+	return r.db.Omit(clause.Associations).Save(delivery).Error
+}
+
+// ListDeliveries returns the delivery log of an endpoint, newest first
+func (r *outgoingWebhookRepository) ListDeliveries(endpointID int64, params domain.WebhookDeliveryQueryParams) ([]domain.WebhookDelivery, error) {
+	var deliveries []domain.WebhookDelivery
+	query := r.db.Where("endpoint_id = ?", endpointID)
+	if params.Status != "" {
+		query = query.Where("status = ?", params.Status)
+	}
+	err := query.Order("id DESC").Limit(params.Limit).Find(&deliveries).Error
+	return deliveries, err
+}
+
+// FindDelivery returns a delivery of an endpoint
+func (r *outgoingWebhookRepository) FindDelivery(endpointID, id int64) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	err := r.db.Where("id = ? AND endpoint_id = ?", id, endpointID).First(&delivery).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrWebhookDeliveryNotFound
+		}
+		return nil, err
+	}
+
+	return &delivery, nil
+}
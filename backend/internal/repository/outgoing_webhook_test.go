@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test CreateEndpoint
+
+func TestOutgoingWebhookRepository_CreateEndpoint(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "webhook_endpoints"`)).
+		WithArgs(`["transaction.created"]`, "https://example.com/hook", "", "whsec_x", sqlmock.AnyArg(), sqlmock.AnyArg(), int64(3), true).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	endpoint := &domain.WebhookEndpoint{
+		UserID: 3, URL: "https://example.com/hook", Secret: "whsec_x", Active: true,
+		Events: []domain.WebhookEventType{domain.WebhookEventTransactionCreated},
+	}
+	err := repo.CreateEndpoint(endpoint)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), endpoint.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test FindEndpoint
+
+func TestOutgoingWebhookRepository_FindEndpoint_ScopedToUser(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_endpoints" WHERE id = $1 AND user_id = $2`)).
+		WithArgs(int64(1), int64(3), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "url", "events"}).
+			AddRow(1, 3, "https://example.com/hook", `["budget.exceeded"]`))
+
+	endpoint, err := repo.FindEndpoint(3, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.WebhookEventType{domain.WebhookEventBudgetExceeded}, endpoint.Events)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutgoingWebhookRepository_FindEndpoint_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	endpoint, err := repo.FindEndpoint(3, 1)
+
+	assert.ErrorIs(t, err, ErrWebhookEndpointNotFound)
+	assert.Nil(t, endpoint)
+}
+
+// Test DeleteEndpoint
+
+func TestOutgoingWebhookRepository_DeleteEndpoint_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "webhook_endpoints" WHERE id = $1 AND user_id = $2`)).
+		WithArgs(int64(1), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.DeleteEndpoint(3, 1)
+
+	assert.ErrorIs(t, err, ErrWebhookEndpointNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test ListActiveEndpoints
+
+func TestOutgoingWebhookRepository_ListActiveEndpoints(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`JOIN users ON users.id = webhook_endpoints.user_id WHERE webhook_endpoints.active AND users.is_active`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "active", "events"}).AddRow(1, true, `["transaction.created"]`))
+
+	endpoints, err := repo.ListActiveEndpoints()
+
+	assert.NoError(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test ClaimDueDeliveries
+
+func TestOutgoingWebhookRepository_ClaimDueDeliveries(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+	now := time.Date(2026, 3, 9, 6, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE status = $1 AND next_attempt_at <= $2 ORDER BY next_attempt_at ASC, id ASC LIMIT $3 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(domain.WebhookDeliveryPending, now, 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "endpoint_id", "status"}).
+			AddRow(10, 1, "pending").
+			AddRow(11, 2, "pending"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "webhook_deliveries" SET "next_attempt_at"=$1 WHERE id IN ($2,$3)`)).
+		WithArgs(now.Add(5*time.Minute), int64(10), int64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_endpoints" WHERE id IN ($1,$2)`)).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "active"}).AddRow(1, "https://example.com/hook", true))
+
+	deliveries, err := repo.ClaimDueDeliveries(now, 5*time.Minute, 50)
+
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	require.NotNil(t, deliveries[0].Endpoint)
+	assert.Equal(t, "https://example.com/hook", deliveries[0].Endpoint.URL)
+	assert.Nil(t, deliveries[1].Endpoint, "endpoint deleted meanwhile")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutgoingWebhookRepository_ClaimDueDeliveries_NoneDue(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	deliveries, err := repo.ClaimDueDeliveries(time.Now(), time.Minute, 50)
+
+	assert.NoError(t, err)
+	assert.Empty(t, deliveries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test ListDeliveries
+
+func TestOutgoingWebhookRepository_ListDeliveries_Status(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE endpoint_id = $1 AND status = $2 ORDER BY id DESC LIMIT $3`)).
+		WithArgs(int64(1), domain.WebhookDeliveryFailed, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status", "payload"}).AddRow(4, "failed", `{"id":"evt_1"}`))
+
+	deliveries, err := repo.ListDeliveries(1, domain.WebhookDeliveryQueryParams{Status: domain.WebhookDeliveryFailed, Limit: 20})
+
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.JSONEq(t, `{"id":"evt_1"}`, string(deliveries[0].Payload))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test FindDelivery
+
+func TestOutgoingWebhookRepository_FindDelivery_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewOutgoingWebhookRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE id = $1 AND endpoint_id = $2`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	delivery, err := repo.FindDelivery(1, 4)
+
+	assert.ErrorIs(t, err, ErrWebhookDeliveryNotFound)
+	assert.Nil(t, delivery)
+}
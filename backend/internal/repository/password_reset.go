@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+var (
+	// ErrPasswordResetTokenNotFound is returned when a reset token is unknown, used or expired
+	ErrPasswordResetTokenNotFound = errors.New("invalid or expired reset token")
+)
+
+// consumePasswordResetTokenSQL marks a valid token as used in one statement,
+// so concurrent requests cannot both use it
+const consumePasswordResetTokenSQL = `
+UPDATE password_reset_tokens SET used_at = @now
+WHERE token_hash = @hash AND used_at IS NULL AND expires_at > @now
+RETURNING id, user_id, token_hash, expires_at, used_at, created_at`
+
+// PasswordResetRepository handles database operations for password reset tokens
+type PasswordResetRepository interface {
+	Create(token *domain.PasswordResetToken) error
+	Consume(tokenHash string, now time.Time) (*domain.PasswordResetToken, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository creates a new password reset repository
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+// Create stores a new token, invalidating the unused tokens the user was sent before
+func (r *passwordResetRepository) Create(token *domain.PasswordResetToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND used_at IS NULL", token.UserID).Delete(&domain.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// Consume marks the token with tokenHash as used and returns it, unless it
+// was used before or has expired at now
+func (r *passwordResetRepository) Consume(tokenHash string, now time.Time) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	err := r.db.Raw(consumePasswordResetTokenSQL, map[string]interface{}{
+		"hash": tokenHash,
+		"now":  now,
+	}).Scan(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	if token.ID == 0 {
+		return nil, ErrPasswordResetTokenNotFound
+	}
+	return &token, nil
+}
+
+// DeleteExpired deletes the tokens that can no longer be used
+func (r *passwordResetRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&domain.PasswordResetToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+func TestPasswordResetRepository_Create(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPasswordResetRepository(db)
+	expiresAt := time.Date(2026, 3, 10, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "password_reset_tokens" WHERE user_id = $1 AND used_at IS NULL`)).
+		WithArgs(int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "password_reset_tokens"`)).
+		WithArgs(expiresAt, sqlmock.AnyArg(), nil, "hash", int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectCommit()
+
+	token := &domain.PasswordResetToken{UserID: 1, TokenHash: "hash", ExpiresAt: expiresAt}
+	err := repo.Create(token)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), token.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetRepository_Consume(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPasswordResetRepository(db)
+	now := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE password_reset_tokens SET used_at = $1`)).
+		WithArgs(now, "hash", now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "used_at"}).
+			AddRow(5, 1, "hash", now.Add(30*time.Minute), now))
+
+	token, err := repo.Consume("hash", now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), token.UserID)
+	assert.Equal(t, now, *token.UsedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetRepository_Consume_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPasswordResetRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`UPDATE password_reset_tokens SET used_at = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "used_at"}))
+
+	token, err := repo.Consume("hash", time.Now())
+
+	assert.Nil(t, token)
+	assert.ErrorIs(t, err, ErrPasswordResetTokenNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetRepository_DeleteExpired(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPasswordResetRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "password_reset_tokens" WHERE expires_at <= $1`)).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteExpired(now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrPayeeNotFound is returned when a payee is not found
+	ErrPayeeNotFound = errors.New("payee not found")
+	// ErrPayeeAlreadyExists is returned when another payee uses the same name or alias
+	ErrPayeeAlreadyExists = errors.New("payee with this name or alias already exists")
+)
+
+// mergeNamesSQL adds the names of merged payees as aliases of the target,
+// skipping names the target already has as an alias in any case
+const mergeNamesSQL = `
+INSERT INTO payee_aliases (payee_id, pattern)
+SELECT @target, p.name FROM payees p
+WHERE p.id IN @sources
+	AND NOT EXISTS (SELECT 1 FROM payee_aliases a WHERE a.payee_id = @target AND LOWER(a.pattern) = LOWER(p.name))
+ON CONFLICT (pattern) DO NOTHING`
+
+// PayeeRepository handles database operations for payees and their aliases
+type PayeeRepository interface {
+	Create(payee *domain.Payee) error
+	FindByID(id int64) (*domain.Payee, error)
+	List() ([]domain.Payee, error)
+	Update(payee *domain.Payee) error
+	Delete(id int64) error
+	Merge(targetID int64, sourceIDs []int64) (int64, error)
+	ListUnlinked() ([]domain.Transaction, error)
+	LinkTransactions(payee *domain.Payee, transactionIDs []int64) error
+	GetBreakdown(filter *domain.PayeeBreakdownFilter) ([]domain.PayeeBreakdown, error)
+}
+
+type payeeRepository struct {
+	db        *gorm.DB
+	sanitizer *security.Sanitizer
+}
+
+// NewPayeeRepository creates a new payee repository
+func NewPayeeRepository(db *gorm.DB) PayeeRepository {
+	return &payeeRepository{
+		db:        db,
+		sanitizer: security.NewSanitizer(),
+	}
+}
+
+// Create stores a payee together with its aliases
+func (r *payeeRepository) Create(payee *domain.Payee) error {
+	r.sanitize(payee)
+
+	if err := r.checkDuplicate(payee); err != nil {
+		return err
+	}
+
+	return r.db.Create(payee).Error
+}
+
+func (r *payeeRepository) FindByID(id int64) (*domain.Payee, error) {
+	var payee domain.Payee
+	err := r.db.Preload("Aliases").First(&payee, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPayeeNotFound
+		}
+		return nil, err
+	}
+
+	return &payee, nil
+}
+
+func (r *payeeRepository) List() ([]domain.Payee, error) {
+	var payees []domain.Payee
+	err := r.db.Preload("Aliases").Order("name ASC").Find(&payees).Error
+	return payees, err
+}
+
+// Update saves the payee and replaces its aliases
+func (r *payeeRepository) Update(payee *domain.Payee) error {
+	r.sanitize(payee)
+
+	if err := r.checkDuplicate(payee); err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("payee_id = ?", payee.ID).Delete(&domain.PayeeAlias{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Omit("Aliases").Save(payee).Error; err != nil {
+			return err
+		}
+		if len(payee.Aliases) == 0 {
+			return nil
+		}
+		for i := range payee.Aliases {
+			payee.Aliases[i].ID = 0
+			payee.Aliases[i].PayeeID = payee.ID
+		}
+		return tx.Create(&payee.Aliases).Error
+	})
+}
+
+// Delete removes a payee and its aliases. Linked transactions are kept and unlinked.
+func (r *payeeRepository) Delete(id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Transaction{}).Where("payee_id = ?", id).UpdateColumn("payee_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("payee_id = ?", id).Delete(&domain.PayeeAlias{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.Payee{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrPayeeNotFound
+		}
+		return nil
+	})
+}
+
+// Merge moves the aliases and transactions of the source payees to the target,
+// keeps the source names as aliases of the target so they still resolve at
+// ingest, and deletes the sources. It returns the number of relinked transactions.
+func (r *payeeRepository) Merge(targetID int64, sourceIDs []int64) (int64, error) {
+	var relinked int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.PayeeAlias{}).Where("payee_id IN ?", sourceIDs).UpdateColumn("payee_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(mergeNamesSQL, map[string]interface{}{"target": targetID, "sources": sourceIDs}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&domain.Transaction{}).Where("payee_id IN ?", sourceIDs).UpdateColumn("payee_id", targetID)
+		if result.Error != nil {
+			return result.Error
+		}
+		relinked = result.RowsAffected
+
+		return tx.Where("id IN ?", sourceIDs).Delete(&domain.Payee{}).Error
+	})
+	return relinked, err
+}
+
+// ListUnlinked returns the fields needed for matching of every transaction without a payee
+func (r *payeeRepository) ListUnlinked() ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.Select("id", "recipient", "description", "category").
+		Where("payee_id IS NULL").
+		Order("id ASC").
+		Find(&transactions).Error
+	return transactions, err
+}
+
+// LinkTransactions links transactions to a payee and applies its default
+// category to those without one
+func (r *payeeRepository) LinkTransactions(payee *domain.Payee, transactionIDs []int64) error {
+	if len(transactionIDs) == 0 {
+		return nil
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&domain.Transaction{}).Where("id IN ?", transactionIDs).UpdateColumn("payee_id", payee.ID).Error; err != nil {
+			return err
+		}
+		if payee.DefaultCategory == "" {
+			return nil
+		}
+		return tx.Model(&domain.Transaction{}).
+			Where("id IN ? AND (category IS NULL OR category = '')", transactionIDs).
+			UpdateColumn("category", payee.DefaultCategory).Error
+	})
+}
+
+// GetBreakdown returns the payees with the highest totals for a transaction type
+func (r *payeeRepository) GetBreakdown(filter *domain.PayeeBreakdownFilter) ([]domain.PayeeBreakdown, error) {
+	var results []domain.PayeeBreakdown
+
+	if !r.sanitizer.ValidateTransactionType(string(filter.Type)) {
+		return nil, errors.New("invalid transaction type")
+	}
+
+	where := "WHERE type = ?"
+	args := []interface{}{filter.Type}
+	if filter.Start != nil {
+		where += " AND transaction_date >= ?"
+		args = append(args, *filter.Start)
+	}
+	if filter.End != nil {
+		where += " AND transaction_date <= ?"
+		args = append(args, *filter.End)
+	}
+	args = append(args, filter.Limit)
+
+	// Transactions without a payee are grouped too so that percentages are
+	// relative to the full total; the join then drops that group
+	query := `
+		SELECT
+			payees.id as payee_id,
+			payees.name as name,
+			totals.amount,
+			totals.count,
+			totals.amount / totals.count as average,
+			totals.percentage
+		FROM (
+			SELECT
+				payee_id,
+				COALESCE(SUM(amount), 0) as amount,
+				COUNT(*) as count,
+				COALESCE(SUM(amount) * 100.0 / NULLIF(SUM(SUM(amount)) OVER (), 0), 0) as percentage
+			FROM transactions
+			` + where + `
+			GROUP BY payee_id
+		) totals
+		JOIN payees ON payees.id = totals.payee_id
+		ORDER BY totals.amount DESC, payees.name ASC
+		LIMIT ?
+	`
+
+	err := r.db.Raw(query, args...).Scan(&results).Error
+	return results, err
+}
+
+// sanitize cleans free-text fields before they are stored
+func (r *payeeRepository) sanitize(payee *domain.Payee) {
+	payee.Name = r.sanitizer.CleanInput(payee.Name, domain.MaxPayeeNameLength)
+	for i := range payee.Aliases {
+		payee.Aliases[i].Pattern = r.sanitizer.CleanInput(payee.Aliases[i].Pattern, domain.MaxPayeeAliasLength)
+	}
+}
+
+// checkDuplicate ensures no other payee uses the same name or any of the aliases
+func (r *payeeRepository) checkDuplicate(payee *domain.Payee) error {
+	var existing domain.Payee
+	err := r.db.Where("name = ? AND id <> ?", payee.Name, payee.ID).First(&existing).Error
+	if err == nil {
+		return ErrPayeeAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if len(payee.Aliases) == 0 {
+		return nil
+	}
+
+	patterns := make([]string, 0, len(payee.Aliases))
+	for _, alias := range payee.Aliases {
+		patterns = append(patterns, strings.ToLower(alias.Pattern))
+	}
+
+	var alias domain.PayeeAlias
+	err = r.db.Where("LOWER(pattern) IN ? AND payee_id <> ?", patterns, payee.ID).First(&alias).Error
+	if err == nil {
+		return ErrPayeeAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
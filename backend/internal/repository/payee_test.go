@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test Create
+
+func TestPayeeRepository_Create_Success(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPayeeRepository(db)
+
+	payee := &domain.Payee{Name: "Grab", Aliases: []domain.PayeeAlias{{Pattern: "GRAB*"}}}
+
+	// Mock checks for existing name and alias
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`LOWER(pattern) IN`)).
+		WithArgs("grab*", int64(0), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "payees"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "payee_aliases"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	err := repo.Create(payee)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), payee.ID)
+	assert.Equal(t, int64(1), payee.Aliases[0].PayeeID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPayeeRepository_Create_DuplicateAlias(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPayeeRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`LOWER(pattern) IN`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "pattern"}).AddRow(3, "GRAB*"))
+
+	err := repo.Create(&domain.Payee{Name: "Grab", Aliases: []domain.PayeeAlias{{Pattern: "grab*"}}})
+
+	assert.ErrorIs(t, err, ErrPayeeAlreadyExists)
+}
+
+// Test Delete
+
+func TestPayeeRepository_Delete_UnlinksTransactions(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPayeeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "payee_id"=$1 WHERE payee_id = $2`)).
+		WithArgs(nil, int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "payee_aliases"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "payees"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.Delete(5)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPayeeRepository_Delete_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPayeeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Delete(99)
+
+	assert.ErrorIs(t, err, ErrPayeeNotFound)
+}
+
+// Test Merge
+
+func TestPayeeRepository_Merge_RelinksTransactions(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPayeeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "payee_aliases" SET "payee_id"=$1`)).
+		WithArgs(int64(1), int64(2), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO payee_aliases (payee_id, pattern)
+SELECT $1, p.name FROM payees p
+WHERE p.id IN ($2,$3)`)).
+		WithArgs(int64(1), int64(2), int64(3), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "payee_id"=$1`)).
+		WithArgs(int64(1), int64(2), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 7))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "payees"`)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	relinked, err := repo.Merge(1, []int64{2, 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), relinked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test LinkTransactions
+
+func TestPayeeRepository_LinkTransactions_AppliesDefaultCategory(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPayeeRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "payee_id"=$1`)).
+		WithArgs(int64(1), int64(10), int64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "transactions" SET "category"=$1`)).
+		WithArgs("Food", int64(10), int64(11)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.LinkTransactions(&domain.Payee{ID: 1, DefaultCategory: "Food"}, []int64{10, 11})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPayeeRepository_LinkTransactions_Empty(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPayeeRepository(db)
+
+	err := repo.LinkTransactions(&domain.Payee{ID: 1}, nil)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test GetBreakdown
+
+func TestPayeeRepository_GetBreakdown(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewPayeeRepository(db)
+
+	rows := sqlmock.NewRows([]string{"payee_id", "name", "amount", "count", "average", "percentage"}).
+		AddRow(1, "Grab", 300000.0, 3, 100000.0, 60.0)
+
+	mock.ExpectQuery(regexp.QuoteMeta("JOIN payees ON payees.id = totals.payee_id")).
+		WithArgs(domain.TransactionTypeOut, 5).
+		WillReturnRows(rows)
+
+	results, err := repo.GetBreakdown(&domain.PayeeBreakdownFilter{Type: domain.TransactionTypeOut, Limit: 5})
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Grab", results[0].Name)
+	assert.Equal(t, 60.0, results[0].Percentage)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
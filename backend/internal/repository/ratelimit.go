@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// takeTokenSQL refills and takes from a bucket in one statement, so
+// concurrent requests on any replica see each other's tokens. The SET
+// expressions all read the row as it was before the update. Time comes from
+// the database clock, which all replicas share.
+const takeTokenSQL = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)
+VALUES (@key, CAST(@capacity AS double precision) - 1, true, now())
+ON CONFLICT (key) DO UPDATE SET
+	tokens = LEAST(CAST(@capacity AS double precision), b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate)
+		- CASE WHEN LEAST(CAST(@capacity AS double precision), b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate) >= 1 THEN 1 ELSE 0 END,
+	allowed = LEAST(CAST(@capacity AS double precision), b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at) * @rate) >= 1,
+	updated_at = now()
+RETURNING tokens, allowed`
+
+// RateLimitRepository stores token buckets in Postgres for rate limits shared by all API replicas
+type RateLimitRepository interface {
+	Take(key string, policy domain.RateLimitPolicy) (domain.RateLimitResult, error)
+	DeleteIdle(idle time.Duration) (int64, error)
+}
+
+type rateLimitRepository struct {
+	db *gorm.DB
+}
+
+// NewRateLimitRepository creates a new rate limit repository
+func NewRateLimitRepository(db *gorm.DB) RateLimitRepository {
+	return &rateLimitRepository{db: db}
+}
+
+// Take takes a token from the bucket of key, creating a full bucket for a new key
+func (r *rateLimitRepository) Take(key string, policy domain.RateLimitPolicy) (domain.RateLimitResult, error) {
+	var bucket domain.RateLimitBucket
+	err := r.db.Raw(takeTokenSQL, map[string]interface{}{
+		"key":      key,
+		"capacity": policy.Capacity(),
+		"rate":     policy.Rate(),
+	}).Scan(&bucket).Error
+	if err != nil {
+		return domain.RateLimitResult{}, err
+	}
+
+	return policy.Result(bucket.Tokens, bucket.Allowed), nil
+}
+
+// DeleteIdle deletes buckets untouched for longer than idle. Buckets that
+// have refilled completely behave like missing ones and can go.
+func (r *rateLimitRepository) DeleteIdle(idle time.Duration) (int64, error) {
+	result := r.db.Where("updated_at < now() - make_interval(secs => ?)", idle.Seconds()).
+		Delete(&domain.RateLimitBucket{})
+	return result.RowsAffected, result.Error
+}
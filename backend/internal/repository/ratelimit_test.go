@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+func TestRateLimitRepository_Take(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewRateLimitRepository(db)
+	policy := domain.RateLimitPolicy{Name: "auth", Requests: 10, Period: time.Minute, Burst: 5}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at)`)).
+		WithArgs("auth:203.0.113.7", 5.0, 5.0, 5.0/30, 5.0, 5.0/30, 5.0, 5.0/30).
+		WillReturnRows(sqlmock.NewRows([]string{"tokens", "allowed"}).AddRow(0.5, false))
+
+	result, err := repo.Take("auth:203.0.113.7", policy)
+
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 3*time.Second, result.RetryAfter)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRateLimitRepository_DeleteIdle(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewRateLimitRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "rate_limit_buckets" WHERE updated_at < now() - make_interval(secs => $1)`)).
+		WithArgs(30.0).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteIdle(30 * time.Second)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
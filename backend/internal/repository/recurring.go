@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// RecurringRepository reads transaction history for recurring series detection
+type RecurringRepository interface {
+	ListSince(since time.Time) ([]domain.Transaction, error)
+}
+
+type recurringRepository struct {
+	db *gorm.DB
+}
+
+// NewRecurringRepository creates a new recurring repository
+func NewRecurringRepository(db *gorm.DB) RecurringRepository {
+	return &recurringRepository{db: db}
+}
+
+// ListSince returns all transactions on or after since, oldest first
+func (r *recurringRepository) ListSince(since time.Time) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.Where("transaction_date >= ?", since).
+		Order("transaction_date ASC, id ASC").
+		Find(&transactions).Error
+	return transactions, err
+}
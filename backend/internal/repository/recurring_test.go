@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+// Test ListSince
+
+func TestRecurringRepository_ListSince(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewRecurringRepository(db)
+
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "type", "recipient", "amount", "transaction_date"}).
+		AddRow(1, "out", "Netflix", 260000.00, since.AddDate(0, 1, 0)).
+		AddRow(2, "out", "Netflix", 260000.00, since.AddDate(0, 2, 0))
+
+	mock.ExpectQuery(regexp.QuoteMeta("transaction_date >= $1 ORDER BY transaction_date ASC, id ASC")).
+		WithArgs(since).
+		WillReturnRows(rows)
+
+	transactions, err := repo.ListSince(since)
+
+	assert.NoError(t, err)
+	assert.Len(t, transactions, 2)
+	assert.Equal(t, "Netflix", transactions[0].Recipient)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
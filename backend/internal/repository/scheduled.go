@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+// ErrScheduledTransactionNotFound is returned when a scheduled transaction is not found
+var ErrScheduledTransactionNotFound = errors.New("scheduled transaction not found")
+
+// ScheduledRepository handles database operations for scheduled transactions and their payments
+type ScheduledRepository interface {
+	Create(scheduled *domain.ScheduledTransaction) error
+	FindByID(id int64) (*domain.ScheduledTransaction, error)
+	List() ([]domain.ScheduledTransaction, error)
+	ListDueBefore(before time.Time) ([]domain.ScheduledTransaction, error)
+	Update(scheduled *domain.ScheduledTransaction) error
+	Delete(id int64) error
+	RecordPayment(scheduled *domain.ScheduledTransaction, payment *domain.ScheduledPayment) error
+	ListPayments(scheduledID int64) ([]domain.ScheduledPayment, error)
+}
+
+type scheduledRepository struct {
+	db        *gorm.DB
+	sanitizer *security.Sanitizer
+}
+
+// NewScheduledRepository creates a new scheduled transaction repository
+func NewScheduledRepository(db *gorm.DB) ScheduledRepository {
+	return &scheduledRepository{
+		db:        db,
+		sanitizer: security.NewSanitizer(),
+	}
+}
+
+func (r *scheduledRepository) Create(scheduled *domain.ScheduledTransaction) error {
+	r.sanitize(scheduled)
+	return r.db.Create(scheduled).Error
+}
+
+func (r *scheduledRepository) FindByID(id int64) (*domain.ScheduledTransaction, error) {
+	var scheduled domain.ScheduledTransaction
+	err := r.db.First(&scheduled, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduledTransactionNotFound
+		}
+		return nil, err
+	}
+
+	return &scheduled, nil
+}
+
+func (r *scheduledRepository) List() ([]domain.ScheduledTransaction, error) {
+	var scheduled []domain.ScheduledTransaction
+	err := r.db.Order("next_due_date ASC, id ASC").Find(&scheduled).Error
+	return scheduled, err
+}
+
+// ListDueBefore returns active scheduled transactions whose next occurrence is due on or before the given time
+func (r *scheduledRepository) ListDueBefore(before time.Time) ([]domain.ScheduledTransaction, error) {
+	var scheduled []domain.ScheduledTransaction
+	err := r.db.Where("active = ? AND next_due_date <= ?", true, before).
+		Order("next_due_date ASC, id ASC").
+		Find(&scheduled).Error
+	return scheduled, err
+}
+
+func (r *scheduledRepository) Update(scheduled *domain.ScheduledTransaction) error {
+	r.sanitize(scheduled)
+	return r.db.Save(scheduled).Error
+}
+
+// Delete removes a scheduled transaction together with its payment history
+func (r *scheduledRepository) Delete(id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("scheduled_transaction_id = ?", id).Delete(&domain.ScheduledPayment{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.ScheduledTransaction{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrScheduledTransactionNotFound
+		}
+		return nil
+	})
+}
+
+// RecordPayment stores a payment and the advanced schedule atomically
+func (r *scheduledRepository) RecordPayment(scheduled *domain.ScheduledTransaction, payment *domain.ScheduledPayment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(payment).Error; err != nil {
+			return err
+		}
+		return tx.Save(scheduled).Error
+	})
+}
+
+func (r *scheduledRepository) ListPayments(scheduledID int64) ([]domain.ScheduledPayment, error) {
+	var payments []domain.ScheduledPayment
+	err := r.db.Where("scheduled_transaction_id = ?", scheduledID).
+		Order("due_date DESC").
+		Find(&payments).Error
+	return payments, err
+}
+
+// sanitize cleans free-text fields before they are stored
+func (r *scheduledRepository) sanitize(scheduled *domain.ScheduledTransaction) {
+	scheduled.Name = r.sanitizer.CleanInput(scheduled.Name, domain.MaxScheduleNameLength)
+	scheduled.Source = r.sanitizer.CleanInput(scheduled.Source, domain.MaxSourceLength)
+	scheduled.Recipient = r.sanitizer.CleanInput(scheduled.Recipient, domain.MaxRecipientLength)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// Test FindByID
+
+func TestScheduledRepository_FindByID_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewScheduledRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	scheduled, err := repo.FindByID(99)
+
+	assert.ErrorIs(t, err, ErrScheduledTransactionNotFound)
+	assert.Nil(t, scheduled)
+}
+
+// Test ListDueBefore
+
+func TestScheduledRepository_ListDueBefore(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewScheduledRepository(db)
+
+	before := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "name", "next_due_date", "active"}).
+		AddRow(1, "Rent", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), true)
+
+	mock.ExpectQuery(regexp.QuoteMeta("active = $1 AND next_due_date <= $2")).
+		WithArgs(true, before).
+		WillReturnRows(rows)
+
+	scheduled, err := repo.ListDueBefore(before)
+
+	assert.NoError(t, err)
+	assert.Len(t, scheduled, 1)
+	assert.Equal(t, "Rent", scheduled[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// Test RecordPayment
+
+func TestScheduledRepository_RecordPayment(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewScheduledRepository(db)
+
+	scheduled := &domain.ScheduledTransaction{ID: 1, Name: "Rent", Occurrences: 1, Active: true}
+	payment := &domain.ScheduledPayment{ScheduledTransactionID: 1, TransactionID: 10, Amount: 5000000}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "scheduled_payments"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "scheduled_transactions"`)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.RecordPayment(scheduled, payment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), payment.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+var (
+	// ErrLoginThrottleNotFound is returned when an account or IP has no failed logins on record
+	ErrLoginThrottleNotFound = errors.New("no failed logins on record")
+)
+
+// recordLoginFailureSQL counts a failed login in one statement, so
+// concurrent attempts on any replica see each other's failures. Failures
+// older than the window start the count over.
+const recordLoginFailureSQL = `
+INSERT INTO login_throttles AS t (key, failures, last_failed_at, blocked_until, locked)
+VALUES (@key, 1, @now, @now, false)
+ON CONFLICT (key) DO UPDATE SET
+	failures = CASE WHEN t.last_failed_at > @since THEN t.failures + 1 ELSE 1 END,
+	last_failed_at = @now
+RETURNING key, failures, last_failed_at, blocked_until, locked`
+
+// SecurityRepository handles failed login tracking and the security log
+type SecurityRepository interface {
+	FindBlockedLogins(keys []string, now time.Time) ([]domain.LoginThrottle, error)
+	RecordLoginFailure(key string, now time.Time, window time.Duration) (*domain.LoginThrottle, error)
+	BlockLogin(key string, until time.Time, locked bool) error
+	DeleteLoginThrottle(key string) error
+	DeleteExpiredLoginThrottles(now time.Time, window time.Duration) (int64, error)
+	CreateEvent(event *domain.SecurityEvent) error
+	ListEvents(userID int64, params domain.SecurityEventQueryParams) ([]domain.SecurityEvent, error)
+}
+
+type securityRepository struct {
+	db *gorm.DB
+}
+
+// NewSecurityRepository creates a new security repository
+func NewSecurityRepository(db *gorm.DB) SecurityRepository {
+	return &securityRepository{db: db}
+}
+
+// FindBlockedLogins returns the throttles of keys that block logins at now
+func (r *securityRepository) FindBlockedLogins(keys []string, now time.Time) ([]domain.LoginThrottle, error) {
+	var throttles []domain.LoginThrottle
+	err := r.db.Where("key IN ? AND blocked_until > ?", keys, now).Find(&throttles).Error
+	return throttles, err
+}
+
+// RecordLoginFailure counts a failed login of key and returns its throttle
+func (r *securityRepository) RecordLoginFailure(key string, now time.Time, window time.Duration) (*domain.LoginThrottle, error) {
+	var throttle domain.LoginThrottle
+	err := r.db.Raw(recordLoginFailureSQL, map[string]interface{}{
+		"key":   key,
+		"now":   now,
+		"since": now.Add(-window),
+	}).Scan(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// BlockLogin blocks logins of key until the given time
+func (r *securityRepository) BlockLogin(key string, until time.Time, locked bool) error {
+	return r.db.Model(&domain.LoginThrottle{}).
+		Where("key = ?", key).
+		Updates(map[string]interface{}{
+			"blocked_until": until,
+			"locked":        locked,
+		}).Error
+}
+
+// DeleteLoginThrottle forgets the failed logins of key, lifting any block
+func (r *securityRepository) DeleteLoginThrottle(key string) error {
+	result := r.db.Where("key = ?", key).Delete(&domain.LoginThrottle{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrLoginThrottleNotFound
+	}
+	return nil
+}
+
+// DeleteExpiredLoginThrottles deletes throttles that no longer block logins
+// and whose failures are outside the window
+func (r *securityRepository) DeleteExpiredLoginThrottles(now time.Time, window time.Duration) (int64, error) {
+	result := r.db.Where("blocked_until <= ? AND last_failed_at <= ?", now, now.Add(-window)).
+		Delete(&domain.LoginThrottle{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *securityRepository) CreateEvent(event *domain.SecurityEvent) error {
+	return r.db.Create(event).Error
+}
+
+// ListEvents returns the security log of a user, newest first
+func (r *securityRepository) ListEvents(userID int64, params domain.SecurityEventQueryParams) ([]domain.SecurityEvent, error) {
+	var events []domain.SecurityEvent
+	query := r.db.Where("user_id = ?", userID)
+	if params.Type != "" {
+		query = query.Where("type = ?", params.Type)
+	}
+	err := query.Order("id DESC").Limit(params.Limit).Find(&events).Error
+	return events, err
+}
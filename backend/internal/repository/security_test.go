@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+func TestSecurityRepository_FindBlockedLogins(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewSecurityRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "login_throttles" WHERE key IN ($1,$2) AND blocked_until > $3`)).
+		WithArgs("account:test@example.com", "ip:203.0.113.7", now).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "failures", "blocked_until", "locked"}).
+			AddRow("account:test@example.com", 5, now.Add(time.Minute), true))
+
+	throttles, err := repo.FindBlockedLogins([]string{"account:test@example.com", "ip:203.0.113.7"}, now)
+
+	require.NoError(t, err)
+	require.Len(t, throttles, 1)
+	assert.True(t, throttles[0].Locked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecurityRepository_RecordLoginFailure(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewSecurityRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO login_throttles AS t (key, failures, last_failed_at, blocked_until, locked)`)).
+		WithArgs("ip:203.0.113.7", now, now, now.Add(-15*time.Minute), now).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "failures", "last_failed_at", "blocked_until", "locked"}).
+			AddRow("ip:203.0.113.7", 3, now, now.Add(-time.Minute), false))
+
+	throttle, err := repo.RecordLoginFailure("ip:203.0.113.7", now, 15*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, throttle.Failures)
+	assert.Equal(t, "ip:203.0.113.7", throttle.Key)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecurityRepository_BlockLogin(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewSecurityRepository(db)
+	until := time.Date(2026, 3, 10, 9, 15, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "login_throttles" SET "blocked_until"=$1,"locked"=$2 WHERE key = $3`)).
+		WithArgs(until, true, "account:test@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.BlockLogin("account:test@example.com", until, true)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecurityRepository_DeleteLoginThrottle_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewSecurityRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "login_throttles" WHERE key = $1`)).
+		WithArgs("account:test@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.DeleteLoginThrottle("account:test@example.com")
+
+	assert.ErrorIs(t, err, ErrLoginThrottleNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecurityRepository_DeleteExpiredLoginThrottles(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewSecurityRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "login_throttles" WHERE blocked_until <= $1 AND last_failed_at <= $2`)).
+		WithArgs(now, now.Add(-15*time.Minute)).
+		WillReturnResult(sqlmock.NewResult(0, 6))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteExpiredLoginThrottles(now, 15*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSecurityRepository_ListEvents_Type(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewSecurityRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "security_events" WHERE user_id = $1 AND type = $2 ORDER BY id DESC LIMIT $3`)).
+		WithArgs(int64(1), domain.SecurityEventLoginFailed, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "type"}).AddRow(4, 1, domain.SecurityEventLoginFailed))
+
+	events, err := repo.ListEvents(1, domain.SecurityEventQueryParams{Type: domain.SecurityEventLoginFailed, Limit: 20})
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(4), events[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
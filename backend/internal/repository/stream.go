@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// StreamChannel is the Postgres NOTIFY channel for stored and updated transactions
+const StreamChannel = "transaction_events"
+
+// StreamRepository publishes transaction notifications between API replicas
+// through Postgres LISTEN/NOTIFY and loads the transactions they refer to
+type StreamRepository interface {
+	Notify(payload string) error
+	Listen(ctx context.Context, onListening func(), fn func(payload string)) error
+	FindTransactions(ids []int64) ([]domain.Transaction, error)
+	ListTransactionsAfter(id int64, limit int) ([]domain.Transaction, error)
+}
+
+type streamRepository struct {
+	db  *gorm.DB
+	dsn string
+}
+
+// NewStreamRepository creates a new stream repository. Listening uses a
+// dedicated connection to dsn, as a pooled connection would drop the LISTEN.
+func NewStreamRepository(db *gorm.DB, dsn string) StreamRepository {
+	return &streamRepository{db: db, dsn: dsn}
+}
+
+// Notify sends payload to every listener; Postgres delivers it when the
+// surrounding transaction commits
+func (r *streamRepository) Notify(payload string) error {
+	return r.db.Exec("SELECT pg_notify(?, ?)", StreamChannel, payload).Error
+}
+
+// Listen calls fn with every notification until ctx is cancelled or the
+// connection fails. onListening is called once the LISTEN is active.
+func (r *streamRepository) Listen(ctx context.Context, onListening func(), fn func(payload string)) error {
+	conn, err := pgx.Connect(ctx, r.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{StreamChannel}.Sanitize()); err != nil {
+		return err
+	}
+	onListening()
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		fn(notification.Payload)
+	}
+}
+
+// FindTransactions returns the transactions with the given IDs, oldest first
+func (r *streamRepository) FindTransactions(ids []int64) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	if len(ids) == 0 {
+		return transactions, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("id ASC").Find(&transactions).Error
+	return transactions, err
+}
+
+// ListTransactionsAfter returns up to limit of the newest transactions with
+// an ID above id, oldest first
+func (r *streamRepository) ListTransactionsAfter(id int64, limit int) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.Where("id > ?", id).Order("id DESC").Limit(limit).Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(transactions)-1; i < j; i, j = i+1, j-1 {
+		transactions[i], transactions[j] = transactions[j], transactions[i]
+	}
+	return transactions, nil
+}
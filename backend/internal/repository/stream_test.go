@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamRepository_Notify(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewStreamRepository(db, "")
+
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_notify($1, $2)`)).
+		WithArgs(StreamChannel, `{"created":[1,2]}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Notify(`{"created":[1,2]}`)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamRepository_FindTransactions(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewStreamRepository(db, "")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE id IN ($1,$2) ORDER BY id ASC`)).
+		WithArgs(int64(4), int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount"}).AddRow(4, 100).AddRow(7, 200))
+
+	transactions, err := repo.FindTransactions([]int64{4, 7})
+
+	require.NoError(t, err)
+	assert.Len(t, transactions, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamRepository_FindTransactions_Empty(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewStreamRepository(db, "")
+
+	transactions, err := repo.FindTransactions(nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, transactions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStreamRepository_ListTransactionsAfter_OldestFirst(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewStreamRepository(db, "")
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "transactions" WHERE id > $1 ORDER BY id DESC LIMIT $2`)).
+		WithArgs(int64(10), 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(13).AddRow(12))
+
+	transactions, err := repo.ListTransactionsAfter(10, 2)
+
+	require.NoError(t, err)
+	require.Len(t, transactions, 2)
+	assert.Equal(t, int64(12), transactions[0].ID)
+	assert.Equal(t, int64(13), transactions[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
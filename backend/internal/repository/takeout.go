@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+var (
+	// ErrTakeoutNotFound is returned when a takeout is not found or has expired
+	ErrTakeoutNotFound = errors.New("takeout not found")
+	// ErrLedgerNotEmpty is returned when a restore targets a ledger that already holds data
+	ErrLedgerNotEmpty = errors.New("ledger already contains transactions, payees or budgets")
+)
+
+// takeoutRestoreBatch is how many transactions one INSERT of a restore writes
+const takeoutRestoreBatch = 500
+
+// countLedgerSQL counts the records a restore would collide with
+const countLedgerSQL = `SELECT (SELECT COUNT(*) FROM transactions) + (SELECT COUNT(*) FROM payees) + (SELECT COUNT(*) FROM budgets)`
+
+// TakeoutRepository handles database operations for takeout archives and the
+// user data only they read or restore
+type TakeoutRepository interface {
+	Create(takeout *domain.Takeout) error
+	Find(userID, id int64, now time.Time) (*domain.Takeout, error)
+	FindArchive(userID, id int64, now time.Time) (*domain.Takeout, error)
+	List(userID int64, now time.Time) ([]domain.Takeout, error)
+	HasPending(userID int64) (bool, error)
+	ClaimPending(now time.Time, lease time.Duration, limit int) ([]domain.Takeout, error)
+	Save(takeout *domain.Takeout) error
+	DeleteExpired(now time.Time) (int64, error)
+	ListSecurityEvents(userID int64) ([]domain.SecurityEvent, error)
+	Restore(userID int64, name string, settings domain.UserSettings, ledger *domain.TakeoutLedger) error
+}
+
+type takeoutRepository struct {
+	db *gorm.DB
+}
+
+// NewTakeoutRepository creates a new takeout repository
+func NewTakeoutRepository(db *gorm.DB) TakeoutRepository {
+	return &takeoutRepository{db: db}
+}
+
+func (r *takeoutRepository) Create(takeout *domain.Takeout) error {
+	return r.db.Create(takeout).Error
+}
+
+// Find returns an unexpired takeout of userID without its archive
+func (r *takeoutRepository) Find(userID, id int64, now time.Time) (*domain.Takeout, error) {
+	return r.find(r.db.Omit("archive"), userID, id, now)
+}
+
+// FindArchive returns an unexpired takeout of userID with its archive
+func (r *takeoutRepository) FindArchive(userID, id int64, now time.Time) (*domain.Takeout, error) {
+	return r.find(r.db, userID, id, now)
+}
+
+func (r *takeoutRepository) find(query *gorm.DB, userID, id int64, now time.Time) (*domain.Takeout, error) {
+	var takeout domain.Takeout
+	err := query.Where("id = ? AND user_id = ? AND (expires_at IS NULL OR expires_at > ?)", id, userID, now).
+		First(&takeout).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTakeoutNotFound
+		}
+		return nil, err
+	}
+
+	return &takeout, nil
+}
+
+// List returns the unexpired takeouts of a user without their archives, newest first
+func (r *takeoutRepository) List(userID int64, now time.Time) ([]domain.Takeout, error) {
+	var takeouts []domain.Takeout
+	err := r.db.Omit("archive").
+		Where("user_id = ? AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Order("id DESC").
+		Find(&takeouts).Error
+	return takeouts, err
+}
+
+// HasPending reports whether a takeout of the user is still being built
+func (r *takeoutRepository) HasPending(userID int64) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Takeout{}).
+		Where("user_id = ? AND status = ?", userID, domain.TakeoutStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ClaimPending locks pending takeouts whose lease has passed and moves the
+// lease into the future, so concurrent workers skip them while they are
+// built. A takeout whose worker died is claimed again once its lease passes.
+func (r *takeoutRepository) ClaimPending(now time.Time, lease time.Duration, limit int) ([]domain.Takeout, error) {
+	var takeouts []domain.Takeout
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Omit("archive").
+			Where("status = ? AND lease_until <= ?", domain.TakeoutStatusPending, now).
+			Order("id ASC").
+			Limit(limit).
+			Find(&takeouts).Error
+		if err != nil || len(takeouts) == 0 {
+			return err
+		}
+
+		ids := make([]int64, len(takeouts))
+		for i := range takeouts {
+			ids[i] = takeouts[i].ID
+		}
+		return tx.Model(&domain.Takeout{}).
+			Where("id IN ?", ids).
+			UpdateColumn("lease_until", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return takeouts, nil
+}
+
+// Save stores the outcome of building a takeout
+func (r *takeoutRepository) Save(takeout *domain.Takeout) error {
+	return r.db.Save(takeout).Error
+}
+
+// DeleteExpired deletes the takeouts that can no longer be downloaded
+func (r *takeoutRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at <= ?", now).Delete(&domain.Takeout{})
+	return result.RowsAffected, result.Error
+}
+
+// ListSecurityEvents returns the whole security log of a user, oldest first
+func (r *takeoutRepository) ListSecurityEvents(userID int64) ([]domain.SecurityEvent, error) {
+	var events []domain.SecurityEvent
+	err := r.db.Where("user_id = ?", userID).Order("id ASC").Find(&events).Error
+	return events, err
+}
+
+// Restore sets the name and settings of the user and brings the payees,
+// transactions and budgets of an archive into an empty ledger, all or
+// nothing. Records get new IDs; transactions keep their payees.
+func (r *takeoutRepository) Restore(userID int64, name string, settings domain.UserSettings, ledger *domain.TakeoutLedger) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var records int64
+		if err := tx.Raw(countLedgerSQL).Scan(&records).Error; err != nil {
+			return err
+		}
+		if records > 0 {
+			return ErrLedgerNotEmpty
+		}
+
+		result := tx.Model(&domain.User{ID: userID}).
+			Select("name", "settings").
+			Updates(&domain.User{Name: name, Settings: settings})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+
+		payeeIDs := make(map[int64]int64, len(ledger.Payees))
+		for i := range ledger.Payees {
+			payee := ledger.Payees[i]
+			archivedID := payee.ID
+			payee.ID = 0
+			payee.Aliases = make([]domain.PayeeAlias, len(ledger.Payees[i].Aliases))
+			for j, alias := range ledger.Payees[i].Aliases {
+				payee.Aliases[j] = domain.PayeeAlias{Pattern: alias.Pattern}
+			}
+			if err := tx.Create(&payee).Error; err != nil {
+				return err
+			}
+			payeeIDs[archivedID] = payee.ID
+		}
+
+		if len(ledger.Transactions) > 0 {
+			transactions := make([]domain.Transaction, len(ledger.Transactions))
+			for i, transaction := range ledger.Transactions {
+				transaction.ID = 0
+				if transaction.PayeeID != nil {
+					if id, ok := payeeIDs[*transaction.PayeeID]; ok {
+						transaction.PayeeID = &id
+					} else {
+						transaction.PayeeID = nil
+					}
+				}
+				transactions[i] = transaction
+			}
+			if err := tx.CreateInBatches(transactions, takeoutRestoreBatch).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(ledger.Budgets) > 0 {
+			budgets := make([]domain.Budget, len(ledger.Budgets))
+			for i, budget := range ledger.Budgets {
+				budget.ID = 0
+				budgets[i] = budget
+			}
+			if err := tx.Create(&budgets).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+func TestTakeoutRepository_Find(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTakeoutRepository(db)
+	now := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM "takeouts" WHERE id = $1 AND user_id = $2 AND (expires_at IS NULL OR expires_at > $3)`)).
+		WithArgs(int64(4), int64(1), now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "size"}).AddRow(4, 1, "ready", 2048))
+
+	takeout, err := repo.Find(1, 4, now)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TakeoutStatusReady, takeout.Status)
+	assert.Equal(t, int64(2048), takeout.Size)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTakeoutRepository_Find_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTakeoutRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM "takeouts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	_, err := repo.FindArchive(1, 4, time.Now())
+
+	assert.ErrorIs(t, err, ErrTakeoutNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTakeoutRepository_HasPending(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTakeoutRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(*) FROM "takeouts" WHERE user_id = $1 AND status = $2`)).
+		WithArgs(int64(1), domain.TakeoutStatusPending).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	pending, err := repo.HasPending(1)
+
+	require.NoError(t, err)
+	assert.True(t, pending)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTakeoutRepository_ClaimPending(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTakeoutRepository(db)
+	now := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`FROM "takeouts" WHERE status = $1 AND lease_until <= $2 ORDER BY id ASC LIMIT $3 FOR UPDATE SKIP LOCKED`)).
+		WithArgs(domain.TakeoutStatusPending, now, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status"}).
+			AddRow(3, 1, "pending").
+			AddRow(5, 2, "pending"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "takeouts" SET "lease_until"=$1 WHERE id IN ($2,$3)`)).
+		WithArgs(now.Add(30*time.Minute), int64(3), int64(5)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	takeouts, err := repo.ClaimPending(now, 30*time.Minute, 5)
+
+	require.NoError(t, err)
+	require.Len(t, takeouts, 2)
+	assert.Equal(t, int64(2), takeouts[1].UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTakeoutRepository_DeleteExpired(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTakeoutRepository(db)
+	now := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "takeouts" WHERE expires_at <= $1`)).
+		WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	deleted, err := repo.DeleteExpired(now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTakeoutRepository_Restore_LedgerNotEmpty(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTakeoutRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(countLedgerSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectRollback()
+
+	err := repo.Restore(1, "Test User", domain.UserSettings{}, &domain.TakeoutLedger{})
+
+	assert.ErrorIs(t, err, ErrLedgerNotEmpty)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTakeoutRepository_Restore_UserNotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTakeoutRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(countLedgerSQL)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "name"=$1,"settings"=$2,"updated_at"=$3 WHERE "id" = $4`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.Restore(9, "Test User", domain.UserSettings{}, &domain.TakeoutLedger{})
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
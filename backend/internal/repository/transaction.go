@@ -1,22 +1,35 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/metrics"
 	"github.com/dev/personal-finance-tracker/backend/internal/security"
 )
 
+// ErrTransactionNotFound is returned when a transaction to update does not exist
+var ErrTransactionNotFound = errors.New("transaction not found")
+
 // TransactionRepository handles database operations for transactions
 type TransactionRepository interface {
 	Create(tx *domain.Transaction) error
 	CreateInBatch(transactions []domain.Transaction) error
+	Update(tx *domain.Transaction) error
 	FindByID(id int64) (*domain.Transaction, error)
 	List(params domain.ListTransactionsQueryParams) ([]domain.Transaction, int64, error)
+	Stream(ctx context.Context, params domain.ListTransactionsQueryParams, fn func(tx *domain.Transaction) error) error
 	GetSummary() (*domain.SummaryResponse, error)
 	GetTrends(period string) ([]domain.TrendDataPoint, error)
 	GetBreakdownBySource() ([]domain.BreakdownResponse, error)
 	GetBreakdownByCategory() ([]domain.BreakdownResponse, error)
+	GetBreakdown(filter *domain.BreakdownFilter) ([]domain.BreakdownResponse, error)
+	GetPivot(filter *domain.PivotFilter) ([]domain.PivotCell, error)
 }
 
 type transactionRepository struct {
@@ -47,6 +60,10 @@ func (r *transactionRepository) CreateInBatch(transactions []domain.Transaction)
 	return r.db.CreateInBatches(transactions, 100).Error
 }
 
+func (r *transactionRepository) Update(tx *domain.Transaction) error {
+	return r.db.Save(tx).Error
+}
+
 func (r *transactionRepository) FindByID(id int64) (*domain.Transaction, error) {
 	var tx domain.Transaction
 	// Use parameterized query (implicit protection via GORM)
@@ -61,8 +78,64 @@ func (r *transactionRepository) List(params domain.ListTransactionsQueryParams)
 	var transactions []domain.Transaction
 	var total int64
 
-	query := r.db.Model(&domain.Transaction{})
+	query := r.applyListFilters(r.db.Model(&domain.Transaction{}), params)
+
+	// Count total
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Enforce maximum page size (prevent DoS via large page sizes)
+	pageSize := params.PageSize
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	if pageSize <= 0 {
+		pageSize = 20 // default page size
+	}
+
+	// Apply pagination with offset validation
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	offset := (params.Page - 1) * pageSize
+
+	err := query.
+		Order("transaction_date DESC").
+		Limit(pageSize).
+		Offset(offset).
+		Find(&transactions).Error
+
+	return transactions, total, err
+}
+
+// Stream calls fn for every transaction matching the listing filters, newest
+// first, reading rows from a single cursor instead of loading them all.
+// Pagination is ignored. Iteration stops at the first error returned by fn
+// and when ctx is done.
+func (r *transactionRepository) Stream(ctx context.Context, params domain.ListTransactionsQueryParams, fn func(tx *domain.Transaction) error) error {
+	rows, err := r.applyListFilters(r.db.WithContext(ctx).Model(&domain.Transaction{}), params).
+		Order("transaction_date DESC, id DESC").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tx domain.Transaction
+		if err := r.db.ScanRows(rows, &tx); err != nil {
+			return err
+		}
+		if err := fn(&tx); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
 
+// applyListFilters adds the type, source, category and date filters of a listing
+func (r *transactionRepository) applyListFilters(query *gorm.DB, params domain.ListTransactionsQueryParams) *gorm.DB {
 	// Apply filters with explicit sanitization (defense-in-depth)
 	// GORM's ? placeholder provides parameterized query protection
 	if params.Type != "" {
@@ -84,45 +157,25 @@ func (r *transactionRepository) List(params domain.ListTransactionsQueryParams)
 			query = query.Where("category = ?", safeCategory)
 		}
 	}
-	if params.StartDate != "" {
+	if params.Dates.Start != nil {
+		query = query.Where("transaction_date >= ?", *params.Dates.Start)
+	} else if params.StartDate != "" {
 		// Dates are always in ISO 8601 format (RFC3339), which is safe
 		// The validation in domain layer ensures proper format
 		query = query.Where("transaction_date >= ?", params.StartDate)
 	}
-	if params.EndDate != "" {
+	if params.Dates.End != nil {
+		query = query.Where("transaction_date <= ?", *params.Dates.End)
+	} else if params.EndDate != "" {
 		query = query.Where("transaction_date <= ?", params.EndDate)
 	}
 
-	// Count total
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Enforce maximum page size (prevent DoS via large page sizes)
-	pageSize := params.PageSize
-	if pageSize > maxPageSize {
-		pageSize = maxPageSize
-	}
-	if pageSize <= 0 {
-		pageSize = 20 // default page size
-	}
-
-	// Apply pagination with offset validation
-	if params.Page < 1 {
-		params.Page = 1
-	}
-	offset := (params.Page - 1) * pageSize
-
-	err := query.
-		Order("transaction_date DESC").
-		Limit(pageSize).
-		Offset(offset).
-		Find(&transactions).Error
-
-	return transactions, total, err
+	return query
 }
 
 func (r *transactionRepository) GetSummary() (*domain.SummaryResponse, error) {
+	defer metrics.ObserveAnalyticsQuery("summary", time.Now())
+
 	var result struct {
 		TotalIncome      float64
 		TotalExpense     float64
@@ -151,6 +204,8 @@ func (r *transactionRepository) GetSummary() (*domain.SummaryResponse, error) {
 }
 
 func (r *transactionRepository) GetTrends(period string) ([]domain.TrendDataPoint, error) {
+	defer metrics.ObserveAnalyticsQuery("trends", time.Now())
+
 	var results []domain.TrendDataPoint
 
 	// Validate and sanitize period parameter (whitelist approach)
@@ -220,76 +275,128 @@ func (r *transactionRepository) GetTrends(period string) ([]domain.TrendDataPoin
 	return results, err
 }
 
+// breakdownLabelColumns maps each breakdown dimension to a hardcoded SQL expression.
+// Only whitelisted expressions are ever interpolated into the query.
+var breakdownLabelColumns = map[string]string{
+	domain.BreakdownBySource:        "source",
+	domain.BreakdownBySourceAccount: "COALESCE(NULLIF(source_account, ''), 'Unknown')",
+	domain.BreakdownByCategory:      "COALESCE(NULLIF(category, ''), 'Uncategorized')",
+	domain.BreakdownByRecipient:     "COALESCE(NULLIF(recipient, ''), 'Unknown')",
+	domain.BreakdownByWeekday:       "TO_CHAR(transaction_date, 'FMDay')",
+}
+
 func (r *transactionRepository) GetBreakdownBySource() ([]domain.BreakdownResponse, error) {
+	return r.GetBreakdown(&domain.BreakdownFilter{
+		Type:    domain.TransactionTypeOut,
+		GroupBy: domain.BreakdownBySource,
+	})
+}
+
+func (r *transactionRepository) GetBreakdownByCategory() ([]domain.BreakdownResponse, error) {
+	return r.GetBreakdown(&domain.BreakdownFilter{
+		Type:    domain.TransactionTypeOut,
+		GroupBy: domain.BreakdownByCategory,
+	})
+}
+
+func (r *transactionRepository) GetBreakdown(filter *domain.BreakdownFilter) ([]domain.BreakdownResponse, error) {
+	defer metrics.ObserveAnalyticsQuery("breakdown", time.Now())
+
 	var results []domain.BreakdownResponse
 
-	// First get total amount
-	var totalExpense float64
-	r.db.Model(&domain.Transaction{}).
-		Where("type = ?", domain.TransactionTypeOut).
-		Select("COALESCE(SUM(amount), 0)").
-		Scan(&totalExpense)
+	// Validate type and dimension against whitelists before building the query
+	if !r.sanitizer.ValidateTransactionType(string(filter.Type)) {
+		return nil, fmt.Errorf("invalid transaction type: %q", filter.Type)
+	}
+	labelColumn, ok := breakdownLabelColumns[filter.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("invalid breakdown dimension: %q", filter.GroupBy)
+	}
 
-	// Get breakdown by source - hardcoded SQL with no user input
+	where := "WHERE type = ?"
+	args := []interface{}{filter.Type}
+	if filter.Start != nil {
+		where += " AND transaction_date >= ?"
+		args = append(args, *filter.Start)
+	}
+	if filter.End != nil {
+		where += " AND transaction_date <= ?"
+		args = append(args, *filter.End)
+	}
+
+	// Percentages are computed with a window over the grouped sums,
+	// so the total and the rows always come from the same snapshot
 	query := `
 		SELECT
-			source as label,
+			` + labelColumn + ` as label,
 			COALESCE(SUM(amount), 0) as amount,
-			COUNT(*) as count
+			COUNT(*) as count,
+			COALESCE(SUM(amount) * 100.0 / NULLIF(SUM(SUM(amount)) OVER (), 0), 0) as percentage
 		FROM transactions
-		WHERE type = 'out'
-		GROUP BY source
+		` + where + `
+		GROUP BY label
 		ORDER BY amount DESC
 	`
 
-	err := r.db.Raw(query).Scan(&results).Error
+	err := r.db.Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate percentages
-	for i := range results {
-		if totalExpense > 0 {
-			results[i].Percentage = (results[i].Amount / totalExpense) * 100
-		}
-	}
-
 	return results, nil
 }
 
-func (r *transactionRepository) GetBreakdownByCategory() ([]domain.BreakdownResponse, error) {
-	var results []domain.BreakdownResponse
+// pivotPeriodFormats maps each pivot period to a hardcoded TO_CHAR format
+var pivotPeriodFormats = map[string]string{
+	"daily":   "YYYY-MM-DD",
+	"weekly":  `IYYY-"W"IW`,
+	"monthly": "YYYY-MM",
+}
+
+func (r *transactionRepository) GetPivot(filter *domain.PivotFilter) ([]domain.PivotCell, error) {
+	defer metrics.ObserveAnalyticsQuery("pivot", time.Now())
 
-	// First get total amount
-	var totalExpense float64
-	r.db.Model(&domain.Transaction{}).
-		Where("type = ?", domain.TransactionTypeOut).
-		Select("COALESCE(SUM(amount), 0)").
-		Scan(&totalExpense)
+	var results []domain.PivotCell
+
+	// Validate every interpolated fragment against a whitelist
+	if !r.sanitizer.ValidateTransactionType(string(filter.Type)) {
+		return nil, fmt.Errorf("invalid transaction type: %q", filter.Type)
+	}
+	rowColumn, ok := breakdownLabelColumns[filter.Rows]
+	if !ok {
+		return nil, fmt.Errorf("invalid pivot row dimension: %q", filter.Rows)
+	}
+	periodFormat, ok := pivotPeriodFormats[filter.Period]
+	if !ok {
+		return nil, fmt.Errorf("invalid pivot period: %q", filter.Period)
+	}
+
+	where := "WHERE type = ?"
+	args := []interface{}{filter.Type}
+	if filter.Start != nil {
+		where += " AND transaction_date >= ?"
+		args = append(args, *filter.Start)
+	}
+	if filter.End != nil {
+		where += " AND transaction_date <= ?"
+		args = append(args, *filter.End)
+	}
 
-	// Get breakdown by category - hardcoded SQL with no user input
 	query := `
 		SELECT
-			COALESCE(category, 'Uncategorized') as label,
-			COALESCE(SUM(amount), 0) as amount,
-			COUNT(*) as count
+			` + rowColumn + ` as row_label,
+			TO_CHAR(transaction_date, '` + periodFormat + `') as column_label,
+			COALESCE(SUM(amount), 0) as amount
 		FROM transactions
-		WHERE type = 'out'
-		GROUP BY category
-		ORDER BY amount DESC
+		` + where + `
+		GROUP BY row_label, column_label
+		ORDER BY column_label, row_label
 	`
 
-	err := r.db.Raw(query).Scan(&results).Error
+	err := r.db.Raw(query, args...).Scan(&results).Error
 	if err != nil {
 		return nil, err
 	}
 
-	// Calculate percentages
-	for i := range results {
-		if totalExpense > 0 {
-			results[i].Percentage = (results[i].Amount / totalExpense) * 100
-		}
-	}
-
 	return results, nil
 }
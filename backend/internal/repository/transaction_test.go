@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
@@ -342,16 +344,14 @@ func TestTransactionRepository_GetBreakdownBySource_Success(t *testing.T) {
 
 	repo := NewTransactionRepository(db)
 
-	// Mock total expense query
-	totalRows := sqlmock.NewRows([]string{"COALESCE(SUM(amount), 0)"}).AddRow(1000.00)
-	mock.ExpectQuery("SELECT").WillReturnRows(totalRows)
+	// Percentages are computed in the same query as the breakdown
+	rows := sqlmock.NewRows([]string{"label", "amount", "count", "percentage"}).
+		AddRow("Bank ABC", 500.00, 5, 50.0).
+		AddRow("Bank XYZ", 300.00, 3, 30.0)
 
-	// Mock breakdown query
-	rows := sqlmock.NewRows([]string{"label", "amount", "count"}).
-		AddRow("Bank ABC", 500.00, 5).
-		AddRow("Bank XYZ", 300.00, 3)
-
-	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("GROUP BY label")).
+		WithArgs(domain.TransactionTypeOut).
+		WillReturnRows(rows)
 
 	breakdown, err := repo.GetBreakdownBySource()
 
@@ -360,6 +360,7 @@ func TestTransactionRepository_GetBreakdownBySource_Success(t *testing.T) {
 	assert.Equal(t, "Bank ABC", breakdown[0].Label)
 	assert.Equal(t, 500.00, breakdown[0].Amount)
 	assert.Equal(t, 50.0, breakdown[0].Percentage)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 // Test GetBreakdownByCategory
@@ -370,14 +371,9 @@ func TestTransactionRepository_GetBreakdownByCategory_Success(t *testing.T) {
 
 	repo := NewTransactionRepository(db)
 
-	// Mock total expense query
-	totalRows := sqlmock.NewRows([]string{"COALESCE(SUM(amount), 0)"}).AddRow(1000.00)
-	mock.ExpectQuery("SELECT").WillReturnRows(totalRows)
-
-	// Mock breakdown query
-	rows := sqlmock.NewRows([]string{"label", "amount", "count"}).
-		AddRow("Food", 300.00, 10).
-		AddRow("Transportation", 200.00, 5)
+	rows := sqlmock.NewRows([]string{"label", "amount", "count", "percentage"}).
+		AddRow("Food", 300.00, 10, 30.0).
+		AddRow("Transportation", 200.00, 5, 20.0)
 
 	mock.ExpectQuery("SELECT").WillReturnRows(rows)
 
@@ -396,15 +392,11 @@ func TestTransactionRepository_GetBreakdownByCategory_Uncategorized(t *testing.T
 
 	repo := NewTransactionRepository(db)
 
-	// Mock total expense query
-	totalRows := sqlmock.NewRows([]string{"COALESCE(SUM(amount), 0)"}).AddRow(500.00)
-	mock.ExpectQuery("SELECT").WillReturnRows(totalRows)
-
 	// Mock breakdown query with NULL category
-	rows := sqlmock.NewRows([]string{"label", "amount", "count"}).
-		AddRow("Uncategorized", 100.00, 2)
+	rows := sqlmock.NewRows([]string{"label", "amount", "count", "percentage"}).
+		AddRow("Uncategorized", 100.00, 2, 100.0)
 
-	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta("'Uncategorized'")).WillReturnRows(rows)
 
 	breakdown, err := repo.GetBreakdownByCategory()
 
@@ -412,3 +404,205 @@ func TestTransactionRepository_GetBreakdownByCategory_Uncategorized(t *testing.T
 	assert.Len(t, breakdown, 1)
 	assert.Equal(t, "Uncategorized", breakdown[0].Label)
 }
+
+func TestTransactionRepository_GetBreakdownByCategory_DatabaseError(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnError(sql.ErrConnDone)
+
+	breakdown, err := repo.GetBreakdownByCategory()
+
+	assert.Error(t, err)
+	assert.Nil(t, breakdown)
+}
+
+// Test GetBreakdown
+
+func TestTransactionRepository_GetBreakdown_IncomeWithDateRange(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"label", "amount", "count", "percentage"}).
+		AddRow("Monday", 2000.00, 1, 100.0)
+
+	mock.ExpectQuery(regexp.QuoteMeta("TO_CHAR(transaction_date, 'FMDay') as label")).
+		WithArgs(domain.TransactionTypeIn, start, end).
+		WillReturnRows(rows)
+
+	breakdown, err := repo.GetBreakdown(&domain.BreakdownFilter{
+		Type:      domain.TransactionTypeIn,
+		GroupBy:   domain.BreakdownByWeekday,
+		DateRange: domain.DateRange{Start: &start, End: &end},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, breakdown, 1)
+	assert.Equal(t, "Monday", breakdown[0].Label)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepository_GetBreakdown_InvalidDimension(t *testing.T) {
+	db, _, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	breakdown, err := repo.GetBreakdown(&domain.BreakdownFilter{
+		Type:    domain.TransactionTypeOut,
+		GroupBy: "amount; DROP TABLE transactions",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, breakdown)
+}
+
+// Test GetPivot
+
+func TestTransactionRepository_GetPivot_Monthly(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 2, 28, 23, 59, 59, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"row_label", "column_label", "amount"}).
+		AddRow("Food", "2026-01", 100.00).
+		AddRow("Food", "2026-02", 150.00)
+
+	mock.ExpectQuery(regexp.QuoteMeta("TO_CHAR(transaction_date, 'YYYY-MM') as column_label")).
+		WithArgs(domain.TransactionTypeOut, start, end).
+		WillReturnRows(rows)
+
+	cells, err := repo.GetPivot(&domain.PivotFilter{
+		Type:      domain.TransactionTypeOut,
+		Rows:      domain.BreakdownByCategory,
+		Period:    "monthly",
+		DateRange: domain.DateRange{Start: &start, End: &end},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, cells, 2)
+	assert.Equal(t, "2026-02", cells[1].ColumnLabel)
+	assert.Equal(t, 150.00, cells[1].Amount)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepository_GetPivot_InvalidPeriod(t *testing.T) {
+	db, _, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	cells, err := repo.GetPivot(&domain.PivotFilter{
+		Type:   domain.TransactionTypeOut,
+		Rows:   domain.BreakdownByCategory,
+		Period: "hourly",
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, cells)
+}
+
+func TestTransactionRepository_Stream(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	now := time.Now().Truncate(time.Second)
+	rows := sqlmock.NewRows([]string{"id", "amount", "type", "category", "source", "transaction_date"}).
+		AddRow(2, 20.0, "out", "Food", "Bank ABC", now).
+		AddRow(1, 10.0, "out", "Food", "Bank ABC", now.Add(-time.Hour))
+
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE type = $1 AND category = $2 ORDER BY transaction_date DESC, id DESC")).
+		WithArgs(domain.TransactionTypeOut, "Food").
+		WillReturnRows(rows)
+
+	params := domain.ListTransactionsQueryParams{Type: domain.TransactionTypeOut, Category: "Food", Page: 3, PageSize: 1}
+	var ids []int64
+	err := repo.Stream(context.Background(), params, func(tx *domain.Transaction) error {
+		ids = append(ids, tx.ID)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 1}, ids)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionRepository_Stream_StopsOnError(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	rows := sqlmock.NewRows([]string{"id"}).AddRow(2).AddRow(1)
+	mock.ExpectQuery("SELECT").WillReturnRows(rows)
+
+	calls := 0
+	err := repo.Stream(context.Background(), domain.ListTransactionsQueryParams{}, func(tx *domain.Transaction) error {
+		calls++
+		return errors.New("client went away")
+	})
+
+	assert.EqualError(t, err, "client went away")
+	assert.Equal(t, 1, calls)
+}
+
+func TestTransactionRepository_Stream_StopsWhenContextDone(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	err := repo.Stream(ctx, domain.ListTransactionsQueryParams{}, func(tx *domain.Transaction) error {
+		calls++
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Zero(t, calls)
+}
+
+func TestTransactionRepository_Stream_ParsedDates(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewTransactionRepository(db)
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 23, 59, 59, 999999999, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE transaction_date >= $1 AND transaction_date <= $2")).
+		WithArgs(start, end).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+
+	params := domain.ListTransactionsQueryParams{
+		StartDate: "2026-03-01",
+		EndDate:   "2026-03-31",
+		Dates:     domain.DateRange{Start: &start, End: &end},
+	}
+	calls := 0
+	err := repo.Stream(context.Background(), params, func(tx *domain.Transaction) error {
+		calls++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -25,6 +25,7 @@ type UserRepository interface {
 	FindByID(id int64) (*domain.User, error)
 	UpdateLastLogin(userID int64) error
 	Update(user *domain.User) error
+	UpdatePassword(userID int64, passwordHash string, revokeSessionsAt time.Time) error
 }
 
 type userRepository struct {
@@ -127,3 +128,21 @@ func (r *userRepository) UpdateLastLogin(userID int64) error {
 func (r *userRepository) Update(user *domain.User) error {
 	return r.db.Save(user).Error
 }
+
+// UpdatePassword sets a new password hash and revokes the tokens issued
+// before revokeSessionsAt
+func (r *userRepository) UpdatePassword(userID int64, passwordHash string, revokeSessionsAt time.Time) error {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{
+			"password_hash":       passwordHash,
+			"sessions_revoked_at": revokeSessionsAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
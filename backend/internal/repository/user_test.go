@@ -304,3 +304,40 @@ func TestUserRepository_Create_SanitizesEmail(t *testing.T) {
 	// Email should be sanitized (trimmed and lowercase) before being used
 	// The sanitizer in the repo should handle this
 }
+
+// Test UpdatePassword()
+
+func TestUserRepository_UpdatePassword_Success(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewUserRepository(db)
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "password_hash"=$1,"sessions_revoked_at"=$2,"updated_at"=$3 WHERE id = $4`)).
+		WithArgs("$argon2id$new", now, sqlmock.AnyArg(), int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.UpdatePassword(1, "$argon2id$new", now)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_UpdatePassword_NotFound(t *testing.T) {
+	db, mock, sqlDB := setupMockDB(t)
+	defer sqlDB.Close()
+
+	repo := NewUserRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := repo.UpdatePassword(99, "$argon2id$new", time.Now())
+
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
@@ -9,44 +9,63 @@ import (
 	"github.com/google/uuid"
 )
 
+// MFATokenPurpose marks tokens that only prove the password of a user
+// with two-factor authentication, until the second factor is verified
+const MFATokenPurpose = "mfa"
+
 // JWTManager handles JWT token creation and validation
 type JWTManager struct {
 	secretKey string
 	issuer    string
 	// TokenExpiry is how long tokens are valid for
 	TokenExpiry time.Duration
+	// MFATokenExpiry is how long the second login step may take
+	MFATokenExpiry time.Duration
 }
 
 // Claims represents the JWT claims structure
 type Claims struct {
-	UserID int64  `json:"user_id"`
-	Email  string `json:"email"`
-	UUID   string `json:"uuid"`
+	UserID  int64  `json:"user_id"`
+	Email   string `json:"email"`
+	UUID    string `json:"uuid"`
+	Purpose string `json:"purpose,omitempty"` // Empty for access tokens
 	jwt.RegisteredClaims
 }
 
 // NewJWTManager creates a new JWT manager
 func NewJWTManager(secretKey string) *JWTManager {
 	return &JWTManager{
-		secretKey:   secretKey,
-		issuer:      "personal-finance-tracker",
-		TokenExpiry: 7 * 24 * time.Hour, // 7 days
+		secretKey:      secretKey,
+		issuer:         "personal-finance-tracker",
+		TokenExpiry:    7 * 24 * time.Hour, // 7 days
+		MFATokenExpiry: 5 * time.Minute,
 	}
 }
 
 // GenerateToken generates a new JWT token for a user
 func (j *JWTManager) GenerateToken(userID int64, email string, userUUID uuid.UUID) (string, error) {
+	return j.generate(userID, email, userUUID, "", j.TokenExpiry)
+}
+
+// GenerateMFAToken generates a short-lived token for the second login step
+// of a user with two-factor authentication. It is not an access token.
+func (j *JWTManager) GenerateMFAToken(userID int64, email string, userUUID uuid.UUID) (string, error) {
+	return j.generate(userID, email, userUUID, MFATokenPurpose, j.MFATokenExpiry)
+}
+
+func (j *JWTManager) generate(userID int64, email string, userUUID uuid.UUID, purpose string, expiry time.Duration) (string, error) {
 	if j.secretKey == "" {
 		return "", errors.New("JWT secret key is not configured")
 	}
 
 	now := time.Now()
-	expiresAt := now.Add(j.TokenExpiry)
+	expiresAt := now.Add(expiry)
 
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		UUID:   userUUID.String(),
+		UserID:  userID,
+		Email:   email,
+		UUID:    userUUID.String(),
+		Purpose: purpose,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    j.issuer,
 			Subject:   email,
@@ -65,8 +84,17 @@ func (j *JWTManager) GenerateToken(userID int64, email string, userUUID uuid.UUI
 	return tokenString, nil
 }
 
-// ValidateToken validates a JWT token and returns the claims
+// ValidateToken validates a JWT access token and returns the claims
 func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
+	return j.validate(tokenString, "")
+}
+
+// ValidateMFAToken validates a token of the second login step and returns the claims
+func (j *JWTManager) ValidateMFAToken(tokenString string) (*Claims, error) {
+	return j.validate(tokenString, MFATokenPurpose)
+}
+
+func (j *JWTManager) validate(tokenString, purpose string) (*Claims, error) {
 	if j.secretKey == "" {
 		return nil, errors.New("JWT secret key is not configured")
 	}
@@ -93,6 +121,11 @@ func (j *JWTManager) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, fmt.Errorf("invalid issuer: %s", claims.Issuer)
 	}
 
+	// Tokens for one purpose never pass for another
+	if claims.Purpose != purpose {
+		return nil, errors.New("invalid token purpose")
+	}
+
 	return claims, nil
 }
 
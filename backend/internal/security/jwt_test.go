@@ -169,6 +169,39 @@ func TestJWTManager_ValidateToken_WrongIssuer(t *testing.T) {
 	assert.Contains(t, err.Error(), "issuer")
 }
 
+// Test JWTManager MFA tokens
+
+func TestJWTManager_GenerateMFAToken(t *testing.T) {
+	manager := NewJWTManager(testJWTSecret)
+	userUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	token, err := manager.GenerateMFAToken(1, "user@example.com", userUUID)
+	assert.NoError(t, err)
+
+	claims, err := manager.ValidateMFAToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), claims.UserID)
+	assert.Equal(t, MFATokenPurpose, claims.Purpose)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), claims.ExpiresAt.Time, 2*time.Second)
+}
+
+func TestJWTManager_MFAToken_IsNoAccessToken(t *testing.T) {
+	manager := NewJWTManager(testJWTSecret)
+	userUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	mfaToken, err := manager.GenerateMFAToken(1, "user@example.com", userUUID)
+	assert.NoError(t, err)
+	accessToken, err := manager.GenerateToken(1, "user@example.com", userUUID)
+	assert.NoError(t, err)
+
+	_, err = manager.ValidateToken(mfaToken)
+	assert.EqualError(t, err, "invalid token purpose")
+	_, err = manager.RefreshToken(mfaToken)
+	assert.Error(t, err)
+	_, err = manager.ValidateMFAToken(accessToken)
+	assert.EqualError(t, err, "invalid token purpose")
+}
+
 // Test JWTManager.RefreshToken()
 
 func TestJWTManager_RefreshToken_ValidToken(t *testing.T) {
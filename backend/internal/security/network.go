@@ -0,0 +1,88 @@
+package security
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+// ErrAddressNotAllowed is returned for addresses outbound requests must not reach
+var ErrAddressNotAllowed = errors.New("address is loopback, private, link-local or unspecified")
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which cluster
+// networks also use
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// AddressPolicy decides which addresses outbound requests to user-supplied
+// URLs, such as webhook endpoints, may connect to. Loopback, private,
+// link-local, unspecified and multicast addresses are rejected so users cannot
+// reach the server itself, cloud metadata services or cluster-internal
+// services, unless they are in one of the allowed networks.
+type AddressPolicy struct {
+	allowed []netip.Prefix
+}
+
+// NewAddressPolicy creates a policy that also allows the given networks in
+// CIDR notation, such as 127.0.0.0/8 for local development
+func NewAddressPolicy(allowedNetworks []string) (*AddressPolicy, error) {
+	policy := &AddressPolicy{}
+	for _, network := range allowedNetworks {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(network))
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed network %q: %w", network, err)
+		}
+		policy.allowed = append(policy.allowed, prefix.Masked())
+	}
+	return policy, nil
+}
+
+// Allows reports whether outbound requests may connect to addr
+func (p *AddressPolicy) Allows(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range p.allowed {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return !(addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr))
+}
+
+// CheckHost rejects a URL host that is a forbidden IP address or localhost.
+// Other host names are checked by Control once they are resolved, which
+// also covers names that resolve to another address later.
+func (p *AddressPolicy) CheckHost(host string) error {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		host = "127.0.0.1"
+	}
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
+	if err != nil {
+		return nil
+	}
+	if !p.Allows(addr) {
+		return ErrAddressNotAllowed
+	}
+	return nil
+}
+
+// Control rejects connections to forbidden addresses. Used as the Control
+// function of a net.Dialer, it checks the resolved address of every
+// connection, so DNS rebinding cannot get around CheckHost.
+func (p *AddressPolicy) Control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	if !p.Allows(addr) {
+		return fmt.Errorf("connect to %s: %w", address, ErrAddressNotAllowed)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package security
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressPolicy_Allows(t *testing.T) {
+	policy, err := NewAddressPolicy(nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.5", false},
+		{"172.16.3.4", false},
+		{"192.168.1.10", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, policy.Allows(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestAddressPolicy_AllowedNetworks(t *testing.T) {
+	policy, err := NewAddressPolicy([]string{"127.0.0.0/8", " 10.1.0.0/16 "})
+	require.NoError(t, err)
+
+	assert.True(t, policy.Allows(netip.MustParseAddr("127.0.0.1")))
+	assert.True(t, policy.Allows(netip.MustParseAddr("10.1.2.3")))
+	assert.False(t, policy.Allows(netip.MustParseAddr("10.2.0.1")))
+	assert.False(t, policy.Allows(netip.MustParseAddr("169.254.169.254")))
+
+	_, err = NewAddressPolicy([]string{"localhost"})
+	assert.Error(t, err)
+}
+
+func TestAddressPolicy_CheckHost(t *testing.T) {
+	policy, err := NewAddressPolicy(nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.CheckHost("example.com"))
+	assert.NoError(t, policy.CheckHost("93.184.216.34"))
+	assert.ErrorIs(t, policy.CheckHost("169.254.169.254"), ErrAddressNotAllowed)
+	assert.ErrorIs(t, policy.CheckHost("[::1]"), ErrAddressNotAllowed)
+	assert.ErrorIs(t, policy.CheckHost("LOCALHOST."), ErrAddressNotAllowed)
+	assert.ErrorIs(t, policy.CheckHost("api.localhost"), ErrAddressNotAllowed)
+}
+
+func TestAddressPolicy_Control(t *testing.T) {
+	policy, err := NewAddressPolicy(nil)
+	require.NoError(t, err)
+
+	assert.NoError(t, policy.Control("tcp4", "93.184.216.34:443", nil))
+	assert.ErrorIs(t, policy.Control("tcp4", "10.0.0.5:80", nil), ErrAddressNotAllowed)
+	assert.ErrorIs(t, policy.Control("tcp6", "[::1]:8080", nil), ErrAddressNotAllowed)
+}
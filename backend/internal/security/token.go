@@ -0,0 +1,29 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// linkTokenBytes is the entropy of the tokens in emailed links
+const linkTokenBytes = 32
+
+// GenerateLinkToken returns a random URL-safe token for an emailed link,
+// such as a password reset or email confirmation link
+func GenerateLinkToken() (string, error) {
+	bytes := make([]byte, linkTokenBytes)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashLinkToken returns the hex SHA-256 of a link token, the form it is
+// stored in. Tokens are random, so they need no salt or slow hash.
+func HashLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
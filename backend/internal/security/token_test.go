@@ -0,0 +1,24 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateLinkToken(t *testing.T) {
+	token, err := GenerateLinkToken()
+	require.NoError(t, err)
+	other, err := GenerateLinkToken()
+	require.NoError(t, err)
+
+	assert.Len(t, token, 43)
+	assert.Regexp(t, `^[A-Za-z0-9_-]+$`, token, "safe in a URL without escaping")
+	assert.NotEqual(t, token, other)
+}
+
+func TestHashLinkToken(t *testing.T) {
+	// echo -n reset-token | sha256sum
+	assert.Equal(t, "7c18b43a1d8227cddb332e67971e790ce35ac2303f4fccfb2a565622f2fe1cec", HashLinkToken("reset-token"))
+}
@@ -0,0 +1,116 @@
+package security
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"image/png"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/hotp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// TOTPPeriod is how long a TOTP code is valid
+	TOTPPeriod = 30 * time.Second
+	// totpSkew accepts codes of the periods before and after the current one, against clock drift
+	totpSkew = 1
+	// recoveryCodeAlphabet leaves out characters that are easily confused, like 0 and o
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	// recoveryCodeLength is the number of random characters of a recovery code
+	recoveryCodeLength = 10
+)
+
+// TOTPKey is a new TOTP secret and the otpauth:// URI that adds it to an authenticator app
+type TOTPKey struct {
+	Secret string // Base32, for manual entry
+	URL    string
+}
+
+// GenerateTOTPKey creates a random 160-bit secret for account, listed under
+// issuer in authenticator apps. Codes have 6 digits and change every 30 seconds.
+func GenerateTOTPKey(issuer, account string) (*TOTPKey, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: account,
+		Period:      uint(TOTPPeriod / time.Second),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate TOTP secret: %w", err)
+	}
+	return &TOTPKey{Secret: key.Secret(), URL: key.URL()}, nil
+}
+
+// TOTPQRCode renders an otpauth:// URI as a PNG QR code of size by size pixels
+func TOTPQRCode(url string, size int) ([]byte, error) {
+	key, err := otp.NewKeyFromURL(url)
+	if err != nil {
+		return nil, err
+	}
+	img, err := key.Image(size, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render QR code: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ValidateTOTP checks a code against secret at now and returns its time
+// step. Codes of steps up to lastStep have been used before and are
+// refused, so every code works only once.
+func ValidateTOTP(secret, code string, now time.Time, lastStep int64) (int64, bool) {
+	step := now.Unix() / int64(TOTPPeriod/time.Second)
+	for s := step - totpSkew; s <= step+totpSkew; s++ {
+		if s <= lastStep {
+			continue
+		}
+		expected, err := hotp.GenerateCode(secret, uint64(s))
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCodes returns n random one-time codes formatted like "k7m2p-x9qrt"
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	codes := make([]string, n)
+	for i := range codes {
+		var code strings.Builder
+		for j := 0; j < recoveryCodeLength; j++ {
+			if j == recoveryCodeLength/2 {
+				code.WriteByte('-')
+			}
+			k, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+			}
+			code.WriteByte(recoveryCodeAlphabet[k.Int64()])
+		}
+		codes[i] = code.String()
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the hex SHA-256 of a recovery code, ignoring case,
+// spaces and dashes. Codes are random, so they need no salt or slow hash.
+func HashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer(" ", "", "-", "").Replace(strings.ToLower(strings.TrimSpace(code)))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
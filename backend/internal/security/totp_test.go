@@ -0,0 +1,78 @@
+package security
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestValidateTOTP_RFC6238Vectors(t *testing.T) {
+	step, ok := ValidateTOTP(rfc6238Secret, "287082", time.Unix(59, 0), 0)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), step)
+
+	step, ok = ValidateTOTP(rfc6238Secret, "081804", time.Unix(1111111109, 0), 0)
+	assert.True(t, ok)
+	assert.Equal(t, int64(37037036), step)
+}
+
+func TestValidateTOTP_Skew(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	_, ok := ValidateTOTP(rfc6238Secret, "081804", now.Add(TOTPPeriod), 0)
+	assert.True(t, ok, "codes of the previous period are accepted")
+	_, ok = ValidateTOTP(rfc6238Secret, "081804", now.Add(-TOTPPeriod), 0)
+	assert.True(t, ok, "codes of the next period are accepted")
+	_, ok = ValidateTOTP(rfc6238Secret, "081804", now.Add(2*TOTPPeriod), 0)
+	assert.False(t, ok)
+}
+
+func TestValidateTOTP_RefusesUsedSteps(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	_, ok := ValidateTOTP(rfc6238Secret, "081804", now, 37037036)
+	assert.False(t, ok)
+	_, ok = ValidateTOTP(rfc6238Secret, "000000", now, 0)
+	assert.False(t, ok)
+}
+
+func TestGenerateTOTPKey(t *testing.T) {
+	key, err := GenerateTOTPKey("Finance Tracker", "user@example.com")
+
+	require.NoError(t, err)
+	assert.Len(t, key.Secret, 32)
+	assert.Contains(t, key.URL, "otpauth://totp/Finance%20Tracker:user@example.com?")
+	assert.Contains(t, key.URL, "secret="+key.Secret)
+
+	qrCode, err := TOTPQRCode(key.URL, 200)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(qrCode, []byte("\x89PNG\r\n\x1a\n")))
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+
+	require.NoError(t, err)
+	assert.Len(t, codes, 10)
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		assert.Regexp(t, `^[a-hjkmnp-z2-9]{5}-[a-hjkmnp-z2-9]{5}$`, code)
+		assert.False(t, seen[code], "codes are unique")
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	hash := HashRecoveryCode("k7m2p-x9qrt")
+
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashRecoveryCode(" K7M2P X9QRT "))
+	assert.Equal(t, hash, HashRecoveryCode("k7m2px9qrt"))
+	assert.NotEqual(t, hash, HashRecoveryCode("k7m2p-x9qrs"))
+}
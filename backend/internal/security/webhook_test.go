@@ -0,0 +1,27 @@
+package security
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignWebhook(t *testing.T) {
+	body := []byte(`{"type":"transaction.created"}`)
+
+	signature := SignWebhook("whsec_test", 1773100800, body)
+
+	// echo -n '1773100800.{"type":"transaction.created"}' | openssl dgst -sha256 -hmac whsec_test
+	assert.Equal(t, "sha256=576ea2ee59c833de037f09c740b1ff2096cde52a7c9d1b4d919a3a50b401e2b1", signature)
+	assert.True(t, VerifyWebhook("whsec_test", signature, 1773100800, body))
+}
+
+func TestVerifyWebhook_Rejects(t *testing.T) {
+	body := []byte(`{"type":"transaction.created"}`)
+	signature := SignWebhook("whsec_test", 1773100800, body)
+
+	assert.False(t, VerifyWebhook("whsec_other", signature, 1773100800, body), "wrong secret")
+	assert.False(t, VerifyWebhook("whsec_test", signature, 1773100801, body), "replayed with another timestamp")
+	assert.False(t, VerifyWebhook("whsec_test", signature, 1773100800, []byte(`{}`)), "tampered body")
+	assert.False(t, VerifyWebhook("whsec_test", signature[len("sha256="):], 1773100800, body), "missing prefix")
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrEmailChangeDisabled is returned when no notifier can deliver confirmation links
+	ErrEmailChangeDisabled = errors.New("email change is not available")
+)
+
+// accountRemovalBatch is how many accounts one RemoveDue run removes at most
+const accountRemovalBatch = 100
+
+// AccountService manages the profile of signed-in users and the
+// deactivation and closing of their accounts
+type AccountService interface {
+	GetProfile(userID int64) (*domain.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, userID int64, req *domain.UpdateProfileRequest) (*domain.ProfileResponse, error)
+	ConfirmEmail(req *domain.ConfirmEmailRequest) error
+	Deactivate(userID int64, req *domain.CloseAccountRequest) error
+	Close(userID int64, req *domain.CloseAccountRequest) (*domain.AccountClosure, error)
+	Reactivate(email string) error
+	RemoveDue() (int, error)
+	DeleteExpiredEmailChanges() (int64, error)
+}
+
+type accountService struct {
+	userRepo        repository.UserRepository
+	accountRepo     repository.AccountRepository
+	emailChangeRepo repository.EmailChangeRepository
+	security        SecurityService
+	notifier        EmailChangeNotifier
+	passwordHasher  *security.PasswordHasher
+	sanitizer       *security.Sanitizer
+	confirmURL      string
+	emailChangeTTL  time.Duration
+	gracePeriod     time.Duration
+	now             func() time.Time
+}
+
+// NewAccountService creates a new account service. Links confirming a new
+// email point to confirmURL with the token in the token query parameter,
+// stay valid for emailChangeTTL and are delivered by notifier; a nil
+// notifier disables email changes. The sign-in accounts of closed accounts
+// are removed once gracePeriod has passed.
+func NewAccountService(
+	userRepo repository.UserRepository,
+	accountRepo repository.AccountRepository,
+	emailChangeRepo repository.EmailChangeRepository,
+	securityService SecurityService,
+	notifier EmailChangeNotifier,
+	confirmURL string,
+	emailChangeTTL time.Duration,
+	gracePeriod time.Duration,
+) AccountService {
+	if emailChangeTTL <= 0 {
+		emailChangeTTL = domain.DefaultEmailChangeTTL
+	}
+	if gracePeriod <= 0 {
+		gracePeriod = domain.DefaultAccountClosureGracePeriod
+	}
+	return &accountService{
+		userRepo:        userRepo,
+		accountRepo:     accountRepo,
+		emailChangeRepo: emailChangeRepo,
+		security:        securityService,
+		notifier:        notifier,
+		passwordHasher:  security.NewPasswordHasher(),
+		sanitizer:       security.NewSanitizer(),
+		confirmURL:      confirmURL,
+		emailChangeTTL:  emailChangeTTL,
+		gracePeriod:     gracePeriod,
+		now:             time.Now,
+	}
+}
+
+// GetProfile returns the profile of the user with a pending email change
+func (s *accountService) GetProfile(userID int64) (*domain.ProfileResponse, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := domain.NewProfileResponse(user)
+	pending, err := s.emailChangeRepo.FindPending(userID, s.now())
+	if err != nil && !errors.Is(err, repository.ErrEmailChangeTokenNotFound) {
+		return nil, err
+	}
+	if pending != nil {
+		profile.PendingEmail = pending.NewEmail
+	}
+	return profile, nil
+}
+
+// UpdateProfile changes the name and settings of the user. A new email is
+// only sent a confirmation link; ConfirmEmail makes it the user's email.
+func (s *accountService) UpdateProfile(ctx context.Context, userID int64, req *domain.UpdateProfileRequest) (*domain.ProfileResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	changeEmail := req.Email != nil && *req.Email != user.Email
+	if changeEmail {
+		if s.notifier == nil {
+			return nil, ErrEmailChangeDisabled
+		}
+		if err := verifyPassword(s.security, s.passwordHasher, user, req.CurrentPassword, req.Client); err != nil {
+			return nil, err
+		}
+		if err := s.checkEmailAvailable(*req.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Name != nil || req.Settings != nil {
+		if req.Name != nil {
+			user.Name = s.sanitizer.CleanInput(*req.Name, domain.MaxNameLength)
+		}
+		if req.Settings != nil {
+			user.Settings = *req.Settings
+		}
+		if err := s.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	profile := domain.NewProfileResponse(user)
+	if changeEmail {
+		if err := s.requestEmailChange(ctx, user, *req.Email, req.Client); err != nil {
+			return nil, err
+		}
+		profile.PendingEmail = *req.Email
+	}
+	return profile, nil
+}
+
+// ConfirmEmail makes the email of a confirmation link the user's email.
+// The token works once.
+func (s *accountService) ConfirmEmail(req *domain.ConfirmEmailRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	token, err := s.emailChangeRepo.Consume(security.HashLinkToken(req.Token), s.now())
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return repository.ErrEmailChangeTokenNotFound
+		}
+		return err
+	}
+	if !user.IsActive {
+		return repository.ErrEmailChangeTokenNotFound
+	}
+	// Someone may have registered the email since the link was sent
+	if err := s.checkEmailAvailable(token.NewEmail); err != nil {
+		return err
+	}
+
+	oldEmail := user.Email
+	user.Email = token.NewEmail
+	if err := s.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	s.recordEvent(user.ID, domain.SecurityEventEmailChanged, req.Client, "changed from "+oldEmail)
+	return nil
+}
+
+// Deactivate marks the account inactive once the password is verified.
+// Its tokens stop working at once and it can no longer sign in.
+func (s *accountService) Deactivate(userID int64, req *domain.CloseAccountRequest) error {
+	user, err := s.checkClose(userID, req)
+	if err != nil {
+		return err
+	}
+
+	if err := s.accountRepo.Deactivate(user.ID, s.now().Truncate(time.Second), nil); err != nil {
+		return err
+	}
+
+	s.recordEvent(user.ID, domain.SecurityEventAccountDeactivated, req.Client, "")
+	return nil
+}
+
+// Close deactivates the account once the password is verified and schedules
+// the removal of its sign-in account after the grace period. Until then an
+// administrator can reactivate it. Shared ledger data is kept.
+func (s *accountService) Close(userID int64, req *domain.CloseAccountRequest) (*domain.AccountClosure, error) {
+	user, err := s.checkClose(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().Truncate(time.Second)
+	removeAt := now.Add(s.gracePeriod)
+	if err := s.accountRepo.Deactivate(user.ID, now, &removeAt); err != nil {
+		return nil, err
+	}
+
+	s.recordEvent(user.ID, domain.SecurityEventAccountClosed, req.Client, "")
+	return &domain.AccountClosure{RemovalScheduledAt: removeAt, Retained: domain.SharedLedgerData}, nil
+}
+
+// Reactivate makes a deactivated account active again and cancels the
+// removal of a closed one. It is meant for administrators; users cannot sign in to do it.
+func (s *accountService) Reactivate(email string) error {
+	user, err := s.userRepo.FindByEmail(domain.SanitizeEmail(email))
+	if err != nil {
+		return err
+	}
+
+	if err := s.accountRepo.Reactivate(user.ID); err != nil {
+		return err
+	}
+
+	s.recordEvent(user.ID, domain.SecurityEventAccountReactivated, domain.ClientInfo{}, "reactivated by an administrator")
+	return nil
+}
+
+// RemoveDue removes the sign-in accounts of closed accounts whose grace
+// period has passed and returns how many were removed. Accounts that fail
+// are retried on the next run.
+func (s *accountService) RemoveDue() (int, error) {
+	users, err := s.accountRepo.FindDueForDeletion(s.now(), accountRemovalBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	var removed int
+	var lastErr error
+	for i := range users {
+		if err := s.accountRepo.Delete(&users[i]); err != nil {
+			log := logger.Get()
+			log.Error().Err(err).Int64("user_id", users[i].ID).Msg("Failed to remove account")
+			lastErr = err
+			continue
+		}
+		removed++
+	}
+	return removed, lastErr
+}
+
+// DeleteExpiredEmailChanges deletes the confirmation tokens that can no longer be used
+func (s *accountService) DeleteExpiredEmailChanges() (int64, error) {
+	return s.emailChangeRepo.DeleteExpired(s.now())
+}
+
+// checkClose validates a deactivation or closing request and verifies the
+// password of the user
+func (s *accountService) checkClose(userID int64, req *domain.CloseAccountRequest) (*domain.User, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyPassword(s.security, s.passwordHasher, user, req.Password, req.Client); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// checkEmailAvailable returns ErrUserAlreadyExists if an account uses email
+func (s *accountService) checkEmailAvailable(email string) error {
+	_, err := s.userRepo.FindByEmail(email)
+	if err == nil {
+		return repository.ErrUserAlreadyExists
+	}
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return nil
+	}
+	return err
+}
+
+// requestEmailChange stores a confirmation token for newEmail and sends its
+// link to newEmail
+func (s *accountService) requestEmailChange(ctx context.Context, user *domain.User, newEmail string, client domain.ClientInfo) error {
+	token, err := security.GenerateLinkToken()
+	if err != nil {
+		return err
+	}
+	err = s.emailChangeRepo.Create(&domain.EmailChangeToken{
+		UserID:    user.ID,
+		NewEmail:  newEmail,
+		TokenHash: security.HashLinkToken(token),
+		ExpiresAt: s.now().Add(s.emailChangeTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := tokenLink(s.confirmURL, token)
+	if err != nil {
+		return err
+	}
+	if err := s.notifier.NotifyEmailChange(ctx, user, newEmail, link, s.emailChangeTTL); err != nil {
+		return err
+	}
+
+	s.recordEvent(user.ID, domain.SecurityEventEmailChangeRequested, client, "to "+newEmail)
+	return nil
+}
+
+// recordEvent adds an event to the security log, logging rather than
+// returning errors once the change is made
+func (s *accountService) recordEvent(userID int64, eventType string, client domain.ClientInfo, details string) {
+	if err := s.security.RecordEvent(userID, eventType, client, details); err != nil {
+		log := logger.Get()
+		log.Error().Err(err).Int64("user_id", userID).Str("type", eventType).Msg("Failed to record security event")
+	}
+}
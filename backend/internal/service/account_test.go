@@ -0,0 +1,379 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockAccountRepository records deactivations and deletions
+type mockAccountRepository struct {
+	deactivatedAt time.Time
+	deleteAt      *time.Time
+	reactivated   []int64
+	due           []domain.User
+	deleted       []int64
+	deleteErr     map[int64]error
+	err           error
+}
+
+func (m *mockAccountRepository) Deactivate(userID int64, now time.Time, deleteAt *time.Time) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.deactivatedAt = now
+	m.deleteAt = deleteAt
+	return nil
+}
+
+func (m *mockAccountRepository) Reactivate(userID int64) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.reactivated = append(m.reactivated, userID)
+	return nil
+}
+
+func (m *mockAccountRepository) FindDueForDeletion(now time.Time, limit int) ([]domain.User, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var due []domain.User
+	for _, user := range m.due {
+		if user.DeletionScheduledAt != nil && !user.DeletionScheduledAt.After(now) && len(due) < limit {
+			due = append(due, user)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockAccountRepository) Delete(user *domain.User) error {
+	if err := m.deleteErr[user.ID]; err != nil {
+		return err
+	}
+	m.deleted = append(m.deleted, user.ID)
+	return nil
+}
+
+// mockEmailChangeRepository keeps email change tokens by hash
+type mockEmailChangeRepository struct {
+	tokens map[string]*domain.EmailChangeToken
+}
+
+func newMockEmailChangeRepository() *mockEmailChangeRepository {
+	return &mockEmailChangeRepository{tokens: make(map[string]*domain.EmailChangeToken)}
+}
+
+func (m *mockEmailChangeRepository) Create(token *domain.EmailChangeToken) error {
+	for hash, t := range m.tokens {
+		if t.UserID == token.UserID && t.UsedAt == nil {
+			delete(m.tokens, hash)
+		}
+	}
+	token.ID = int64(len(m.tokens) + 1)
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockEmailChangeRepository) FindPending(userID int64, now time.Time) (*domain.EmailChangeToken, error) {
+	for _, token := range m.tokens {
+		if token.UserID == userID && token.UsedAt == nil && token.ExpiresAt.After(now) {
+			return token, nil
+		}
+	}
+	return nil, repository.ErrEmailChangeTokenNotFound
+}
+
+func (m *mockEmailChangeRepository) Consume(tokenHash string, now time.Time) (*domain.EmailChangeToken, error) {
+	token, ok := m.tokens[tokenHash]
+	if !ok || token.UsedAt != nil || !token.ExpiresAt.After(now) {
+		return nil, repository.ErrEmailChangeTokenNotFound
+	}
+	token.UsedAt = &now
+	return token, nil
+}
+
+func (m *mockEmailChangeRepository) DeleteExpired(now time.Time) (int64, error) {
+	var deleted int64
+	for hash, token := range m.tokens {
+		if !token.ExpiresAt.After(now) {
+			delete(m.tokens, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// mockEmailChangeNotifier records the confirmation links it is asked to deliver
+type mockEmailChangeNotifier struct {
+	to    []string
+	links []string
+}
+
+func (m *mockEmailChangeNotifier) NotifyEmailChange(ctx context.Context, user *domain.User, newEmail, link string, validFor time.Duration) error {
+	m.to = append(m.to, newEmail)
+	m.links = append(m.links, link)
+	return nil
+}
+
+const testConfirmURL = "https://app.example.com/confirm-email"
+
+// accountTestDeps are the mocks behind a test account service
+type accountTestDeps struct {
+	accounts     *mockAccountRepository
+	emailChanges *mockEmailChangeRepository
+	security     *mockSecurityRepository
+}
+
+// newTestAccountService returns an account service over mocks whose clock is at *now
+func newTestAccountService(userRepo repository.UserRepository, notifier EmailChangeNotifier, now *time.Time) (AccountService, *accountTestDeps) {
+	deps := &accountTestDeps{
+		accounts:     &mockAccountRepository{},
+		emailChanges: newMockEmailChangeRepository(),
+		security:     newMockSecurityRepository(),
+	}
+	securityService := NewSecurityService(deps.security, userRepo, testLoginPolicy)
+	svc := NewAccountService(userRepo, deps.accounts, deps.emailChanges, securityService, notifier,
+		testConfirmURL, time.Hour, 30*24*time.Hour).(*accountService)
+	svc.now = func() time.Time { return *now }
+	return svc, deps
+}
+
+func TestAccountService_UpdateProfile_NameAndSettings(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, deps := newTestAccountService(userRepo, nil, &now)
+
+	name := "  Renamed\x00 User "
+	profile, err := svc.UpdateProfile(context.Background(), testUser.ID, &domain.UpdateProfileRequest{
+		Name:     &name,
+		Settings: &domain.UserSettings{Locale: "de-DE", Timezone: "Europe/Berlin"},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, userRepo.updateUser)
+	assert.Equal(t, "Renamed User", userRepo.updateUser.Name)
+	assert.Equal(t, "Europe/Berlin", userRepo.updateUser.Settings.Timezone)
+	assert.Equal(t, "Renamed User", profile.Name)
+	assert.Equal(t, "de-DE", profile.Settings.Locale)
+	assert.Empty(t, profile.PendingEmail)
+	assert.Empty(t, deps.security.events)
+}
+
+func TestAccountService_ChangeEmail(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser, findByEmailErr: repository.ErrUserNotFound}
+	notifier := &mockEmailChangeNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, deps := newTestAccountService(userRepo, notifier, &now)
+
+	email := "New@Example.com"
+	profile, err := svc.UpdateProfile(context.Background(), testUser.ID, &domain.UpdateProfileRequest{
+		Email:           &email,
+		CurrentPassword: "Password123",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "test@example.com", profile.Email)
+	assert.Equal(t, "new@example.com", profile.PendingEmail)
+	assert.Nil(t, userRepo.updateUser, "the email must not change before it is confirmed")
+	require.Len(t, notifier.links, 1)
+	assert.Equal(t, []string{"new@example.com"}, notifier.to)
+
+	pending, err := svc.GetProfile(testUser.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new@example.com", pending.PendingEmail)
+
+	req := &domain.ConfirmEmailRequest{Token: tokenFromLink(t, notifier.links[0])}
+	require.NoError(t, svc.ConfirmEmail(req))
+	require.NotNil(t, userRepo.updateUser)
+	assert.Equal(t, "new@example.com", userRepo.updateUser.Email)
+	assert.Equal(t, []string{
+		domain.SecurityEventEmailChangeRequested,
+		domain.SecurityEventEmailChanged,
+	}, deps.security.eventTypes())
+
+	// The link works once
+	assert.ErrorIs(t, svc.ConfirmEmail(req), repository.ErrEmailChangeTokenNotFound)
+}
+
+func TestAccountService_ChangeEmail_WrongPassword(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser, findByEmailErr: repository.ErrUserNotFound}
+	notifier := &mockEmailChangeNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, deps := newTestAccountService(userRepo, notifier, &now)
+
+	email := "new@example.com"
+	_, err := svc.UpdateProfile(context.Background(), testUser.ID, &domain.UpdateProfileRequest{
+		Email:           &email,
+		CurrentPassword: "WrongPassword789",
+	})
+
+	assert.Equal(t, ErrWrongPassword, err)
+	assert.Empty(t, notifier.links)
+	assert.Equal(t, []string{domain.SecurityEventLoginFailed}, deps.security.eventTypes())
+}
+
+func TestAccountService_ChangeEmail_Taken(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	other := createTestUser(t, "taken@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser, findByEmailUser: other}
+	notifier := &mockEmailChangeNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, _ := newTestAccountService(userRepo, notifier, &now)
+
+	email := "taken@example.com"
+	_, err := svc.UpdateProfile(context.Background(), testUser.ID, &domain.UpdateProfileRequest{
+		Email:           &email,
+		CurrentPassword: "Password123",
+	})
+
+	assert.ErrorIs(t, err, repository.ErrUserAlreadyExists)
+	assert.Empty(t, notifier.links)
+}
+
+func TestAccountService_ChangeEmail_Disabled(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, _ := newTestAccountService(userRepo, nil, &now)
+
+	email := "new@example.com"
+	_, err := svc.UpdateProfile(context.Background(), testUser.ID, &domain.UpdateProfileRequest{
+		Email:           &email,
+		CurrentPassword: "Password123",
+	})
+
+	assert.ErrorIs(t, err, ErrEmailChangeDisabled)
+
+	// Sending the current email is not a change
+	email = "test@example.com"
+	_, err = svc.UpdateProfile(context.Background(), testUser.ID, &domain.UpdateProfileRequest{
+		Email:           &email,
+		CurrentPassword: "Password123",
+	})
+	assert.NoError(t, err)
+}
+
+func TestAccountService_ConfirmEmail_Expired(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser, findByEmailErr: repository.ErrUserNotFound}
+	notifier := &mockEmailChangeNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, _ := newTestAccountService(userRepo, notifier, &now)
+
+	email := "new@example.com"
+	_, err := svc.UpdateProfile(context.Background(), testUser.ID, &domain.UpdateProfileRequest{
+		Email:           &email,
+		CurrentPassword: "Password123",
+	})
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	err = svc.ConfirmEmail(&domain.ConfirmEmailRequest{Token: tokenFromLink(t, notifier.links[0])})
+
+	assert.ErrorIs(t, err, repository.ErrEmailChangeTokenNotFound)
+	assert.Nil(t, userRepo.updateUser)
+}
+
+func TestAccountService_ConfirmEmail_InactiveUser(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser, findByEmailErr: repository.ErrUserNotFound}
+	notifier := &mockEmailChangeNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, _ := newTestAccountService(userRepo, notifier, &now)
+
+	email := "new@example.com"
+	_, err := svc.UpdateProfile(context.Background(), testUser.ID, &domain.UpdateProfileRequest{
+		Email:           &email,
+		CurrentPassword: "Password123",
+	})
+	require.NoError(t, err)
+
+	testUser.IsActive = false
+	err = svc.ConfirmEmail(&domain.ConfirmEmailRequest{Token: tokenFromLink(t, notifier.links[0])})
+
+	assert.ErrorIs(t, err, repository.ErrEmailChangeTokenNotFound)
+}
+
+func TestAccountService_Deactivate(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 500, time.UTC)
+	svc, deps := newTestAccountService(userRepo, nil, &now)
+
+	err := svc.Deactivate(testUser.ID, &domain.CloseAccountRequest{Password: "Password123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, now.Truncate(time.Second), deps.accounts.deactivatedAt)
+	assert.Nil(t, deps.accounts.deleteAt)
+	assert.Equal(t, []string{domain.SecurityEventAccountDeactivated}, deps.security.eventTypes())
+}
+
+func TestAccountService_Deactivate_WrongPassword(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, deps := newTestAccountService(userRepo, nil, &now)
+
+	err := svc.Deactivate(testUser.ID, &domain.CloseAccountRequest{Password: "WrongPassword789"})
+
+	assert.Equal(t, ErrWrongPassword, err)
+	assert.True(t, deps.accounts.deactivatedAt.IsZero())
+}
+
+func TestAccountService_Close(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 500, time.UTC)
+	svc, deps := newTestAccountService(userRepo, nil, &now)
+
+	closure, err := svc.Close(testUser.ID, &domain.CloseAccountRequest{Password: "Password123"})
+
+	require.NoError(t, err)
+	want := now.Truncate(time.Second).Add(30 * 24 * time.Hour)
+	assert.Equal(t, want, closure.RemovalScheduledAt)
+	assert.Contains(t, closure.Retained, "transactions", "shared ledger data is kept")
+	require.NotNil(t, deps.accounts.deleteAt)
+	assert.Equal(t, want, *deps.accounts.deleteAt)
+	assert.Equal(t, []string{domain.SecurityEventAccountClosed}, deps.security.eventTypes())
+}
+
+func TestAccountService_Reactivate(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByEmailUser: testUser}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, deps := newTestAccountService(userRepo, nil, &now)
+
+	require.NoError(t, svc.Reactivate(" Test@Example.com"))
+	assert.Equal(t, []int64{testUser.ID}, deps.accounts.reactivated)
+	assert.Equal(t, []string{domain.SecurityEventAccountReactivated}, deps.security.eventTypes())
+}
+
+func TestAccountService_RemoveDue(t *testing.T) {
+	now := time.Date(2026, 4, 9, 9, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+	svc, deps := newTestAccountService(&mockUserRepository{}, nil, &now)
+	deps.accounts.due = []domain.User{
+		{ID: 1, DeletionScheduledAt: &past},
+		{ID: 2, DeletionScheduledAt: &future},
+		{ID: 3, DeletionScheduledAt: &past},
+		{ID: 4, DeletionScheduledAt: &past},
+	}
+	deps.accounts.deleteErr = map[int64]error{3: assert.AnError}
+
+	removed, err := svc.RemoveDue()
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 2, removed)
+	assert.Equal(t, []int64{1, 4}, deps.accounts.deleted)
+}
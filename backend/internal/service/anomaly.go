@@ -0,0 +1,294 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+const (
+	// anomalyMinSamples is the minimum history needed before a distribution is trusted
+	anomalyMinSamples = 5
+	// anomalyDeviations is the number of standard deviations above the mean treated as unusual
+	anomalyDeviations = 3.0
+	// anomalyMedianRatio keeps low-variance histories from flagging small increases
+	anomalyMedianRatio = 2.0
+	// anomalyNewRecipientRatio is the multiple of the median expense that makes a first payment suspicious
+	anomalyNewRecipientRatio = 3.0
+	// anomalyBurstCount is the number of expenses within anomalyBurstWindow that forms a burst
+	anomalyBurstCount = 5
+	// anomalyBurstWindow is the window in which anomalyBurstCount expenses form a burst
+	anomalyBurstWindow = 10 * time.Minute
+)
+
+// AnomalyService flags unusual expenses as they are ingested
+type AnomalyService interface {
+	Detect(transactions []domain.Transaction) ([]domain.TransactionAnomaly, error)
+	ListAnomalies(params domain.AnomalyQueryParams) (*domain.AnomaliesResponse, error)
+	OnTransactionsCreated(transactions []domain.Transaction)
+}
+
+type anomalyService struct {
+	repo           repository.AnomalyRepository
+	lookbackMonths int
+	now            func() time.Time
+}
+
+// NewAnomalyService creates a new anomaly service that compares against lookbackMonths of history
+func NewAnomalyService(repo repository.AnomalyRepository, lookbackMonths int) AnomalyService {
+	if lookbackMonths <= 0 {
+		lookbackMonths = 24
+	}
+	return &anomalyService{
+		repo:           repo,
+		lookbackMonths: lookbackMonths,
+		now:            time.Now,
+	}
+}
+
+// Detect checks stored expenses against the history before them and saves the
+// anomalies found. Income is never flagged.
+func (s *anomalyService) Detect(transactions []domain.Transaction) ([]domain.TransactionAnomaly, error) {
+	var expenses []domain.Transaction
+	for _, tx := range transactions {
+		if tx.Type == domain.TransactionTypeOut {
+			expenses = append(expenses, tx)
+		}
+	}
+	if len(expenses) == 0 {
+		return nil, nil
+	}
+
+	first, last := expenses[0].TransactionDate, expenses[0].TransactionDate
+	for _, tx := range expenses[1:] {
+		if tx.TransactionDate.Before(first) {
+			first = tx.TransactionDate
+		}
+		if tx.TransactionDate.After(last) {
+			last = tx.TransactionDate
+		}
+	}
+
+	expensesBefore, err := s.repo.ListExpensesBetween(first.AddDate(0, -s.lookbackMonths, 0), last)
+	if err != nil {
+		return nil, err
+	}
+	history := newAnomalyHistory(expensesBefore)
+
+	var anomalies []domain.TransactionAnomaly
+	for i := range expenses {
+		anomalies = append(anomalies, history.detect(&expenses[i])...)
+	}
+
+	if err := s.repo.Save(anomalies); err != nil {
+		return nil, err
+	}
+	return anomalies, nil
+}
+
+// ListAnomalies returns flagged transactions dated within the last params.Days days, newest first
+func (s *anomalyService) ListAnomalies(params domain.AnomalyQueryParams) (*domain.AnomaliesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	anomalies, err := s.repo.ListSince(today.AddDate(0, 0, -params.Days), params.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := collectAnomalous(s.repo, anomalies)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.AnomaliesResponse{
+		Data: data,
+		Days: params.Days,
+	}
+	response.Total = len(response.Data)
+
+	return response, nil
+}
+
+// collectAnomalous groups anomalies by transaction, in the order of their
+// first anomaly, and loads the flagged transactions
+func collectAnomalous(repo repository.AnomalyRepository, anomalies []domain.TransactionAnomaly) ([]domain.AnomalousTransaction, error) {
+	reasons := make(map[int64][]domain.TransactionAnomaly)
+	var ids []int64
+	for _, anomaly := range anomalies {
+		if _, ok := reasons[anomaly.TransactionID]; !ok {
+			ids = append(ids, anomaly.TransactionID)
+		}
+		reasons[anomaly.TransactionID] = append(reasons[anomaly.TransactionID], anomaly)
+	}
+
+	transactions, err := repo.ListTransactions(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]domain.Transaction, len(transactions))
+	for _, tx := range transactions {
+		byID[tx.ID] = tx
+	}
+
+	data := []domain.AnomalousTransaction{}
+	for _, id := range ids {
+		tx, ok := byID[id]
+		if !ok {
+			continue
+		}
+		data = append(data, domain.AnomalousTransaction{
+			Transaction: tx,
+			Reasons:     reasons[id],
+		})
+	}
+
+	return data, nil
+}
+
+// OnTransactionsCreated runs anomaly detection on newly stored transactions.
+// Detection failures are logged and never fail the incoming webhook.
+func (s *anomalyService) OnTransactionsCreated(transactions []domain.Transaction) {
+	log := logger.Get()
+
+	anomalies, err := s.Detect(transactions)
+	if err != nil {
+		log.Error().Err(err).Int("transactions", len(transactions)).Msg("Failed to detect transaction anomalies")
+		return
+	}
+	flagged := make(map[int64]bool, len(anomalies))
+	for _, anomaly := range anomalies {
+		flagged[anomaly.TransactionID] = true
+		log.Warn().
+			Int64("transaction_id", anomaly.TransactionID).
+			Str("reason", string(anomaly.Reason)).
+			Msg("Unusual transaction flagged")
+	}
+
+	// Hooks registered after this one see the flag that was just stored
+	for i := range transactions {
+		if flagged[transactions[i].ID] {
+			transactions[i].Anomalous = true
+		}
+	}
+}
+
+// anomalyHistory holds the expenses new ones are compared with, with their
+// payees normalized once rather than for every new expense
+type anomalyHistory struct {
+	expenses []domain.Transaction
+	payees   []string // normalized payee of each expense
+}
+
+// newAnomalyHistory prepares expenses, sorted by date, for detection
+func newAnomalyHistory(expenses []domain.Transaction) *anomalyHistory {
+	payees := make([]string, len(expenses))
+	for i := range expenses {
+		payees[i] = normalizePayee(displayPayee(expenses[i]))
+	}
+	return &anomalyHistory{expenses: expenses, payees: payees}
+}
+
+// detect compares an expense with the expenses recorded before it
+func (h *anomalyHistory) detect(tx *domain.Transaction) []domain.TransactionAnomaly {
+	payee := normalizePayee(displayPayee(*tx))
+
+	var all, sameCategory, samePayee []float64
+	recent := 1 // tx itself
+	for i := range h.expenses {
+		prior := &h.expenses[i]
+		if prior.ID == tx.ID || !isBefore(prior, tx) {
+			continue
+		}
+
+		all = append(all, prior.Amount)
+		if tx.Category != "" && prior.Category == tx.Category {
+			sameCategory = append(sameCategory, prior.Amount)
+		}
+		if payee != "" && h.payees[i] == payee {
+			samePayee = append(samePayee, prior.Amount)
+		}
+		if tx.TransactionDate.Sub(prior.TransactionDate) < anomalyBurstWindow {
+			recent++
+		}
+	}
+
+	var anomalies []domain.TransactionAnomaly
+	add := func(reason domain.AnomalyReason, score float64, detail string) {
+		anomalies = append(anomalies, domain.TransactionAnomaly{
+			TransactionID: tx.ID,
+			Reason:        reason,
+			Score:         score,
+			Detail:        detail,
+		})
+	}
+
+	if threshold, ok := outlierThreshold(sameCategory); ok && tx.Amount > threshold {
+		add(domain.AnomalyReasonCategoryAmount, tx.Amount/threshold,
+			fmt.Sprintf("amount is above the usual maximum of %.2f for category %s", threshold, tx.Category))
+	}
+
+	if threshold, ok := outlierThreshold(samePayee); ok && tx.Amount > threshold {
+		add(domain.AnomalyReasonRecipientAmount, tx.Amount/threshold,
+			fmt.Sprintf("amount is above the usual maximum of %.2f for this recipient", threshold))
+	}
+
+	if payee != "" && len(samePayee) == 0 && len(all) >= anomalyMinSamples {
+		threshold := medianOf(all) * anomalyNewRecipientRatio
+		if tx.Amount > threshold {
+			add(domain.AnomalyReasonNewRecipient, tx.Amount/threshold,
+				fmt.Sprintf("first payment to this recipient is above %.2f", threshold))
+		}
+	}
+
+	// Date-only transactions all fall on midnight and cannot form a burst
+	if !isMidnight(tx.TransactionDate) && recent >= anomalyBurstCount {
+		add(domain.AnomalyReasonBurst, float64(recent)/anomalyBurstCount,
+			fmt.Sprintf("%d expenses within %s", recent, anomalyBurstWindow))
+	}
+
+	return anomalies
+}
+
+// outlierThreshold returns the amount above which a value is unusual for the
+// given history, or false when the history is too short to judge
+func outlierThreshold(amounts []float64) (float64, bool) {
+	if len(amounts) < anomalyMinSamples {
+		return 0, false
+	}
+
+	var sum float64
+	for _, amount := range amounts {
+		sum += amount
+	}
+	mean := sum / float64(len(amounts))
+
+	var variance float64
+	for _, amount := range amounts {
+		variance += (amount - mean) * (amount - mean)
+	}
+	stddev := math.Sqrt(variance / float64(len(amounts)))
+
+	return math.Max(mean+anomalyDeviations*stddev, medianOf(amounts)*anomalyMedianRatio), true
+}
+
+// isBefore reports whether a was recorded before b, using the ID to order equal dates
+func isBefore(a, b *domain.Transaction) bool {
+	if a.TransactionDate.Equal(b.TransactionDate) {
+		return a.ID < b.ID
+	}
+	return a.TransactionDate.Before(b.TransactionDate)
+}
+
+// isMidnight reports whether t has no time-of-day component
+func isMidnight(t time.Time) bool {
+	return t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0
+}
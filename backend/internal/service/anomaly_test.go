@@ -0,0 +1,212 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockAnomalyRepository is a mock implementation of AnomalyRepository for testing
+type mockAnomalyRepository struct {
+	history      []domain.Transaction
+	anomalies    []domain.TransactionAnomaly
+	transactions []domain.Transaction
+}
+
+func (m *mockAnomalyRepository) ListExpensesBetween(since, until time.Time) ([]domain.Transaction, error) {
+	return m.history, nil
+}
+
+func (m *mockAnomalyRepository) Save(anomalies []domain.TransactionAnomaly) error {
+	m.anomalies = append(m.anomalies, anomalies...)
+	return nil
+}
+
+func (m *mockAnomalyRepository) ListSince(since time.Time, reason domain.AnomalyReason) ([]domain.TransactionAnomaly, error) {
+	return m.anomalies, nil
+}
+
+func (m *mockAnomalyRepository) ListTransactions(ids []int64) ([]domain.Transaction, error) {
+	return m.transactions, nil
+}
+
+// newTestAnomalyService creates an anomaly service with a fixed clock
+func newTestAnomalyService(repo repository.AnomalyRepository, now time.Time) *anomalyService {
+	svc := NewAnomalyService(repo, 24).(*anomalyService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+// groceryHistory returns weekly grocery expenses of about 500,000 at the same store
+func groceryHistory() []domain.Transaction {
+	start := time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC)
+	amounts := []float64{480000, 520000, 500000, 510000, 490000, 505000}
+
+	history := make([]domain.Transaction, 0, len(amounts))
+	for i, amount := range amounts {
+		history = append(history, domain.Transaction{
+			ID:              int64(i + 1),
+			Type:            domain.TransactionTypeOut,
+			Category:        "Food",
+			Recipient:       "Bach Hoa Xanh",
+			Amount:          amount,
+			TransactionDate: start.AddDate(0, 0, 7*i),
+		})
+	}
+	return history
+}
+
+func anomalyReasons(anomalies []domain.TransactionAnomaly) []domain.AnomalyReason {
+	reasons := make([]domain.AnomalyReason, 0, len(anomalies))
+	for _, a := range anomalies {
+		reasons = append(reasons, a.Reason)
+	}
+	return reasons
+}
+
+// Test anomalyHistory.detect
+
+func TestDetectAnomalies_UsualAmount(t *testing.T) {
+	history := groceryHistory()
+	tx := domain.Transaction{
+		ID: 100, Type: domain.TransactionTypeOut, Category: "Food", Recipient: "Bach Hoa Xanh",
+		Amount: 530000, TransactionDate: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	assert.Empty(t, newAnomalyHistory(append(history, tx)).detect(&tx))
+}
+
+func TestDetectAnomalies_CategoryAndRecipientOutlier(t *testing.T) {
+	history := groceryHistory()
+	tx := domain.Transaction{
+		ID: 100, Type: domain.TransactionTypeOut, Category: "Food", Recipient: "BACH HOA XANH 0231",
+		Amount: 4500000, TransactionDate: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	anomalies := newAnomalyHistory(append(history, tx)).detect(&tx)
+
+	assert.Equal(t, []domain.AnomalyReason{domain.AnomalyReasonCategoryAmount, domain.AnomalyReasonRecipientAmount}, anomalyReasons(anomalies))
+	assert.Equal(t, int64(100), anomalies[0].TransactionID)
+	assert.Greater(t, anomalies[0].Score, 1.0)
+}
+
+func TestDetectAnomalies_NewRecipientLargeAmount(t *testing.T) {
+	history := groceryHistory()
+	tx := domain.Transaction{
+		ID: 100, Type: domain.TransactionTypeOut, Category: "Shopping", Recipient: "Unknown Electronics",
+		Amount: 9000000, TransactionDate: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	anomalies := newAnomalyHistory(append(history, tx)).detect(&tx)
+
+	assert.Equal(t, []domain.AnomalyReason{domain.AnomalyReasonNewRecipient}, anomalyReasons(anomalies))
+}
+
+func TestDetectAnomalies_IgnoresLaterTransactions(t *testing.T) {
+	history := groceryHistory()
+	// The first grocery trip has no history before it
+	tx := history[0]
+	tx.Amount = 4500000
+
+	assert.Empty(t, newAnomalyHistory(history).detect(&tx))
+}
+
+func TestDetectAnomalies_Burst(t *testing.T) {
+	at := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	var history []domain.Transaction
+	for i := 0; i < anomalyBurstCount; i++ {
+		history = append(history, domain.Transaction{
+			ID:              int64(i + 1),
+			Type:            domain.TransactionTypeOut,
+			Recipient:       "Shopee",
+			Amount:          100000,
+			TransactionDate: at.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	last := history[len(history)-1]
+	anomalies := newAnomalyHistory(history).detect(&last)
+
+	assert.Equal(t, []domain.AnomalyReason{domain.AnomalyReasonBurst}, anomalyReasons(anomalies))
+	assert.Empty(t, newAnomalyHistory(history).detect(&history[len(history)-2]))
+}
+
+func TestDetectAnomalies_DateOnlyIsNotBurst(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	var history []domain.Transaction
+	for i := 0; i < anomalyBurstCount+2; i++ {
+		history = append(history, domain.Transaction{
+			ID: int64(i + 1), Type: domain.TransactionTypeOut, Recipient: "Shopee", Amount: 100000, TransactionDate: day,
+		})
+	}
+
+	last := history[len(history)-1]
+
+	assert.Empty(t, newAnomalyHistory(history).detect(&last))
+}
+
+// Test Detect
+
+func TestAnomalyService_Detect_SavesAnomalies(t *testing.T) {
+	tx := domain.Transaction{
+		ID: 100, Type: domain.TransactionTypeOut, Category: "Food", Recipient: "Bach Hoa Xanh",
+		Amount: 4500000, TransactionDate: time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+	repo := &mockAnomalyRepository{history: append(groceryHistory(), tx)}
+	svc := newTestAnomalyService(repo, tx.TransactionDate)
+
+	income := domain.Transaction{ID: 101, Type: domain.TransactionTypeIn, Amount: 90000000, TransactionDate: tx.TransactionDate}
+	anomalies, err := svc.Detect([]domain.Transaction{tx, income})
+
+	assert.NoError(t, err)
+	assert.Len(t, anomalies, 2)
+	assert.Len(t, repo.anomalies, 2)
+}
+
+func TestAnomalyService_Detect_IncomeOnly(t *testing.T) {
+	repo := &mockAnomalyRepository{}
+	svc := newTestAnomalyService(repo, time.Now())
+
+	anomalies, err := svc.Detect([]domain.Transaction{{ID: 1, Type: domain.TransactionTypeIn, Amount: 100}})
+
+	assert.NoError(t, err)
+	assert.Empty(t, anomalies)
+}
+
+// Test ListAnomalies
+
+func TestAnomalyService_ListAnomalies_GroupsReasons(t *testing.T) {
+	repo := &mockAnomalyRepository{
+		anomalies: []domain.TransactionAnomaly{
+			{ID: 1, TransactionID: 9, Reason: domain.AnomalyReasonCategoryAmount},
+			{ID: 2, TransactionID: 9, Reason: domain.AnomalyReasonRecipientAmount},
+			{ID: 3, TransactionID: 4, Reason: domain.AnomalyReasonBurst},
+		},
+		transactions: []domain.Transaction{
+			{ID: 4, Amount: 100000, Anomalous: true},
+			{ID: 9, Amount: 4500000, Anomalous: true},
+		},
+	}
+	svc := newTestAnomalyService(repo, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	response, err := svc.ListAnomalies(domain.AnomalyQueryParams{Days: 30})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.Total)
+	assert.Equal(t, int64(9), response.Data[0].Transaction.ID)
+	assert.Len(t, response.Data[0].Reasons, 2)
+	assert.Equal(t, int64(4), response.Data[1].Transaction.ID)
+}
+
+func TestAnomalyService_ListAnomalies_InvalidParams(t *testing.T) {
+	svc := newTestAnomalyService(&mockAnomalyRepository{}, time.Now())
+
+	_, err := svc.ListAnomalies(domain.AnomalyQueryParams{Days: 0})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
 	"github.com/dev/personal-finance-tracker/backend/internal/repository"
 	"github.com/dev/personal-finance-tracker/backend/internal/security"
 )
@@ -16,26 +17,37 @@ var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	// ErrUserInactive is returned when trying to authenticate an inactive user
 	ErrUserInactive = errors.New("user account is inactive")
+	// ErrInvalidMFAToken is returned when an MFA challenge token is invalid or expired
+	ErrInvalidMFAToken = errors.New("invalid or expired MFA token")
+	// ErrSessionRevoked is returned for tokens issued before the password was changed or reset
+	ErrSessionRevoked = errors.New("session has been revoked")
 )
 
 // AuthService handles business logic for authentication
 type AuthService interface {
 	Register(req *domain.RegisterRequest) (*domain.AuthResponse, error)
 	Login(req *domain.LoginRequest) (*domain.AuthResponse, error)
+	VerifyMFA(req *domain.MFAVerifyRequest) (*domain.AuthResponse, error)
 	ValidateToken(token string) (*security.Claims, error)
 	GetJWTManager() *security.JWTManager
 }
 
 type authService struct {
 	userRepo       repository.UserRepository
+	security       SecurityService
+	mfa            MFAService
 	passwordHasher *security.PasswordHasher
 	jwtManager     *security.JWTManager
 }
 
-// NewAuthService creates a new auth service
-func NewAuthService(userRepo repository.UserRepository, jwtSecret string) AuthService {
+// NewAuthService creates a new auth service; logins are throttled and
+// logged by securityService and need a second factor from mfaService for
+// users with 2FA
+func NewAuthService(userRepo repository.UserRepository, securityService SecurityService, mfaService MFAService, jwtSecret string) AuthService {
 	return &authService{
 		userRepo:       userRepo,
+		security:       securityService,
+		mfa:            mfaService,
 		passwordHasher: security.NewPasswordHasher(),
 		jwtManager:     security.NewJWTManager(jwtSecret),
 	}
@@ -89,18 +101,27 @@ func (s *authService) Register(req *domain.RegisterRequest) (*domain.AuthRespons
 	}, nil
 }
 
-// Login authenticates a user and returns auth response
+// Login authenticates a user and returns auth response. While the account
+// or the client IP is blocked after failed logins, it returns a
+// *LoginThrottledError without checking the password. For users with 2FA
+// the response holds an MFA challenge token for VerifyMFA instead of a JWT.
 func (s *authService) Login(req *domain.LoginRequest) (*domain.AuthResponse, error) {
 	// Perform validation
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
+	// Refuse attempts while the account or client IP is blocked
+	if err := s.security.CheckLogin(req.Email, req.Client); err != nil {
+		return nil, err
+	}
+
 	// Find user by email
 	user, err := s.userRepo.FindByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			// Return generic error to prevent user enumeration
+			// Throttle unknown emails too, and return generic error to prevent user enumeration
+			s.loginFailed(nil, req)
 			return nil, ErrInvalidCredentials
 		}
 		return nil, err
@@ -118,9 +139,70 @@ func (s *authService) Login(req *domain.LoginRequest) (*domain.AuthResponse, err
 	}
 
 	if !valid {
+		s.loginFailed(user, req)
 		return nil, ErrInvalidCredentials
 	}
 
+	// Ask for the second factor before signing in
+	mfaEnabled, err := s.mfa.IsEnabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfaEnabled {
+		mfaToken, err := s.jwtManager.GenerateMFAToken(user.ID, user.Email, user.UUID)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.AuthResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+		}, nil
+	}
+
+	return s.signIn(user, req.Client)
+}
+
+// VerifyMFA completes the login of a user with 2FA: it checks the TOTP or
+// recovery code against the challenge token returned by Login and returns
+// auth response. Wrong codes are throttled like wrong passwords.
+func (s *authService) VerifyMFA(req *domain.MFAVerifyRequest) (*domain.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	claims, err := s.jwtManager.ValidateMFAToken(req.MFAToken)
+	if err != nil {
+		return nil, ErrInvalidMFAToken
+	}
+
+	user, err := s.userRepo.FindByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+	if sessionRevoked(user, claims) {
+		return nil, ErrInvalidMFAToken
+	}
+
+	if err := s.mfa.Verify(user, req.Code, req.Client); err != nil {
+		return nil, err
+	}
+
+	return s.signIn(user, req.Client)
+}
+
+// signIn forgets earlier failures, logs the sign-in and returns auth response
+func (s *authService) signIn(user *domain.User, client domain.ClientInfo) (*domain.AuthResponse, error) {
+	if err := s.security.LoginSucceeded(user, client); err != nil {
+		log := logger.Get()
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to record successful login")
+	}
+
 	// Update last login
 	if err := s.userRepo.UpdateLastLogin(user.ID); err != nil {
 		// Log the error but don't fail login
@@ -140,6 +222,15 @@ func (s *authService) Login(req *domain.LoginRequest) (*domain.AuthResponse, err
 	}, nil
 }
 
+// loginFailed counts a failed login. Errors are logged rather than returned,
+// so the caller still gets invalid credentials.
+func (s *authService) loginFailed(user *domain.User, req *domain.LoginRequest) {
+	if err := s.security.LoginFailed(user, req.Email, req.Client); err != nil {
+		log := logger.Get()
+		log.Error().Err(err).Msg("Failed to record failed login")
+	}
+}
+
 // ValidateToken validates a JWT token and returns the claims
 func (s *authService) ValidateToken(token string) (*security.Claims, error) {
 	claims, err := s.jwtManager.ValidateToken(token)
@@ -160,9 +251,23 @@ func (s *authService) ValidateToken(token string) (*security.Claims, error) {
 		return nil, ErrUserInactive
 	}
 
+	if sessionRevoked(user, claims) {
+		return nil, ErrSessionRevoked
+	}
+
 	return claims, nil
 }
 
+// sessionRevoked reports whether a token was issued before the user's
+// sessions were revoked. Both times have second precision, so the token
+// issued along with a password change stays valid.
+func sessionRevoked(user *domain.User, claims *security.Claims) bool {
+	if user.SessionsRevokedAt == nil {
+		return false
+	}
+	return claims.IssuedAt == nil || claims.IssuedAt.Time.Before(*user.SessionsRevokedAt)
+}
+
 // GetJWTManager returns the JWT manager for use in middleware
 func (s *authService) GetJWTManager() *security.JWTManager {
 	return s.jwtManager
@@ -3,8 +3,10 @@ package service
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/pquerna/otp/totp"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 
@@ -26,6 +28,9 @@ type mockUserRepository struct {
 	updateLastLoginErr error
 	updateUser         *domain.User
 	updateErr          error
+	updatePasswordErr  error
+	updatedPassword    string
+	sessionsRevokedAt  time.Time
 }
 
 func (m *mockUserRepository) Create(user *domain.User) error {
@@ -70,6 +75,17 @@ func (m *mockUserRepository) Update(user *domain.User) error {
 	if m.updateErr != nil {
 		return m.updateErr
 	}
+	updated := *user
+	m.updateUser = &updated
+	return nil
+}
+
+func (m *mockUserRepository) UpdatePassword(userID int64, passwordHash string, revokeSessionsAt time.Time) error {
+	if m.updatePasswordErr != nil {
+		return m.updatePasswordErr
+	}
+	m.updatedPassword = passwordHash
+	m.sessionsRevokedAt = revokeSessionsAt
 	return nil
 }
 
@@ -98,7 +114,7 @@ func TestAuthService_Register_Success(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByEmailErr: repository.ErrUserNotFound, // User doesn't exist
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	req := &domain.RegisterRequest{
 		Email:    "newuser@example.com",
@@ -118,7 +134,7 @@ func TestAuthService_Register_Success(t *testing.T) {
 
 func TestAuthService_Register_ValidationError(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	req := &domain.RegisterRequest{
 		Email:    "invalid-email",
@@ -140,7 +156,7 @@ func TestAuthService_Register_DuplicateEmail(t *testing.T) {
 		findByEmailUser: existingUser,
 		findByEmailErr:  nil, // User found
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	req := &domain.RegisterRequest{
 		Email:    "existing@example.com",
@@ -162,7 +178,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByEmailUser: testUser,
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	req := &domain.LoginRequest{
 		Email:    "test@example.com",
@@ -179,7 +195,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 
 func TestAuthService_Login_ValidationError(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	req := &domain.LoginRequest{
 		Email:    "invalid-email",
@@ -198,7 +214,7 @@ func TestAuthService_Login_UserNotFound(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByEmailErr: repository.ErrUserNotFound,
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	req := &domain.LoginRequest{
 		Email:    "nonexistent@example.com",
@@ -218,7 +234,7 @@ func TestAuthService_Login_WrongPassword(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByEmailUser: testUser,
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	req := &domain.LoginRequest{
 		Email:    "test@example.com",
@@ -239,7 +255,7 @@ func TestAuthService_Login_InactiveUser(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByEmailUser: testUser,
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	req := &domain.LoginRequest{
 		Email:    "test@example.com",
@@ -253,6 +269,154 @@ func TestAuthService_Login_InactiveUser(t *testing.T) {
 	assert.Equal(t, ErrUserInactive, err)
 }
 
+func TestAuthService_Login_WrongPasswordThrottles(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "CorrectPassword123")
+
+	mockRepo := &mockUserRepository{
+		findByEmailUser: testUser,
+	}
+	securityRepo := newMockSecurityRepository()
+	authService := NewAuthService(mockRepo, NewSecurityService(securityRepo, mockRepo, testLoginPolicy), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
+
+	req := &domain.LoginRequest{
+		Email:    "test@example.com",
+		Password: "WrongPassword456",
+		Client:   domain.ClientInfo{IPAddress: "203.0.113.7"},
+	}
+	_, err := authService.Login(req)
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	// The correct password is refused too while the account is blocked
+	req.Password = "CorrectPassword123"
+	response, err := authService.Login(req)
+
+	assert.Nil(t, response)
+	var throttled *LoginThrottledError
+	assert.True(t, errors.As(err, &throttled))
+	assert.Equal(t, []string{domain.SecurityEventLoginFailed}, securityRepo.eventTypes())
+}
+
+func TestAuthService_Login_UnknownEmailThrottles(t *testing.T) {
+	mockRepo := &mockUserRepository{
+		findByEmailErr: repository.ErrUserNotFound,
+	}
+	securityRepo := newMockSecurityRepository()
+	authService := NewAuthService(mockRepo, NewSecurityService(securityRepo, mockRepo, testLoginPolicy), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
+
+	_, err := authService.Login(&domain.LoginRequest{
+		Email:    "nonexistent@example.com",
+		Password: "Password123",
+	})
+
+	assert.Equal(t, ErrInvalidCredentials, err)
+	assert.Equal(t, 1, securityRepo.throttles[domain.LoginAccountKey("nonexistent@example.com")].Failures)
+}
+
+func TestAuthService_Login_SuccessLogsEvent(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "CorrectPassword123")
+
+	mockRepo := &mockUserRepository{
+		findByEmailUser: testUser,
+	}
+	securityRepo := newMockSecurityRepository()
+	authService := NewAuthService(mockRepo, NewSecurityService(securityRepo, mockRepo, testLoginPolicy), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
+
+	_, err := authService.Login(&domain.LoginRequest{
+		Email:    "test@example.com",
+		Password: "CorrectPassword123",
+		Client:   domain.ClientInfo{IPAddress: "203.0.113.7", UserAgent: "FinanceApp/2.1"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{domain.SecurityEventLoginSucceeded}, securityRepo.eventTypes())
+	assert.Equal(t, "FinanceApp/2.1", securityRepo.events[0].UserAgent)
+}
+
+// Test the two-step login with MFA
+
+// newMFAAuthService returns an auth service for testUser with 2FA enabled
+func newMFAAuthService(testUser *domain.User) (AuthService, *mockSecurityRepository) {
+	mockRepo := &mockUserRepository{
+		findByEmailUser: testUser,
+		findByIDUser:    testUser,
+	}
+	securityRepo := newMockSecurityRepository()
+	securityService := NewSecurityService(securityRepo, mockRepo, testLoginPolicy)
+	mfaRepo := newMockMFARepository()
+	mfaRepo.mfa[testUser.ID] = &domain.UserMFA{UserID: testUser.ID, Secret: testMFASecret, Enabled: true}
+	mfaService := NewMFAService(mfaRepo, mockRepo, securityService)
+	return NewAuthService(mockRepo, securityService, mfaService, "test-jwt-secret-minimum-32-chars"), securityRepo
+}
+
+const testMFASecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func TestAuthService_Login_MFARequired(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "CorrectPassword123")
+	authService, securityRepo := newMFAAuthService(testUser)
+
+	response, err := authService.Login(&domain.LoginRequest{
+		Email:    "test@example.com",
+		Password: "CorrectPassword123",
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, response.MFARequired)
+	assert.Empty(t, response.Token)
+	assert.Empty(t, response.User.Email, "no user data before the second factor")
+	assert.Empty(t, securityRepo.events, "the login is not complete yet")
+
+	_, err = authService.ValidateToken(response.MFAToken)
+	assert.Error(t, err, "the challenge token is no access token")
+}
+
+func TestAuthService_VerifyMFA_Success(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "CorrectPassword123")
+	authService, securityRepo := newMFAAuthService(testUser)
+
+	login, err := authService.Login(&domain.LoginRequest{Email: "test@example.com", Password: "CorrectPassword123"})
+	assert.NoError(t, err)
+	code, err := totp.GenerateCode(testMFASecret, time.Now())
+	assert.NoError(t, err)
+
+	response, err := authService.VerifyMFA(&domain.MFAVerifyRequest{MFAToken: login.MFAToken, Code: code})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, response.Token)
+	assert.Equal(t, testUser.Email, response.User.Email)
+	assert.Equal(t, []string{domain.SecurityEventLoginSucceeded}, securityRepo.eventTypes())
+
+	claims, err := authService.ValidateToken(response.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, testUser.ID, claims.UserID)
+}
+
+func TestAuthService_VerifyMFA_WrongCode(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "CorrectPassword123")
+	authService, securityRepo := newMFAAuthService(testUser)
+
+	login, err := authService.Login(&domain.LoginRequest{Email: "test@example.com", Password: "CorrectPassword123"})
+	assert.NoError(t, err)
+
+	response, err := authService.VerifyMFA(&domain.MFAVerifyRequest{MFAToken: login.MFAToken, Code: "abcde-fghjk"})
+
+	assert.Nil(t, response)
+	assert.Equal(t, ErrInvalidMFACode, err)
+	assert.Equal(t, []string{domain.SecurityEventMFAFailed}, securityRepo.eventTypes())
+}
+
+func TestAuthService_VerifyMFA_InvalidToken(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "CorrectPassword123")
+	authService, _ := newMFAAuthService(testUser)
+
+	// An access token cannot stand in for the challenge token
+	token, err := authService.GetJWTManager().GenerateToken(testUser.ID, testUser.Email, testUser.UUID)
+	assert.NoError(t, err)
+
+	_, err = authService.VerifyMFA(&domain.MFAVerifyRequest{MFAToken: token, Code: "123456"})
+
+	assert.Equal(t, ErrInvalidMFAToken, err)
+}
+
 // Test ValidateToken()
 
 func TestAuthService_ValidateToken_ValidToken(t *testing.T) {
@@ -261,7 +425,7 @@ func TestAuthService_ValidateToken_ValidToken(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByIDUser: testUser,
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	// First, generate a valid token
 	token, err := authService.GetJWTManager().GenerateToken(testUser.ID, testUser.Email, testUser.UUID)
@@ -280,7 +444,7 @@ func TestAuthService_ValidateToken_UserNotFound(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByIDErr: repository.ErrUserNotFound,
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	// Create a token for a non-existent user
 	jwtManager := security.NewJWTManager("test-jwt-secret-minimum-32-chars")
@@ -301,7 +465,7 @@ func TestAuthService_ValidateToken_InactiveUser(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByIDUser: testUser,
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	// Create a token for inactive user
 	token, _ := authService.GetJWTManager().GenerateToken(testUser.ID, testUser.Email, testUser.UUID)
@@ -313,9 +477,35 @@ func TestAuthService_ValidateToken_InactiveUser(t *testing.T) {
 	assert.Equal(t, ErrUserInactive, err)
 }
 
+func TestAuthService_ValidateToken_RevokedSession(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+
+	mockRepo := &mockUserRepository{
+		findByIDUser: testUser,
+	}
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
+
+	token, _ := authService.GetJWTManager().GenerateToken(testUser.ID, testUser.Email, testUser.UUID)
+
+	// Revoked after the token was issued
+	revokedAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	testUser.SessionsRevokedAt = &revokedAt
+	claims, err := authService.ValidateToken(token)
+
+	assert.Nil(t, claims)
+	assert.Equal(t, ErrSessionRevoked, err)
+
+	// Tokens issued in the second of the revocation stay valid
+	revokedAt = time.Now().Truncate(time.Second)
+	claims, err = authService.ValidateToken(token)
+
+	assert.NoError(t, err)
+	assert.Equal(t, testUser.ID, claims.UserID)
+}
+
 func TestAuthService_ValidateToken_InvalidToken(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	claims, err := authService.ValidateToken("invalid-token")
 
@@ -344,7 +534,7 @@ func TestIsDuplicateEmailError_OtherError(t *testing.T) {
 
 func TestAuthService_GetJWTManager_ReturnsManager(t *testing.T) {
 	mockRepo := &mockUserRepository{}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	manager := authService.GetJWTManager()
 
@@ -359,7 +549,7 @@ func TestAuthService_RegisterLoginFlow(t *testing.T) {
 	mockRepo := &mockUserRepository{
 		findByEmailErr: repository.ErrUserNotFound, // User doesn't exist initially
 	}
-	authService := NewAuthService(mockRepo, "test-jwt-secret-minimum-32-chars")
+	authService := NewAuthService(mockRepo, newTestSecurityService(mockRepo), newTestMFAService(mockRepo), "test-jwt-secret-minimum-32-chars")
 
 	// Register
 	registerReq := &domain.RegisterRequest{
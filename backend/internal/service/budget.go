@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+// BudgetService handles business logic for budgets
+type BudgetService interface {
+	CreateBudget(req *domain.BudgetRequest) (*domain.Budget, error)
+	GetBudget(id int64) (*domain.Budget, error)
+	ListBudgets() ([]domain.Budget, error)
+	UpdateBudget(id int64, req *domain.BudgetRequest) (*domain.Budget, error)
+	DeleteBudget(id int64) error
+	GetBudgetProgress() (*domain.BudgetProgressResponse, error)
+}
+
+type budgetService struct {
+	repo      repository.BudgetRepository
+	sanitizer *security.Sanitizer
+	now       func() time.Time
+}
+
+// NewBudgetService creates a new budget service
+func NewBudgetService(repo repository.BudgetRepository) BudgetService {
+	return &budgetService{
+		repo:      repo,
+		sanitizer: security.NewSanitizer(),
+		now:       time.Now,
+	}
+}
+
+func (s *budgetService) CreateBudget(req *domain.BudgetRequest) (*domain.Budget, error) {
+	req.Source = s.sanitizer.CleanInput(req.Source, domain.MaxSourceLength)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	budget := req.ToBudget()
+	if err := s.repo.Create(budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+func (s *budgetService) GetBudget(id int64) (*domain.Budget, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid budget ID")
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *budgetService) ListBudgets() ([]domain.Budget, error) {
+	return s.repo.List()
+}
+
+func (s *budgetService) UpdateBudget(id int64, req *domain.BudgetRequest) (*domain.Budget, error) {
+	req.Source = s.sanitizer.CleanInput(req.Source, domain.MaxSourceLength)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	budget, err := s.GetBudget(id)
+	if err != nil {
+		return nil, err
+	}
+
+	budget.Category = req.Category
+	budget.Source = req.Source
+	budget.Amount = req.Amount
+
+	if err := s.repo.Update(budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+func (s *budgetService) DeleteBudget(id int64) error {
+	if id <= 0 {
+		return errors.New("invalid budget ID")
+	}
+	return s.repo.Delete(id)
+}
+
+// GetBudgetProgress computes spending against every budget for the current month.
+// Spending is read live from the transactions table, so newly created
+// transactions are reflected immediately.
+func (s *budgetService) GetBudgetProgress() (*domain.BudgetProgressResponse, error) {
+	now := s.now().UTC()
+	start, end := domain.MonthPeriod(now)
+
+	budgets, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	spending, err := s.repo.GetCategorySpending(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.BudgetProgressResponse{
+		Period:       start.Format("2006-01"),
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		DaysElapsed:  now.Day(),
+		DaysInPeriod: end.Day(),
+	}
+	response.Budgets, response.TotalBudget, response.TotalSpent = summarizeBudgets(budgets, spending, response.DaysElapsed, response.DaysInPeriod)
+
+	return response, nil
+}
+
+// summarizeBudgets computes the progress of every budget from the category
+// spending of a period, along with the budgeted and spent totals
+func summarizeBudgets(budgets []domain.Budget, spending []domain.CategorySpending, daysElapsed, daysInPeriod int) ([]domain.BudgetProgress, float64, float64) {
+	// Index spending by category (all sources) and by category + source
+	byCategory := make(map[string]float64)
+	bySource := make(map[[2]string]float64)
+	for _, item := range spending {
+		byCategory[item.Category] += item.Amount
+		bySource[[2]string{item.Category, item.Source}] += item.Amount
+	}
+
+	progress := make([]domain.BudgetProgress, 0, len(budgets))
+	var totalBudget, totalSpent float64
+	for _, budget := range budgets {
+		spent := byCategory[budget.Category]
+		if budget.Source != "" {
+			spent = bySource[[2]string{budget.Category, budget.Source}]
+		}
+
+		progress = append(progress, calculateBudgetProgress(budget, spent, daysElapsed, daysInPeriod))
+		totalBudget += budget.Amount
+		totalSpent += spent
+	}
+
+	return progress, totalBudget, totalSpent
+}
+
+// calculateBudgetProgress derives usage, remaining amount and a linear
+// month-end projection for a single budget
+func calculateBudgetProgress(budget domain.Budget, spent float64, daysElapsed, daysInPeriod int) domain.BudgetProgress {
+	progress := domain.BudgetProgress{
+		ID:        budget.ID,
+		Category:  budget.Category,
+		Source:    budget.Source,
+		Amount:    budget.Amount,
+		Spent:     spent,
+		Remaining: budget.Amount - spent,
+	}
+
+	if budget.Amount > 0 {
+		progress.PercentUsed = spent / budget.Amount * 100
+	}
+	if daysElapsed > 0 {
+		progress.ProjectedSpend = spent / float64(daysElapsed) * float64(daysInPeriod)
+	}
+
+	switch {
+	case spent > budget.Amount:
+		progress.Status = domain.BudgetStatusExceeded
+	case progress.PercentUsed >= domain.BudgetWarningThreshold || progress.ProjectedSpend > budget.Amount:
+		progress.Status = domain.BudgetStatusWarning
+	default:
+		progress.Status = domain.BudgetStatusOnTrack
+	}
+
+	return progress
+}
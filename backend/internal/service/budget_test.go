@@ -0,0 +1,212 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockBudgetRepository is a mock implementation of BudgetRepository for testing
+type mockBudgetRepository struct {
+	budgets     []domain.Budget
+	spending    []domain.CategorySpending
+	createErr   error
+	listErr     error
+	spendingErr error
+	deleted     int64
+}
+
+func (m *mockBudgetRepository) Create(budget *domain.Budget) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	budget.ID = int64(len(m.budgets) + 1)
+	m.budgets = append(m.budgets, *budget)
+	return nil
+}
+
+func (m *mockBudgetRepository) FindByID(id int64) (*domain.Budget, error) {
+	for i := range m.budgets {
+		if m.budgets[i].ID == id {
+			budget := m.budgets[i]
+			return &budget, nil
+		}
+	}
+	return nil, repository.ErrBudgetNotFound
+}
+
+func (m *mockBudgetRepository) List() ([]domain.Budget, error) {
+	return m.budgets, m.listErr
+}
+
+func (m *mockBudgetRepository) Update(budget *domain.Budget) error {
+	for i := range m.budgets {
+		if m.budgets[i].ID == budget.ID {
+			m.budgets[i] = *budget
+		}
+	}
+	return nil
+}
+
+func (m *mockBudgetRepository) Delete(id int64) error {
+	m.deleted = id
+	return nil
+}
+
+func (m *mockBudgetRepository) GetCategorySpending(start, end time.Time) ([]domain.CategorySpending, error) {
+	return m.spending, m.spendingErr
+}
+
+// newTestBudgetService creates a budget service with a fixed clock
+func newTestBudgetService(repo repository.BudgetRepository, now time.Time) *budgetService {
+	svc := NewBudgetService(repo).(*budgetService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+// Test CreateBudget
+
+func TestBudgetService_CreateBudget_Success(t *testing.T) {
+	repo := &mockBudgetRepository{}
+	svc := NewBudgetService(repo)
+
+	budget, err := svc.CreateBudget(&domain.BudgetRequest{Category: "Food", Source: "  MoMo ", Amount: 200})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if budget.ID != 1 {
+		t.Errorf("expected ID 1, got %d", budget.ID)
+	}
+	if budget.Source != "MoMo" {
+		t.Errorf("expected sanitized source 'MoMo', got %q", budget.Source)
+	}
+}
+
+func TestBudgetService_CreateBudget_ValidationError(t *testing.T) {
+	repo := &mockBudgetRepository{}
+	svc := NewBudgetService(repo)
+
+	_, err := svc.CreateBudget(&domain.BudgetRequest{Category: "Nope", Amount: 200})
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+// Test UpdateBudget
+
+func TestBudgetService_UpdateBudget_NotFound(t *testing.T) {
+	repo := &mockBudgetRepository{}
+	svc := NewBudgetService(repo)
+
+	_, err := svc.UpdateBudget(42, &domain.BudgetRequest{Category: "Food", Amount: 200})
+
+	if !errors.Is(err, repository.ErrBudgetNotFound) {
+		t.Errorf("expected ErrBudgetNotFound, got %v", err)
+	}
+}
+
+func TestBudgetService_UpdateBudget_Success(t *testing.T) {
+	repo := &mockBudgetRepository{budgets: []domain.Budget{{ID: 1, Category: "Food", Amount: 100}}}
+	svc := NewBudgetService(repo)
+
+	budget, err := svc.UpdateBudget(1, &domain.BudgetRequest{Category: "Food", Amount: 250})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if budget.Amount != 250 {
+		t.Errorf("expected amount 250, got %f", budget.Amount)
+	}
+}
+
+// Test GetBudgetProgress
+
+func TestBudgetService_GetBudgetProgress(t *testing.T) {
+	repo := &mockBudgetRepository{
+		budgets: []domain.Budget{
+			{ID: 1, Category: "Food", Amount: 3000},
+			{ID: 2, Category: "Transportation", Source: "Grab", Amount: 500},
+			{ID: 3, Category: "Shopping", Amount: 1000},
+		},
+		spending: []domain.CategorySpending{
+			{Category: "Food", Source: "Bank ABC", Amount: 600},
+			{Category: "Food", Source: "MoMo", Amount: 400},
+			{Category: "Transportation", Source: "Grab", Amount: 600},
+			{Category: "Transportation", Source: "Bank ABC", Amount: 100},
+		},
+	}
+	// 10 of 30 days elapsed in April
+	svc := newTestBudgetService(repo, time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC))
+
+	progress, err := svc.GetBudgetProgress()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if progress.Period != "2026-04" {
+		t.Errorf("expected period 2026-04, got %s", progress.Period)
+	}
+	if progress.DaysElapsed != 10 || progress.DaysInPeriod != 30 {
+		t.Errorf("expected 10/30 days, got %d/%d", progress.DaysElapsed, progress.DaysInPeriod)
+	}
+
+	food := progress.Budgets[0]
+	if food.Spent != 1000 || food.Remaining != 2000 {
+		t.Errorf("unexpected food spent/remaining: %+v", food)
+	}
+	if food.ProjectedSpend != 3000 {
+		t.Errorf("expected projected spend 3000, got %f", food.ProjectedSpend)
+	}
+	if food.Status != domain.BudgetStatusOnTrack {
+		t.Errorf("expected food on track, got %s", food.Status)
+	}
+
+	// Source-scoped budget only counts that source
+	transport := progress.Budgets[1]
+	if transport.Spent != 600 || transport.Status != domain.BudgetStatusExceeded {
+		t.Errorf("unexpected transport progress: %+v", transport)
+	}
+
+	shopping := progress.Budgets[2]
+	if shopping.Spent != 0 || shopping.PercentUsed != 0 || shopping.Status != domain.BudgetStatusOnTrack {
+		t.Errorf("unexpected shopping progress: %+v", shopping)
+	}
+
+	if progress.TotalBudget != 4500 || progress.TotalSpent != 1600 {
+		t.Errorf("unexpected totals: budget %f, spent %f", progress.TotalBudget, progress.TotalSpent)
+	}
+}
+
+func TestBudgetService_GetBudgetProgress_ProjectedOverspendIsWarning(t *testing.T) {
+	repo := &mockBudgetRepository{
+		budgets:  []domain.Budget{{ID: 1, Category: "Food", Amount: 1000}},
+		spending: []domain.CategorySpending{{Category: "Food", Source: "Bank ABC", Amount: 500}},
+	}
+	svc := newTestBudgetService(repo, time.Date(2026, 4, 10, 12, 0, 0, 0, time.UTC))
+
+	progress, err := svc.GetBudgetProgress()
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if progress.Budgets[0].Status != domain.BudgetStatusWarning {
+		t.Errorf("expected warning, got %s", progress.Budgets[0].Status)
+	}
+}
+
+func TestBudgetService_GetBudgetProgress_RepositoryError(t *testing.T) {
+	repo := &mockBudgetRepository{spendingErr: errors.New("database error")}
+	svc := NewBudgetService(repo)
+
+	_, err := svc.GetBudgetProgress()
+
+	if err == nil {
+		t.Error("expected error, got nil")
+	}
+}
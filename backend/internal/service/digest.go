@@ -0,0 +1,292 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/mailer"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+var (
+	//go:embed templates/digest.html
+	digestHTML string
+	//go:embed templates/digest.txt
+	digestText string
+)
+
+// digestFuncs extend the report helpers with digest titles
+var digestFuncs = map[string]interface{}{
+	"title":      digestTitle,
+	"periodName": digestPeriodName,
+}
+
+var (
+	digestHTMLTemplate = htmltemplate.Must(htmltemplate.New("digest.html").
+				Funcs(reportFuncs).Funcs(digestFuncs).Parse(digestHTML))
+	digestTextTemplate = texttemplate.Must(texttemplate.New("digest.txt").
+				Funcs(texttemplate.FuncMap(reportFuncs)).Funcs(digestFuncs).Parse(digestText))
+)
+
+// DigestService manages digest subscriptions and mails the digests that are due
+type DigestService interface {
+	GetPreferences(userID int64) (*domain.DigestSubscription, error)
+	UpdatePreferences(userID int64, req *domain.DigestPreferencesRequest) (*domain.DigestSubscription, error)
+	BuildDigest(ctx context.Context, frequency domain.DigestFrequency) (*domain.Digest, error)
+	SendDue(ctx context.Context) (*domain.DigestRunResult, error)
+}
+
+type digestService struct {
+	repo        repository.DigestRepository
+	userRepo    repository.UserRepository
+	txRepo      repository.TransactionRepository
+	budgetRepo  repository.BudgetRepository
+	anomalyRepo repository.AnomalyRepository
+	mailer      mailer.Mailer
+	currency    string
+	now         func() time.Time
+}
+
+// NewDigestService creates a new digest service. Digests are sent through
+// mailer with amounts labelled in currency.
+func NewDigestService(
+	repo repository.DigestRepository,
+	userRepo repository.UserRepository,
+	txRepo repository.TransactionRepository,
+	budgetRepo repository.BudgetRepository,
+	anomalyRepo repository.AnomalyRepository,
+	mailer mailer.Mailer,
+	currency string,
+) DigestService {
+	return &digestService{
+		repo:        repo,
+		userRepo:    userRepo,
+		txRepo:      txRepo,
+		budgetRepo:  budgetRepo,
+		anomalyRepo: anomalyRepo,
+		mailer:      mailer,
+		currency:    currency,
+		now:         time.Now,
+	}
+}
+
+// GetPreferences returns a user's subscription; users who never chose
+// receive no digests
+func (s *digestService) GetPreferences(userID int64) (*domain.DigestSubscription, error) {
+	subscription, err := s.repo.FindByUserID(userID)
+	if errors.Is(err, repository.ErrDigestSubscriptionNotFound) {
+		return &domain.DigestSubscription{UserID: userID}, nil
+	}
+	return subscription, err
+}
+
+// UpdatePreferences opts a user in or out of weekly and monthly digests.
+// Opting in starts with the next period to complete rather than mailing the
+// one that just ended.
+func (s *digestService) UpdatePreferences(userID int64, req *domain.DigestPreferencesRequest) (*domain.DigestSubscription, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.GetPreferences(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	if req.Weekly != nil {
+		if *req.Weekly && !subscription.Weekly {
+			subscription.LastWeeklyPeriod, _, _ = domain.DigestPeriod(domain.DigestWeekly, now)
+		}
+		subscription.Weekly = *req.Weekly
+	}
+	if req.Monthly != nil {
+		if *req.Monthly && !subscription.Monthly {
+			subscription.LastMonthlyPeriod, _, _ = domain.DigestPeriod(domain.DigestMonthly, now)
+		}
+		subscription.Monthly = *req.Monthly
+	}
+
+	if err := s.repo.Save(subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// BuildDigest collects the digest of the most recent complete week or month
+func (s *digestService) BuildDigest(ctx context.Context, frequency domain.DigestFrequency) (*domain.Digest, error) {
+	period, start, end := domain.DigestPeriod(frequency, s.now())
+	previousStart, previousEnd := start.AddDate(0, 0, -7), start.Add(-time.Nanosecond)
+	if frequency == domain.DigestMonthly {
+		previousStart, previousEnd = domain.MonthPeriod(start.AddDate(0, -1, 0))
+	}
+
+	digest := &domain.Digest{
+		Frequency:   frequency,
+		Period:      period,
+		Currency:    s.currency,
+		PeriodStart: start,
+		PeriodEnd:   end,
+	}
+
+	var err error
+	if digest.Summary, _, err = summarizePeriod(ctx, s.txRepo, start, end, 0); err != nil {
+		return nil, err
+	}
+	if digest.Previous, _, err = summarizePeriod(ctx, s.txRepo, previousStart, previousEnd, 0); err != nil {
+		return nil, err
+	}
+	digest.Comparison = domain.ComparePeriods(digest.Summary, digest.Previous)
+
+	digest.TopCategories, err = s.txRepo.GetBreakdown(&domain.BreakdownFilter{
+		Type:      domain.TransactionTypeOut,
+		GroupBy:   domain.BreakdownByCategory,
+		DateRange: domain.DateRange{Start: &start, End: &end},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(digest.TopCategories) > domain.DigestTopCategories {
+		digest.TopCategories = digest.TopCategories[:domain.DigestTopCategories]
+	}
+
+	// Budgets are monthly, so show the month to date at the end of the period
+	monthStart, monthEnd := domain.MonthPeriod(end)
+	budgets, err := s.budgetRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	spending, err := s.budgetRepo.GetCategorySpending(monthStart, end)
+	if err != nil {
+		return nil, err
+	}
+	digest.Budgets, _, _ = summarizeBudgets(budgets, spending, end.Day(), monthEnd.Day())
+
+	anomalies, err := s.anomalyRepo.ListSince(start, "")
+	if err != nil {
+		return nil, err
+	}
+	flagged, err := collectAnomalous(s.anomalyRepo, anomalies)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range flagged {
+		if item.Transaction.TransactionDate.After(end) || len(digest.Anomalies) == domain.DigestMaxAnomalies {
+			continue
+		}
+		digest.Anomalies = append(digest.Anomalies, item)
+	}
+
+	return digest, nil
+}
+
+// SendDue mails every subscriber the digests of periods completed since
+// their last one. Each period is built once and shared by all recipients.
+// A period is claimed before its digest is sent, so runs on several replicas
+// send it once. A failed delivery is retried on the next run; cancelling ctx
+// stops the run between messages.
+func (s *digestService) SendDue(ctx context.Context) (*domain.DigestRunResult, error) {
+	log := logger.Get()
+	result := &domain.DigestRunResult{}
+
+	subscriptions, err := s.repo.ListSubscribed()
+	if err != nil {
+		return result, err
+	}
+
+	digests := make(map[domain.DigestFrequency]*domain.Digest)
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+		for _, frequency := range []domain.DigestFrequency{domain.DigestWeekly, domain.DigestMonthly} {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			period, _, _ := domain.DigestPeriod(frequency, s.now())
+			if !subscription.Wants(frequency) || subscription.LastPeriod(frequency) >= period {
+				continue
+			}
+
+			digest, ok := digests[frequency]
+			if !ok || digest.Period != period {
+				if digest, err = s.BuildDigest(ctx, frequency); err != nil {
+					return result, err
+				}
+				digests[frequency] = digest
+			}
+
+			claimed, err := s.repo.ClaimPeriod(subscription.UserID, frequency, period)
+			if err != nil {
+				return result, err
+			}
+			if !claimed {
+				// Another run has sent it since the subscriptions were listed
+				continue
+			}
+
+			if err := s.send(ctx, subscription.UserID, digest); err != nil {
+				log.Error().Err(err).Int64("user_id", subscription.UserID).Str("period", period).Msg("Failed to send digest")
+				result.Failed++
+				if err := s.repo.ReleasePeriod(subscription.UserID, frequency, period, subscription.LastPeriod(frequency)); err != nil {
+					return result, err
+				}
+				continue
+			}
+			if err := s.repo.MarkSent(subscription.UserID, frequency, period, s.now().UTC()); err != nil {
+				return result, err
+			}
+			result.Sent++
+		}
+	}
+
+	return result, nil
+}
+
+// send renders a digest for one user and mails it
+func (s *digestService) send(ctx context.Context, userID int64, digest *domain.Digest) error {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+
+	personal := *digest
+	personal.Name = user.Name
+
+	var html, text bytes.Buffer
+	if err := digestHTMLTemplate.Execute(&html, &personal); err != nil {
+		return err
+	}
+	if err := digestTextTemplate.Execute(&text, &personal); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, &mailer.Message{
+		To:      []string{user.Email},
+		Subject: digestTitle(&personal),
+		HTML:    html.String(),
+		Text:    text.String(),
+	})
+}
+
+// digestTitle returns the subject line of a digest
+func digestTitle(digest *domain.Digest) string {
+	if digest.Frequency == domain.DigestWeekly {
+		return "Your weekly digest: " + digest.Period
+	}
+	return "Your monthly digest: " + digest.Period
+}
+
+// digestPeriodName returns "week" or "month"
+func digestPeriodName(digest *domain.Digest) string {
+	if digest.Frequency == domain.DigestWeekly {
+		return "week"
+	}
+	return "month"
+}
@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/mailer"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockDigestRepository is a mock implementation of DigestRepository for testing
+type mockDigestRepository struct {
+	subscriptions map[int64]*domain.DigestSubscription
+	saved         []domain.DigestSubscription
+	listed        []domain.DigestSubscription // when set, listed instead, as read by a run before another's claims
+	listErr       error
+}
+
+func (m *mockDigestRepository) FindByUserID(userID int64) (*domain.DigestSubscription, error) {
+	subscription, ok := m.subscriptions[userID]
+	if !ok {
+		return nil, repository.ErrDigestSubscriptionNotFound
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+func (m *mockDigestRepository) Save(subscription *domain.DigestSubscription) error {
+	m.saved = append(m.saved, *subscription)
+	return nil
+}
+
+func (m *mockDigestRepository) ListSubscribed() ([]domain.DigestSubscription, error) {
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	if m.listed != nil {
+		return m.listed, nil
+	}
+	var subscriptions []domain.DigestSubscription
+	for id := int64(1); id <= int64(len(m.subscriptions)); id++ {
+		if s, ok := m.subscriptions[id]; ok && (s.Weekly || s.Monthly) {
+			subscriptions = append(subscriptions, *s)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (m *mockDigestRepository) ClaimPeriod(userID int64, frequency domain.DigestFrequency, period string) (bool, error) {
+	subscription := m.subscriptions[userID]
+	if subscription.LastPeriod(frequency) >= period {
+		return false, nil
+	}
+	if frequency == domain.DigestWeekly {
+		subscription.LastWeeklyPeriod = period
+	} else {
+		subscription.LastMonthlyPeriod = period
+	}
+	return true, nil
+}
+
+func (m *mockDigestRepository) ReleasePeriod(userID int64, frequency domain.DigestFrequency, period, previous string) error {
+	subscription := m.subscriptions[userID]
+	if frequency == domain.DigestWeekly && subscription.LastWeeklyPeriod == period {
+		subscription.LastWeeklyPeriod = previous
+	} else if frequency == domain.DigestMonthly && subscription.LastMonthlyPeriod == period {
+		subscription.LastMonthlyPeriod = previous
+	}
+	return nil
+}
+
+func (m *mockDigestRepository) MarkSent(userID int64, frequency domain.DigestFrequency, period string, sentAt time.Time) error {
+	subscription := m.subscriptions[userID]
+	if frequency == domain.DigestWeekly {
+		subscription.LastWeeklyPeriod = period
+	} else {
+		subscription.LastMonthlyPeriod = period
+	}
+	subscription.LastSentAt = &sentAt
+	return nil
+}
+
+// mockMailer records the messages it is asked to send
+type mockMailer struct {
+	sent    []*mailer.Message
+	failFor string
+}
+
+func (m *mockMailer) Send(ctx context.Context, msg *mailer.Message) error {
+	if msg.To[0] == m.failFor {
+		return errors.New("550 mailbox unavailable")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// newTestDigestService creates a digest service over mock repositories with a fixed clock
+func newTestDigestService(repo *mockDigestRepository, txRepo *mockRepository, m *mockMailer, now time.Time) *digestService {
+	userRepo := &mockUserRepository{findByIDUser: &domain.User{ID: 1, Email: "an@example.com", Name: "An"}}
+	svc := NewDigestService(repo, userRepo, txRepo, &mockBudgetRepository{}, &mockAnomalyRepository{}, m, "VND").(*digestService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestDigestService_GetPreferences_Default(t *testing.T) {
+	svc := newTestDigestService(&mockDigestRepository{}, &mockRepository{}, &mockMailer{}, time.Now())
+
+	subscription, err := svc.GetPreferences(3)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), subscription.UserID)
+	assert.False(t, subscription.Weekly)
+	assert.False(t, subscription.Monthly)
+}
+
+func TestDigestService_UpdatePreferences_OptInSkipsFinishedPeriod(t *testing.T) {
+	repo := &mockDigestRepository{}
+	svc := newTestDigestService(repo, &mockRepository{}, &mockMailer{}, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC))
+	on := true
+
+	subscription, err := svc.UpdatePreferences(1, &domain.DigestPreferencesRequest{Weekly: &on, Monthly: &on})
+
+	require.NoError(t, err)
+	assert.True(t, subscription.Weekly)
+	assert.True(t, subscription.Monthly)
+	assert.Equal(t, "2026-W10", subscription.LastWeeklyPeriod)
+	assert.Equal(t, "2026-02", subscription.LastMonthlyPeriod)
+	assert.Len(t, repo.saved, 1)
+}
+
+func TestDigestService_UpdatePreferences_KeepsOtherChoice(t *testing.T) {
+	repo := &mockDigestRepository{subscriptions: map[int64]*domain.DigestSubscription{
+		1: {UserID: 1, Weekly: true, Monthly: true, LastWeeklyPeriod: "2026-W08"},
+	}}
+	svc := newTestDigestService(repo, &mockRepository{}, &mockMailer{}, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC))
+	off := false
+
+	subscription, err := svc.UpdatePreferences(1, &domain.DigestPreferencesRequest{Monthly: &off})
+
+	require.NoError(t, err)
+	assert.True(t, subscription.Weekly)
+	assert.False(t, subscription.Monthly)
+	assert.Equal(t, "2026-W08", subscription.LastWeeklyPeriod, "staying opted in keeps the pending period")
+}
+
+func TestDigestService_UpdatePreferences_ValidationError(t *testing.T) {
+	svc := newTestDigestService(&mockDigestRepository{}, &mockRepository{}, &mockMailer{}, time.Now())
+
+	_, err := svc.UpdatePreferences(1, &domain.DigestPreferencesRequest{})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestDigestService_BuildDigest_Weekly(t *testing.T) {
+	transactions := []domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeOut, Amount: 200, Category: "Food", TransactionDate: time.Date(2026, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{ID: 2, Type: domain.TransactionTypeIn, Amount: 1000, Category: "Salary", TransactionDate: time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC)},
+		// Previous week and the current week
+		{ID: 3, Type: domain.TransactionTypeOut, Amount: 100, Category: "Food", TransactionDate: time.Date(2026, 2, 27, 12, 0, 0, 0, time.UTC)},
+		{ID: 4, Type: domain.TransactionTypeOut, Amount: 999, Category: "Food", TransactionDate: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+	}
+	txRepo := &mockRepository{streamFunc: monthStream(transactions)}
+	svc := newTestDigestService(&mockDigestRepository{}, txRepo, &mockMailer{}, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC))
+
+	digest, err := svc.BuildDigest(context.Background(), domain.DigestWeekly)
+
+	require.NoError(t, err)
+	assert.Equal(t, "2026-W10", digest.Period)
+	assert.Equal(t, "VND", digest.Currency)
+	assert.Equal(t, domain.PeriodSummary{Income: 1000, Expense: 200, Net: 800, TransactionCount: 2}, digest.Summary)
+	assert.Equal(t, domain.PeriodSummary{Expense: 100, Net: -100, TransactionCount: 1}, digest.Previous)
+	require.NotNil(t, digest.Comparison.ExpensePercent)
+	assert.InDelta(t, 100, *digest.Comparison.ExpensePercent, 0.001)
+}
+
+func TestDigestService_SendDue(t *testing.T) {
+	repo := &mockDigestRepository{subscriptions: map[int64]*domain.DigestSubscription{
+		1: {UserID: 1, Weekly: true, Monthly: true, LastWeeklyPeriod: "2026-W09", LastMonthlyPeriod: "2026-02"},
+	}}
+	m := &mockMailer{}
+	svc := newTestDigestService(repo, &mockRepository{streamFunc: monthStream(nil)}, m, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC))
+
+	result, err := svc.SendDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DigestRunResult{Sent: 1}, result, "the monthly digest of 2026-02 was already sent")
+	require.Len(t, m.sent, 1)
+	assert.Equal(t, []string{"an@example.com"}, m.sent[0].To)
+	assert.Equal(t, "Your weekly digest: 2026-W10", m.sent[0].Subject)
+	assert.Contains(t, m.sent[0].HTML, "Hi An")
+	assert.Contains(t, m.sent[0].Text, "Your weekly digest: 2026-W10")
+	assert.Equal(t, "2026-W10", repo.subscriptions[1].LastWeeklyPeriod)
+
+	// A second run in the same week sends nothing
+	result, err = svc.SendDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DigestRunResult{}, result)
+	assert.Len(t, m.sent, 1)
+}
+
+func TestDigestService_SendDue_ConcurrentRunSendsNothing(t *testing.T) {
+	repo := &mockDigestRepository{subscriptions: map[int64]*domain.DigestSubscription{
+		1: {UserID: 1, Weekly: true, Monthly: true, LastWeeklyPeriod: "2026-W09", LastMonthlyPeriod: "2026-01"},
+	}}
+	// Both replicas read the subscriptions before either has sent anything
+	repo.listed = []domain.DigestSubscription{*repo.subscriptions[1]}
+	now := time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC)
+	m := &mockMailer{}
+	first := newTestDigestService(repo, &mockRepository{streamFunc: monthStream(nil)}, m, now)
+	second := newTestDigestService(repo, &mockRepository{streamFunc: monthStream(nil)}, m, now)
+
+	result, err := first.SendDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DigestRunResult{Sent: 2}, result)
+
+	result, err = second.SendDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DigestRunResult{}, result)
+	assert.Len(t, m.sent, 2, "each digest is sent once")
+}
+
+func TestDigestService_SendDue_FailedDeliveryIsRetried(t *testing.T) {
+	repo := &mockDigestRepository{subscriptions: map[int64]*domain.DigestSubscription{
+		1: {UserID: 1, Monthly: true, LastMonthlyPeriod: "2026-01"},
+	}}
+	m := &mockMailer{failFor: "an@example.com"}
+	svc := newTestDigestService(repo, &mockRepository{streamFunc: monthStream(nil)}, m, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC))
+
+	result, err := svc.SendDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.DigestRunResult{Failed: 1}, result)
+	assert.Equal(t, "2026-01", repo.subscriptions[1].LastMonthlyPeriod)
+}
+
+func TestDigestService_SendDue_Cancelled(t *testing.T) {
+	repo := &mockDigestRepository{subscriptions: map[int64]*domain.DigestSubscription{
+		1: {UserID: 1, Weekly: true},
+	}}
+	m := &mockMailer{}
+	svc := newTestDigestService(repo, &mockRepository{streamFunc: monthStream(nil)}, m, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := svc.SendDue(ctx)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, m.sent)
+}
+
+func TestDigestService_SendDue_RepositoryError(t *testing.T) {
+	svc := newTestDigestService(&mockDigestRepository{listErr: errors.New("connection refused")}, &mockRepository{}, &mockMailer{}, time.Now())
+
+	_, err := svc.SendDue(context.Background())
+	assert.Error(t, err)
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// amountTolerance absorbs rounding when comparing decimal(15,2) amounts
+const amountTolerance = 0.005
+
+// EnvelopeService handles business logic for envelope budgeting
+type EnvelopeService interface {
+	CreateEnvelope(req *domain.EnvelopeRequest) (*domain.Envelope, error)
+	GetEnvelope(id int64) (*domain.Envelope, error)
+	ListEnvelopes() ([]domain.Envelope, error)
+	UpdateEnvelope(id int64, req *domain.EnvelopeRequest) (*domain.Envelope, error)
+	DeleteEnvelope(id int64) error
+	Allocate(envelopeID int64, req *domain.AllocationRequest) (*domain.EnvelopeAllocation, error)
+	ListAllocations(envelopeID int64) ([]domain.EnvelopeAllocation, error)
+	Move(fromEnvelopeID int64, req *domain.MoveRequest) (*domain.EnvelopeMove, error)
+	GetBalances(params domain.EnvelopeBalancesQueryParams) (*domain.EnvelopeBalancesResponse, error)
+}
+
+type envelopeService struct {
+	repo   repository.EnvelopeRepository
+	txRepo repository.TransactionRepository
+	now    func() time.Time
+}
+
+// NewEnvelopeService creates a new envelope service
+func NewEnvelopeService(repo repository.EnvelopeRepository, txRepo repository.TransactionRepository) EnvelopeService {
+	return &envelopeService{
+		repo:   repo,
+		txRepo: txRepo,
+		now:    time.Now,
+	}
+}
+
+func (s *envelopeService) CreateEnvelope(req *domain.EnvelopeRequest) (*domain.Envelope, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	envelope := &domain.Envelope{
+		Name:     req.Name,
+		Category: req.Category,
+	}
+	if err := s.repo.Create(envelope); err != nil {
+		return nil, err
+	}
+
+	return envelope, nil
+}
+
+func (s *envelopeService) GetEnvelope(id int64) (*domain.Envelope, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid envelope ID")
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *envelopeService) ListEnvelopes() ([]domain.Envelope, error) {
+	return s.repo.List()
+}
+
+func (s *envelopeService) UpdateEnvelope(id int64, req *domain.EnvelopeRequest) (*domain.Envelope, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	envelope, err := s.GetEnvelope(id)
+	if err != nil {
+		return nil, err
+	}
+
+	envelope.Name = req.Name
+	envelope.Category = req.Category
+	if err := s.repo.Update(envelope); err != nil {
+		return nil, err
+	}
+
+	return envelope, nil
+}
+
+func (s *envelopeService) DeleteEnvelope(id int64) error {
+	if id <= 0 {
+		return errors.New("invalid envelope ID")
+	}
+	return s.repo.Delete(id)
+}
+
+// Allocate assigns money to an envelope. When the allocation is funded by an
+// income transaction, the total allocated from it may not exceed its amount.
+func (s *envelopeService) Allocate(envelopeID int64, req *domain.AllocationRequest) (*domain.EnvelopeAllocation, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetEnvelope(envelopeID); err != nil {
+		return nil, err
+	}
+
+	month := req.Month
+	if req.TransactionID != nil {
+		tx, err := s.txRepo.FindByID(*req.TransactionID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, &domain.ValidationError{
+					Field:   "transaction_id",
+					Message: "transaction not found",
+				}
+			}
+			return nil, err
+		}
+
+		if tx.Type != domain.TransactionTypeIn {
+			return nil, &domain.ValidationError{
+				Field:   "transaction_id",
+				Message: "only income transactions can be allocated",
+			}
+		}
+
+		allocated, err := s.repo.SumAllocationsForTransaction(tx.ID)
+		if err != nil {
+			return nil, err
+		}
+		if allocated+req.Amount > tx.Amount+amountTolerance {
+			return nil, &domain.ValidationError{
+				Field:   "amount",
+				Message: fmt.Sprintf("amount exceeds the unallocated income of this transaction (%.2f remaining)", tx.Amount-allocated),
+			}
+		}
+
+		// Income is allocated in the month it was received unless stated otherwise
+		if month == "" {
+			month = tx.TransactionDate.UTC().Format(domain.MonthLayout)
+		}
+	}
+	if month == "" {
+		month = s.now().UTC().Format(domain.MonthLayout)
+	}
+
+	allocation := &domain.EnvelopeAllocation{
+		EnvelopeID:    envelopeID,
+		TransactionID: req.TransactionID,
+		Month:         month,
+		Amount:        req.Amount,
+		Note:          req.Note,
+	}
+	if err := s.repo.CreateAllocation(allocation); err != nil {
+		return nil, err
+	}
+
+	return allocation, nil
+}
+
+func (s *envelopeService) ListAllocations(envelopeID int64) ([]domain.EnvelopeAllocation, error) {
+	if _, err := s.GetEnvelope(envelopeID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAllocations(envelopeID)
+}
+
+// Move transfers available money between envelopes, typically to cover overspending.
+// The source envelope must have enough available in that month.
+func (s *envelopeService) Move(fromEnvelopeID int64, req *domain.MoveRequest) (*domain.EnvelopeMove, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if fromEnvelopeID == req.ToEnvelopeID {
+		return nil, &domain.ValidationError{
+			Field:   "to_envelope_id",
+			Message: "cannot move money to the same envelope",
+		}
+	}
+
+	if _, err := s.GetEnvelope(fromEnvelopeID); err != nil {
+		return nil, err
+	}
+	if _, err := s.GetEnvelope(req.ToEnvelopeID); err != nil {
+		return nil, err
+	}
+
+	month := req.Month
+	if month == "" {
+		month = s.now().UTC().Format(domain.MonthLayout)
+	}
+
+	balances, err := s.GetBalances(domain.EnvelopeBalancesQueryParams{StartMonth: month, EndMonth: month})
+	if err != nil {
+		return nil, err
+	}
+
+	var available float64
+	for _, series := range balances.Envelopes {
+		if series.ID == fromEnvelopeID && len(series.Points) > 0 {
+			available = series.Points[0].Available
+		}
+	}
+	if req.Amount > available+amountTolerance {
+		return nil, &domain.ValidationError{
+			Field:   "amount",
+			Message: fmt.Sprintf("amount exceeds the available balance of the source envelope (%.2f available)", available),
+		}
+	}
+
+	move := &domain.EnvelopeMove{
+		FromEnvelopeID: fromEnvelopeID,
+		ToEnvelopeID:   req.ToEnvelopeID,
+		Month:          month,
+		Amount:         req.Amount,
+		Note:           req.Note,
+	}
+	if err := s.repo.CreateMove(move); err != nil {
+		return nil, err
+	}
+
+	return move, nil
+}
+
+// GetBalances returns each envelope's available balance per month.
+// Balances roll over from the envelope's creation month, so history before
+// the requested range still contributes to the opening balance.
+func (s *envelopeService) GetBalances(params domain.EnvelopeBalancesQueryParams) (*domain.EnvelopeBalancesResponse, error) {
+	if err := params.Validate(s.now()); err != nil {
+		return nil, err
+	}
+
+	envelopes, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	allocations, err := s.repo.GetMonthlyAllocations()
+	if err != nil {
+		return nil, err
+	}
+	moves, err := s.repo.GetMonthlyMoves()
+	if err != nil {
+		return nil, err
+	}
+	spending, err := s.repo.GetMonthlyCategorySpending()
+	if err != nil {
+		return nil, err
+	}
+	income, err := s.repo.GetMonthlyIncome()
+	if err != nil {
+		return nil, err
+	}
+
+	return buildEnvelopeBalances(envelopes, allocations, moves, spending, income, params.StartMonth, params.EndMonth), nil
+}
+
+// buildEnvelopeBalances rolls envelope balances forward month by month and
+// reports the months between startMonth and endMonth inclusive
+func buildEnvelopeBalances(
+	envelopes []domain.Envelope,
+	allocations []domain.EnvelopeMonthAmount,
+	moves []domain.EnvelopeMoveTotal,
+	spending []domain.CategoryMonthAmount,
+	income []domain.MonthAmount,
+	startMonth, endMonth string,
+) *domain.EnvelopeBalancesResponse {
+	type monthKey struct {
+		month      string
+		envelopeID int64
+	}
+
+	allocated := make(map[monthKey]float64)
+	movedIn := make(map[monthKey]float64)
+	movedOut := make(map[monthKey]float64)
+	spent := make(map[[2]string]float64)
+	incomeByMonth := make(map[string]float64)
+	allocatedByMonth := make(map[string]float64)
+
+	// Find the earliest month with any activity so rollover starts there
+	firstMonth := startMonth
+	track := func(month string) {
+		if month != "" && month < firstMonth {
+			firstMonth = month
+		}
+	}
+
+	for _, a := range allocations {
+		allocated[monthKey{a.Month, a.EnvelopeID}] += a.Amount
+		allocatedByMonth[a.Month] += a.Amount
+		track(a.Month)
+	}
+	for _, m := range moves {
+		movedOut[monthKey{m.Month, m.FromEnvelopeID}] += m.Amount
+		movedIn[monthKey{m.Month, m.ToEnvelopeID}] += m.Amount
+		track(m.Month)
+	}
+	for _, sp := range spending {
+		spent[[2]string{sp.Month, sp.Category}] += sp.Amount
+	}
+	for _, in := range income {
+		incomeByMonth[in.Month] += in.Amount
+		track(in.Month)
+	}
+
+	response := &domain.EnvelopeBalancesResponse{
+		Months:      []string{},
+		Envelopes:   make([]domain.EnvelopeBalanceSeries, len(envelopes)),
+		Unallocated: []float64{},
+	}
+	for i, envelope := range envelopes {
+		response.Envelopes[i] = domain.EnvelopeBalanceSeries{
+			ID:       envelope.ID,
+			Name:     envelope.Name,
+			Category: envelope.Category,
+			Points:   []domain.EnvelopeBalancePoint{},
+		}
+	}
+
+	available := make([]float64, len(envelopes))
+	var unallocated float64
+
+	start, err := time.Parse(domain.MonthLayout, firstMonth)
+	if err != nil {
+		return response
+	}
+	for t := start; t.Format(domain.MonthLayout) <= endMonth; t = t.AddDate(0, 1, 0) {
+		month := t.Format(domain.MonthLayout)
+		unallocated += incomeByMonth[month] - allocatedByMonth[month]
+		inRange := month >= startMonth
+
+		if inRange {
+			response.Months = append(response.Months, month)
+			response.Unallocated = append(response.Unallocated, unallocated)
+		}
+
+		for i, envelope := range envelopes {
+			// Spending before the envelope existed is not charged to it
+			if month < envelope.CreatedAt.UTC().Format(domain.MonthLayout) {
+				if inRange {
+					response.Envelopes[i].Points = append(response.Envelopes[i].Points, domain.EnvelopeBalancePoint{Month: month})
+				}
+				continue
+			}
+
+			key := monthKey{month, envelope.ID}
+			point := domain.EnvelopeBalancePoint{
+				Month:     month,
+				Rollover:  available[i],
+				Allocated: allocated[key],
+				MovedIn:   movedIn[key],
+				MovedOut:  movedOut[key],
+				Spent:     spent[[2]string{month, envelope.Category}],
+			}
+			point.Available = point.Rollover + point.Allocated + point.MovedIn - point.MovedOut - point.Spent
+			point.Overspent = point.Available < -amountTolerance
+			available[i] = point.Available
+
+			if inRange {
+				response.Envelopes[i].Points = append(response.Envelopes[i].Points, point)
+			}
+		}
+	}
+
+	return response
+}
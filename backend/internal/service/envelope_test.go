@@ -0,0 +1,287 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockEnvelopeRepository is a mock implementation of EnvelopeRepository for testing
+type mockEnvelopeRepository struct {
+	envelopes   []domain.Envelope
+	allocations []domain.EnvelopeAllocation
+	moves       []domain.EnvelopeMove
+	spending    []domain.CategoryMonthAmount
+	income      []domain.MonthAmount
+}
+
+func (m *mockEnvelopeRepository) Create(envelope *domain.Envelope) error {
+	envelope.ID = int64(len(m.envelopes) + 1)
+	m.envelopes = append(m.envelopes, *envelope)
+	return nil
+}
+
+func (m *mockEnvelopeRepository) FindByID(id int64) (*domain.Envelope, error) {
+	for i := range m.envelopes {
+		if m.envelopes[i].ID == id {
+			envelope := m.envelopes[i]
+			return &envelope, nil
+		}
+	}
+	return nil, repository.ErrEnvelopeNotFound
+}
+
+func (m *mockEnvelopeRepository) List() ([]domain.Envelope, error) {
+	return m.envelopes, nil
+}
+
+func (m *mockEnvelopeRepository) Update(envelope *domain.Envelope) error {
+	return nil
+}
+
+func (m *mockEnvelopeRepository) Delete(id int64) error {
+	return nil
+}
+
+func (m *mockEnvelopeRepository) CreateAllocation(allocation *domain.EnvelopeAllocation) error {
+	allocation.ID = int64(len(m.allocations) + 1)
+	m.allocations = append(m.allocations, *allocation)
+	return nil
+}
+
+func (m *mockEnvelopeRepository) ListAllocations(envelopeID int64) ([]domain.EnvelopeAllocation, error) {
+	return m.allocations, nil
+}
+
+func (m *mockEnvelopeRepository) SumAllocationsForTransaction(transactionID int64) (float64, error) {
+	var total float64
+	for _, a := range m.allocations {
+		if a.TransactionID != nil && *a.TransactionID == transactionID {
+			total += a.Amount
+		}
+	}
+	return total, nil
+}
+
+func (m *mockEnvelopeRepository) CreateMove(move *domain.EnvelopeMove) error {
+	move.ID = int64(len(m.moves) + 1)
+	m.moves = append(m.moves, *move)
+	return nil
+}
+
+func (m *mockEnvelopeRepository) GetMonthlyAllocations() ([]domain.EnvelopeMonthAmount, error) {
+	var results []domain.EnvelopeMonthAmount
+	for _, a := range m.allocations {
+		results = append(results, domain.EnvelopeMonthAmount{Month: a.Month, EnvelopeID: a.EnvelopeID, Amount: a.Amount})
+	}
+	return results, nil
+}
+
+func (m *mockEnvelopeRepository) GetMonthlyMoves() ([]domain.EnvelopeMoveTotal, error) {
+	var results []domain.EnvelopeMoveTotal
+	for _, mv := range m.moves {
+		results = append(results, domain.EnvelopeMoveTotal{
+			Month:          mv.Month,
+			FromEnvelopeID: mv.FromEnvelopeID,
+			ToEnvelopeID:   mv.ToEnvelopeID,
+			Amount:         mv.Amount,
+		})
+	}
+	return results, nil
+}
+
+func (m *mockEnvelopeRepository) GetMonthlyCategorySpending() ([]domain.CategoryMonthAmount, error) {
+	return m.spending, nil
+}
+
+func (m *mockEnvelopeRepository) GetMonthlyIncome() ([]domain.MonthAmount, error) {
+	return m.income, nil
+}
+
+// newTestEnvelopeService creates an envelope service with a fixed clock
+func newTestEnvelopeService(repo repository.EnvelopeRepository, txRepo repository.TransactionRepository, now time.Time) *envelopeService {
+	svc := NewEnvelopeService(repo, txRepo).(*envelopeService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func testEnvelopes() []domain.Envelope {
+	created := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	return []domain.Envelope{
+		{ID: 1, Name: "Groceries", Category: "Food", CreatedAt: created},
+		{ID: 2, Name: "Fun", Category: "Entertainment", CreatedAt: created},
+	}
+}
+
+// Test Allocate
+
+func TestEnvelopeService_Allocate_FromIncomeTransaction(t *testing.T) {
+	repo := &mockEnvelopeRepository{envelopes: testEnvelopes()}
+	txRepo := &mockRepository{
+		findByIDFunc: func(id int64) (*domain.Transaction, error) {
+			return &domain.Transaction{
+				ID:              id,
+				Type:            domain.TransactionTypeIn,
+				Amount:          1000,
+				TransactionDate: time.Date(2026, 2, 28, 9, 0, 0, 0, time.UTC),
+			}, nil
+		},
+	}
+	svc := newTestEnvelopeService(repo, txRepo, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	txID := int64(42)
+	allocation, err := svc.Allocate(1, &domain.AllocationRequest{TransactionID: &txID, Amount: 600})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-02", allocation.Month, "month should default to the transaction month")
+
+	// Only 400 of the income remains unallocated
+	_, err = svc.Allocate(2, &domain.AllocationRequest{TransactionID: &txID, Amount: 500})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "amount", validationErr.Field)
+}
+
+func TestEnvelopeService_Allocate_RejectsExpenseTransaction(t *testing.T) {
+	repo := &mockEnvelopeRepository{envelopes: testEnvelopes()}
+	txRepo := &mockRepository{
+		findByIDFunc: func(id int64) (*domain.Transaction, error) {
+			return &domain.Transaction{ID: id, Type: domain.TransactionTypeOut, Amount: 1000}, nil
+		},
+	}
+	svc := NewEnvelopeService(repo, txRepo)
+
+	txID := int64(7)
+	_, err := svc.Allocate(1, &domain.AllocationRequest{TransactionID: &txID, Amount: 100})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "transaction_id", validationErr.Field)
+}
+
+func TestEnvelopeService_Allocate_TransactionNotFound(t *testing.T) {
+	repo := &mockEnvelopeRepository{envelopes: testEnvelopes()}
+	txRepo := &mockRepository{
+		findByIDFunc: func(id int64) (*domain.Transaction, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	svc := NewEnvelopeService(repo, txRepo)
+
+	txID := int64(7)
+	_, err := svc.Allocate(1, &domain.AllocationRequest{TransactionID: &txID, Amount: 100})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "transaction_id", validationErr.Field)
+}
+
+func TestEnvelopeService_Allocate_EnvelopeNotFound(t *testing.T) {
+	svc := NewEnvelopeService(&mockEnvelopeRepository{}, &mockRepository{})
+
+	_, err := svc.Allocate(9, &domain.AllocationRequest{Amount: 100})
+
+	assert.ErrorIs(t, err, repository.ErrEnvelopeNotFound)
+}
+
+// Test Move
+
+func TestEnvelopeService_Move_CoversOverspending(t *testing.T) {
+	repo := &mockEnvelopeRepository{
+		envelopes: testEnvelopes(),
+		allocations: []domain.EnvelopeAllocation{
+			{EnvelopeID: 1, Month: "2026-03", Amount: 300},
+			{EnvelopeID: 2, Month: "2026-03", Amount: 200},
+		},
+		spending: []domain.CategoryMonthAmount{{Month: "2026-03", Category: "Food", Amount: 350}},
+	}
+	svc := newTestEnvelopeService(repo, &mockRepository{}, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	_, err := svc.Move(2, &domain.MoveRequest{ToEnvelopeID: 1, Amount: 50})
+	assert.NoError(t, err)
+
+	balances, err := svc.GetBalances(domain.EnvelopeBalancesQueryParams{StartMonth: "2026-03", EndMonth: "2026-03"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, balances.Envelopes[0].Points[0].Available)
+	assert.False(t, balances.Envelopes[0].Points[0].Overspent)
+	assert.Equal(t, 150.0, balances.Envelopes[1].Points[0].Available)
+}
+
+func TestEnvelopeService_Move_InsufficientFunds(t *testing.T) {
+	repo := &mockEnvelopeRepository{
+		envelopes:   testEnvelopes(),
+		allocations: []domain.EnvelopeAllocation{{EnvelopeID: 2, Month: "2026-03", Amount: 20}},
+	}
+	svc := newTestEnvelopeService(repo, &mockRepository{}, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	_, err := svc.Move(2, &domain.MoveRequest{ToEnvelopeID: 1, Amount: 50})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "amount", validationErr.Field)
+	assert.Empty(t, repo.moves)
+}
+
+func TestEnvelopeService_Move_SameEnvelope(t *testing.T) {
+	svc := NewEnvelopeService(&mockEnvelopeRepository{envelopes: testEnvelopes()}, &mockRepository{})
+
+	_, err := svc.Move(1, &domain.MoveRequest{ToEnvelopeID: 1, Amount: 10})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "to_envelope_id", validationErr.Field)
+}
+
+// Test GetBalances
+
+func TestEnvelopeService_GetBalances_RollsOverAndFlagsOverspending(t *testing.T) {
+	repo := &mockEnvelopeRepository{
+		envelopes: testEnvelopes(),
+		allocations: []domain.EnvelopeAllocation{
+			{EnvelopeID: 1, Month: "2026-01", Amount: 500},
+			{EnvelopeID: 1, Month: "2026-02", Amount: 100},
+			{EnvelopeID: 2, Month: "2026-01", Amount: 100},
+		},
+		spending: []domain.CategoryMonthAmount{
+			{Month: "2025-12", Category: "Food", Amount: 999}, // before the envelope existed
+			{Month: "2026-01", Category: "Food", Amount: 300},
+			{Month: "2026-02", Category: "Food", Amount: 450},
+		},
+		income: []domain.MonthAmount{
+			{Month: "2026-01", Amount: 1000},
+		},
+	}
+	svc := newTestEnvelopeService(repo, &mockRepository{}, time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC))
+
+	balances, err := svc.GetBalances(domain.EnvelopeBalancesQueryParams{StartMonth: "2026-02", EndMonth: "2026-03"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"2026-02", "2026-03"}, balances.Months)
+
+	groceries := balances.Envelopes[0].Points
+	assert.Equal(t, 200.0, groceries[0].Rollover)
+	assert.Equal(t, -150.0, groceries[0].Available)
+	assert.True(t, groceries[0].Overspent)
+	assert.Equal(t, -150.0, groceries[1].Rollover, "overspending carries into the next month until covered")
+
+	fun := balances.Envelopes[1].Points
+	assert.Equal(t, 100.0, fun[1].Available)
+
+	// 1000 income - 700 allocated
+	assert.Equal(t, []float64{300, 300}, balances.Unallocated)
+}
+
+func TestEnvelopeService_GetBalances_InvalidRange(t *testing.T) {
+	svc := NewEnvelopeService(&mockEnvelopeRepository{}, &mockRepository{})
+
+	_, err := svc.GetBalances(domain.EnvelopeBalancesQueryParams{StartMonth: "2026-06", EndMonth: "2026-01"})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,399 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+// ExportService writes transactions to CSV, XLSX or NDJSON files and to
+// Beancount, hledger or ledger journals
+type ExportService interface {
+	Export(ctx context.Context, w io.Writer, params *domain.ExportQueryParams) error
+}
+
+type exportService struct {
+	txRepo    repository.TransactionRepository
+	ledger    *domain.LedgerMapping
+	sanitizer *security.Sanitizer
+}
+
+// NewExportService creates a new export service. ledger maps transactions
+// onto accounts for journal exports.
+func NewExportService(txRepo repository.TransactionRepository, ledger *domain.LedgerMapping) ExportService {
+	return &exportService{txRepo: txRepo, ledger: ledger, sanitizer: security.NewSanitizer()}
+}
+
+// exportWriter writes one export file row by row
+type exportWriter interface {
+	WriteHeader(columns []domain.ExportColumn) error
+	WriteTransaction(tx *domain.Transaction) error
+	Close() error
+}
+
+// Export validates params and streams every matching transaction to w until
+// ctx is done. Nothing is written when params are invalid.
+func (s *exportService) Export(ctx context.Context, w io.Writer, params *domain.ExportQueryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+	// The repository ignores an unknown category, which would export everything
+	if !s.sanitizer.ValidateCategory(params.Category) {
+		return &domain.ValidationError{
+			Field:   "category",
+			Message: "invalid category. Valid categories are: Food, Transportation, Housing, Utilities, Entertainment, Healthcare, Shopping, Education, Salary, Investment, Transfer, Other",
+		}
+	}
+
+	columns := params.SelectedColumns()
+	var writer exportWriter
+	switch params.Format {
+	case domain.ExportFormatXLSX:
+		writer = newXLSXExportWriter(w, columns)
+	case domain.ExportFormatNDJSON:
+		writer = newNDJSONExportWriter(w, columns)
+	case domain.ExportFormatBeancount, domain.ExportFormatHledger, domain.ExportFormatLedger:
+		writer = newJournalExportWriter(w, params.Format, s.ledger)
+	default:
+		writer = newCSVExportWriter(w, columns, params.NumberFormat())
+	}
+
+	if err := writer.WriteHeader(columns); err != nil {
+		return err
+	}
+	if err := s.txRepo.Stream(ctx, params.ListTransactionsQueryParams, writer.WriteTransaction); err != nil {
+		return err
+	}
+	return writer.Close()
+}
+
+// exportValue returns the value of a column: an int64, float64, bool, string or time.Time
+func exportValue(tx *domain.Transaction, column domain.ExportColumn) interface{} {
+	switch column {
+	case domain.ExportColumnID:
+		return tx.ID
+	case domain.ExportColumnTransactionDate:
+		return tx.TransactionDate.UTC()
+	case domain.ExportColumnType:
+		return string(tx.Type)
+	case domain.ExportColumnAmount:
+		return tx.Amount
+	case domain.ExportColumnSignedAmount:
+		if tx.Type == domain.TransactionTypeOut {
+			return -tx.Amount
+		}
+		return tx.Amount
+	case domain.ExportColumnCategory:
+		return tx.Category
+	case domain.ExportColumnDescription:
+		return tx.Description
+	case domain.ExportColumnSource:
+		return tx.Source
+	case domain.ExportColumnSourceAccount:
+		return tx.SourceAccount
+	case domain.ExportColumnRecipient:
+		return tx.Recipient
+	case domain.ExportColumnExternalID:
+		return tx.ExternalID
+	case domain.ExportColumnAnomalous:
+		return tx.Anomalous
+	case domain.ExportColumnCreatedAt:
+		return tx.CreatedAt.UTC()
+	default:
+		return ""
+	}
+}
+
+// csvExportWriter writes CSV with locale-formatted amounts. Locales using a
+// decimal comma get semicolon-separated fields, as spreadsheets there expect.
+type csvExportWriter struct {
+	writer  *csv.Writer
+	columns []domain.ExportColumn
+	numbers domain.NumberFormat
+	record  []string
+}
+
+func newCSVExportWriter(w io.Writer, columns []domain.ExportColumn, numbers domain.NumberFormat) *csvExportWriter {
+	writer := csv.NewWriter(w)
+	if numbers.Decimal == "," {
+		writer.Comma = ';'
+	}
+	return &csvExportWriter{
+		writer:  writer,
+		columns: columns,
+		numbers: numbers,
+		record:  make([]string, len(columns)),
+	}
+}
+
+func (e *csvExportWriter) WriteHeader(columns []domain.ExportColumn) error {
+	for i, column := range columns {
+		e.record[i] = string(column)
+	}
+	return e.writer.Write(e.record)
+}
+
+func (e *csvExportWriter) WriteTransaction(tx *domain.Transaction) error {
+	for i, column := range e.columns {
+		switch value := exportValue(tx, column).(type) {
+		case int64:
+			e.record[i] = strconv.FormatInt(value, 10)
+		case float64:
+			e.record[i] = e.numbers.Format(value)
+		case bool:
+			e.record[i] = strconv.FormatBool(value)
+		case time.Time:
+			e.record[i] = value.Format(time.RFC3339)
+		case string:
+			e.record[i] = csvSafeText(value)
+		}
+	}
+	return e.writer.Write(e.record)
+}
+
+func (e *csvExportWriter) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// csvSafeText prefixes text that a spreadsheet would run as a formula
+func csvSafeText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// ndjsonExportWriter writes one JSON object per line, keys in column order
+type ndjsonExportWriter struct {
+	writer  *bufio.Writer
+	columns []domain.ExportColumn
+	keys    [][]byte
+}
+
+func newNDJSONExportWriter(w io.Writer, columns []domain.ExportColumn) *ndjsonExportWriter {
+	return &ndjsonExportWriter{
+		writer:  bufio.NewWriter(w),
+		columns: columns,
+	}
+}
+
+func (e *ndjsonExportWriter) WriteHeader(columns []domain.ExportColumn) error {
+	e.keys = make([][]byte, len(columns))
+	for i, column := range columns {
+		key, err := json.Marshal(string(column))
+		if err != nil {
+			return err
+		}
+		e.keys[i] = key
+	}
+	return nil
+}
+
+func (e *ndjsonExportWriter) WriteTransaction(tx *domain.Transaction) error {
+	e.writer.WriteByte('{')
+	for i, column := range e.columns {
+		if i > 0 {
+			e.writer.WriteByte(',')
+		}
+		e.writer.Write(e.keys[i])
+		e.writer.WriteByte(':')
+
+		value := exportValue(tx, column)
+		if t, ok := value.(time.Time); ok {
+			value = t.Format(time.RFC3339)
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		e.writer.Write(encoded)
+	}
+	e.writer.WriteByte('}')
+	return e.writer.WriteByte('\n')
+}
+
+func (e *ndjsonExportWriter) Close() error {
+	return e.writer.Flush()
+}
+
+// xlsxStyle indexes the cell formats declared in xlsxStyles
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleAmount
+	xlsxStyleDateTime
+	xlsxStyleHeader
+)
+
+// xlsxExcelEpoch is day zero of spreadsheet date serials
+var xlsxExcelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// xlsxExportWriter writes a single-sheet workbook. The sheet is deflated into
+// the zip as rows arrive, so memory use does not grow with the export. Amounts
+// use the built-in #,##0.00 format, which spreadsheets display in the
+// reader's locale.
+type xlsxExportWriter struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	columns []domain.ExportColumn
+	row     int
+}
+
+func newXLSXExportWriter(w io.Writer, columns []domain.ExportColumn) *xlsxExportWriter {
+	return &xlsxExportWriter{
+		zip:     zip.NewWriter(w),
+		columns: columns,
+	}
+}
+
+func (e *xlsxExportWriter) WriteHeader(columns []domain.ExportColumn) error {
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		f, err := e.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.content); err != nil {
+			return err
+		}
+	}
+
+	f, err := e.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	e.sheet = bufio.NewWriter(f)
+	e.sheet.WriteString(xml.Header)
+	e.sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	e.sheet.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" state="frozen"/></sheetView></sheetViews>`)
+	e.sheet.WriteString(`<sheetData>`)
+
+	e.startRow()
+	for i, column := range columns {
+		e.textCell(i, string(column), xlsxStyleHeader)
+	}
+	return e.endRow()
+}
+
+func (e *xlsxExportWriter) WriteTransaction(tx *domain.Transaction) error {
+	e.startRow()
+	for i, column := range e.columns {
+		switch value := exportValue(tx, column).(type) {
+		case int64:
+			e.numberCell(i, strconv.FormatInt(value, 10), xlsxStyleDefault)
+		case float64:
+			e.numberCell(i, strconv.FormatFloat(value, 'f', -1, 64), xlsxStyleAmount)
+		case bool:
+			fmt.Fprintf(e.sheet, `<c r="%s" t="b"><v>%d</v></c>`, xlsxCellRef(i, e.row), boolToInt(value))
+		case time.Time:
+			serial := value.Sub(xlsxExcelEpoch).Hours() / 24
+			e.numberCell(i, strconv.FormatFloat(serial, 'f', -1, 64), xlsxStyleDateTime)
+		case string:
+			if value != "" {
+				e.textCell(i, value, xlsxStyleDefault)
+			}
+		}
+	}
+	return e.endRow()
+}
+
+func (e *xlsxExportWriter) Close() error {
+	e.sheet.WriteString(`</sheetData></worksheet>`)
+	if err := e.sheet.Flush(); err != nil {
+		return err
+	}
+	return e.zip.Close()
+}
+
+func (e *xlsxExportWriter) startRow() {
+	e.row++
+	fmt.Fprintf(e.sheet, `<row r="%d">`, e.row)
+}
+
+func (e *xlsxExportWriter) endRow() error {
+	_, err := e.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (e *xlsxExportWriter) numberCell(column int, value string, style int) {
+	fmt.Fprintf(e.sheet, `<c r="%s" s="%d"><v>%s</v></c>`, xlsxCellRef(column, e.row), style, value)
+}
+
+func (e *xlsxExportWriter) textCell(column int, value string, style int) {
+	fmt.Fprintf(e.sheet, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">`, xlsxCellRef(column, e.row), style)
+	xml.EscapeText(e.sheet, []byte(value))
+	e.sheet.WriteString(`</t></is></c>`)
+}
+
+// xlsxCellRef returns the A1 reference of a zero-based column and 1-based row
+func xlsxCellRef(column, row int) string {
+	name := ""
+	for column >= 0 {
+		name = string(rune('A'+column%26)) + name
+		column = column/26 - 1
+	}
+	return name + strconv.Itoa(row)
+}
+
+func boolToInt(value bool) int {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+const xlsxContentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+	`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+	`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` +
+	`</Types>`
+
+const xlsxRootRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+const xlsxWorkbook = xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+	`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+	`<sheets><sheet name="Transactions" sheetId="1" r:id="rId1"/></sheets>` +
+	`</workbook>`
+
+const xlsxWorkbookRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+// xlsxStyles declares the cell formats in xlsxStyle order
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm:ss"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="4">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
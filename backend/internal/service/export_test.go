@@ -0,0 +1,173 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+func exportTransactions() []domain.Transaction {
+	return []domain.Transaction{
+		{ID: 2, Type: domain.TransactionTypeOut, Amount: 1234.5, Category: "Food", Description: "=HYPERLINK(\"x\")",
+			Source: "Vietcombank", TransactionDate: time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC)},
+		{ID: 1, Type: domain.TransactionTypeIn, Amount: 20000000, Category: "Salary", Description: "March, salary",
+			Source: "Vietcombank", Anomalous: true, TransactionDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+// Test Export
+
+func TestExport_CSVWithLocale(t *testing.T) {
+	svc := NewExportService(&mockRepository{streamed: exportTransactions()}, nil)
+	var out bytes.Buffer
+
+	params := &domain.ExportQueryParams{Columns: "id,transaction_date,signed_amount,description", Locale: "de-DE"}
+	err := svc.Export(context.Background(), &out, params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id;transaction_date;signed_amount;description\n"+
+		"2;2026-03-05T12:00:00Z;-1.234,50;\"'=HYPERLINK(\"\"x\"\")\"\n"+
+		"1;2026-03-01T00:00:00Z;20.000.000,00;March, salary\n", out.String())
+}
+
+func TestExport_CSVPlainDefaults(t *testing.T) {
+	svc := NewExportService(&mockRepository{streamed: exportTransactions()[1:]}, nil)
+	var out bytes.Buffer
+
+	err := svc.Export(context.Background(), &out, &domain.ExportQueryParams{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "id,transaction_date,type,amount,category,description,source,source_account,recipient\n"+
+		"1,2026-03-01T00:00:00Z,in,20000000.00,Salary,\"March, salary\",Vietcombank,,\n", out.String())
+}
+
+func TestExport_InvalidCategory(t *testing.T) {
+	repo := &mockRepository{
+		streamFunc: func(params domain.ListTransactionsQueryParams, fn func(tx *domain.Transaction) error) error {
+			t.Error("expected an unknown category not to export the whole ledger")
+			return nil
+		},
+	}
+	svc := NewExportService(repo, nil)
+	var out bytes.Buffer
+
+	err := svc.Export(context.Background(), &out, &domain.ExportQueryParams{
+		ListTransactionsQueryParams: domain.ListTransactionsQueryParams{Category: "Fod"},
+	})
+
+	var validationErr *domain.ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, "category", validationErr.Field)
+	}
+	assert.Empty(t, out.String())
+}
+
+func TestExport_DateOnlyEndIncludesLastDay(t *testing.T) {
+	var filtered domain.ListTransactionsQueryParams
+	repo := &mockRepository{
+		streamFunc: func(params domain.ListTransactionsQueryParams, fn func(tx *domain.Transaction) error) error {
+			filtered = params
+			return nil
+		},
+	}
+	svc := NewExportService(repo, nil)
+
+	err := svc.Export(context.Background(), io.Discard, &domain.ExportQueryParams{
+		ListTransactionsQueryParams: domain.ListTransactionsQueryParams{StartDate: "2026-03-01", EndDate: "2026-03-31"},
+	})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, filtered.Dates.End) {
+		assert.Equal(t, time.Date(2026, 3, 31, 23, 59, 59, 999999999, time.UTC), *filtered.Dates.End)
+	}
+	if assert.NotNil(t, filtered.Dates.Start) {
+		assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), *filtered.Dates.Start)
+	}
+}
+
+func TestExport_NDJSONKeepsColumnOrder(t *testing.T) {
+	svc := NewExportService(&mockRepository{streamed: exportTransactions()}, nil)
+	var out bytes.Buffer
+
+	params := &domain.ExportQueryParams{Format: domain.ExportFormatNDJSON, Columns: "type,amount,anomalous,transaction_date"}
+	err := svc.Export(context.Background(), &out, params)
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"out","amount":1234.5,"anomalous":false,"transaction_date":"2026-03-05T12:00:00Z"}`+"\n"+
+		`{"type":"in","amount":20000000,"anomalous":true,"transaction_date":"2026-03-01T00:00:00Z"}`+"\n", out.String())
+}
+
+func TestExport_XLSXWorkbook(t *testing.T) {
+	svc := NewExportService(&mockRepository{streamed: exportTransactions()}, nil)
+	var out bytes.Buffer
+
+	params := &domain.ExportQueryParams{Format: domain.ExportFormatXLSX, Columns: "id,transaction_date,amount,description,anomalous"}
+	err := svc.Export(context.Background(), &out, params)
+	assert.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	assert.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		assert.NoError(t, err)
+		content, _ := io.ReadAll(r)
+		parts[f.Name] = string(content)
+	}
+
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "xl/workbook.xml")
+	assert.Contains(t, parts, "xl/styles.xml")
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" s="3" t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="A2" s="0"><v>2</v></c>`)
+	assert.Contains(t, sheet, `<c r="B2" s="2"><v>46086.5</v></c>`)
+	assert.Contains(t, sheet, `<c r="C2" s="1"><v>1234.5</v></c>`)
+	assert.Contains(t, sheet, `=HYPERLINK(&#34;x&#34;)`)
+	assert.Contains(t, sheet, `<c r="E3" t="b"><v>1</v></c>`)
+	assert.True(t, strings.HasSuffix(sheet, "</sheetData></worksheet>"))
+}
+
+func TestExport_InvalidParamsWriteNothing(t *testing.T) {
+	svc := NewExportService(&mockRepository{streamed: exportTransactions()}, nil)
+	var out bytes.Buffer
+
+	err := svc.Export(context.Background(), &out, &domain.ExportQueryParams{Format: "pdf"})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, 0, out.Len())
+}
+
+func TestExport_WriteErrorStopsStream(t *testing.T) {
+	svc := NewExportService(&mockRepository{streamed: exportTransactions()}, nil)
+
+	err := svc.Export(context.Background(), failingWriter{}, &domain.ExportQueryParams{Format: domain.ExportFormatNDJSON})
+
+	assert.Error(t, err)
+}
+
+// failingWriter fails every write, like a disconnected client
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestXLSXCellRef(t *testing.T) {
+	assert.Equal(t, "A1", xlsxCellRef(0, 1))
+	assert.Equal(t, "Z9", xlsxCellRef(25, 9))
+	assert.Equal(t, "AA10", xlsxCellRef(26, 10))
+	assert.Equal(t, "AZ2", xlsxCellRef(51, 2))
+}
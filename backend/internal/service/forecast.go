@@ -0,0 +1,221 @@
+package service
+
+import (
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// ForecastService projects the balance forward using known and estimated cash flows
+type ForecastService interface {
+	GetForecast(params domain.ForecastQueryParams) (*domain.ForecastResponse, error)
+}
+
+type forecastService struct {
+	txRepo        repository.TransactionRepository
+	historyRepo   repository.RecurringRepository
+	scheduledRepo repository.ScheduledRepository
+	recurring     RecurringService
+	now           func() time.Time
+}
+
+// NewForecastService creates a new forecast service
+func NewForecastService(
+	txRepo repository.TransactionRepository,
+	historyRepo repository.RecurringRepository,
+	scheduledRepo repository.ScheduledRepository,
+	recurring RecurringService,
+) ForecastService {
+	return &forecastService{
+		txRepo:        txRepo,
+		historyRepo:   historyRepo,
+		scheduledRepo: scheduledRepo,
+		recurring:     recurring,
+		now:           time.Now,
+	}
+}
+
+// GetForecast projects the balance day by day, starting tomorrow, from the
+// current balance, scheduled transactions, detected recurring items and the
+// average daily discretionary spend
+func (s *forecastService) GetForecast(params domain.ForecastQueryParams) (*domain.ForecastResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	horizon := today.AddDate(0, 0, params.Days+1).Add(-time.Nanosecond)
+
+	summary, err := s.txRepo.GetSummary()
+	if err != nil {
+		return nil, err
+	}
+
+	recurring, err := s.recurring.GetRecurring(domain.RecurringQueryParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled, err := s.scheduledRepo.ListDueBefore(horizon)
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := s.historyRepo.ListSince(today.AddDate(0, 0, -domain.DiscretionaryLookbackDays))
+	if err != nil {
+		return nil, err
+	}
+
+	dailySpend := discretionaryDailySpend(history, recurring.Series, scheduled, today)
+
+	return buildForecast(summary.CurrentBalance, today, params.Days, dailySpend, scheduled, recurring.Series), nil
+}
+
+// discretionaryDailySpend averages daily expenses that are neither part of a
+// recurring series nor payments of a scheduled transaction
+func discretionaryDailySpend(
+	history []domain.Transaction,
+	series []domain.RecurringSeries,
+	scheduled []domain.ScheduledTransaction,
+	today time.Time,
+) float64 {
+	if len(history) == 0 {
+		return 0
+	}
+
+	recurringIDs := make(map[int64]bool)
+	for _, s := range series {
+		for _, id := range s.TransactionIDs {
+			recurringIDs[id] = true
+		}
+	}
+
+	var total float64
+	for i := range history {
+		tx := &history[i]
+		if tx.Type != domain.TransactionTypeOut || recurringIDs[tx.ID] || matchesAnySchedule(scheduled, tx) {
+			continue
+		}
+		total += tx.Amount
+	}
+
+	// Short histories are averaged over the days actually covered
+	first := history[0].TransactionDate.UTC()
+	firstDay := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	days := today.Sub(firstDay).Hours() / 24
+	if days > domain.DiscretionaryLookbackDays {
+		days = domain.DiscretionaryLookbackDays
+	}
+	if days < 1 {
+		days = 1
+	}
+
+	return total / days
+}
+
+// matchesAnySchedule reports whether tx would be matched by one of the schedules
+func matchesAnySchedule(scheduled []domain.ScheduledTransaction, tx *domain.Transaction) bool {
+	for i := range scheduled {
+		if matchesSchedule(&scheduled[i], tx) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildForecast lays out expected items and discretionary spend over days
+// starting tomorrow. Overdue items are expected on the first day.
+func buildForecast(
+	balance float64,
+	today time.Time,
+	days int,
+	dailySpend float64,
+	scheduled []domain.ScheduledTransaction,
+	series []domain.RecurringSeries,
+) *domain.ForecastResponse {
+	first := today.AddDate(0, 0, 1)
+	last := today.AddDate(0, 0, days+1).Add(-time.Nanosecond)
+
+	points := make([]domain.ForecastDataPoint, days)
+	for i := range points {
+		points[i].Date = first.AddDate(0, 0, i).Format("2006-01-02")
+		points[i].Expense = dailySpend
+	}
+
+	addItem := func(due time.Time, item domain.ForecastItem) {
+		index := int(due.Sub(first).Hours() / 24)
+		if due.Before(first) {
+			index = 0
+		}
+		if index >= days {
+			return
+		}
+
+		points[index].Items = append(points[index].Items, item)
+		if item.Type == domain.TransactionTypeIn {
+			points[index].Income += item.Amount
+		} else {
+			points[index].Expense += item.Amount
+		}
+	}
+
+	for i := range scheduled {
+		sched := &scheduled[i]
+		for n := sched.Occurrences; n < sched.Occurrences+maxUpcomingPerSchedule && sched.HasOccurrence(n); n++ {
+			due := sched.DueDate(n)
+			if due.After(last) {
+				break
+			}
+			addItem(due, domain.ForecastItem{
+				Name:   sched.Name,
+				Type:   sched.Type,
+				Origin: domain.ForecastItemScheduled,
+				Amount: sched.Amount,
+			})
+		}
+	}
+
+	for _, s := range series {
+		// Missed series may have been cancelled; scheduled ones are already counted
+		if s.Missed || matchesAnySchedule(scheduled, &domain.Transaction{Type: s.Type, Recipient: s.Payee, Amount: s.LastAmount}) {
+			continue
+		}
+		for due := s.NextExpectedDate; !due.After(last); due = nextRecurringDate(due, s.Interval) {
+			addItem(due, domain.ForecastItem{
+				Name:   s.Payee,
+				Type:   s.Type,
+				Origin: domain.ForecastItemRecurring,
+				Amount: s.LastAmount,
+			})
+		}
+	}
+
+	response := &domain.ForecastResponse{
+		Data:                    points,
+		Days:                    days,
+		StartingBalance:         balance,
+		DailyDiscretionarySpend: dailySpend,
+		LowestBalance:           balance,
+		LowestBalanceDate:       today.Format("2006-01-02"),
+	}
+
+	for i := range points {
+		points[i].Net = points[i].Income - points[i].Expense
+		balance += points[i].Net
+		points[i].Balance = balance
+		points[i].BelowZero = balance < 0
+
+		if points[i].BelowZero {
+			response.DaysBelowZero++
+		}
+		if balance < response.LowestBalance {
+			response.LowestBalance = balance
+			response.LowestBalanceDate = points[i].Date
+		}
+	}
+	response.EndingBalance = balance
+
+	return response
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+func TestBuildForecast_ScheduledAndRecurringItems(t *testing.T) {
+	today := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rent := rentSchedule()
+	rent.NextDueDate = time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	rent.Occurrences = 2
+
+	series := []domain.RecurringSeries{
+		{
+			Payee:            "Netflix",
+			Type:             domain.TransactionTypeOut,
+			Interval:         domain.RecurringIntervalMonthly,
+			LastAmount:       260000,
+			NextExpectedDate: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Payee:            "Cancelled gym",
+			Type:             domain.TransactionTypeOut,
+			Interval:         domain.RecurringIntervalMonthly,
+			LastAmount:       500000,
+			NextExpectedDate: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			Missed:           true,
+		},
+	}
+
+	forecast := buildForecast(6000000, today, 30, 100000, []domain.ScheduledTransaction{rent}, series)
+
+	assert.Len(t, forecast.Data, 30)
+	assert.Equal(t, "2026-03-02", forecast.Data[0].Date)
+
+	// Rent on March 5th
+	assert.Equal(t, 5100000.0, forecast.Data[3].Expense)
+	assert.Equal(t, domain.ForecastItemScheduled, forecast.Data[3].Items[0].Origin)
+
+	// Netflix on March 15th, the missed gym is ignored
+	assert.Equal(t, 360000.0, forecast.Data[13].Expense)
+	assert.Equal(t, "Netflix", forecast.Data[13].Items[0].Name)
+
+	// 6,000,000 - 30 * 100,000 - 5,000,000 - 260,000; rent for April 5th is out of range
+	assert.InDelta(t, -2260000.0, forecast.EndingBalance, 0.01)
+	// The balance reaches exactly zero on March 11th and goes negative the day after
+	assert.False(t, forecast.Data[9].BelowZero)
+	assert.True(t, forecast.Data[10].BelowZero)
+	assert.Equal(t, 20, forecast.DaysBelowZero)
+	assert.Equal(t, forecast.EndingBalance, forecast.LowestBalance)
+}
+
+func TestBuildForecast_OverdueItemsOnFirstDay(t *testing.T) {
+	today := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	rent := rentSchedule()
+	rent.NextDueDate = time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	rent.Occurrences = 2
+
+	forecast := buildForecast(10000000, today, 30, 0, []domain.ScheduledTransaction{rent}, nil)
+
+	assert.Equal(t, 5000000.0, forecast.Data[0].Expense)
+	assert.Equal(t, 5000000.0, forecast.Data[25].Expense, "next rent on April 5th")
+}
+
+func TestBuildForecast_RecurringCoveredBySchedule(t *testing.T) {
+	today := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	rent := rentSchedule()
+	rent.NextDueDate = time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	rent.Occurrences = 2
+
+	series := []domain.RecurringSeries{{
+		Payee:            "Nguyen Van A",
+		Type:             domain.TransactionTypeOut,
+		Interval:         domain.RecurringIntervalMonthly,
+		LastAmount:       5000000,
+		NextExpectedDate: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC),
+	}}
+
+	forecast := buildForecast(0, today, 30, 0, []domain.ScheduledTransaction{rent}, series)
+
+	assert.Len(t, forecast.Data[3].Items, 1, "rent must not be counted twice")
+}
+
+func TestDiscretionaryDailySpend_ExcludesRecurringAndScheduled(t *testing.T) {
+	today := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	history := []domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeOut, Amount: 300000, TransactionDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Type: domain.TransactionTypeOut, Amount: 260000, TransactionDate: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{ID: 3, Type: domain.TransactionTypeOut, Recipient: "Nguyen Van A", Amount: 5000000, TransactionDate: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{ID: 4, Type: domain.TransactionTypeIn, Amount: 30000000, TransactionDate: time.Date(2026, 3, 25, 0, 0, 0, 0, time.UTC)},
+		{ID: 5, Type: domain.TransactionTypeOut, Amount: 600000, TransactionDate: time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)},
+	}
+	series := []domain.RecurringSeries{{TransactionIDs: []int64{2}}}
+
+	spend := discretionaryDailySpend(history, series, []domain.ScheduledTransaction{rentSchedule()}, today)
+
+	// (300,000 + 600,000) over the 30 days since the first transaction
+	assert.InDelta(t, 30000.0, spend, 0.01)
+}
+
+func TestForecastService_GetForecast(t *testing.T) {
+	txRepo := &mockRepository{
+		getSummaryFunc: func() (*domain.SummaryResponse, error) {
+			return &domain.SummaryResponse{CurrentBalance: 1000000}, nil
+		},
+	}
+	history := &mockRecurringRepository{}
+	svc := NewForecastService(txRepo, history, &mockScheduledRepository{}, NewRecurringService(history, 12)).(*forecastService)
+	svc.now = func() time.Time { return time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC) }
+
+	forecast, err := svc.GetForecast(domain.ForecastQueryParams{Days: 60})
+
+	assert.NoError(t, err)
+	assert.Len(t, forecast.Data, 60)
+	assert.Equal(t, 1000000.0, forecast.StartingBalance)
+	assert.Equal(t, 1000000.0, forecast.EndingBalance)
+	assert.Equal(t, 0, forecast.DaysBelowZero)
+}
+
+func TestForecastService_GetForecast_InvalidDays(t *testing.T) {
+	history := &mockRecurringRepository{}
+	svc := NewForecastService(&mockRepository{}, history, &mockScheduledRepository{}, NewRecurringService(history, 12))
+
+	_, err := svc.GetForecast(domain.ForecastQueryParams{Days: 365})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "days", validationErr.Field)
+}
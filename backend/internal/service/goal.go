@@ -0,0 +1,238 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// GoalService handles business logic for savings goals
+type GoalService interface {
+	CreateGoal(req *domain.GoalRequest) (*domain.Goal, error)
+	GetGoal(id int64) (*domain.Goal, error)
+	ListGoals() ([]domain.Goal, error)
+	UpdateGoal(id int64, req *domain.GoalRequest) (*domain.Goal, error)
+	DeleteGoal(id int64) error
+	AddContribution(goalID int64, req *domain.GoalContributionRequest) (*domain.GoalContribution, error)
+	ListContributions(goalID int64) ([]domain.GoalContribution, error)
+	GetProgress(id int64) (*domain.GoalProgress, error)
+	ListProgress() ([]domain.GoalProgress, error)
+}
+
+type goalService struct {
+	repo repository.GoalRepository
+	now  func() time.Time
+}
+
+// NewGoalService creates a new goal service
+func NewGoalService(repo repository.GoalRepository) GoalService {
+	return &goalService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+func (s *goalService) CreateGoal(req *domain.GoalRequest) (*domain.Goal, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	goal := req.ToGoal(s.now().UTC())
+	if err := validateGoalDates(goal); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(goal); err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+func (s *goalService) GetGoal(id int64) (*domain.Goal, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid goal ID")
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *goalService) ListGoals() ([]domain.Goal, error) {
+	return s.repo.List()
+}
+
+// UpdateGoal replaces the goal's settings. The start date is kept unless the request sets one.
+func (s *goalService) UpdateGoal(id int64, req *domain.GoalRequest) (*domain.Goal, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.GetGoal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	goal := req.ToGoal(existing.StartDate)
+	goal.ID = existing.ID
+	goal.CreatedAt = existing.CreatedAt
+	if err := validateGoalDates(goal); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(goal); err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+func (s *goalService) DeleteGoal(id int64) error {
+	if id <= 0 {
+		return errors.New("invalid goal ID")
+	}
+	return s.repo.Delete(id)
+}
+
+// AddContribution records a manual allocation to a goal; negative amounts are withdrawals
+func (s *goalService) AddContribution(goalID int64, req *domain.GoalContributionRequest) (*domain.GoalContribution, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetGoal(goalID); err != nil {
+		return nil, err
+	}
+
+	contribution := req.ToGoalContribution(goalID, s.now().UTC())
+	if err := s.repo.CreateContribution(contribution); err != nil {
+		return nil, err
+	}
+
+	return contribution, nil
+}
+
+func (s *goalService) ListContributions(goalID int64) ([]domain.GoalContribution, error) {
+	if _, err := s.GetGoal(goalID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListContributions(goalID)
+}
+
+func (s *goalService) GetProgress(id int64) (*domain.GoalProgress, error) {
+	goal, err := s.GetGoal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	progress, err := s.progress(goal)
+	if err != nil {
+		return nil, err
+	}
+	return &progress, nil
+}
+
+// ListProgress returns the progress of every goal ordered by target date
+func (s *goalService) ListProgress() ([]domain.GoalProgress, error) {
+	goals, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.GoalProgress, 0, len(goals))
+	for i := range goals {
+		progress, err := s.progress(&goals[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, progress)
+	}
+	return result, nil
+}
+
+func (s *goalService) progress(goal *domain.Goal) (domain.GoalProgress, error) {
+	manual, err := s.repo.SumContributions(goal.ID)
+	if err != nil {
+		return domain.GoalProgress{}, err
+	}
+
+	fromTransactions, err := s.repo.SumTransactionContributions(goal)
+	if err != nil {
+		return domain.GoalProgress{}, err
+	}
+
+	return calculateGoalProgress(goal, manual, fromTransactions, s.now().UTC()), nil
+}
+
+// validateGoalDates ensures the target date lies after the start date
+func validateGoalDates(goal *domain.Goal) error {
+	if !goal.TargetDate.After(goal.StartDate) {
+		return &domain.ValidationError{
+			Field:   "target_date",
+			Message: "target_date must be after start_date",
+		}
+	}
+	return nil
+}
+
+// calculateGoalProgress compares the saved amount with a linear pace from the
+// start date to the target date and spreads the remainder over the months left
+func calculateGoalProgress(goal *domain.Goal, manual, fromTransactions float64, now time.Time) domain.GoalProgress {
+	saved := manual + fromTransactions
+
+	progress := domain.GoalProgress{
+		Goal:                     *goal,
+		ManualContributions:      manual,
+		TransactionContributions: fromTransactions,
+		Saved:                    saved,
+		Remaining:                math.Max(goal.TargetAmount-saved, 0),
+		PercentComplete:          saved / goal.TargetAmount * 100,
+	}
+
+	total := goal.TargetDate.Sub(goal.StartDate)
+	elapsed := now.Sub(goal.StartDate)
+	switch {
+	case elapsed <= 0:
+		progress.ExpectedSaved = 0
+	case elapsed >= total:
+		progress.ExpectedSaved = goal.TargetAmount
+	default:
+		progress.ExpectedSaved = goal.TargetAmount * elapsed.Hours() / total.Hours()
+	}
+
+	if now.Before(goal.TargetDate) {
+		progress.MonthsRemaining = monthsUntil(now, goal.TargetDate)
+		progress.RequiredMonthlyContribution = progress.Remaining / float64(progress.MonthsRemaining)
+	} else {
+		// Past the target date the whole remainder is due now
+		progress.RequiredMonthlyContribution = progress.Remaining
+	}
+
+	switch {
+	case progress.Remaining <= amountTolerance:
+		progress.Remaining = 0
+		progress.RequiredMonthlyContribution = 0
+		progress.Status = domain.GoalStatusCompleted
+	case !now.Before(goal.TargetDate):
+		progress.Status = domain.GoalStatusOverdue
+	case saved+amountTolerance >= progress.ExpectedSaved:
+		progress.Status = domain.GoalStatusOnTrack
+	default:
+		progress.Status = domain.GoalStatusBehind
+	}
+
+	return progress
+}
+
+// monthsUntil counts the calendar months left until target, rounding partial months up
+func monthsUntil(now, target time.Time) int {
+	months := (target.Year()-now.Year())*12 + int(target.Month()) - int(now.Month())
+	if target.Day() > now.Day() {
+		months++
+	}
+	if months < 1 {
+		months = 1
+	}
+	return months
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockGoalRepository is a mock implementation of GoalRepository for testing
+type mockGoalRepository struct {
+	goals            []domain.Goal
+	contributions    []domain.GoalContribution
+	fromTransactions map[int64]float64
+}
+
+func (m *mockGoalRepository) Create(goal *domain.Goal) error {
+	goal.ID = int64(len(m.goals) + 1)
+	m.goals = append(m.goals, *goal)
+	return nil
+}
+
+func (m *mockGoalRepository) FindByID(id int64) (*domain.Goal, error) {
+	for i := range m.goals {
+		if m.goals[i].ID == id {
+			goal := m.goals[i]
+			return &goal, nil
+		}
+	}
+	return nil, repository.ErrGoalNotFound
+}
+
+func (m *mockGoalRepository) List() ([]domain.Goal, error) {
+	return m.goals, nil
+}
+
+func (m *mockGoalRepository) Update(goal *domain.Goal) error {
+	return nil
+}
+
+func (m *mockGoalRepository) Delete(id int64) error {
+	return nil
+}
+
+func (m *mockGoalRepository) CreateContribution(contribution *domain.GoalContribution) error {
+	contribution.ID = int64(len(m.contributions) + 1)
+	m.contributions = append(m.contributions, *contribution)
+	return nil
+}
+
+func (m *mockGoalRepository) ListContributions(goalID int64) ([]domain.GoalContribution, error) {
+	return m.contributions, nil
+}
+
+func (m *mockGoalRepository) SumContributions(goalID int64) (float64, error) {
+	var total float64
+	for _, c := range m.contributions {
+		if c.GoalID == goalID {
+			total += c.Amount
+		}
+	}
+	return total, nil
+}
+
+func (m *mockGoalRepository) SumTransactionContributions(goal *domain.Goal) (float64, error) {
+	return m.fromTransactions[goal.ID], nil
+}
+
+// newTestGoalService creates a goal service with a fixed clock
+func newTestGoalService(repo repository.GoalRepository, now time.Time) *goalService {
+	svc := NewGoalService(repo).(*goalService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+// vacationGoal saves 12,000 over 2026
+func vacationGoal() domain.Goal {
+	return domain.Goal{
+		ID:           1,
+		Name:         "Vacation",
+		TargetAmount: 12000,
+		StartDate:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		TargetDate:   time.Date(2026, 12, 31, 23, 59, 59, 0, time.UTC),
+		Tag:          "vacation",
+	}
+}
+
+// Test CreateGoal
+
+func TestGoalService_CreateGoal_DefaultsStartDate(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc := newTestGoalService(&mockGoalRepository{}, now)
+
+	goal, err := svc.CreateGoal(&domain.GoalRequest{Name: "Laptop", TargetAmount: 2000, TargetDate: "2026-09-30"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), goal.ID)
+	assert.Equal(t, now, goal.StartDate)
+}
+
+func TestGoalService_CreateGoal_TargetDateInPast(t *testing.T) {
+	svc := newTestGoalService(&mockGoalRepository{}, time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC))
+
+	_, err := svc.CreateGoal(&domain.GoalRequest{Name: "Laptop", TargetAmount: 2000, TargetDate: "2026-01-31"})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "target_date", validationErr.Field)
+}
+
+// Test UpdateGoal
+
+func TestGoalService_UpdateGoal_KeepsStartDate(t *testing.T) {
+	repo := &mockGoalRepository{goals: []domain.Goal{vacationGoal()}}
+	svc := newTestGoalService(repo, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	goal, err := svc.UpdateGoal(1, &domain.GoalRequest{Name: "Vacation", TargetAmount: 15000, TargetDate: "2027-03-31"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, vacationGoal().StartDate, goal.StartDate)
+	assert.Equal(t, 15000.0, goal.TargetAmount)
+}
+
+// Test AddContribution
+
+func TestGoalService_AddContribution_GoalNotFound(t *testing.T) {
+	svc := newTestGoalService(&mockGoalRepository{}, time.Now())
+
+	_, err := svc.AddContribution(9, &domain.GoalContributionRequest{Amount: 100})
+
+	assert.ErrorIs(t, err, repository.ErrGoalNotFound)
+}
+
+func TestGoalService_AddContribution_DefaultsDate(t *testing.T) {
+	now := time.Date(2026, 4, 2, 8, 0, 0, 0, time.UTC)
+	repo := &mockGoalRepository{goals: []domain.Goal{vacationGoal()}}
+	svc := newTestGoalService(repo, now)
+
+	contribution, err := svc.AddContribution(1, &domain.GoalContributionRequest{Amount: 500, Note: "bonus"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, now, contribution.ContributionDate)
+	assert.Equal(t, int64(1), contribution.GoalID)
+}
+
+// Test GetProgress
+
+func TestGoalService_GetProgress_OnTrack(t *testing.T) {
+	repo := &mockGoalRepository{
+		goals:            []domain.Goal{vacationGoal()},
+		contributions:    []domain.GoalContribution{{GoalID: 1, Amount: 1000}},
+		fromTransactions: map[int64]float64{1: 5000},
+	}
+	svc := newTestGoalService(repo, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	progress, err := svc.GetProgress(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.0, progress.ManualContributions)
+	assert.Equal(t, 5000.0, progress.TransactionContributions)
+	assert.Equal(t, 6000.0, progress.Saved)
+	assert.Equal(t, 6000.0, progress.Remaining)
+	assert.Equal(t, 50.0, progress.PercentComplete)
+	assert.Equal(t, 6, progress.MonthsRemaining)
+	assert.Equal(t, 1000.0, progress.RequiredMonthlyContribution)
+	assert.Equal(t, domain.GoalStatusOnTrack, progress.Status)
+}
+
+func TestGoalService_GetProgress_Behind(t *testing.T) {
+	repo := &mockGoalRepository{
+		goals:            []domain.Goal{vacationGoal()},
+		fromTransactions: map[int64]float64{1: 3000},
+	}
+	svc := newTestGoalService(repo, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	progress, err := svc.GetProgress(1)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 5951, progress.ExpectedSaved, 1)
+	assert.Equal(t, 1500.0, progress.RequiredMonthlyContribution)
+	assert.Equal(t, domain.GoalStatusBehind, progress.Status)
+}
+
+func TestGoalService_GetProgress_Completed(t *testing.T) {
+	repo := &mockGoalRepository{
+		goals:         []domain.Goal{vacationGoal()},
+		contributions: []domain.GoalContribution{{GoalID: 1, Amount: 12500}},
+	}
+	svc := newTestGoalService(repo, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC))
+
+	progress, err := svc.GetProgress(1)
+
+	assert.NoError(t, err)
+	assert.Zero(t, progress.Remaining)
+	assert.Zero(t, progress.RequiredMonthlyContribution)
+	assert.Equal(t, domain.GoalStatusCompleted, progress.Status)
+}
+
+func TestGoalService_GetProgress_Overdue(t *testing.T) {
+	repo := &mockGoalRepository{
+		goals:         []domain.Goal{vacationGoal()},
+		contributions: []domain.GoalContribution{{GoalID: 1, Amount: 10000}},
+	}
+	svc := newTestGoalService(repo, time.Date(2027, 2, 1, 0, 0, 0, 0, time.UTC))
+
+	progress, err := svc.GetProgress(1)
+
+	assert.NoError(t, err)
+	assert.Zero(t, progress.MonthsRemaining)
+	assert.Equal(t, 2000.0, progress.RequiredMonthlyContribution)
+	assert.Equal(t, domain.GoalStatusOverdue, progress.Status)
+}
+
+// Test monthsUntil
+
+func TestMonthsUntil(t *testing.T) {
+	now := time.Date(2026, 7, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 5, monthsUntil(now, time.Date(2026, 12, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 5, monthsUntil(now, time.Date(2026, 12, 10, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 1, monthsUntil(now, time.Date(2026, 7, 20, 0, 0, 0, 0, time.UTC)))
+}
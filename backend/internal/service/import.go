@@ -0,0 +1,740 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// importBatchSize is the number of rows stored per batch; it matches the webhook batch limit
+const importBatchSize = 100
+
+// ImportService imports transactions from bank statement exports
+type ImportService interface {
+	CreateProfile(req *domain.ImportProfileRequest) (*domain.ImportProfile, error)
+	GetProfile(id int64) (*domain.ImportProfile, error)
+	ListProfiles() ([]domain.ImportProfile, error)
+	UpdateProfile(id int64, req *domain.ImportProfileRequest) (*domain.ImportProfile, error)
+	DeleteProfile(id int64) error
+	ImportCSV(r io.Reader, req *domain.CSVImportRequest) (*domain.ImportResult, error)
+	ImportOFX(r io.Reader, req *domain.StatementImportRequest) (*domain.ImportResult, error)
+	ImportQIF(r io.Reader, req *domain.StatementImportRequest) (*domain.ImportResult, error)
+}
+
+type importService struct {
+	repo      repository.ImportRepository
+	txService TransactionService
+}
+
+// NewImportService creates a new import service. Imported rows are stored
+// through txService so they get the same validation and hooks as webhook
+// transactions.
+func NewImportService(repo repository.ImportRepository, txService TransactionService) ImportService {
+	return &importService{
+		repo:      repo,
+		txService: txService,
+	}
+}
+
+func (s *importService) CreateProfile(req *domain.ImportProfileRequest) (*domain.ImportProfile, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	profile := req.ToImportProfile()
+	if err := s.repo.CreateProfile(profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (s *importService) GetProfile(id int64) (*domain.ImportProfile, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid import profile ID")
+	}
+	return s.repo.FindProfileByID(id)
+}
+
+func (s *importService) ListProfiles() ([]domain.ImportProfile, error) {
+	return s.repo.ListProfiles()
+}
+
+func (s *importService) UpdateProfile(id int64, req *domain.ImportProfileRequest) (*domain.ImportProfile, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.GetProfile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := req.ToImportProfile()
+	profile.ID = existing.ID
+	profile.CreatedAt = existing.CreatedAt
+	if err := s.repo.UpdateProfile(profile); err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (s *importService) DeleteProfile(id int64) error {
+	if id <= 0 {
+		return errors.New("invalid import profile ID")
+	}
+	return s.repo.DeleteProfile(id)
+}
+
+// ImportCSV parses a CSV statement with a saved profile or an inline mapping,
+// flags rows that fail validation or duplicate stored transactions, and
+// stores the remaining rows unless req.DryRun is set
+func (s *importService) ImportCSV(r io.Reader, req *domain.CSVImportRequest) (*domain.ImportResult, error) {
+	mapping, err := s.resolveMapping(req)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := parseCSVRows(r, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.complete(rows, req.DryRun)
+}
+
+// ImportOFX imports an OFX or QFX statement. The source defaults to the
+// institution named in the file and the account to its account ID; rows whose
+// FITID was imported before are skipped.
+func (s *importService) ImportOFX(r io.Reader, req *domain.StatementImportRequest) (*domain.ImportResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	statement, err := parseOFX(r)
+	if err != nil {
+		return nil, err
+	}
+
+	source := firstNonEmpty(req.Source, statement.Org)
+	if source == "" {
+		return nil, &domain.ValidationError{
+			Field:   "source",
+			Message: "source is required when the file does not name its institution",
+		}
+	}
+
+	rows, err := statementRows(statement.Entries, source, firstNonEmpty(req.SourceAccount, statement.Account))
+	if err != nil {
+		return nil, err
+	}
+	return s.complete(rows, req.DryRun)
+}
+
+// ImportQIF imports a QIF statement. QIF files carry no transaction IDs, so
+// duplicates are found by source, type, amount and day only.
+func (s *importService) ImportQIF(r io.Reader, req *domain.StatementImportRequest) (*domain.ImportResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Source == "" {
+		return nil, &domain.ValidationError{
+			Field:   "source",
+			Message: "source is required",
+		}
+	}
+
+	dateFormat := req.DateFormat
+	if dateFormat == "" {
+		dateFormat = domain.DefaultQIFDateFormat
+	}
+
+	entries, account, err := parseQIF(r, dateFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := statementRows(entries, req.Source, firstNonEmpty(req.SourceAccount, account))
+	if err != nil {
+		return nil, err
+	}
+	return s.complete(rows, req.DryRun)
+}
+
+// statementRows validates parsed statement entries as transaction requests
+func statementRows(entries []statementEntry, source, sourceAccount string) ([]domain.ImportRow, error) {
+	if len(entries) > domain.MaxImportRows {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: fmt.Sprintf("file has more than %d rows", domain.MaxImportRows),
+		}
+	}
+
+	rows := make([]domain.ImportRow, 0, len(entries))
+	for _, entry := range entries {
+		tx := entry.Transaction
+		req := &domain.CreateTransactionRequest{
+			Type:          tx.Type,
+			Category:      tx.Category,
+			Description:   tx.Description,
+			Source:        source,
+			SourceAccount: sourceAccount,
+			Recipient:     tx.Recipient,
+			ExternalID:    tx.ExternalID,
+			Amount:        tx.Amount,
+		}
+		if !tx.TransactionDate.IsZero() {
+			req.TransactionDate = tx.TransactionDate.Format(time.RFC3339)
+		}
+
+		if entry.Err != nil {
+			rows = append(rows, importRowError(entry.Line, req, entry.Err))
+			continue
+		}
+		rows = append(rows, validateImportRow(entry.Line, req))
+	}
+	return rows, nil
+}
+
+// firstNonEmpty returns the first value that is not blank
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// resolveMapping returns the inline mapping or loads the saved profile
+func (s *importService) resolveMapping(req *domain.CSVImportRequest) (*domain.ImportMapping, error) {
+	if req.Mapping != nil && req.ProfileID != 0 {
+		return nil, &domain.ValidationError{
+			Field:   "mapping",
+			Message: "use either profile_id or mapping, not both",
+		}
+	}
+
+	if req.Mapping != nil {
+		if err := req.Mapping.Validate(); err != nil {
+			return nil, err
+		}
+		return req.Mapping, nil
+	}
+
+	if req.ProfileID <= 0 {
+		return nil, &domain.ValidationError{
+			Field:   "profile_id",
+			Message: "profile_id or mapping is required",
+		}
+	}
+
+	profile, err := s.repo.FindProfileByID(req.ProfileID)
+	if err != nil {
+		if errors.Is(err, repository.ErrImportProfileNotFound) {
+			return nil, &domain.ValidationError{
+				Field:   "profile_id",
+				Message: "import profile not found",
+			}
+		}
+		return nil, err
+	}
+	return &profile.ImportMapping, nil
+}
+
+// complete flags duplicates among the parsed rows and, unless dryRun is set,
+// stores the valid ones in batches
+func (s *importService) complete(rows []domain.ImportRow, dryRun bool) (*domain.ImportResult, error) {
+	if err := s.markDuplicates(rows); err != nil {
+		return nil, err
+	}
+
+	result := &domain.ImportResult{
+		Rows:   rows,
+		DryRun: dryRun,
+		Total:  len(rows),
+	}
+
+	var pending []int
+	for i := range rows {
+		switch rows[i].Status {
+		case domain.ImportRowStatusValid:
+			result.Valid++
+			pending = append(pending, i)
+		case domain.ImportRowStatusDuplicate:
+			result.Duplicates++
+		case domain.ImportRowStatusError:
+			result.Errors++
+		}
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	for start := 0; start < len(pending); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(pending) {
+			end = len(pending)
+		}
+
+		batch := &domain.BatchTransactionRequest{
+			Transactions: make([]domain.CreateTransactionRequest, 0, end-start),
+		}
+		for _, i := range pending[start:end] {
+			batch.Transactions = append(batch.Transactions, *rows[i].Transaction)
+		}
+
+		if _, err := s.txService.CreateBatchTransaction(batch); err != nil {
+			return nil, fmt.Errorf("import stopped after %d of %d rows: %w", result.Imported, len(pending), err)
+		}
+
+		for _, i := range pending[start:end] {
+			rows[i].Status = domain.ImportRowStatusImported
+		}
+		result.Imported += end - start
+	}
+
+	return result, nil
+}
+
+// markDuplicates flags valid rows that were stored before. A row with an
+// external ID is a duplicate when a stored transaction of its source has the
+// same ID, or when an earlier row of the file has it. Otherwise a row matches
+// a stored transaction of the same source, type and amount on the same day
+// that has no conflicting external ID. Each stored transaction matches at
+// most one row, so repeated purchases within a statement are kept.
+func (s *importService) markDuplicates(rows []domain.ImportRow) error {
+	bySource := make(map[string][]int)
+	var sources []string
+	for i := range rows {
+		if rows[i].Status != domain.ImportRowStatusValid {
+			continue
+		}
+		key := strings.ToLower(rows[i].Transaction.Source)
+		if _, ok := bySource[key]; !ok {
+			sources = append(sources, key)
+		}
+		bySource[key] = append(bySource[key], i)
+	}
+
+	for _, key := range sources {
+		if err := s.markSourceDuplicates(rows, bySource[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// markSourceDuplicates flags duplicates among the rows at indexes, which share a source
+func (s *importService) markSourceDuplicates(rows []domain.ImportRow, indexes []int) error {
+	source := rows[indexes[0]].Transaction.Source
+
+	var externalIDs []string
+	for _, i := range indexes {
+		if id := rows[i].Transaction.ExternalID; id != "" {
+			externalIDs = append(externalIDs, id)
+		}
+	}
+
+	stored, err := s.repo.FindByExternalIDs(source, externalIDs)
+	if err != nil {
+		return err
+	}
+	byExternalID := make(map[string]int64, len(stored))
+	for _, tx := range stored {
+		byExternalID[tx.ExternalID] = tx.ID
+	}
+
+	seen := make(map[string]bool)
+	var remaining []int
+	for _, i := range indexes {
+		id := rows[i].Transaction.ExternalID
+		if id == "" {
+			remaining = append(remaining, i)
+			continue
+		}
+
+		if storedID, ok := byExternalID[id]; ok {
+			rows[i].Status = domain.ImportRowStatusDuplicate
+			rows[i].DuplicateOf = &storedID
+		} else if seen[id] {
+			rows[i].Status = domain.ImportRowStatusDuplicate
+		} else {
+			remaining = append(remaining, i)
+		}
+		seen[id] = true
+	}
+	if len(remaining) == 0 {
+		return nil
+	}
+
+	dates := make([]time.Time, len(remaining))
+	var first, last time.Time
+	for n, i := range remaining {
+		date, err := time.Parse(time.RFC3339, rows[i].Transaction.TransactionDate)
+		if err != nil {
+			return err
+		}
+		dates[n] = date.UTC()
+		if first.IsZero() || dates[n].Before(first) {
+			first = dates[n]
+		}
+		if dates[n].After(last) {
+			last = dates[n]
+		}
+	}
+
+	start := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.UTC)
+	end := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1).Add(-time.Nanosecond)
+	existing, err := s.repo.ListTransactionsBetween(source, start, end)
+	if err != nil {
+		return err
+	}
+
+	claimed := make(map[int64]bool)
+	for n, i := range remaining {
+		tx := rows[i].Transaction
+		for j := range existing {
+			candidate := &existing[j]
+			if claimed[candidate.ID] || candidate.Type != tx.Type ||
+				(tx.ExternalID != "" && candidate.ExternalID != "") ||
+				math.Abs(candidate.Amount-tx.Amount) > amountTolerance ||
+				!sameDay(candidate.TransactionDate.UTC(), dates[n]) {
+				continue
+			}
+
+			claimed[candidate.ID] = true
+			id := candidate.ID
+			rows[i].Status = domain.ImportRowStatusDuplicate
+			rows[i].DuplicateOf = &id
+			break
+		}
+	}
+
+	return nil
+}
+
+// sameDay reports whether a and b fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
+
+// validateImportRow applies the checks a webhook request gets from its binding
+// tags and CreateTransactionRequest.Validate, and returns the row outcome
+func validateImportRow(line int, req *domain.CreateTransactionRequest) domain.ImportRow {
+	row := domain.ImportRow{
+		Line:        line,
+		Transaction: req,
+		Status:      domain.ImportRowStatusValid,
+	}
+
+	var err error
+	switch {
+	case req.Amount <= 0:
+		err = &domain.ValidationError{Field: "amount", Message: "amount must be greater than zero"}
+	case req.Type != domain.TransactionTypeIn && req.Type != domain.TransactionTypeOut:
+		err = &domain.ValidationError{Field: "type", Message: "type must be one of: in, out"}
+	case strings.TrimSpace(req.Source) == "":
+		err = &domain.ValidationError{Field: "source", Message: "source is required"}
+	default:
+		err = req.Validate()
+	}
+
+	if err != nil {
+		return importRowError(line, req, err)
+	}
+	return row
+}
+
+// importRowError returns an error row, keeping the validation field when there is one
+func importRowError(line int, req *domain.CreateTransactionRequest, err error) domain.ImportRow {
+	row := domain.ImportRow{
+		Line:        line,
+		Transaction: req,
+		Status:      domain.ImportRowStatusError,
+		Error:       err.Error(),
+	}
+
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		row.Error = validationErr.Message
+		row.Field = validationErr.Field
+	}
+	return row
+}
+
+// csvColumns holds the header index of each mapped column, -1 when unmapped
+type csvColumns struct {
+	date, amount, debit, credit, description, recipient, category int
+}
+
+// parseCSVRows reads the header row after mapping.SkipRows lines and converts
+// every following non-empty record. Problems with single rows are reported on
+// the row; an unreadable file or missing column fails the whole import.
+func parseCSVRows(r io.Reader, mapping *domain.ImportMapping) ([]domain.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = []rune(mapping.Delimiter)[0]
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	for i := 0; i < mapping.SkipRows; i++ {
+		if _, err := reader.Read(); err != nil {
+			return nil, &domain.ValidationError{Field: "file", Message: "file ends before the header row"}
+		}
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, &domain.ValidationError{Field: "file", Message: "file has no header row"}
+	}
+
+	columns, err := mapCSVColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []domain.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			rows = append(rows, domain.ImportRow{
+				Line:   parseErr.StartLine,
+				Status: domain.ImportRowStatusError,
+				Error:  parseErr.Err.Error(),
+			})
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if len(rows) >= domain.MaxImportRows {
+			return nil, &domain.ValidationError{
+				Field:   "file",
+				Message: fmt.Sprintf("file has more than %d rows", domain.MaxImportRows),
+			}
+		}
+
+		line, _ := reader.FieldPos(0)
+		req, err := csvRecordToRequest(record, columns, mapping)
+		if err != nil {
+			rows = append(rows, importRowError(line, req, err))
+			continue
+		}
+		rows = append(rows, validateImportRow(line, req))
+	}
+
+	return rows, nil
+}
+
+// mapCSVColumns finds the mapped columns in the header, ignoring case
+func mapCSVColumns(header []string, mapping *domain.ImportMapping) (*csvColumns, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		key := strings.ToLower(strings.TrimSpace(name))
+		if _, ok := index[key]; !ok {
+			index[key] = i
+		}
+	}
+
+	find := func(field, column string) (int, error) {
+		if column == "" {
+			return -1, nil
+		}
+		i, ok := index[strings.ToLower(column)]
+		if !ok {
+			return -1, &domain.ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("column %q not found in the header row", column),
+			}
+		}
+		return i, nil
+	}
+
+	columns := &csvColumns{}
+	targets := []struct {
+		field  string
+		column string
+		index  *int
+	}{
+		{"date_column", mapping.DateColumn, &columns.date},
+		{"amount_column", mapping.AmountColumn, &columns.amount},
+		{"debit_column", mapping.DebitColumn, &columns.debit},
+		{"credit_column", mapping.CreditColumn, &columns.credit},
+		{"description_column", mapping.DescriptionColumn, &columns.description},
+		{"recipient_column", mapping.RecipientColumn, &columns.recipient},
+		{"category_column", mapping.CategoryColumn, &columns.category},
+	}
+	for _, target := range targets {
+		i, err := find(target.field, target.column)
+		if err != nil {
+			return nil, err
+		}
+		*target.index = i
+	}
+
+	return columns, nil
+}
+
+// csvRecordToRequest converts one CSV record to a transaction request. The
+// request is returned together with any error so the preview can show the
+// parsed fields.
+func csvRecordToRequest(record []string, columns *csvColumns, mapping *domain.ImportMapping) (*domain.CreateTransactionRequest, error) {
+	cell := func(i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	req := &domain.CreateTransactionRequest{
+		Source:        mapping.Source,
+		SourceAccount: mapping.SourceAccount,
+		Description:   cell(columns.description),
+		Recipient:     cell(columns.recipient),
+		Category:      cell(columns.category),
+	}
+
+	date, err := time.Parse(mapping.DateFormat, cell(columns.date))
+	if err != nil {
+		return req, &domain.ValidationError{
+			Field:   "transaction_date",
+			Message: fmt.Sprintf("date %q does not match format %s", cell(columns.date), mapping.DateFormat),
+		}
+	}
+	req.TransactionDate = date.UTC().Format(time.RFC3339)
+
+	if columns.amount >= 0 {
+		amount, ok, err := parseImportCell(cell(columns.amount), mapping.DecimalSeparator)
+		if err != nil {
+			return req, err
+		}
+		if !ok {
+			return req, &domain.ValidationError{Field: "amount", Message: "amount is missing or zero"}
+		}
+
+		expense := amount < 0
+		if mapping.AmountSign == domain.AmountSignPositiveOut {
+			expense = !expense
+		}
+		req.Type = domain.TransactionTypeIn
+		if expense {
+			req.Type = domain.TransactionTypeOut
+		}
+		req.Amount = math.Abs(amount)
+		return req, nil
+	}
+
+	debit, hasDebit, err := parseImportCell(cell(columns.debit), mapping.DecimalSeparator)
+	if err != nil {
+		return req, err
+	}
+	credit, hasCredit, err := parseImportCell(cell(columns.credit), mapping.DecimalSeparator)
+	if err != nil {
+		return req, err
+	}
+
+	switch {
+	case hasDebit && hasCredit:
+		return req, &domain.ValidationError{Field: "amount", Message: "row has both a debit and a credit amount"}
+	case hasDebit:
+		req.Type = domain.TransactionTypeOut
+		req.Amount = math.Abs(debit)
+	case hasCredit:
+		req.Type = domain.TransactionTypeIn
+		req.Amount = math.Abs(credit)
+	default:
+		return req, &domain.ValidationError{Field: "amount", Message: "row has neither a debit nor a credit amount"}
+	}
+
+	return req, nil
+}
+
+// parseImportCell parses an amount cell, reporting false for empty or zero cells
+func parseImportCell(value, decimalSeparator string) (float64, bool, error) {
+	if value == "" {
+		return 0, false, nil
+	}
+
+	amount, err := parseImportAmount(value, decimalSeparator)
+	if err != nil {
+		return 0, false, &domain.ValidationError{
+			Field:   "amount",
+			Message: fmt.Sprintf("invalid amount %q", value),
+		}
+	}
+	if amount == 0 {
+		return 0, false, nil
+	}
+	return amount, true, nil
+}
+
+// parseImportAmount parses a localised amount such as "1.234,56", "(45.00)",
+// "-1,500,000 VND" or "12.50-". Thousands separators, spaces and currency
+// symbols are ignored; a minus sign anywhere or surrounding parentheses make
+// the amount negative.
+func parseImportAmount(value, decimalSeparator string) (float64, error) {
+	value = strings.TrimSpace(value)
+	negative := false
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		negative = true
+		value = value[1 : len(value)-1]
+	}
+
+	var digits strings.Builder
+	for _, r := range value {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case string(r) == decimalSeparator:
+			digits.WriteRune('.')
+		case r == '-' || r == '−':
+			negative = true
+		}
+	}
+
+	if digits.Len() == 0 {
+		return 0, errors.New("no digits")
+	}
+
+	amount, err := strconv.ParseFloat(digits.String(), 64)
+	if err != nil {
+		return 0, err
+	}
+	if negative {
+		amount = -amount
+	}
+	return amount, nil
+}
+
+// isBlankRecord reports whether every field of a record is empty
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,399 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockImportRepository is a mock implementation of ImportRepository for testing
+type mockImportRepository struct {
+	profiles []domain.ImportProfile
+	existing []domain.Transaction
+	external []domain.Transaction
+}
+
+func (m *mockImportRepository) CreateProfile(profile *domain.ImportProfile) error {
+	profile.ID = int64(len(m.profiles) + 1)
+	m.profiles = append(m.profiles, *profile)
+	return nil
+}
+
+func (m *mockImportRepository) FindProfileByID(id int64) (*domain.ImportProfile, error) {
+	for i := range m.profiles {
+		if m.profiles[i].ID == id {
+			profile := m.profiles[i]
+			return &profile, nil
+		}
+	}
+	return nil, repository.ErrImportProfileNotFound
+}
+
+func (m *mockImportRepository) ListProfiles() ([]domain.ImportProfile, error) {
+	return m.profiles, nil
+}
+
+func (m *mockImportRepository) UpdateProfile(profile *domain.ImportProfile) error {
+	return nil
+}
+
+func (m *mockImportRepository) DeleteProfile(id int64) error {
+	return nil
+}
+
+func (m *mockImportRepository) ListTransactionsBetween(source string, start, end time.Time) ([]domain.Transaction, error) {
+	return m.existing, nil
+}
+
+func (m *mockImportRepository) FindByExternalIDs(source string, externalIDs []string) ([]domain.Transaction, error) {
+	return m.external, nil
+}
+
+// newTestImportService returns an import service storing through a real
+// transaction service and the batches it stored
+func newTestImportService(repo repository.ImportRepository) (ImportService, *[][]domain.Transaction) {
+	var stored [][]domain.Transaction
+	txRepo := &mockRepository{
+		createInBatchFunc: func(transactions []domain.Transaction) error {
+			stored = append(stored, transactions)
+			return nil
+		},
+	}
+	return NewImportService(repo, NewTransactionService(txRepo)), &stored
+}
+
+func vcbMapping() *domain.ImportMapping {
+	return &domain.ImportMapping{
+		Source:            "Vietcombank",
+		Delimiter:         ";",
+		DateColumn:        "Ngày GD",
+		DateFormat:        "02/01/2006",
+		DecimalSeparator:  ",",
+		DebitColumn:       "Ghi nợ",
+		CreditColumn:      "Ghi có",
+		DescriptionColumn: "Mô tả",
+	}
+}
+
+// Test ImportCSV
+
+func TestImportCSV_DryRunReportsRowErrors(t *testing.T) {
+	svc, stored := newTestImportService(&mockImportRepository{})
+	csv := "Sao kê tài khoản\n" +
+		"Ngày GD;Ghi nợ;Ghi có;Mô tả\n" +
+		"05/03/2026;45.000;;Highlands Coffee\n" +
+		"06/03/2026;;15.000.000;Lương tháng 3\n" +
+		"2026-03-07;10.000;;Sai ngày\n" +
+		"08/03/2026;;;Không có tiền\n" +
+		";;;\n" +
+		"09/03/2026;1.000,50;2.000;Cả hai\n"
+
+	mapping := vcbMapping()
+	mapping.SkipRows = 1
+	result, err := svc.ImportCSV(strings.NewReader(csv), &domain.CSVImportRequest{Mapping: mapping, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Empty(t, *stored)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 2, result.Valid)
+	assert.Equal(t, 3, result.Errors)
+	assert.Equal(t, 0, result.Imported)
+
+	assert.Equal(t, 3, result.Rows[0].Line)
+	assert.Equal(t, domain.TransactionTypeOut, result.Rows[0].Transaction.Type)
+	assert.Equal(t, 45000.0, result.Rows[0].Transaction.Amount)
+	assert.Equal(t, "2026-03-05T00:00:00Z", result.Rows[0].Transaction.TransactionDate)
+	assert.Equal(t, domain.TransactionTypeIn, result.Rows[1].Transaction.Type)
+	assert.Equal(t, 15000000.0, result.Rows[1].Transaction.Amount)
+
+	assert.Equal(t, domain.ImportRowStatusError, result.Rows[2].Status)
+	assert.Equal(t, "transaction_date", result.Rows[2].Field)
+	assert.Equal(t, "amount", result.Rows[3].Field)
+	assert.Equal(t, 8, result.Rows[4].Line)
+	assert.Equal(t, "amount", result.Rows[4].Field)
+}
+
+func TestImportCSV_SignedAmountColumn(t *testing.T) {
+	svc, _ := newTestImportService(&mockImportRepository{})
+	csv := "date,amount,payee,category\n" +
+		"2026-03-05,-45.50,Starbucks,Food\n" +
+		"2026-03-06,\"1,200.00\",Refund,\n" +
+		"2026-03-07,(20.00),Uber,Transportation\n"
+
+	mapping := &domain.ImportMapping{
+		Source: "Chase", DateColumn: "Date", AmountColumn: "Amount",
+		RecipientColumn: "Payee", CategoryColumn: "Category",
+	}
+	result, err := svc.ImportCSV(strings.NewReader(csv), &domain.CSVImportRequest{Mapping: mapping, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Valid)
+	assert.Equal(t, domain.TransactionTypeOut, result.Rows[0].Transaction.Type)
+	assert.Equal(t, 45.5, result.Rows[0].Transaction.Amount)
+	assert.Equal(t, "Starbucks", result.Rows[0].Transaction.Recipient)
+	assert.Equal(t, domain.TransactionTypeIn, result.Rows[1].Transaction.Type)
+	assert.Equal(t, 1200.0, result.Rows[1].Transaction.Amount)
+	assert.Equal(t, domain.TransactionTypeOut, result.Rows[2].Transaction.Type)
+}
+
+func TestImportCSV_PositiveOutSign(t *testing.T) {
+	svc, _ := newTestImportService(&mockImportRepository{})
+	csv := "Date,Amount\n2026-03-05,99.00\n2026-03-06,-10.00\n"
+
+	mapping := &domain.ImportMapping{
+		Source: "Visa", DateColumn: "Date", AmountColumn: "Amount", AmountSign: domain.AmountSignPositiveOut,
+	}
+	result, err := svc.ImportCSV(strings.NewReader(csv), &domain.CSVImportRequest{Mapping: mapping, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.TransactionTypeOut, result.Rows[0].Transaction.Type)
+	assert.Equal(t, domain.TransactionTypeIn, result.Rows[1].Transaction.Type)
+}
+
+func TestImportCSV_SameValidationAsWebhook(t *testing.T) {
+	svc, _ := newTestImportService(&mockImportRepository{})
+	future := time.Now().AddDate(0, 1, 0).Format("2006-01-02")
+	csv := "Date,Amount,Category\n" +
+		"2026-03-05,-10.00,Groceries\n" +
+		future + ",-10.00,Food\n" +
+		"2026-03-05,-9999999999.00,Food\n"
+
+	mapping := &domain.ImportMapping{Source: "Bank", DateColumn: "Date", AmountColumn: "Amount", CategoryColumn: "Category"}
+	result, err := svc.ImportCSV(strings.NewReader(csv), &domain.CSVImportRequest{Mapping: mapping, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Errors)
+	assert.Equal(t, "category", result.Rows[0].Field)
+	assert.Equal(t, "transaction date cannot be in the future", result.Rows[1].Error)
+	assert.Equal(t, "amount", result.Rows[2].Field)
+}
+
+func TestImportCSV_FlagsDuplicatesOncePerStoredTransaction(t *testing.T) {
+	repo := &mockImportRepository{
+		existing: []domain.Transaction{
+			{ID: 41, Source: "Vietcombank", Type: domain.TransactionTypeOut, Amount: 45000,
+				TransactionDate: time.Date(2026, 3, 5, 8, 12, 0, 0, time.UTC)},
+		},
+	}
+	svc, stored := newTestImportService(repo)
+	csv := "Ngày GD;Ghi nợ;Ghi có;Mô tả\n" +
+		"05/03/2026;45.000;;Highlands Coffee\n" +
+		"05/03/2026;45.000;;Highlands Coffee\n"
+
+	result, err := svc.ImportCSV(strings.NewReader(csv), &domain.CSVImportRequest{Mapping: vcbMapping()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, domain.ImportRowStatusDuplicate, result.Rows[0].Status)
+	assert.Equal(t, int64(41), *result.Rows[0].DuplicateOf)
+	assert.Equal(t, domain.ImportRowStatusImported, result.Rows[1].Status)
+	assert.Len(t, *stored, 1)
+}
+
+func TestImportCSV_CommitsInBatches(t *testing.T) {
+	svc, stored := newTestImportService(&mockImportRepository{})
+	var b strings.Builder
+	b.WriteString("Date,Amount\n")
+	for i := 0; i < 250; i++ {
+		fmt.Fprintf(&b, "2026-03-05,-%d.00\n", i+1)
+	}
+
+	mapping := &domain.ImportMapping{Source: "Bank", DateColumn: "Date", AmountColumn: "Amount"}
+	result, err := svc.ImportCSV(strings.NewReader(b.String()), &domain.CSVImportRequest{Mapping: mapping})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 250, result.Imported)
+	assert.Len(t, *stored, 3)
+	assert.Len(t, (*stored)[2], 50)
+}
+
+func TestImportCSV_CommitErrorStopsImport(t *testing.T) {
+	txRepo := &mockRepository{
+		createInBatchFunc: func(transactions []domain.Transaction) error {
+			return errors.New("database error")
+		},
+	}
+	svc := NewImportService(&mockImportRepository{}, NewTransactionService(txRepo))
+
+	mapping := &domain.ImportMapping{Source: "Bank", DateColumn: "Date", AmountColumn: "Amount"}
+	_, err := svc.ImportCSV(strings.NewReader("Date,Amount\n2026-03-05,-1.00\n"), &domain.CSVImportRequest{Mapping: mapping})
+
+	assert.Error(t, err)
+}
+
+func TestImportCSV_SavedProfile(t *testing.T) {
+	repo := &mockImportRepository{
+		profiles: []domain.ImportProfile{{ID: 1, Name: "VCB", ImportMapping: *vcbMapping()}},
+	}
+	svc, _ := newTestImportService(repo)
+
+	result, err := svc.ImportCSV(strings.NewReader("\ufeffNgày GD;Ghi nợ;Ghi có;Mô tả\n05/03/2026;45.000;;Grab\n"),
+		&domain.CSVImportRequest{ProfileID: 1, DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Valid)
+}
+
+func TestImportCSV_MappingErrors(t *testing.T) {
+	svc, _ := newTestImportService(&mockImportRepository{})
+
+	tests := []struct {
+		name  string
+		req   *domain.CSVImportRequest
+		csv   string
+		field string
+	}{
+		{"no mapping", &domain.CSVImportRequest{}, "Date,Amount\n", "profile_id"},
+		{"unknown profile", &domain.CSVImportRequest{ProfileID: 5}, "Date,Amount\n", "profile_id"},
+		{"missing column", &domain.CSVImportRequest{Mapping: vcbMapping()}, "Ngày GD;Số tiền\n", "debit_column"},
+		{"empty file", &domain.CSVImportRequest{Mapping: vcbMapping()}, "", "file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ImportCSV(strings.NewReader(tt.csv), tt.req)
+
+			var validationErr *domain.ValidationError
+			assert.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+// Test ImportOFX
+
+func TestImportOFX_SkipsKnownFITIDs(t *testing.T) {
+	repo := &mockImportRepository{
+		external: []domain.Transaction{{ID: 7, Source: "Chase Bank", ExternalID: "2026030501"}},
+	}
+	svc, stored := newTestImportService(repo)
+
+	result, err := svc.ImportOFX(strings.NewReader(sgmlOFX), &domain.StatementImportRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Total)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, int64(7), *result.Rows[0].DuplicateOf)
+	assert.Len(t, *stored, 1)
+
+	tx := (*stored)[0][0]
+	assert.Equal(t, "Chase Bank", tx.Source)
+	assert.Equal(t, "000123456", tx.SourceAccount)
+	assert.Equal(t, "2026031001", tx.ExternalID)
+}
+
+func TestImportOFX_RepeatedFITIDInFile(t *testing.T) {
+	svc, _ := newTestImportService(&mockImportRepository{})
+	ofx := strings.Replace(sgmlOFX, "2026031001", "2026030501", 1)
+
+	result, err := svc.ImportOFX(strings.NewReader(ofx), &domain.StatementImportRequest{Source: "Chase", DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Valid)
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Equal(t, domain.ImportRowStatusDuplicate, result.Rows[1].Status)
+	assert.Nil(t, result.Rows[1].DuplicateOf)
+	assert.Equal(t, "Chase", result.Rows[0].Transaction.Source)
+}
+
+func TestImportOFX_SameDayHeuristicIgnoresOtherFITIDs(t *testing.T) {
+	repo := &mockImportRepository{
+		existing: []domain.Transaction{
+			{ID: 3, Source: "Chase Bank", Type: domain.TransactionTypeOut, Amount: 45.50, ExternalID: "OTHER",
+				TransactionDate: time.Date(2026, 3, 5, 17, 0, 0, 0, time.UTC)},
+			{ID: 4, Source: "Chase Bank", Type: domain.TransactionTypeIn, Amount: 1200,
+				TransactionDate: time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)},
+		},
+	}
+	svc, _ := newTestImportService(repo)
+
+	result, err := svc.ImportOFX(strings.NewReader(sgmlOFX), &domain.StatementImportRequest{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportRowStatusValid, result.Rows[0].Status)
+	assert.Equal(t, domain.ImportRowStatusDuplicate, result.Rows[1].Status)
+	assert.Equal(t, int64(4), *result.Rows[1].DuplicateOf)
+}
+
+func TestImportOFX_RequiresSource(t *testing.T) {
+	svc, _ := newTestImportService(&mockImportRepository{})
+
+	_, err := svc.ImportOFX(strings.NewReader(xmlOFX), &domain.StatementImportRequest{})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "source", validationErr.Field)
+}
+
+// Test ImportQIF
+
+func TestImportQIF_ReportsRows(t *testing.T) {
+	svc, stored := newTestImportService(&mockImportRepository{})
+	qif := "!Type:Bank\n" +
+		"D05/03/2026\nT-45.50\nPStarbucks\n^\n" +
+		"D06/03/2026\nT0.00\n^\n"
+
+	result, err := svc.ImportQIF(strings.NewReader(qif), &domain.StatementImportRequest{
+		Source:     "Cash",
+		DateFormat: "02/01/2006",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 1, result.Errors)
+	assert.Equal(t, 6, result.Rows[1].Line)
+	assert.Equal(t, "amount", result.Rows[1].Field)
+	assert.Len(t, *stored, 1)
+	assert.Equal(t, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), (*stored)[0][0].TransactionDate)
+}
+
+func TestImportQIF_RequiresSource(t *testing.T) {
+	svc, _ := newTestImportService(&mockImportRepository{})
+
+	_, err := svc.ImportQIF(strings.NewReader("!Type:Bank\n"), &domain.StatementImportRequest{})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "source", validationErr.Field)
+}
+
+// Test parseImportAmount
+
+func TestParseImportAmount(t *testing.T) {
+	tests := []struct {
+		value     string
+		separator string
+		want      float64
+	}{
+		{"1,234.56", ".", 1234.56},
+		{"1.234,56", ",", 1234.56},
+		{"-1.500.000 VND", ",", -1500000},
+		{"(45.00)", ".", -45},
+		{"12.50-", ".", -12.5},
+		{"$ 9.99", ".", 9.99},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseImportAmount(tt.value, tt.separator)
+
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+
+	_, err := parseImportAmount("n/a", ".")
+	assert.Error(t, err)
+	_, err = parseImportAmount("1.234.567", ".")
+	assert.Error(t, err)
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// ledgerAmountColumn is where posting amounts are aligned in journal output
+const ledgerAmountColumn = 52
+
+// journalExportWriter writes transactions as double-entry journal entries for
+// Beancount, hledger or ledger. Each transaction moves its amount between the
+// source account and the category account, with the transaction ID as
+// metadata. Beancount files also get open directives for every account used,
+// written at the end once all accounts are known.
+type journalExportWriter struct {
+	writer  *bufio.Writer
+	format  domain.ExportFormat
+	mapping *domain.LedgerMapping
+	opened  map[string]time.Time // Beancount: account → first posting date
+}
+
+func newJournalExportWriter(w io.Writer, format domain.ExportFormat, mapping *domain.LedgerMapping) *journalExportWriter {
+	return &journalExportWriter{
+		writer:  bufio.NewWriter(w),
+		format:  format,
+		mapping: mapping,
+		opened:  make(map[string]time.Time),
+	}
+}
+
+func (e *journalExportWriter) WriteHeader(columns []domain.ExportColumn) error {
+	if e.format == domain.ExportFormatBeancount {
+		fmt.Fprintf(e.writer, "option \"operating_currency\" %q\n\n", e.mapping.Currency)
+	}
+	_, err := fmt.Fprintf(e.writer, "; Exported by personal-finance-tracker on %s\n", time.Now().UTC().Format("2006-01-02"))
+	return err
+}
+
+func (e *journalExportWriter) WriteTransaction(tx *domain.Transaction) error {
+	from, to := e.mapping.SourceAccount(tx), e.mapping.CategoryAccount(tx)
+	if tx.Type == domain.TransactionTypeIn {
+		from, to = to, from
+	}
+	date := tx.TransactionDate.UTC()
+	amount := strconv.FormatFloat(tx.Amount, 'f', 2, 64)
+
+	e.writer.WriteByte('\n')
+	switch e.format {
+	case domain.ExportFormatBeancount:
+		fmt.Fprintf(e.writer, "%s * %s %s\n", date.Format("2006-01-02"),
+			beancountString(tx.Recipient), beancountString(tx.Description))
+		fmt.Fprintf(e.writer, "  id: \"%d\"\n", tx.ID)
+		if tx.Description != "" {
+			fmt.Fprintf(e.writer, "  description: %s\n", beancountString(tx.Description))
+		}
+		e.open(to, date)
+		e.open(from, date)
+	case domain.ExportFormatHledger:
+		fmt.Fprintf(e.writer, "%s * %s\n", date.Format("2006-01-02"), hledgerDescription(tx))
+		fmt.Fprintf(e.writer, "    ; id: %d\n", tx.ID)
+		if tx.Description != "" {
+			fmt.Fprintf(e.writer, "    ; description: %s\n", journalText(tx.Description))
+		}
+	default:
+		fmt.Fprintf(e.writer, "%s * %s\n", date.Format("2006/01/02"),
+			journalText(firstNonEmpty(tx.Recipient, tx.Description, string(tx.Type))))
+		fmt.Fprintf(e.writer, "    ; id: %d\n", tx.ID)
+		if tx.Description != "" {
+			fmt.Fprintf(e.writer, "    ; description: %s\n", journalText(tx.Description))
+		}
+	}
+
+	indent := "    "
+	if e.format == domain.ExportFormatBeancount {
+		indent = "  "
+	}
+	e.posting(indent, to, amount)
+	return e.posting(indent, from, "-"+amount)
+}
+
+func (e *journalExportWriter) Close() error {
+	if e.format == domain.ExportFormatBeancount && len(e.opened) > 0 {
+		accounts := make([]string, 0, len(e.opened))
+		for account := range e.opened {
+			accounts = append(accounts, account)
+		}
+		sort.Strings(accounts)
+
+		e.writer.WriteString("\n; Accounts used above\n")
+		for _, account := range accounts {
+			fmt.Fprintf(e.writer, "%s open %s %s\n", e.opened[account].Format("2006-01-02"), account, e.mapping.Currency)
+		}
+	}
+	return e.writer.Flush()
+}
+
+// posting writes one account line with the amount aligned to ledgerAmountColumn
+func (e *journalExportWriter) posting(indent, account, amount string) error {
+	padding := ledgerAmountColumn - len(indent) - utf8.RuneCountInString(account) - len(amount)
+	if padding < 2 {
+		padding = 2 // ledger and hledger need two spaces before an amount
+	}
+	_, err := fmt.Fprintf(e.writer, "%s%s%s%s %s\n", indent, account, strings.Repeat(" ", padding), amount, e.mapping.Currency)
+	return err
+}
+
+// open records the earliest posting date of a Beancount account
+func (e *journalExportWriter) open(account string, date time.Time) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	if first, ok := e.opened[account]; !ok || day.Before(first) {
+		e.opened[account] = day
+	}
+}
+
+// beancountString quotes a value as a Beancount string literal
+func beancountString(value string) string {
+	value = strings.ReplaceAll(journalText(value), `\`, `\\`)
+	return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+}
+
+// hledgerDescription joins payee and note with the pipe hledger splits on
+func hledgerDescription(tx *domain.Transaction) string {
+	payee := strings.ReplaceAll(journalText(tx.Recipient), "|", "/")
+	note := journalText(tx.Description)
+	switch {
+	case payee == "":
+		return firstNonEmpty(note, string(tx.Type))
+	case note == "":
+		return payee
+	default:
+		return payee + " | " + note
+	}
+}
+
+// journalText collapses a value onto one line, as journal entries are line based
+func journalText(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
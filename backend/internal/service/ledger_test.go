@@ -0,0 +1,80 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+func journalTransactions() []domain.Transaction {
+	return []domain.Transaction{
+		{ID: 7, Type: domain.TransactionTypeOut, Amount: 45.5, Category: "Food", Recipient: "Highlands \"Coffee\"",
+			Description: "Latte\nand cake", Source: "Vietcombank", TransactionDate: time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC)},
+		{ID: 3, Type: domain.TransactionTypeIn, Amount: 2000, Category: "Salary",
+			Source: "Vietcombank", TransactionDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+func exportJournal(t *testing.T, format domain.ExportFormat) string {
+	t.Helper()
+	mapping := domain.NewLedgerMapping("VND", map[string]string{"vietcombank": "Assets:Bank:Vietcombank"}, nil)
+	svc := NewExportService(&mockRepository{streamed: journalTransactions()}, mapping)
+
+	var out bytes.Buffer
+	err := svc.Export(context.Background(), &out, &domain.ExportQueryParams{Format: format})
+	assert.NoError(t, err)
+	return out.String()
+}
+
+// Test journal exports
+
+func TestExport_Beancount(t *testing.T) {
+	out := exportJournal(t, domain.ExportFormatBeancount)
+
+	assert.True(t, strings.HasPrefix(out, "option \"operating_currency\" \"VND\"\n"))
+	assert.Contains(t, out, "\n2026-03-05 * \"Highlands \\\"Coffee\\\"\" \"Latte and cake\"\n"+
+		"  id: \"7\"\n"+
+		"  description: \"Latte and cake\"\n"+
+		"  Expenses:Food                                45.50 VND\n"+
+		"  Assets:Bank:Vietcombank                     -45.50 VND\n")
+	assert.Contains(t, out, "\n2026-03-01 * \"\" \"\"\n"+
+		"  id: \"3\"\n"+
+		"  Assets:Bank:Vietcombank                    2000.00 VND\n"+
+		"  Income:Salary                             -2000.00 VND\n")
+	assert.True(t, strings.HasSuffix(out, "; Accounts used above\n"+
+		"2026-03-01 open Assets:Bank:Vietcombank VND\n"+
+		"2026-03-05 open Expenses:Food VND\n"+
+		"2026-03-01 open Income:Salary VND\n"))
+}
+
+func TestExport_Hledger(t *testing.T) {
+	out := exportJournal(t, domain.ExportFormatHledger)
+
+	assert.Contains(t, out, "\n2026-03-05 * Highlands \"Coffee\" | Latte and cake\n"+
+		"    ; id: 7\n"+
+		"    ; description: Latte and cake\n"+
+		"    Expenses:Food                              45.50 VND\n")
+	assert.Contains(t, out, "\n2026-03-01 * in\n    ; id: 3\n")
+	assert.NotContains(t, out, " open ")
+}
+
+func TestExport_Ledger(t *testing.T) {
+	out := exportJournal(t, domain.ExportFormatLedger)
+
+	assert.Contains(t, out, "\n2026/03/05 * Highlands \"Coffee\"\n"+
+		"    ; id: 7\n"+
+		"    ; description: Latte and cake\n")
+	assert.Contains(t, out, "    Income:Salary                           -2000.00 VND\n")
+}
+
+func TestHledgerDescription(t *testing.T) {
+	assert.Equal(t, "A/B | note", hledgerDescription(&domain.Transaction{Recipient: "A|B", Description: "note"}))
+	assert.Equal(t, "Payee", hledgerDescription(&domain.Transaction{Recipient: "Payee"}))
+	assert.Equal(t, "out", hledgerDescription(&domain.Transaction{Type: domain.TransactionTypeOut}))
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrMFAAlreadyEnabled is returned when enrolling a user whose 2FA is already on
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrMFANotEnabled is returned when changing 2FA of a user who has not enabled it
+	ErrMFANotEnabled = errors.New("two-factor authentication is not enabled")
+	// ErrInvalidMFACode is returned when a TOTP or recovery code does not match
+	ErrInvalidMFACode = errors.New("invalid two-factor code")
+)
+
+// MFAService handles TOTP two-factor authentication. Enrolment creates a
+// secret for an authenticator app; 2FA is enabled once a first code from the
+// app proves it was added. Wrong codes are throttled like wrong passwords.
+type MFAService interface {
+	GetStatus(userID int64) (*domain.MFAStatus, error)
+	Enroll(userID int64) (*domain.MFAEnrollment, error)
+	Activate(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) (*domain.MFARecoveryCodes, error)
+	Disable(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) error
+	RegenerateRecoveryCodes(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) (*domain.MFARecoveryCodes, error)
+	IsEnabled(userID int64) (bool, error)
+	Verify(user *domain.User, code string, client domain.ClientInfo) error
+}
+
+type mfaService struct {
+	repo     repository.MFARepository
+	userRepo repository.UserRepository
+	security SecurityService
+	now      func() time.Time
+}
+
+// NewMFAService creates a new two-factor authentication service; wrong
+// codes are throttled and logged by securityService
+func NewMFAService(repo repository.MFARepository, userRepo repository.UserRepository, securityService SecurityService) MFAService {
+	return &mfaService{
+		repo:     repo,
+		userRepo: userRepo,
+		security: securityService,
+		now:      time.Now,
+	}
+}
+
+// GetStatus tells whether a user has 2FA enabled and how many recovery codes are left
+func (s *mfaService) GetStatus(userID int64) (*domain.MFAStatus, error) {
+	mfa, err := s.repo.Find(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFANotFound) {
+			return &domain.MFAStatus{}, nil
+		}
+		return nil, err
+	}
+	if !mfa.Enabled {
+		return &domain.MFAStatus{}, nil
+	}
+
+	remaining, err := s.repo.CountRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MFAStatus{
+		Enabled:                true,
+		EnabledAt:              mfa.EnabledAt,
+		RecoveryCodesRemaining: int(remaining),
+	}, nil
+}
+
+// Enroll creates a new TOTP secret for a user without 2FA. It replaces any
+// earlier enrolment that was not activated.
+func (s *mfaService) Enroll(userID int64) (*domain.MFAEnrollment, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	mfa, err := s.repo.Find(userID)
+	if err != nil && !errors.Is(err, repository.ErrMFANotFound) {
+		return nil, err
+	}
+	if mfa != nil && mfa.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	key, err := security.GenerateTOTPKey(domain.MFAIssuer, user.Email)
+	if err != nil {
+		return nil, err
+	}
+	qrCode, err := security.TOTPQRCode(key.URL, domain.MFAQRCodeSize)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SaveEnrollment(&domain.UserMFA{UserID: userID, Secret: key.Secret}); err != nil {
+		return nil, err
+	}
+
+	return &domain.MFAEnrollment{
+		Secret:     key.Secret,
+		OTPAuthURL: key.URL,
+		QRCodePNG:  qrCode,
+	}, nil
+}
+
+// Activate enables 2FA once req holds a valid code of the enrolled secret,
+// and returns the recovery codes. They are only ever shown here.
+func (s *mfaService) Activate(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) (*domain.MFARecoveryCodes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	user, err := s.checkUser(userID, client)
+	if err != nil {
+		return nil, err
+	}
+	mfa, err := s.repo.Find(userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	if !domain.IsTOTPCode(req.Code) {
+		return nil, s.codeFailed(user, client)
+	}
+	step, ok := security.ValidateTOTP(mfa.Secret, req.Code, s.now(), mfa.LastUsedStep)
+	if !ok {
+		return nil, s.codeFailed(user, client)
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Activate(userID, step, hashes, s.now()); err != nil {
+		return nil, err
+	}
+
+	s.recordEvent(userID, domain.SecurityEventMFAEnabled, client, "")
+	return &domain.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// Disable turns 2FA off when req holds a valid TOTP or recovery code
+func (s *mfaService) Disable(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	user, err := s.checkUser(userID, client)
+	if err != nil {
+		return err
+	}
+	if err := s.verify(user, req.Code, client, true); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(userID); err != nil {
+		return err
+	}
+	s.recordEvent(userID, domain.SecurityEventMFADisabled, client, "")
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of a user when req
+// holds a valid TOTP code
+func (s *mfaService) RegenerateRecoveryCodes(userID int64, req *domain.MFACodeRequest, client domain.ClientInfo) (*domain.MFARecoveryCodes, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	user, err := s.checkUser(userID, client)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.verify(user, req.Code, client, false); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	s.recordEvent(userID, domain.SecurityEventRecoveryCodesRegenerated, client, "")
+	return &domain.MFARecoveryCodes{RecoveryCodes: codes}, nil
+}
+
+// IsEnabled reports whether logins of a user need a second factor
+func (s *mfaService) IsEnabled(userID int64) (bool, error) {
+	mfa, err := s.repo.Find(userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrMFANotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return mfa.Enabled, nil
+}
+
+// Verify checks the TOTP or recovery code of the second login step. While
+// the account or the client IP is blocked, it returns a *LoginThrottledError
+// without checking the code.
+func (s *mfaService) Verify(user *domain.User, code string, client domain.ClientInfo) error {
+	if err := s.security.CheckLogin(user.Email, client); err != nil {
+		return err
+	}
+	return s.verify(user, code, client, true)
+}
+
+// checkUser loads a user and refuses code attempts while the account or
+// the client IP is blocked
+func (s *mfaService) checkUser(userID int64, client domain.ClientInfo) (*domain.User, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.security.CheckLogin(user.Email, client); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// verify checks a TOTP code of the user's enabled 2FA, or a recovery code
+// if allowed. Accepted codes are used up.
+func (s *mfaService) verify(user *domain.User, code string, client domain.ClientInfo, allowRecovery bool) error {
+	mfa, err := s.repo.Find(user.ID)
+	if err != nil && !errors.Is(err, repository.ErrMFANotFound) {
+		return err
+	}
+	if mfa == nil || !mfa.Enabled {
+		return ErrMFANotEnabled
+	}
+
+	if domain.IsTOTPCode(code) {
+		step, ok := security.ValidateTOTP(mfa.Secret, code, s.now(), mfa.LastUsedStep)
+		if !ok {
+			return s.codeFailed(user, client)
+		}
+		used, err := s.repo.UseStep(user.ID, step)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return s.codeFailed(user, client)
+		}
+		return nil
+	}
+
+	if !allowRecovery {
+		return s.codeFailed(user, client)
+	}
+	used, err := s.repo.UseRecoveryCode(user.ID, security.HashRecoveryCode(code), s.now())
+	if err != nil {
+		return err
+	}
+	if !used {
+		return s.codeFailed(user, client)
+	}
+
+	details := ""
+	if remaining, err := s.repo.CountRecoveryCodes(user.ID); err == nil {
+		details = fmt.Sprintf("%d recovery codes left", remaining)
+	}
+	s.recordEvent(user.ID, domain.SecurityEventRecoveryCodeUsed, client, details)
+	return nil
+}
+
+// codeFailed counts a wrong code against the account and returns
+// ErrInvalidMFACode. Errors are logged rather than returned.
+func (s *mfaService) codeFailed(user *domain.User, client domain.ClientInfo) error {
+	if err := s.security.MFAFailed(user, client); err != nil {
+		log := logger.Get()
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to record rejected two-factor code")
+	}
+	return ErrInvalidMFACode
+}
+
+// recordEvent adds an event to the security log, logging rather than
+// returning errors once the change is made
+func (s *mfaService) recordEvent(userID int64, eventType string, client domain.ClientInfo, details string) {
+	if err := s.security.RecordEvent(userID, eventType, client, details); err != nil {
+		log := logger.Get()
+		log.Error().Err(err).Int64("user_id", userID).Str("type", eventType).Msg("Failed to record security event")
+	}
+}
+
+// generateRecoveryCodes returns new recovery codes and their hashes
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes, err := security.GenerateRecoveryCodes(domain.MFARecoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = security.HashRecoveryCode(code)
+	}
+	return codes, hashes, nil
+}
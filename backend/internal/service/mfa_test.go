@@ -0,0 +1,284 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockMFARepository is an in-memory implementation of MFARepository for testing
+type mockMFARepository struct {
+	mfa   map[int64]*domain.UserMFA
+	codes map[int64][]domain.MFARecoveryCode
+}
+
+func newMockMFARepository() *mockMFARepository {
+	return &mockMFARepository{
+		mfa:   make(map[int64]*domain.UserMFA),
+		codes: make(map[int64][]domain.MFARecoveryCode),
+	}
+}
+
+func (m *mockMFARepository) Find(userID int64) (*domain.UserMFA, error) {
+	mfa, ok := m.mfa[userID]
+	if !ok {
+		return nil, repository.ErrMFANotFound
+	}
+	copied := *mfa
+	return &copied, nil
+}
+
+func (m *mockMFARepository) SaveEnrollment(mfa *domain.UserMFA) error {
+	if existing, ok := m.mfa[mfa.UserID]; ok {
+		existing.Secret = mfa.Secret
+		existing.LastUsedStep = mfa.LastUsedStep
+		return nil
+	}
+	copied := *mfa
+	m.mfa[mfa.UserID] = &copied
+	return nil
+}
+
+func (m *mockMFARepository) Activate(userID, step int64, codeHashes []string, now time.Time) error {
+	mfa, ok := m.mfa[userID]
+	if !ok || mfa.Enabled {
+		return repository.ErrMFANotFound
+	}
+	mfa.Enabled, mfa.EnabledAt, mfa.LastUsedStep = true, &now, step
+	return m.ReplaceRecoveryCodes(userID, codeHashes)
+}
+
+func (m *mockMFARepository) UseStep(userID, step int64) (bool, error) {
+	mfa, ok := m.mfa[userID]
+	if !ok || mfa.LastUsedStep >= step {
+		return false, nil
+	}
+	mfa.LastUsedStep = step
+	return true, nil
+}
+
+func (m *mockMFARepository) UseRecoveryCode(userID int64, codeHash string, now time.Time) (bool, error) {
+	for i, code := range m.codes[userID] {
+		if code.CodeHash == codeHash && code.UsedAt == nil {
+			m.codes[userID][i].UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockMFARepository) CountRecoveryCodes(userID int64) (int64, error) {
+	var count int64
+	for _, code := range m.codes[userID] {
+		if code.UsedAt == nil {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockMFARepository) ReplaceRecoveryCodes(userID int64, codeHashes []string) error {
+	m.codes[userID] = nil
+	for _, hash := range codeHashes {
+		m.codes[userID] = append(m.codes[userID], domain.MFARecoveryCode{UserID: userID, CodeHash: hash})
+	}
+	return nil
+}
+
+func (m *mockMFARepository) Delete(userID int64) error {
+	if _, ok := m.mfa[userID]; !ok {
+		return repository.ErrMFANotFound
+	}
+	delete(m.mfa, userID)
+	delete(m.codes, userID)
+	return nil
+}
+
+func newTestMFAService(userRepo repository.UserRepository) MFAService {
+	return NewMFAService(newMockMFARepository(), userRepo, newTestSecurityService(userRepo))
+}
+
+// newClockedMFAService returns an MFA service whose clock is at *now
+func newClockedMFAService(repo *mockMFARepository, userRepo repository.UserRepository, securityService SecurityService, now *time.Time) MFAService {
+	svc := NewMFAService(repo, userRepo, securityService).(*mfaService)
+	svc.now = func() time.Time { return *now }
+	return svc
+}
+
+// mfaTestSetup enrols and activates 2FA for user at *now and returns the
+// service, its repositories and the recovery codes
+func mfaTestSetup(t *testing.T, user *domain.User, now *time.Time) (MFAService, *mockMFARepository, *mockSecurityRepository, []string) {
+	t.Helper()
+	userRepo := &mockUserRepository{findByIDUser: user}
+	securityRepo := newMockSecurityRepository()
+	repo := newMockMFARepository()
+	svc := newClockedMFAService(repo, userRepo, newClockedSecurityService(securityRepo, userRepo, now), now)
+
+	enrollment, err := svc.Enroll(user.ID)
+	require.NoError(t, err)
+	codes, err := svc.Activate(user.ID, &domain.MFACodeRequest{Code: totpCode(t, enrollment.Secret, *now)}, testClient)
+	require.NoError(t, err)
+	return svc, repo, securityRepo, codes.RecoveryCodes
+}
+
+func totpCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := totp.GenerateCode(secret, at)
+	require.NoError(t, err)
+	return code
+}
+
+func TestMFAService_Enroll(t *testing.T) {
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	repo := newMockMFARepository()
+	svc := NewMFAService(repo, &mockUserRepository{findByIDUser: user}, newTestSecurityService(&mockUserRepository{}))
+
+	enrollment, err := svc.Enroll(1)
+
+	require.NoError(t, err)
+	assert.Len(t, enrollment.Secret, 32)
+	assert.Contains(t, enrollment.OTPAuthURL, "otpauth://totp/Finance%20Tracker:test@example.com")
+	assert.True(t, bytes.HasPrefix(enrollment.QRCodePNG, []byte("\x89PNG")))
+	assert.Equal(t, enrollment.Secret, repo.mfa[1].Secret)
+	assert.False(t, repo.mfa[1].Enabled)
+
+	enabled, err := svc.IsEnabled(1)
+	require.NoError(t, err)
+	assert.False(t, enabled, "enrolment alone does not enable 2FA")
+}
+
+func TestMFAService_Activate(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	svc, _, securityRepo, codes := mfaTestSetup(t, user, &now)
+
+	assert.Len(t, codes, domain.MFARecoveryCodeCount)
+	assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, codes[0])
+
+	status, err := svc.GetStatus(1)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, now, *status.EnabledAt)
+	assert.Equal(t, domain.MFARecoveryCodeCount, status.RecoveryCodesRemaining)
+	assert.Equal(t, []string{domain.SecurityEventMFAEnabled}, securityRepo.eventTypes())
+
+	_, err = svc.Enroll(1)
+	assert.ErrorIs(t, err, ErrMFAAlreadyEnabled)
+}
+
+func TestMFAService_Activate_WrongCode(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	userRepo := &mockUserRepository{findByIDUser: user}
+	securityRepo := newMockSecurityRepository()
+	securityService := newClockedSecurityService(securityRepo, userRepo, &now)
+	svc := newClockedMFAService(newMockMFARepository(), userRepo, securityService, &now)
+
+	_, err := svc.Activate(1, &domain.MFACodeRequest{Code: "123456"}, testClient)
+	assert.ErrorIs(t, err, repository.ErrMFANotFound)
+
+	enrollment, err := svc.Enroll(1)
+	require.NoError(t, err)
+	wrong := totpCode(t, enrollment.Secret, now.Add(-5*time.Minute))
+	_, err = svc.Activate(1, &domain.MFACodeRequest{Code: wrong}, testClient)
+
+	assert.ErrorIs(t, err, ErrInvalidMFACode)
+	assert.Equal(t, []string{domain.SecurityEventMFAFailed}, securityRepo.eventTypes())
+	var throttled *LoginThrottledError
+	assert.ErrorAs(t, securityService.CheckLogin("test@example.com", domain.ClientInfo{}), &throttled, "wrong codes block the account like wrong passwords")
+}
+
+func TestMFAService_Verify_TOTPCodeWorksOnce(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	svc, repo, _, _ := mfaTestSetup(t, user, &now)
+
+	now = now.Add(time.Minute)
+	code := totpCode(t, repo.mfa[1].Secret, now)
+
+	require.NoError(t, svc.Verify(user, code, testClient))
+	assert.ErrorIs(t, svc.Verify(user, code, domain.ClientInfo{}), ErrInvalidMFACode, "a code cannot be replayed")
+}
+
+func TestMFAService_Verify_RecoveryCodeWorksOnce(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	svc, _, securityRepo, codes := mfaTestSetup(t, user, &now)
+
+	require.NoError(t, svc.Verify(user, " "+strings.ToUpper(codes[0]), testClient))
+
+	last := securityRepo.events[len(securityRepo.events)-1]
+	assert.Equal(t, domain.SecurityEventRecoveryCodeUsed, last.Type)
+	assert.Equal(t, "9 recovery codes left", last.Details)
+
+	now = now.Add(time.Minute)
+	assert.ErrorIs(t, svc.Verify(user, codes[0], domain.ClientInfo{}), ErrInvalidMFACode)
+}
+
+func TestMFAService_Verify_Throttled(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	svc, repo, _, _ := mfaTestSetup(t, user, &now)
+
+	assert.ErrorIs(t, svc.Verify(user, "000000", testClient), ErrInvalidMFACode)
+
+	var throttled *LoginThrottledError
+	assert.ErrorAs(t, svc.Verify(user, totpCode(t, repo.mfa[1].Secret, now), testClient), &throttled)
+}
+
+func TestMFAService_Verify_NotEnabled(t *testing.T) {
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	svc := newTestMFAService(&mockUserRepository{findByIDUser: user})
+
+	err := svc.Verify(user, "123456", testClient)
+
+	assert.ErrorIs(t, err, ErrMFANotEnabled)
+}
+
+func TestMFAService_Disable(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	svc, repo, securityRepo, codes := mfaTestSetup(t, user, &now)
+
+	require.NoError(t, svc.Disable(1, &domain.MFACodeRequest{Code: codes[1]}, testClient))
+
+	assert.Empty(t, repo.mfa)
+	assert.Empty(t, repo.codes)
+	assert.Equal(t, domain.SecurityEventMFADisabled, securityRepo.events[len(securityRepo.events)-1].Type)
+	assert.ErrorIs(t, svc.Disable(1, &domain.MFACodeRequest{Code: codes[2]}, testClient), ErrMFANotEnabled)
+}
+
+func TestMFAService_RegenerateRecoveryCodes(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+	svc, repo, _, oldCodes := mfaTestSetup(t, user, &now)
+
+	_, err := svc.RegenerateRecoveryCodes(1, &domain.MFACodeRequest{Code: oldCodes[0]}, testClient)
+	assert.ErrorIs(t, err, ErrInvalidMFACode, "recovery codes cannot replace themselves")
+
+	now = now.Add(10 * time.Minute)
+	codes, err := svc.RegenerateRecoveryCodes(1, &domain.MFACodeRequest{Code: totpCode(t, repo.mfa[1].Secret, now)}, testClient)
+
+	require.NoError(t, err)
+	assert.Len(t, codes.RecoveryCodes, domain.MFARecoveryCodeCount)
+	assert.NotEqual(t, oldCodes, codes.RecoveryCodes)
+	assert.ErrorIs(t, svc.Verify(user, oldCodes[1], domain.ClientInfo{}), ErrInvalidMFACode, "old codes are invalidated")
+}
+
+func TestMFAService_GetStatus_NotEnrolled(t *testing.T) {
+	svc := newTestMFAService(&mockUserRepository{})
+
+	status, err := svc.GetStatus(1)
+
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+	assert.Nil(t, status.EnabledAt)
+}
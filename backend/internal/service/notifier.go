@@ -0,0 +1,142 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/mailer"
+)
+
+var (
+	//go:embed templates/password_reset.html
+	passwordResetHTML string
+	//go:embed templates/password_reset.txt
+	passwordResetText string
+	//go:embed templates/email_change.html
+	emailChangeHTML string
+	//go:embed templates/email_change.txt
+	emailChangeText string
+)
+
+var (
+	passwordResetHTMLTemplate = htmltemplate.Must(htmltemplate.New("password_reset.html").Parse(passwordResetHTML))
+	passwordResetTextTemplate = texttemplate.Must(texttemplate.New("password_reset.txt").Parse(passwordResetText))
+	emailChangeHTMLTemplate   = htmltemplate.Must(htmltemplate.New("email_change.html").Parse(emailChangeHTML))
+	emailChangeTextTemplate   = texttemplate.Must(texttemplate.New("email_change.txt").Parse(emailChangeText))
+)
+
+// PasswordResetNotifier delivers password reset links to users
+type PasswordResetNotifier interface {
+	NotifyPasswordReset(ctx context.Context, user *domain.User, link string, validFor time.Duration) error
+}
+
+// EmailChangeNotifier delivers links that confirm a new email to that email
+type EmailChangeNotifier interface {
+	NotifyEmailChange(ctx context.Context, user *domain.User, newEmail, link string, validFor time.Duration) error
+}
+
+// Notifier delivers the links of all account flows
+type Notifier interface {
+	PasswordResetNotifier
+	EmailChangeNotifier
+}
+
+// accountLinkEmail is the data of the account link templates
+type accountLinkEmail struct {
+	Name     string
+	Email    string
+	Link     string
+	ValidFor string
+}
+
+type emailNotifier struct {
+	mailer mailer.Mailer
+}
+
+// NewEmailNotifier creates a notifier that emails links through sender
+func NewEmailNotifier(sender mailer.Mailer) Notifier {
+	return &emailNotifier{mailer: sender}
+}
+
+func (n *emailNotifier) NotifyPasswordReset(ctx context.Context, user *domain.User, link string, validFor time.Duration) error {
+	data := accountLinkEmail{Name: user.Name, Email: user.Email, Link: link, ValidFor: formatValidity(validFor)}
+	return n.send(ctx, user.Email, "Reset your Finance Tracker password",
+		passwordResetHTMLTemplate, passwordResetTextTemplate, data)
+}
+
+func (n *emailNotifier) NotifyEmailChange(ctx context.Context, user *domain.User, newEmail, link string, validFor time.Duration) error {
+	data := accountLinkEmail{Name: user.Name, Email: newEmail, Link: link, ValidFor: formatValidity(validFor)}
+	return n.send(ctx, newEmail, "Confirm your new Finance Tracker email",
+		emailChangeHTMLTemplate, emailChangeTextTemplate, data)
+}
+
+// send renders both templates with data and mails them to to
+func (n *emailNotifier) send(ctx context.Context, to, subject string, htmlTemplate *htmltemplate.Template, textTemplate *texttemplate.Template, data accountLinkEmail) error {
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return err
+	}
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return err
+	}
+
+	return n.mailer.Send(ctx, &mailer.Message{
+		To:      []string{to},
+		Subject: subject,
+		HTML:    html.String(),
+		Text:    text.String(),
+	})
+}
+
+type logNotifier struct{}
+
+// NewLogNotifier creates a notifier that writes links to the log instead
+// of sending them, for development without SMTP. Anyone who can read the
+// log can take over accounts, so never use it in production.
+func NewLogNotifier() Notifier {
+	return logNotifier{}
+}
+
+func (logNotifier) NotifyPasswordReset(ctx context.Context, user *domain.User, link string, validFor time.Duration) error {
+	log := logger.Get()
+	log.Warn().
+		Int64("user_id", user.ID).
+		Str("email", user.Email).
+		Str("link", link).
+		Dur("valid_for", validFor).
+		Msg("Password reset link (log notifier, development only)")
+	return nil
+}
+
+func (logNotifier) NotifyEmailChange(ctx context.Context, user *domain.User, newEmail, link string, validFor time.Duration) error {
+	log := logger.Get()
+	log.Warn().
+		Int64("user_id", user.ID).
+		Str("email", newEmail).
+		Str("link", link).
+		Dur("valid_for", validFor).
+		Msg("Email confirmation link (log notifier, development only)")
+	return nil
+}
+
+// formatValidity renders a link lifetime like "1 hour" or "30 minutes"
+func formatValidity(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return plural(int(d/time.Hour), "hour")
+	}
+	return plural(int(d.Round(time.Minute)/time.Minute), "minute")
+}
+
+func plural(n int, unit string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", unit)
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
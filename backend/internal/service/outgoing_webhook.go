@@ -0,0 +1,567 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+const (
+	// webhookRetryBase is the wait after the first failed attempt; it doubles with every further failure
+	webhookRetryBase = 30 * time.Second
+	// webhookRetryMax caps the wait between attempts
+	webhookRetryMax = 6 * time.Hour
+	// webhookClaimLease keeps other workers off a delivery while it is being sent
+	webhookClaimLease = 5 * time.Minute
+	// webhookBatchSize is the number of deliveries sent per run
+	webhookBatchSize = 50
+)
+
+// OutgoingWebhookService manages webhook endpoints and delivers transaction
+// events to them. It is registered as a transaction hook after the anomaly
+// service, so it sees the anomalies flagged on new transactions.
+type OutgoingWebhookService interface {
+	CreateEndpoint(userID int64, req *domain.WebhookEndpointRequest) (*domain.WebhookEndpointCreated, error)
+	ListEndpoints(userID int64) ([]domain.WebhookEndpoint, error)
+	GetEndpoint(userID, id int64) (*domain.WebhookEndpoint, error)
+	UpdateEndpoint(userID, id int64, req *domain.WebhookEndpointRequest) (*domain.WebhookEndpoint, error)
+	DeleteEndpoint(userID, id int64) error
+	ListDeliveries(userID, endpointID int64, params domain.WebhookDeliveryQueryParams) ([]domain.WebhookDelivery, error)
+	Redeliver(userID, endpointID, deliveryID int64) (*domain.WebhookDelivery, error)
+	DeliverDue(ctx context.Context) (*domain.WebhookRunResult, error)
+	OnTransactionsCreated(transactions []domain.Transaction)
+	OnTransactionUpdated(previous, updated domain.Transaction)
+}
+
+type outgoingWebhookService struct {
+	repo        repository.OutgoingWebhookRepository
+	budgetRepo  repository.BudgetRepository
+	anomalyRepo repository.AnomalyRepository
+	client      *http.Client
+	policy      *security.AddressPolicy
+	maxAttempts int
+	now         func() time.Time
+}
+
+// NewOutgoingWebhookService creates a new outgoing webhook service. Each
+// attempt is bounded by timeout; a delivery fails after maxAttempts attempts.
+// Endpoints may only be at addresses policy allows, both when they are
+// registered and when each delivery connects.
+func NewOutgoingWebhookService(
+	repo repository.OutgoingWebhookRepository,
+	budgetRepo repository.BudgetRepository,
+	anomalyRepo repository.AnomalyRepository,
+	policy *security.AddressPolicy,
+	timeout time.Duration,
+	maxAttempts int,
+) OutgoingWebhookService {
+	if maxAttempts <= 0 {
+		maxAttempts = 8
+	}
+
+	dialer := &net.Dialer{Timeout: timeout, Control: policy.Control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Connect directly, so the policy sees the endpoint's address rather than a proxy's
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &outgoingWebhookService{
+		repo:        repo,
+		budgetRepo:  budgetRepo,
+		anomalyRepo: anomalyRepo,
+		policy:      policy,
+		client: &http.Client{
+			Timeout:   timeout,
+			Transport: transport,
+			// A redirect is reported as a failed attempt rather than followed
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+	}
+}
+
+// CreateEndpoint registers an endpoint with a new signing secret
+func (s *outgoingWebhookService) CreateEndpoint(userID int64, req *domain.WebhookEndpointRequest) (*domain.WebhookEndpointCreated, error) {
+	if err := s.validateEndpoint(req); err != nil {
+		return nil, err
+	}
+
+	secret, err := security.NewAPIKeyGenerator().GenerateWithPrefix("whsec")
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := &domain.WebhookEndpoint{UserID: userID, Secret: secret, Active: true}
+	applyWebhookEndpointRequest(endpoint, req)
+	if err := s.repo.CreateEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+
+	return &domain.WebhookEndpointCreated{WebhookEndpoint: *endpoint, Secret: secret}, nil
+}
+
+func (s *outgoingWebhookService) ListEndpoints(userID int64) ([]domain.WebhookEndpoint, error) {
+	return s.repo.ListEndpoints(userID)
+}
+
+func (s *outgoingWebhookService) GetEndpoint(userID, id int64) (*domain.WebhookEndpoint, error) {
+	return s.repo.FindEndpoint(userID, id)
+}
+
+// UpdateEndpoint changes the URL, events or state of an endpoint; the secret is kept
+func (s *outgoingWebhookService) UpdateEndpoint(userID, id int64, req *domain.WebhookEndpointRequest) (*domain.WebhookEndpoint, error) {
+	if err := s.validateEndpoint(req); err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.repo.FindEndpoint(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	applyWebhookEndpointRequest(endpoint, req)
+	if err := s.repo.UpdateEndpoint(endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// validateEndpoint validates the request and rejects URLs whose host is an
+// address the policy forbids
+func (s *outgoingWebhookService) validateEndpoint(req *domain.WebhookEndpointRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	parsed, err := url.Parse(req.URL)
+	if err != nil {
+		return err
+	}
+	if err := s.policy.CheckHost(parsed.Hostname()); err != nil {
+		return &domain.ValidationError{
+			Field:   "url",
+			Message: "url must not point to a loopback, private or link-local address",
+		}
+	}
+	return nil
+}
+
+func (s *outgoingWebhookService) DeleteEndpoint(userID, id int64) error {
+	return s.repo.DeleteEndpoint(userID, id)
+}
+
+// ListDeliveries returns the delivery log of an endpoint, newest first
+func (s *outgoingWebhookService) ListDeliveries(userID, endpointID int64, params domain.WebhookDeliveryQueryParams) ([]domain.WebhookDelivery, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.FindEndpoint(userID, endpointID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListDeliveries(endpointID, params)
+}
+
+// Redeliver queues the event of a past delivery again as a new delivery
+func (s *outgoingWebhookService) Redeliver(userID, endpointID, deliveryID int64) (*domain.WebhookDelivery, error) {
+	if _, err := s.repo.FindEndpoint(userID, endpointID); err != nil {
+		return nil, err
+	}
+	original, err := s.repo.FindDelivery(endpointID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	redelivery := domain.WebhookDelivery{
+		EndpointID:    endpointID,
+		EventID:       original.EventID,
+		EventType:     original.EventType,
+		Payload:       original.Payload,
+		Status:        domain.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		RedeliveryOf:  &original.ID,
+	}
+	deliveries := []domain.WebhookDelivery{redelivery}
+	if err := s.repo.CreateDeliveries(deliveries); err != nil {
+		return nil, err
+	}
+	return &deliveries[0], nil
+}
+
+// DeliverDue sends the deliveries whose next attempt is due. Failed attempts
+// are retried with exponential backoff until maxAttempts is reached.
+// Cancelling ctx stops the run between deliveries.
+func (s *outgoingWebhookService) DeliverDue(ctx context.Context) (*domain.WebhookRunResult, error) {
+	result := &domain.WebhookRunResult{}
+
+	deliveries, err := s.repo.ClaimDueDeliveries(s.now().UTC(), webhookClaimLease, webhookBatchSize)
+	if err != nil {
+		return result, err
+	}
+
+	for i := range deliveries {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		delivery := &deliveries[i]
+		s.attempt(ctx, delivery)
+		if err := s.repo.SaveDelivery(delivery); err != nil {
+			return result, err
+		}
+
+		switch delivery.Status {
+		case domain.WebhookDeliveryDelivered:
+			result.Delivered++
+		case domain.WebhookDeliveryFailed:
+			result.Failed++
+		default:
+			result.Retrying++
+		}
+	}
+
+	return result, nil
+}
+
+// attempt sends a delivery once and records the outcome on it
+func (s *outgoingWebhookService) attempt(ctx context.Context, delivery *domain.WebhookDelivery) {
+	delivery.Attempts++
+	delivery.Error = ""
+	delivery.ResponseStatus = 0
+	delivery.ResponseBody = ""
+
+	started := s.now()
+	err := s.post(ctx, delivery, started)
+	delivery.DurationMs = s.now().Sub(started).Milliseconds()
+
+	if err == nil {
+		delivered := s.now().UTC()
+		delivery.Status = domain.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &delivered
+		delivery.NextAttemptAt = nil
+		return
+	}
+
+	delivery.Error = err.Error()
+	if delivery.Attempts >= s.maxAttempts {
+		delivery.Status = domain.WebhookDeliveryFailed
+		delivery.NextAttemptAt = nil
+		return
+	}
+	next := s.now().UTC().Add(webhookBackoff(delivery.Attempts))
+	delivery.NextAttemptAt = &next
+}
+
+// post sends the signed payload to the endpoint. Only 2xx responses count as
+// delivered; the body of other responses is not kept in the delivery log.
+func (s *outgoingWebhookService) post(ctx context.Context, delivery *domain.WebhookDelivery, sentAt time.Time) error {
+	endpoint := delivery.Endpoint
+	if endpoint == nil || !endpoint.Active {
+		return errors.New("endpoint is disabled")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return err
+	}
+	timestamp := sentAt.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "personal-finance-tracker-webhooks/1.0")
+	req.Header.Set("X-Webhook-Event", string(delivery.EventType))
+	req.Header.Set("X-Webhook-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", security.SignWebhook(endpoint.Secret, timestamp, delivery.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	delivery.ResponseStatus = resp.StatusCode
+	delivery.ResponseBody = ""
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, domain.MaxWebhookResponseLength))
+	delivery.ResponseBody = string(body)
+	return nil
+}
+
+// OnTransactionsCreated queues transaction.created events for new
+// transactions, anomaly.detected for those flagged by anomaly detection and
+// budget.exceeded for budgets they pushed over their amount. Failures are
+// logged and never fail the incoming webhook.
+func (s *outgoingWebhookService) OnTransactionsCreated(transactions []domain.Transaction) {
+	s.publish(func(wants func(domain.WebhookEventType) bool) ([]domain.WebhookEvent, error) {
+		var events []domain.WebhookEvent
+		if wants(domain.WebhookEventTransactionCreated) {
+			for _, tx := range transactions {
+				events = append(events, s.newEvent(domain.WebhookEventTransactionCreated, domain.TransactionEventData{Transaction: tx}))
+			}
+		}
+
+		if wants(domain.WebhookEventAnomalyDetected) {
+			anomalous, err := s.anomalousTransactions(transactions)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range anomalous {
+				events = append(events, s.newEvent(domain.WebhookEventAnomalyDetected, item))
+			}
+		}
+
+		if wants(domain.WebhookEventBudgetExceeded) {
+			exceeded, err := s.exceededBudgets(transactions, nil)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, exceeded...)
+		}
+
+		return events, nil
+	})
+}
+
+// OnTransactionUpdated queues a transaction.updated event and budget.exceeded
+// for budgets the change pushed over their amount
+func (s *outgoingWebhookService) OnTransactionUpdated(previous, updated domain.Transaction) {
+	s.publish(func(wants func(domain.WebhookEventType) bool) ([]domain.WebhookEvent, error) {
+		var events []domain.WebhookEvent
+		if wants(domain.WebhookEventTransactionUpdated) {
+			events = append(events, s.newEvent(domain.WebhookEventTransactionUpdated, domain.TransactionEventData{
+				Transaction: updated,
+				Previous:    &previous,
+			}))
+		}
+
+		if wants(domain.WebhookEventBudgetExceeded) {
+			exceeded, err := s.exceededBudgets([]domain.Transaction{updated}, []domain.Transaction{previous})
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, exceeded...)
+		}
+
+		return events, nil
+	})
+}
+
+// publish queues a delivery of every event collected by build for each
+// active endpoint subscribed to it. build is skipped when no endpoint is
+// active, and can skip the lookups of events nobody subscribed to.
+func (s *outgoingWebhookService) publish(build func(wants func(domain.WebhookEventType) bool) ([]domain.WebhookEvent, error)) {
+	log := logger.Get()
+
+	endpoints, err := s.repo.ListActiveEndpoints()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load webhook endpoints")
+		return
+	}
+	if len(endpoints) == 0 {
+		return
+	}
+
+	wants := func(eventType domain.WebhookEventType) bool {
+		for i := range endpoints {
+			if endpoints[i].Subscribes(eventType) {
+				return true
+			}
+		}
+		return false
+	}
+	events, err := build(wants)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to collect webhook events")
+		return
+	}
+
+	now := s.now().UTC()
+	var deliveries []domain.WebhookDelivery
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			log.Error().Err(err).Str("event", string(event.Type)).Msg("Failed to encode webhook event")
+			continue
+		}
+		for i := range endpoints {
+			if !endpoints[i].Subscribes(event.Type) {
+				continue
+			}
+			deliveries = append(deliveries, domain.WebhookDelivery{
+				EndpointID:    endpoints[i].ID,
+				EventID:       event.ID,
+				EventType:     event.Type,
+				Payload:       payload,
+				Status:        domain.WebhookDeliveryPending,
+				NextAttemptAt: &now,
+			})
+		}
+	}
+
+	if err := s.repo.CreateDeliveries(deliveries); err != nil {
+		log.Error().Err(err).Int("deliveries", len(deliveries)).Msg("Failed to queue webhook deliveries")
+	}
+}
+
+// newEvent wraps data in an event envelope with a unique ID
+func (s *outgoingWebhookService) newEvent(eventType domain.WebhookEventType, data interface{}) domain.WebhookEvent {
+	return domain.WebhookEvent{
+		ID:        "evt_" + uuid.NewString(),
+		Type:      eventType,
+		CreatedAt: s.now().UTC(),
+		Data:      data,
+	}
+}
+
+// anomalousTransactions returns the flagged transactions with the reasons they were flagged
+func (s *outgoingWebhookService) anomalousTransactions(transactions []domain.Transaction) ([]domain.AnomalousTransaction, error) {
+	var flagged []domain.Transaction
+	for _, tx := range transactions {
+		if tx.Anomalous {
+			flagged = append(flagged, tx)
+		}
+	}
+	if len(flagged) == 0 {
+		return nil, nil
+	}
+
+	since := flagged[0].TransactionDate
+	for _, tx := range flagged[1:] {
+		if tx.TransactionDate.Before(since) {
+			since = tx.TransactionDate
+		}
+	}
+	anomalies, err := s.anomalyRepo.ListSince(since, "")
+	if err != nil {
+		return nil, err
+	}
+
+	reasons := make(map[int64][]domain.TransactionAnomaly)
+	for _, anomaly := range anomalies {
+		reasons[anomaly.TransactionID] = append(reasons[anomaly.TransactionID], anomaly)
+	}
+
+	result := make([]domain.AnomalousTransaction, 0, len(flagged))
+	for _, tx := range flagged {
+		result = append(result, domain.AnomalousTransaction{Transaction: tx, Reasons: reasons[tx.ID]})
+	}
+	return result, nil
+}
+
+// exceededBudgets returns a budget.exceeded event for every budget that is
+// over its amount in the month of an added expense but was not before the
+// change. removed holds the expenses the change replaced.
+func (s *outgoingWebhookService) exceededBudgets(added, removed []domain.Transaction) ([]domain.WebhookEvent, error) {
+	months := make(map[string]time.Time)
+	var order []string
+	for _, tx := range added {
+		if tx.Type != domain.TransactionTypeOut {
+			continue
+		}
+		month := tx.TransactionDate.UTC().Format(domain.MonthLayout)
+		if _, ok := months[month]; !ok {
+			months[month] = tx.TransactionDate.UTC()
+			order = append(order, month)
+		}
+	}
+	if len(order) == 0 {
+		return nil, nil
+	}
+
+	budgets, err := s.budgetRepo.List()
+	if err != nil || len(budgets) == 0 {
+		return nil, err
+	}
+
+	var events []domain.WebhookEvent
+	for _, month := range order {
+		start, end := domain.MonthPeriod(months[month])
+		spending, err := s.budgetRepo.GetCategorySpending(start, end)
+		if err != nil {
+			return nil, err
+		}
+		progress, _, _ := summarizeBudgets(budgets, spending, end.Day(), end.Day())
+
+		for i, budget := range budgets {
+			if progress[i].Status != domain.BudgetStatusExceeded {
+				continue
+			}
+
+			// Spending of the month without the change
+			before := progress[i].Spent
+			var last *domain.Transaction
+			for j := range added {
+				if budgetCovers(budget, &added[j], month) {
+					before -= added[j].Amount
+					last = &added[j]
+				}
+			}
+			for j := range removed {
+				if budgetCovers(budget, &removed[j], month) {
+					before += removed[j].Amount
+				}
+			}
+			if last == nil || before > budget.Amount+amountTolerance {
+				continue
+			}
+
+			events = append(events, s.newEvent(domain.WebhookEventBudgetExceeded, domain.BudgetExceededEventData{
+				Period:      month,
+				Budget:      progress[i],
+				Transaction: *last,
+			}))
+		}
+	}
+
+	return events, nil
+}
+
+// budgetCovers reports whether an expense counts towards a budget in month
+func budgetCovers(budget domain.Budget, tx *domain.Transaction, month string) bool {
+	return tx.Type == domain.TransactionTypeOut &&
+		tx.TransactionDate.UTC().Format(domain.MonthLayout) == month &&
+		tx.Category == budget.Category &&
+		(budget.Source == "" || tx.Source == budget.Source)
+}
+
+// webhookBackoff returns the wait after the given number of failed attempts
+func webhookBackoff(attempts int) time.Duration {
+	wait := webhookRetryBase
+	for i := 1; i < attempts; i++ {
+		wait *= 2
+		if wait >= webhookRetryMax {
+			return webhookRetryMax
+		}
+	}
+	return wait
+}
+
+// applyWebhookEndpointRequest copies a validated request onto an endpoint
+func applyWebhookEndpointRequest(endpoint *domain.WebhookEndpoint, req *domain.WebhookEndpointRequest) {
+	endpoint.URL = req.URL
+	endpoint.Description = req.Description
+	endpoint.Events = req.Events
+	if req.Active != nil {
+		endpoint.Active = *req.Active
+	}
+}
@@ -0,0 +1,449 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+// mockOutgoingWebhookRepository is a mock implementation of OutgoingWebhookRepository for testing
+type mockOutgoingWebhookRepository struct {
+	endpoints  []domain.WebhookEndpoint
+	deliveries []domain.WebhookDelivery
+	due        []domain.WebhookDelivery
+	saved      []domain.WebhookDelivery
+}
+
+func (m *mockOutgoingWebhookRepository) CreateEndpoint(endpoint *domain.WebhookEndpoint) error {
+	endpoint.ID = int64(len(m.endpoints) + 1)
+	m.endpoints = append(m.endpoints, *endpoint)
+	return nil
+}
+
+func (m *mockOutgoingWebhookRepository) FindEndpoint(userID, id int64) (*domain.WebhookEndpoint, error) {
+	for _, endpoint := range m.endpoints {
+		if endpoint.ID == id && endpoint.UserID == userID {
+			return &endpoint, nil
+		}
+	}
+	return nil, repository.ErrWebhookEndpointNotFound
+}
+
+func (m *mockOutgoingWebhookRepository) ListEndpoints(userID int64) ([]domain.WebhookEndpoint, error) {
+	return m.endpoints, nil
+}
+
+func (m *mockOutgoingWebhookRepository) UpdateEndpoint(endpoint *domain.WebhookEndpoint) error {
+	m.endpoints[endpoint.ID-1] = *endpoint
+	return nil
+}
+
+func (m *mockOutgoingWebhookRepository) DeleteEndpoint(userID, id int64) error {
+	return nil
+}
+
+func (m *mockOutgoingWebhookRepository) ListActiveEndpoints() ([]domain.WebhookEndpoint, error) {
+	var active []domain.WebhookEndpoint
+	for _, endpoint := range m.endpoints {
+		if endpoint.Active {
+			active = append(active, endpoint)
+		}
+	}
+	return active, nil
+}
+
+func (m *mockOutgoingWebhookRepository) CreateDeliveries(deliveries []domain.WebhookDelivery) error {
+	for i := range deliveries {
+		deliveries[i].ID = int64(len(m.deliveries) + 1)
+		m.deliveries = append(m.deliveries, deliveries[i])
+	}
+	return nil
+}
+
+func (m *mockOutgoingWebhookRepository) ClaimDueDeliveries(now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error) {
+	due := m.due
+	m.due = nil
+	return due, nil
+}
+
+func (m *mockOutgoingWebhookRepository) SaveDelivery(delivery *domain.WebhookDelivery) error {
+	m.saved = append(m.saved, *delivery)
+	return nil
+}
+
+func (m *mockOutgoingWebhookRepository) ListDeliveries(endpointID int64, params domain.WebhookDeliveryQueryParams) ([]domain.WebhookDelivery, error) {
+	return m.deliveries, nil
+}
+
+func (m *mockOutgoingWebhookRepository) FindDelivery(endpointID, id int64) (*domain.WebhookDelivery, error) {
+	for _, delivery := range m.deliveries {
+		if delivery.ID == id && delivery.EndpointID == endpointID {
+			return &delivery, nil
+		}
+	}
+	return nil, repository.ErrWebhookDeliveryNotFound
+}
+
+// newTestOutgoingWebhookService creates an outgoing webhook service with a
+// fixed clock that may deliver to test servers on loopback addresses
+func newTestOutgoingWebhookService(repo *mockOutgoingWebhookRepository, budgetRepo *mockBudgetRepository, anomalyRepo *mockAnomalyRepository, now time.Time) *outgoingWebhookService {
+	policy, _ := security.NewAddressPolicy([]string{"127.0.0.0/8", "::1/128"})
+	svc := NewOutgoingWebhookService(repo, budgetRepo, anomalyRepo, policy, 5*time.Second, 3).(*outgoingWebhookService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func subscribedEndpoint(id int64, events ...domain.WebhookEventType) domain.WebhookEndpoint {
+	return domain.WebhookEndpoint{ID: id, UserID: 1, URL: "https://example.com/hook", Secret: "whsec_test", Active: true, Events: events}
+}
+
+// queuedEvents decodes the payloads of queued deliveries
+func queuedEvents(t *testing.T, deliveries []domain.WebhookDelivery) []map[string]interface{} {
+	t.Helper()
+	events := make([]map[string]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		var event map[string]interface{}
+		require.NoError(t, json.Unmarshal(delivery.Payload, &event))
+		assert.Equal(t, string(delivery.EventType), event["type"])
+		assert.Equal(t, delivery.EventID, event["id"])
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestOutgoingWebhookService_CreateEndpoint(t *testing.T) {
+	repo := &mockOutgoingWebhookRepository{}
+	svc := newTestOutgoingWebhookService(repo, &mockBudgetRepository{}, &mockAnomalyRepository{}, time.Now())
+
+	created, err := svc.CreateEndpoint(7, &domain.WebhookEndpointRequest{
+		URL:    "https://example.com/hook",
+		Events: []domain.WebhookEventType{domain.WebhookEventBudgetExceeded},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Secret, "whsec_"))
+	assert.Equal(t, created.Secret, repo.endpoints[0].Secret)
+	assert.Equal(t, int64(7), created.UserID)
+	assert.True(t, created.Active, "endpoints are active by default")
+
+	body, err := json.Marshal(created)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(body), created.Secret), "secret is only encoded once")
+}
+
+func TestOutgoingWebhookService_CreateEndpoint_InternalAddress(t *testing.T) {
+	policy, err := security.NewAddressPolicy(nil)
+	require.NoError(t, err)
+	repo := &mockOutgoingWebhookRepository{}
+	svc := NewOutgoingWebhookService(repo, &mockBudgetRepository{}, &mockAnomalyRepository{}, policy, time.Second, 3)
+
+	for _, target := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://[::1]/hook",
+		"http://10.0.0.12/hook",
+		"http://localhost:9090/metrics",
+	} {
+		_, err := svc.CreateEndpoint(7, &domain.WebhookEndpointRequest{
+			URL:    target,
+			Events: []domain.WebhookEventType{domain.WebhookEventBudgetExceeded},
+		})
+
+		var validationErr *domain.ValidationError
+		if assert.ErrorAs(t, err, &validationErr, target) {
+			assert.Equal(t, "url", validationErr.Field)
+		}
+	}
+	assert.Empty(t, repo.endpoints)
+}
+
+func TestOutgoingWebhookService_UpdateEndpoint_KeepsSecret(t *testing.T) {
+	repo := &mockOutgoingWebhookRepository{endpoints: []domain.WebhookEndpoint{subscribedEndpoint(1, domain.WebhookEventTransactionCreated)}}
+	svc := newTestOutgoingWebhookService(repo, &mockBudgetRepository{}, &mockAnomalyRepository{}, time.Now())
+	off := false
+
+	endpoint, err := svc.UpdateEndpoint(1, 1, &domain.WebhookEndpointRequest{
+		URL:    "https://example.com/v2",
+		Events: []domain.WebhookEventType{domain.WebhookEventAnomalyDetected},
+		Active: &off,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/v2", endpoint.URL)
+	assert.False(t, endpoint.Active)
+	assert.Equal(t, "whsec_test", repo.endpoints[0].Secret)
+
+	_, err = svc.UpdateEndpoint(2, 1, &domain.WebhookEndpointRequest{URL: "https://example.com", Events: endpoint.Events})
+	assert.ErrorIs(t, err, repository.ErrWebhookEndpointNotFound, "other users' endpoints are hidden")
+}
+
+func TestOutgoingWebhookService_OnTransactionsCreated(t *testing.T) {
+	repo := &mockOutgoingWebhookRepository{endpoints: []domain.WebhookEndpoint{
+		subscribedEndpoint(1, domain.WebhookEventTransactionCreated, domain.WebhookEventAnomalyDetected),
+		subscribedEndpoint(2, domain.WebhookEventTransactionUpdated),
+	}}
+	anomalyRepo := &mockAnomalyRepository{anomalies: []domain.TransactionAnomaly{
+		{TransactionID: 2, Reason: domain.AnomalyReasonCategoryAmount},
+	}}
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	svc := newTestOutgoingWebhookService(repo, &mockBudgetRepository{}, anomalyRepo, now)
+
+	svc.OnTransactionsCreated([]domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeIn, Amount: 5000, TransactionDate: now},
+		{ID: 2, Type: domain.TransactionTypeOut, Amount: 4500, Anomalous: true, TransactionDate: now},
+	})
+
+	require.Len(t, repo.deliveries, 3)
+	events := queuedEvents(t, repo.deliveries)
+	assert.Equal(t, []domain.WebhookEventType{
+		domain.WebhookEventTransactionCreated,
+		domain.WebhookEventTransactionCreated,
+		domain.WebhookEventAnomalyDetected,
+	}, []domain.WebhookEventType{repo.deliveries[0].EventType, repo.deliveries[1].EventType, repo.deliveries[2].EventType})
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, int64(1), delivery.EndpointID, "endpoint 2 is not subscribed")
+		assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+		assert.Equal(t, now, *delivery.NextAttemptAt)
+	}
+	assert.NotEqual(t, repo.deliveries[0].EventID, repo.deliveries[1].EventID)
+
+	anomaly := events[2]["data"].(map[string]interface{})
+	assert.Equal(t, float64(2), anomaly["transaction"].(map[string]interface{})["id"])
+	assert.Len(t, anomaly["reasons"], 1)
+}
+
+func TestOutgoingWebhookService_OnTransactionsCreated_NoEndpoints(t *testing.T) {
+	repo := &mockOutgoingWebhookRepository{}
+	budgetRepo := &mockBudgetRepository{listErr: errors.New("must not be queried")}
+	svc := newTestOutgoingWebhookService(repo, budgetRepo, &mockAnomalyRepository{}, time.Now())
+
+	svc.OnTransactionsCreated([]domain.Transaction{{ID: 1, Type: domain.TransactionTypeOut, Amount: 10}})
+
+	assert.Empty(t, repo.deliveries)
+}
+
+func TestOutgoingWebhookService_BudgetExceeded(t *testing.T) {
+	now := time.Date(2026, 3, 20, 8, 0, 0, 0, time.UTC)
+	budgetRepo := &mockBudgetRepository{
+		budgets: []domain.Budget{
+			{ID: 1, Category: "Food", Amount: 1000},
+			{ID: 2, Category: "Housing", Amount: 500},
+			{ID: 3, Category: "Shopping", Source: "Momo", Amount: 100},
+		},
+		// Month to date, including the new expenses
+		spending: []domain.CategorySpending{
+			{Category: "Food", Source: "Bank", Amount: 1100},
+			{Category: "Housing", Source: "Bank", Amount: 900},
+			{Category: "Shopping", Source: "Bank", Amount: 300},
+		},
+	}
+	repo := &mockOutgoingWebhookRepository{endpoints: []domain.WebhookEndpoint{subscribedEndpoint(1, domain.WebhookEventBudgetExceeded)}}
+	svc := newTestOutgoingWebhookService(repo, budgetRepo, &mockAnomalyRepository{}, now)
+
+	svc.OnTransactionsCreated([]domain.Transaction{
+		// Pushes Food from 950 to 1100
+		{ID: 1, Type: domain.TransactionTypeOut, Category: "Food", Source: "Bank", Amount: 150, TransactionDate: now},
+		// Housing was already over budget at 850
+		{ID: 2, Type: domain.TransactionTypeOut, Category: "Housing", Source: "Bank", Amount: 50, TransactionDate: now},
+		// Shopping at another source does not count
+		{ID: 3, Type: domain.TransactionTypeOut, Category: "Shopping", Source: "Bank", Amount: 300, TransactionDate: now},
+	})
+
+	require.Len(t, repo.deliveries, 1)
+	data := queuedEvents(t, repo.deliveries)[0]["data"].(map[string]interface{})
+	assert.Equal(t, "2026-03", data["period"])
+	assert.Equal(t, "Food", data["budget"].(map[string]interface{})["category"])
+	assert.Equal(t, float64(1100), data["budget"].(map[string]interface{})["spent"])
+	assert.Equal(t, float64(1), data["transaction"].(map[string]interface{})["id"])
+}
+
+func TestOutgoingWebhookService_OnTransactionUpdated(t *testing.T) {
+	now := time.Date(2026, 3, 20, 8, 0, 0, 0, time.UTC)
+	budgetRepo := &mockBudgetRepository{
+		budgets:  []domain.Budget{{ID: 1, Category: "Food", Amount: 1000}},
+		spending: []domain.CategorySpending{{Category: "Food", Source: "Bank", Amount: 1200}},
+	}
+	repo := &mockOutgoingWebhookRepository{endpoints: []domain.WebhookEndpoint{
+		subscribedEndpoint(1, domain.WebhookEventTransactionUpdated, domain.WebhookEventBudgetExceeded),
+	}}
+	svc := newTestOutgoingWebhookService(repo, budgetRepo, &mockAnomalyRepository{}, now)
+
+	previous := domain.Transaction{ID: 5, Type: domain.TransactionTypeOut, Category: "Food", Source: "Bank", Amount: 100, TransactionDate: now}
+	updated := previous
+	updated.Amount = 300
+	// Food went from 1000 to 1200
+	svc.OnTransactionUpdated(previous, updated)
+
+	require.Len(t, repo.deliveries, 2)
+	events := queuedEvents(t, repo.deliveries)
+	assert.Equal(t, "transaction.updated", events[0]["type"])
+	data := events[0]["data"].(map[string]interface{})
+	assert.Equal(t, float64(300), data["transaction"].(map[string]interface{})["amount"])
+	assert.Equal(t, float64(100), data["previous"].(map[string]interface{})["amount"])
+	assert.Equal(t, "budget.exceeded", events[1]["type"])
+}
+
+func TestOutgoingWebhookService_DeliverDue(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	endpoint := subscribedEndpoint(1, domain.WebhookEventTransactionCreated)
+	endpoint.URL = server.URL
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	repo := &mockOutgoingWebhookRepository{due: []domain.WebhookDelivery{{
+		ID: 9, EndpointID: 1, Endpoint: &endpoint, EventID: "evt_1", EventType: domain.WebhookEventTransactionCreated,
+		Payload: json.RawMessage(`{"id":"evt_1","type":"transaction.created"}`), Status: domain.WebhookDeliveryPending, NextAttemptAt: &now,
+	}}}
+	svc := newTestOutgoingWebhookService(repo, &mockBudgetRepository{}, &mockAnomalyRepository{}, now)
+
+	result, err := svc.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.WebhookRunResult{Delivered: 1}, result)
+	require.NotNil(t, received)
+	assert.Equal(t, "transaction.created", received.Header.Get("X-Webhook-Event"))
+	assert.Equal(t, "9", received.Header.Get("X-Webhook-Delivery"))
+	timestamp, err := strconv.ParseInt(received.Header.Get("X-Webhook-Timestamp"), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, now.Unix(), timestamp)
+	assert.True(t, security.VerifyWebhook("whsec_test", received.Header.Get("X-Webhook-Signature"), timestamp, body))
+
+	require.Len(t, repo.saved, 1)
+	saved := repo.saved[0]
+	assert.Equal(t, domain.WebhookDeliveryDelivered, saved.Status)
+	assert.Equal(t, 1, saved.Attempts)
+	assert.Equal(t, http.StatusOK, saved.ResponseStatus)
+	assert.Equal(t, "ok", saved.ResponseBody)
+	assert.Nil(t, saved.NextAttemptAt)
+	assert.NotNil(t, saved.DeliveredAt)
+}
+
+func TestOutgoingWebhookService_DeliverDue_RetriesThenFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	endpoint := subscribedEndpoint(1, domain.WebhookEventTransactionCreated)
+	endpoint.URL = server.URL
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	delivery := domain.WebhookDelivery{
+		ID: 9, EndpointID: 1, Endpoint: &endpoint, EventType: domain.WebhookEventTransactionCreated,
+		Payload: json.RawMessage(`{}`), Status: domain.WebhookDeliveryPending, Attempts: 1,
+	}
+	repo := &mockOutgoingWebhookRepository{due: []domain.WebhookDelivery{delivery}}
+	svc := newTestOutgoingWebhookService(repo, &mockBudgetRepository{}, &mockAnomalyRepository{}, now)
+
+	// Second of three attempts
+	result, err := svc.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &domain.WebhookRunResult{Retrying: 1}, result)
+	saved := repo.saved[0]
+	assert.Equal(t, domain.WebhookDeliveryPending, saved.Status)
+	assert.Equal(t, http.StatusBadGateway, saved.ResponseStatus)
+	assert.Equal(t, "endpoint responded with status 502", saved.Error)
+	assert.Empty(t, saved.ResponseBody, "bodies of failed responses are not kept")
+	assert.Equal(t, now.Add(time.Minute), *saved.NextAttemptAt)
+
+	// Third and last attempt
+	repo.due = []domain.WebhookDelivery{saved}
+	result, err = svc.DeliverDue(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &domain.WebhookRunResult{Failed: 1}, result)
+	assert.Equal(t, domain.WebhookDeliveryFailed, repo.saved[1].Status)
+	assert.Nil(t, repo.saved[1].NextAttemptAt)
+}
+
+func TestOutgoingWebhookService_DeliverDue_InternalAddress(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.Write([]byte("internal"))
+	}))
+	defer server.Close()
+
+	// A host name that passed validation may resolve to an internal address
+	// later; the connection itself is refused
+	endpoint := subscribedEndpoint(1, domain.WebhookEventTransactionCreated)
+	endpoint.URL = strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	repo := &mockOutgoingWebhookRepository{due: []domain.WebhookDelivery{{
+		ID: 9, EndpointID: 1, Endpoint: &endpoint, EventType: domain.WebhookEventTransactionCreated,
+		Payload: json.RawMessage(`{}`), Status: domain.WebhookDeliveryPending,
+	}}}
+	policy, err := security.NewAddressPolicy(nil)
+	require.NoError(t, err)
+	svc := NewOutgoingWebhookService(repo, &mockBudgetRepository{}, &mockAnomalyRepository{}, policy, time.Second, 3)
+
+	result, err := svc.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retrying)
+	assert.False(t, called)
+	saved := repo.saved[0]
+	assert.Contains(t, saved.Error, security.ErrAddressNotAllowed.Error())
+	assert.Zero(t, saved.ResponseStatus)
+	assert.Empty(t, saved.ResponseBody)
+}
+
+func TestOutgoingWebhookService_DeliverDue_DisabledEndpoint(t *testing.T) {
+	now := time.Now()
+	repo := &mockOutgoingWebhookRepository{due: []domain.WebhookDelivery{{ID: 9, EndpointID: 1, Status: domain.WebhookDeliveryPending}}}
+	svc := newTestOutgoingWebhookService(repo, &mockBudgetRepository{}, &mockAnomalyRepository{}, now)
+
+	result, err := svc.DeliverDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Retrying)
+	assert.Equal(t, "endpoint is disabled", repo.saved[0].Error)
+}
+
+func TestOutgoingWebhookService_Redeliver(t *testing.T) {
+	repo := &mockOutgoingWebhookRepository{
+		endpoints: []domain.WebhookEndpoint{subscribedEndpoint(1, domain.WebhookEventTransactionCreated)},
+		deliveries: []domain.WebhookDelivery{{
+			ID: 1, EndpointID: 1, EventID: "evt_1", EventType: domain.WebhookEventTransactionCreated,
+			Payload: json.RawMessage(`{"id":"evt_1"}`), Status: domain.WebhookDeliveryFailed, Attempts: 8,
+		}},
+	}
+	now := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	svc := newTestOutgoingWebhookService(repo, &mockBudgetRepository{}, &mockAnomalyRepository{}, now)
+
+	redelivery, err := svc.Redeliver(1, 1, 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), redelivery.ID)
+	assert.Equal(t, "evt_1", redelivery.EventID)
+	assert.Equal(t, domain.WebhookDeliveryPending, redelivery.Status)
+	assert.Equal(t, 0, redelivery.Attempts)
+	assert.Equal(t, int64(1), *redelivery.RedeliveryOf)
+	assert.Equal(t, now, *redelivery.NextAttemptAt)
+
+	_, err = svc.Redeliver(1, 1, 42)
+	assert.ErrorIs(t, err, repository.ErrWebhookDeliveryNotFound)
+}
+
+func TestWebhookBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhookBackoff(1))
+	assert.Equal(t, 4*time.Minute, webhookBackoff(4))
+	assert.Equal(t, webhookRetryMax, webhookBackoff(20))
+}
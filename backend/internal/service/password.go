@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrWrongPassword is returned when the current password of a password change does not match
+	ErrWrongPassword = errors.New("current password is incorrect")
+	// ErrPasswordResetDisabled is returned when no notifier can deliver reset links
+	ErrPasswordResetDisabled = errors.New("password reset is not available")
+)
+
+// PasswordService changes passwords of signed-in users and resets forgotten
+// ones through single-use links. Both revoke the tokens issued before.
+type PasswordService interface {
+	ChangePassword(userID int64, req *domain.ChangePasswordRequest) (*domain.AuthResponse, error)
+	ForgotPassword(ctx context.Context, req *domain.ForgotPasswordRequest) error
+	ResetPassword(req *domain.ResetPasswordRequest) error
+	DeleteExpiredResetTokens() (int64, error)
+}
+
+type passwordService struct {
+	userRepo       repository.UserRepository
+	resetRepo      repository.PasswordResetRepository
+	security       SecurityService
+	notifier       PasswordResetNotifier
+	passwordHasher *security.PasswordHasher
+	jwtManager     *security.JWTManager
+	resetURL       string
+	resetTTL       time.Duration
+	now            func() time.Time
+}
+
+// NewPasswordService creates a new password service. Reset links point to
+// resetURL with the token in the token query parameter, stay valid for
+// resetTTL and are delivered by notifier; a nil notifier disables resets.
+func NewPasswordService(
+	userRepo repository.UserRepository,
+	resetRepo repository.PasswordResetRepository,
+	securityService SecurityService,
+	notifier PasswordResetNotifier,
+	jwtManager *security.JWTManager,
+	resetURL string,
+	resetTTL time.Duration,
+) PasswordService {
+	if resetTTL <= 0 {
+		resetTTL = domain.DefaultPasswordResetTTL
+	}
+	return &passwordService{
+		userRepo:       userRepo,
+		resetRepo:      resetRepo,
+		security:       securityService,
+		notifier:       notifier,
+		passwordHasher: security.NewPasswordHasher(),
+		jwtManager:     jwtManager,
+		resetURL:       resetURL,
+		resetTTL:       resetTTL,
+		now:            time.Now,
+	}
+}
+
+// ChangePassword sets a new password once the current one is verified and
+// returns a new token; all other sessions of the user are revoked.
+func (s *passwordService) ChangePassword(userID int64, req *domain.ChangePasswordRequest) (*domain.AuthResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyPassword(s.security, s.passwordHasher, user, req.CurrentPassword, req.Client); err != nil {
+		return nil, err
+	}
+
+	if err := s.setPassword(user, req.NewPassword, domain.SecurityEventPasswordChanged, req.Client); err != nil {
+		return nil, err
+	}
+
+	token, err := s.jwtManager.GenerateToken(user.ID, user.Email, user.UUID)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.AuthResponse{
+		Token: token,
+		User:  user.ToResponse(),
+	}, nil
+}
+
+// ForgotPassword sends a reset link to the account of the email. Unknown
+// and inactive accounts are silently ignored, so the response does not
+// reveal which accounts exist.
+func (s *passwordService) ForgotPassword(ctx context.Context, req *domain.ForgotPasswordRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+	if s.notifier == nil {
+		return ErrPasswordResetDisabled
+	}
+
+	user, err := s.userRepo.FindByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	token, err := security.GenerateLinkToken()
+	if err != nil {
+		return err
+	}
+	err = s.resetRepo.Create(&domain.PasswordResetToken{
+		UserID:    user.ID,
+		TokenHash: security.HashLinkToken(token),
+		ExpiresAt: s.now().Add(s.resetTTL),
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := tokenLink(s.resetURL, token)
+	if err != nil {
+		return err
+	}
+	if err := s.notifier.NotifyPasswordReset(ctx, user, link, s.resetTTL); err != nil {
+		// Failing only for existing accounts would reveal them
+		log := logger.Get()
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to deliver password reset link")
+		return nil
+	}
+
+	s.recordEvent(user.ID, domain.SecurityEventPasswordResetRequested, req.Client)
+	return nil
+}
+
+// ResetPassword sets a new password with the token of a reset link. The
+// token works once; all sessions of the user are revoked.
+func (s *passwordService) ResetPassword(req *domain.ResetPasswordRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	token, err := s.resetRepo.Consume(security.HashLinkToken(req.Token), s.now())
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return repository.ErrPasswordResetTokenNotFound
+		}
+		return err
+	}
+	if !user.IsActive {
+		return repository.ErrPasswordResetTokenNotFound
+	}
+
+	return s.setPassword(user, req.NewPassword, domain.SecurityEventPasswordReset, req.Client)
+}
+
+// DeleteExpiredResetTokens deletes the reset tokens that can no longer be used
+func (s *passwordService) DeleteExpiredResetTokens() (int64, error) {
+	return s.resetRepo.DeleteExpired(s.now())
+}
+
+// setPassword stores the hash of password, revokes the sessions of the user
+// and records eventType in the security log
+func (s *passwordService) setPassword(user *domain.User, password, eventType string, client domain.ClientInfo) error {
+	hash, err := s.passwordHasher.Hash(password)
+	if err != nil {
+		return err
+	}
+
+	// Tokens have second precision; truncating keeps those issued from now on valid
+	revokedAt := s.now().Truncate(time.Second)
+	if err := s.userRepo.UpdatePassword(user.ID, hash, revokedAt); err != nil {
+		return err
+	}
+	user.PasswordHash, user.SessionsRevokedAt = hash, &revokedAt
+
+	s.recordEvent(user.ID, eventType, client)
+	return nil
+}
+
+// verifyPassword checks the password a signed-in user confirms a change
+// with. It is throttled like logins and wrong passwords count as failed
+// logins, so a stolen session cannot guess the password faster.
+func verifyPassword(securityService SecurityService, hasher *security.PasswordHasher, user *domain.User, password string, client domain.ClientInfo) error {
+	if err := securityService.CheckLogin(user.Email, client); err != nil {
+		return err
+	}
+
+	valid, err := hasher.Verify(password, user.PasswordHash)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		if err := securityService.LoginFailed(user, user.Email, client); err != nil {
+			log := logger.Get()
+			log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to record wrong current password")
+		}
+		return ErrWrongPassword
+	}
+	return nil
+}
+
+// tokenLink returns baseURL with token as its token query parameter
+func tokenLink(baseURL, token string) (string, error) {
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+// recordEvent adds an event to the security log, logging rather than
+// returning errors once the change is made
+func (s *passwordService) recordEvent(userID int64, eventType string, client domain.ClientInfo) {
+	if err := s.security.RecordEvent(userID, eventType, client, ""); err != nil {
+		log := logger.Get()
+		log.Error().Err(err).Int64("user_id", userID).Str("type", eventType).Msg("Failed to record security event")
+	}
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+// mockPasswordResetRepository keeps reset tokens by hash
+type mockPasswordResetRepository struct {
+	tokens map[string]*domain.PasswordResetToken
+}
+
+func newMockPasswordResetRepository() *mockPasswordResetRepository {
+	return &mockPasswordResetRepository{tokens: make(map[string]*domain.PasswordResetToken)}
+}
+
+func (m *mockPasswordResetRepository) Create(token *domain.PasswordResetToken) error {
+	for hash, t := range m.tokens {
+		if t.UserID == token.UserID && t.UsedAt == nil {
+			delete(m.tokens, hash)
+		}
+	}
+	token.ID = int64(len(m.tokens) + 1)
+	m.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (m *mockPasswordResetRepository) Consume(tokenHash string, now time.Time) (*domain.PasswordResetToken, error) {
+	token, ok := m.tokens[tokenHash]
+	if !ok || token.UsedAt != nil || !token.ExpiresAt.After(now) {
+		return nil, repository.ErrPasswordResetTokenNotFound
+	}
+	token.UsedAt = &now
+	return token, nil
+}
+
+func (m *mockPasswordResetRepository) DeleteExpired(now time.Time) (int64, error) {
+	var deleted int64
+	for hash, token := range m.tokens {
+		if !token.ExpiresAt.After(now) {
+			delete(m.tokens, hash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// mockResetNotifier records the reset links it is asked to deliver
+type mockResetNotifier struct {
+	links []string
+	err   error
+}
+
+func (m *mockResetNotifier) NotifyPasswordReset(ctx context.Context, user *domain.User, link string, validFor time.Duration) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.links = append(m.links, link)
+	return nil
+}
+
+const testResetURL = "https://app.example.com/reset-password"
+
+// newTestPasswordService returns a password service over mocks whose clock is at *now
+func newTestPasswordService(userRepo repository.UserRepository, notifier PasswordResetNotifier, now *time.Time) (PasswordService, *mockPasswordResetRepository, *mockSecurityRepository) {
+	resetRepo := newMockPasswordResetRepository()
+	securityRepo := newMockSecurityRepository()
+	securityService := NewSecurityService(securityRepo, userRepo, testLoginPolicy)
+	svc := NewPasswordService(userRepo, resetRepo, securityService, notifier,
+		security.NewJWTManager("test-jwt-secret-minimum-32-chars"), testResetURL, time.Hour).(*passwordService)
+	svc.now = func() time.Time { return *now }
+	return svc, resetRepo, securityRepo
+}
+
+// tokenFromLink returns the token query parameter of a reset link
+func tokenFromLink(t *testing.T, link string) string {
+	t.Helper()
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	return parsed.Query().Get("token")
+}
+
+func TestPasswordService_ChangePassword_Success(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 500, time.UTC)
+	svc, _, securityRepo := newTestPasswordService(userRepo, nil, &now)
+
+	response, err := svc.ChangePassword(testUser.ID, &domain.ChangePasswordRequest{
+		CurrentPassword: "Password123",
+		NewPassword:     "NewPassword456",
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, response.Token)
+	assert.Equal(t, testUser.ID, response.User.ID)
+	valid, err := security.NewPasswordHasher().Verify("NewPassword456", userRepo.updatedPassword)
+	require.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, now.Truncate(time.Second), userRepo.sessionsRevokedAt)
+	assert.Equal(t, []string{domain.SecurityEventPasswordChanged}, securityRepo.eventTypes())
+}
+
+func TestPasswordService_ChangePassword_WrongPassword(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, _, securityRepo := newTestPasswordService(userRepo, nil, &now)
+
+	req := &domain.ChangePasswordRequest{
+		CurrentPassword: "WrongPassword789",
+		NewPassword:     "NewPassword456",
+	}
+	response, err := svc.ChangePassword(testUser.ID, req)
+
+	assert.Nil(t, response)
+	assert.Equal(t, ErrWrongPassword, err)
+	assert.Empty(t, userRepo.updatedPassword)
+	assert.Equal(t, []string{domain.SecurityEventLoginFailed}, securityRepo.eventTypes())
+
+	// Guessing is throttled like logins
+	req.CurrentPassword = "Password123"
+	_, err = svc.ChangePassword(testUser.ID, req)
+
+	var throttled *LoginThrottledError
+	assert.True(t, errors.As(err, &throttled))
+}
+
+func TestPasswordService_ChangePassword_ValidationError(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByIDUser: testUser}
+	now := time.Now()
+	svc, _, _ := newTestPasswordService(userRepo, nil, &now)
+
+	_, err := svc.ChangePassword(testUser.ID, &domain.ChangePasswordRequest{
+		CurrentPassword: "Password123",
+		NewPassword:     "alllowercase",
+	})
+
+	var validationErr *domain.ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "new_password", validationErr.Field)
+}
+
+func TestPasswordService_ForgotAndResetPassword(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByEmailUser: testUser, findByIDUser: testUser}
+	notifier := &mockResetNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, resetRepo, securityRepo := newTestPasswordService(userRepo, notifier, &now)
+
+	err := svc.ForgotPassword(context.Background(), &domain.ForgotPasswordRequest{Email: " Test@Example.com "})
+
+	require.NoError(t, err)
+	require.Len(t, notifier.links, 1)
+	assert.Contains(t, notifier.links[0], testResetURL+"?token=")
+	token := tokenFromLink(t, notifier.links[0])
+	require.Len(t, resetRepo.tokens, 1)
+	// Only the hash is stored
+	assert.Contains(t, resetRepo.tokens, security.HashLinkToken(token))
+	assert.NotContains(t, resetRepo.tokens, token)
+
+	now = now.Add(30 * time.Minute)
+	err = svc.ResetPassword(&domain.ResetPasswordRequest{Token: token, NewPassword: "NewPassword456"})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, userRepo.updatedPassword)
+	assert.Equal(t, now, userRepo.sessionsRevokedAt)
+	assert.Equal(t, []string{domain.SecurityEventPasswordResetRequested, domain.SecurityEventPasswordReset}, securityRepo.eventTypes())
+
+	// Tokens work once
+	err = svc.ResetPassword(&domain.ResetPasswordRequest{Token: token, NewPassword: "OtherPassword789"})
+	assert.Equal(t, repository.ErrPasswordResetTokenNotFound, err)
+}
+
+func TestPasswordService_ResetPassword_ExpiredToken(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByEmailUser: testUser, findByIDUser: testUser}
+	notifier := &mockResetNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, _, _ := newTestPasswordService(userRepo, notifier, &now)
+
+	require.NoError(t, svc.ForgotPassword(context.Background(), &domain.ForgotPasswordRequest{Email: "test@example.com"}))
+
+	now = now.Add(time.Hour)
+	err := svc.ResetPassword(&domain.ResetPasswordRequest{Token: tokenFromLink(t, notifier.links[0]), NewPassword: "NewPassword456"})
+
+	assert.Equal(t, repository.ErrPasswordResetTokenNotFound, err)
+	assert.Empty(t, userRepo.updatedPassword)
+}
+
+func TestPasswordService_ForgotPassword_NewLinkReplacesOld(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByEmailUser: testUser, findByIDUser: testUser}
+	notifier := &mockResetNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, _, _ := newTestPasswordService(userRepo, notifier, &now)
+
+	req := &domain.ForgotPasswordRequest{Email: "test@example.com"}
+	require.NoError(t, svc.ForgotPassword(context.Background(), req))
+	require.NoError(t, svc.ForgotPassword(context.Background(), req))
+
+	err := svc.ResetPassword(&domain.ResetPasswordRequest{Token: tokenFromLink(t, notifier.links[0]), NewPassword: "NewPassword456"})
+	assert.Equal(t, repository.ErrPasswordResetTokenNotFound, err)
+
+	err = svc.ResetPassword(&domain.ResetPasswordRequest{Token: tokenFromLink(t, notifier.links[1]), NewPassword: "NewPassword456"})
+	assert.NoError(t, err)
+}
+
+func TestPasswordService_ForgotPassword_UnknownEmail(t *testing.T) {
+	userRepo := &mockUserRepository{findByEmailErr: repository.ErrUserNotFound}
+	notifier := &mockResetNotifier{}
+	now := time.Now()
+	svc, resetRepo, _ := newTestPasswordService(userRepo, notifier, &now)
+
+	err := svc.ForgotPassword(context.Background(), &domain.ForgotPasswordRequest{Email: "nobody@example.com"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, notifier.links)
+	assert.Empty(t, resetRepo.tokens)
+}
+
+func TestPasswordService_ForgotPassword_InactiveUser(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	testUser.IsActive = false
+	userRepo := &mockUserRepository{findByEmailUser: testUser}
+	notifier := &mockResetNotifier{}
+	now := time.Now()
+	svc, _, _ := newTestPasswordService(userRepo, notifier, &now)
+
+	err := svc.ForgotPassword(context.Background(), &domain.ForgotPasswordRequest{Email: "test@example.com"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, notifier.links)
+}
+
+func TestPasswordService_ForgotPassword_NotifierErrorHidden(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByEmailUser: testUser}
+	notifier := &mockResetNotifier{err: errors.New("connection refused")}
+	now := time.Now()
+	svc, _, securityRepo := newTestPasswordService(userRepo, notifier, &now)
+
+	err := svc.ForgotPassword(context.Background(), &domain.ForgotPasswordRequest{Email: "test@example.com"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, securityRepo.eventTypes())
+}
+
+func TestPasswordService_ForgotPassword_Disabled(t *testing.T) {
+	userRepo := &mockUserRepository{}
+	now := time.Now()
+	svc, _, _ := newTestPasswordService(userRepo, nil, &now)
+
+	err := svc.ForgotPassword(context.Background(), &domain.ForgotPasswordRequest{Email: "test@example.com"})
+
+	assert.Equal(t, ErrPasswordResetDisabled, err)
+}
+
+func TestPasswordService_ResetPassword_InactiveUser(t *testing.T) {
+	testUser := createTestUser(t, "test@example.com", "Password123")
+	userRepo := &mockUserRepository{findByEmailUser: testUser, findByIDUser: testUser}
+	notifier := &mockResetNotifier{}
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc, _, _ := newTestPasswordService(userRepo, notifier, &now)
+
+	require.NoError(t, svc.ForgotPassword(context.Background(), &domain.ForgotPasswordRequest{Email: "test@example.com"}))
+	testUser.IsActive = false
+
+	err := svc.ResetPassword(&domain.ResetPasswordRequest{Token: tokenFromLink(t, notifier.links[0]), NewPassword: "NewPassword456"})
+
+	assert.Equal(t, repository.ErrPasswordResetTokenNotFound, err)
+	assert.Empty(t, userRepo.updatedPassword)
+}
+
+func TestEmailNotifier_NotifyPasswordReset(t *testing.T) {
+	m := &mockMailer{}
+	notifier := NewEmailNotifier(m)
+	user := &domain.User{ID: 1, Email: "test@example.com", Name: "Test User"}
+
+	err := notifier.NotifyPasswordReset(context.Background(), user, testResetURL+"?token=abc", time.Hour)
+
+	require.NoError(t, err)
+	require.Len(t, m.sent, 1)
+	assert.Equal(t, []string{"test@example.com"}, m.sent[0].To)
+	assert.Contains(t, m.sent[0].Text, testResetURL+"?token=abc")
+	assert.Contains(t, m.sent[0].Text, "1 hour")
+	assert.Contains(t, m.sent[0].HTML, "Test User")
+}
+
+func TestFormatValidity(t *testing.T) {
+	assert.Equal(t, "1 hour", formatValidity(time.Hour))
+	assert.Equal(t, "2 hours", formatValidity(2*time.Hour))
+	assert.Equal(t, "30 minutes", formatValidity(30*time.Minute))
+	assert.Equal(t, "90 minutes", formatValidity(90*time.Minute))
+}
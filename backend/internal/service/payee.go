@@ -0,0 +1,274 @@
+package service
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// PayeeService handles business logic for the payee directory
+type PayeeService interface {
+	CreatePayee(req *domain.PayeeRequest) (*domain.Payee, error)
+	GetPayee(id int64) (*domain.Payee, error)
+	ListPayees() ([]domain.Payee, error)
+	UpdatePayee(id int64, req *domain.PayeeRequest) (*domain.Payee, error)
+	DeletePayee(id int64) error
+	MergePayees(targetID int64, req *domain.MergePayeesRequest) (*domain.MergePayeesResponse, error)
+	GetPayeeBreakdown(params domain.PayeeBreakdownQueryParams) ([]domain.PayeeBreakdown, error)
+	PrepareTransactions(transactions []domain.Transaction)
+	OnTransactionsCreated(transactions []domain.Transaction)
+}
+
+type payeeService struct {
+	repo repository.PayeeRepository
+}
+
+// NewPayeeService creates a new payee service
+func NewPayeeService(repo repository.PayeeRepository) PayeeService {
+	return &payeeService{repo: repo}
+}
+
+// CreatePayee stores a payee and links matching historical transactions to it
+func (s *payeeService) CreatePayee(req *domain.PayeeRequest) (*domain.Payee, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	payee := req.ToPayee()
+	if err := s.repo.Create(payee); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.relink(payee); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+func (s *payeeService) GetPayee(id int64) (*domain.Payee, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid payee ID")
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *payeeService) ListPayees() ([]domain.Payee, error) {
+	return s.repo.List()
+}
+
+// UpdatePayee replaces the payee's name, default category and aliases and links
+// historical transactions matching the new aliases. Existing links are kept.
+func (s *payeeService) UpdatePayee(id int64, req *domain.PayeeRequest) (*domain.Payee, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.GetPayee(id)
+	if err != nil {
+		return nil, err
+	}
+
+	payee := req.ToPayee()
+	payee.ID = existing.ID
+	payee.CreatedAt = existing.CreatedAt
+	if err := s.repo.Update(payee); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.relink(payee); err != nil {
+		return nil, err
+	}
+
+	return payee, nil
+}
+
+func (s *payeeService) DeletePayee(id int64) error {
+	if id <= 0 {
+		return errors.New("invalid payee ID")
+	}
+	return s.repo.Delete(id)
+}
+
+// MergePayees folds the source payees into the target: their aliases and
+// transactions move to the target, and unlinked historical transactions
+// matching the combined aliases are linked as well
+func (s *payeeService) MergePayees(targetID int64, req *domain.MergePayeesRequest) (*domain.MergePayeesResponse, error) {
+	if err := req.Validate(targetID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.GetPayee(targetID); err != nil {
+		return nil, err
+	}
+
+	sources := make([]int64, 0, len(req.SourcePayeeIDs))
+	seen := make(map[int64]bool, len(req.SourcePayeeIDs))
+	for _, id := range req.SourcePayeeIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if _, err := s.repo.FindByID(id); err != nil {
+			if errors.Is(err, repository.ErrPayeeNotFound) {
+				return nil, &domain.ValidationError{
+					Field:   "source_payee_ids",
+					Message: "payee to merge not found",
+				}
+			}
+			return nil, err
+		}
+		sources = append(sources, id)
+	}
+
+	relinked, err := s.repo.Merge(targetID, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err := s.repo.FindByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.relink(payee)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.MergePayeesResponse{
+		Payee:                payee,
+		MergedPayees:         len(sources),
+		RelinkedTransactions: relinked + linked,
+	}, nil
+}
+
+func (s *payeeService) GetPayeeBreakdown(params domain.PayeeBreakdownQueryParams) ([]domain.PayeeBreakdown, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter, err := params.ToFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBreakdown(filter)
+}
+
+// PrepareTransactions links incoming transactions to their payee and applies
+// the payee's default category. Lookup failures are logged and never fail the
+// incoming webhook.
+func (s *payeeService) PrepareTransactions(transactions []domain.Transaction) {
+	payees, err := s.repo.List()
+	if err != nil {
+		log := logger.Get()
+		log.Error().Err(err).Msg("Failed to load payees for normalization")
+		return
+	}
+
+	matchers := compilePayeeMatchers(payees)
+	for i := range transactions {
+		tx := &transactions[i]
+		payee := matchPayee(matchers, tx)
+		if payee == nil {
+			continue
+		}
+
+		id := payee.ID
+		tx.PayeeID = &id
+		if tx.Category == "" {
+			tx.Category = payee.DefaultCategory
+		}
+	}
+}
+
+// OnTransactionsCreated does nothing; payees are linked before transactions are stored
+func (s *payeeService) OnTransactionsCreated(transactions []domain.Transaction) {}
+
+// relink links unlinked historical transactions matching the payee and returns how many were linked
+func (s *payeeService) relink(payee *domain.Payee) (int64, error) {
+	transactions, err := s.repo.ListUnlinked()
+	if err != nil {
+		return 0, err
+	}
+
+	matchers := compilePayeeMatchers([]domain.Payee{*payee})
+	var ids []int64
+	for i := range transactions {
+		if matchPayee(matchers, &transactions[i]) != nil {
+			ids = append(ids, transactions[i].ID)
+		}
+	}
+
+	if err := s.repo.LinkTransactions(payee, ids); err != nil {
+		return 0, err
+	}
+	return int64(len(ids)), nil
+}
+
+// payeeMatcher is a compiled payee name or alias pattern
+type payeeMatcher struct {
+	payee   *domain.Payee
+	pattern *regexp.Regexp
+	literal int // Number of non-wildcard characters; more specific patterns win
+}
+
+// compilePayeeMatchers compiles the name and aliases of every payee
+func compilePayeeMatchers(payees []domain.Payee) []payeeMatcher {
+	var matchers []payeeMatcher
+	for i := range payees {
+		payee := &payees[i]
+		patterns := []string{payee.Name}
+		for _, alias := range payee.Aliases {
+			patterns = append(patterns, alias.Pattern)
+		}
+
+		for _, pattern := range patterns {
+			matchers = append(matchers, payeeMatcher{
+				payee:   payee,
+				pattern: compilePayeePattern(pattern),
+				literal: len(strings.ReplaceAll(pattern, "*", "")),
+			})
+		}
+	}
+	return matchers
+}
+
+// compilePayeePattern turns a glob-style alias into a case-insensitive regexp
+// matching the whole text, where * matches any run of characters
+func compilePayeePattern(pattern string) *regexp.Regexp {
+	parts := strings.Split(strings.TrimSpace(pattern), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile(`(?is)^` + strings.Join(parts, ".*") + `$`)
+}
+
+// matchPayee returns the payee whose most specific pattern matches the
+// transaction's recipient, falling back to its description
+func matchPayee(matchers []payeeMatcher, tx *domain.Transaction) *domain.Payee {
+	for _, text := range []string{tx.Recipient, tx.Description} {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+
+		var best *payeeMatcher
+		for i := range matchers {
+			m := &matchers[i]
+			if m.pattern.MatchString(text) && (best == nil || m.literal > best.literal) {
+				best = m
+			}
+		}
+		if best != nil {
+			return best.payee
+		}
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockPayeeRepository is a mock implementation of PayeeRepository for testing
+type mockPayeeRepository struct {
+	payees   []domain.Payee
+	unlinked []domain.Transaction
+	linked   map[int64][]int64
+	merged   []int64
+	listErr  error
+}
+
+func (m *mockPayeeRepository) Create(payee *domain.Payee) error {
+	payee.ID = int64(len(m.payees) + 1)
+	m.payees = append(m.payees, *payee)
+	return nil
+}
+
+func (m *mockPayeeRepository) FindByID(id int64) (*domain.Payee, error) {
+	for i := range m.payees {
+		if m.payees[i].ID == id {
+			payee := m.payees[i]
+			return &payee, nil
+		}
+	}
+	return nil, repository.ErrPayeeNotFound
+}
+
+func (m *mockPayeeRepository) List() ([]domain.Payee, error) {
+	return m.payees, m.listErr
+}
+
+func (m *mockPayeeRepository) Update(payee *domain.Payee) error {
+	for i := range m.payees {
+		if m.payees[i].ID == payee.ID {
+			m.payees[i] = *payee
+		}
+	}
+	return nil
+}
+
+func (m *mockPayeeRepository) Delete(id int64) error {
+	return nil
+}
+
+// Merge moves the aliases of the sources to the target and adds their names
+// as aliases, as the repository does
+func (m *mockPayeeRepository) Merge(targetID int64, sourceIDs []int64) (int64, error) {
+	m.merged = sourceIDs
+	var moved []domain.PayeeAlias
+	kept := m.payees[:0]
+	for _, payee := range m.payees {
+		if slices.Contains(sourceIDs, payee.ID) {
+			moved = append(moved, payee.Aliases...)
+			moved = append(moved, domain.PayeeAlias{Pattern: payee.Name})
+			continue
+		}
+		kept = append(kept, payee)
+	}
+	m.payees = kept
+	for i := range m.payees {
+		if m.payees[i].ID == targetID {
+			m.payees[i].Aliases = append(m.payees[i].Aliases, moved...)
+		}
+	}
+	return 4, nil
+}
+
+func (m *mockPayeeRepository) ListUnlinked() ([]domain.Transaction, error) {
+	return m.unlinked, nil
+}
+
+func (m *mockPayeeRepository) LinkTransactions(payee *domain.Payee, transactionIDs []int64) error {
+	if m.linked == nil {
+		m.linked = make(map[int64][]int64)
+	}
+	m.linked[payee.ID] = append(m.linked[payee.ID], transactionIDs...)
+	return nil
+}
+
+func (m *mockPayeeRepository) GetBreakdown(filter *domain.PayeeBreakdownFilter) ([]domain.PayeeBreakdown, error) {
+	return []domain.PayeeBreakdown{{PayeeID: 1, Name: "Grab", Amount: 100}}, nil
+}
+
+func grabPayees() []domain.Payee {
+	return []domain.Payee{
+		{
+			ID: 1, Name: "Grab", DefaultCategory: "Transportation",
+			Aliases: []domain.PayeeAlias{{Pattern: "GRAB*"}},
+		},
+		{
+			ID: 2, Name: "GrabFood", DefaultCategory: "Food",
+			Aliases: []domain.PayeeAlias{{Pattern: "GRAB*FOOD*"}},
+		},
+	}
+}
+
+// Test PrepareTransactions
+
+func TestPrepareTransactions_LinksPayeeAndDefaultCategory(t *testing.T) {
+	svc := NewPayeeService(&mockPayeeRepository{payees: grabPayees()})
+	transactions := []domain.Transaction{
+		{Recipient: "grab*trip 8812"},
+		{Recipient: "Unknown shop"},
+	}
+
+	svc.PrepareTransactions(transactions)
+
+	if assert.NotNil(t, transactions[0].PayeeID) {
+		assert.Equal(t, int64(1), *transactions[0].PayeeID)
+	}
+	assert.Equal(t, "Transportation", transactions[0].Category)
+	assert.Nil(t, transactions[1].PayeeID)
+	assert.Empty(t, transactions[1].Category)
+}
+
+func TestPrepareTransactions_MostSpecificPatternWins(t *testing.T) {
+	svc := NewPayeeService(&mockPayeeRepository{payees: grabPayees()})
+	transactions := []domain.Transaction{{Recipient: "GRAB*FOOD 1234"}}
+
+	svc.PrepareTransactions(transactions)
+
+	if assert.NotNil(t, transactions[0].PayeeID) {
+		assert.Equal(t, int64(2), *transactions[0].PayeeID)
+	}
+	assert.Equal(t, "Food", transactions[0].Category)
+}
+
+func TestPrepareTransactions_KeepsCategoryAndFallsBackToDescription(t *testing.T) {
+	svc := NewPayeeService(&mockPayeeRepository{payees: grabPayees()})
+	transactions := []domain.Transaction{{Description: "Grab", Category: "Other"}}
+
+	svc.PrepareTransactions(transactions)
+
+	if assert.NotNil(t, transactions[0].PayeeID) {
+		assert.Equal(t, int64(1), *transactions[0].PayeeID)
+	}
+	assert.Equal(t, "Other", transactions[0].Category)
+}
+
+func TestPrepareTransactions_ListErrorLeavesTransactions(t *testing.T) {
+	svc := NewPayeeService(&mockPayeeRepository{listErr: errors.New("database error")})
+	transactions := []domain.Transaction{{Recipient: "GRAB"}}
+
+	svc.PrepareTransactions(transactions)
+
+	assert.Nil(t, transactions[0].PayeeID)
+}
+
+// Test CreatePayee
+
+func TestCreatePayee_RelinksHistory(t *testing.T) {
+	repo := &mockPayeeRepository{
+		unlinked: []domain.Transaction{
+			{ID: 10, Recipient: "HIGHLANDS COFFEE Q1"},
+			{ID: 11, Recipient: "Pho 24"},
+			{ID: 12, Description: "highlands coffee"},
+		},
+	}
+	svc := NewPayeeService(repo)
+
+	payee, err := svc.CreatePayee(&domain.PayeeRequest{
+		Name:            "Highlands Coffee",
+		DefaultCategory: "Food",
+		Aliases:         []string{"HIGHLANDS COFFEE*"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), payee.ID)
+	assert.Equal(t, []int64{10, 12}, repo.linked[1])
+}
+
+func TestCreatePayee_ValidationError(t *testing.T) {
+	svc := NewPayeeService(&mockPayeeRepository{})
+
+	_, err := svc.CreatePayee(&domain.PayeeRequest{Name: ""})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+// Test MergePayees
+
+func TestMergePayees_Success(t *testing.T) {
+	repo := &mockPayeeRepository{
+		payees:   grabPayees(),
+		unlinked: []domain.Transaction{{ID: 20, Recipient: "GRAB*FOOD 99"}},
+	}
+	svc := NewPayeeService(repo)
+
+	result, err := svc.MergePayees(1, &domain.MergePayeesRequest{SourcePayeeIDs: []int64{2, 2}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2}, repo.merged)
+	assert.Equal(t, 1, result.MergedPayees)
+	assert.Equal(t, int64(5), result.RelinkedTransactions)
+	assert.Equal(t, "Grab", result.Payee.Name)
+}
+
+func TestMergePayees_SourceNameResolvesToTarget(t *testing.T) {
+	repo := &mockPayeeRepository{
+		payees: []domain.Payee{
+			{ID: 1, Name: "Grab", DefaultCategory: "Transportation"},
+			{ID: 2, Name: "GRABPAY"},
+		},
+	}
+	svc := NewPayeeService(repo)
+
+	_, err := svc.MergePayees(1, &domain.MergePayeesRequest{SourcePayeeIDs: []int64{2}})
+	assert.NoError(t, err)
+
+	transactions := []domain.Transaction{{Recipient: "GRABPAY"}}
+	svc.PrepareTransactions(transactions)
+
+	if assert.NotNil(t, transactions[0].PayeeID) {
+		assert.Equal(t, int64(1), *transactions[0].PayeeID)
+	}
+	assert.Equal(t, "Transportation", transactions[0].Category)
+}
+
+func TestMergePayees_UnknownSource(t *testing.T) {
+	svc := NewPayeeService(&mockPayeeRepository{payees: grabPayees()})
+
+	_, err := svc.MergePayees(1, &domain.MergePayeesRequest{SourcePayeeIDs: []int64{9}})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "source_payee_ids", validationErr.Field)
+}
+
+func TestMergePayees_UnknownTarget(t *testing.T) {
+	svc := NewPayeeService(&mockPayeeRepository{payees: grabPayees()})
+
+	_, err := svc.MergePayees(9, &domain.MergePayeesRequest{SourcePayeeIDs: []int64{1}})
+
+	assert.ErrorIs(t, err, repository.ErrPayeeNotFound)
+}
+
+// Test GetPayeeBreakdown
+
+func TestGetPayeeBreakdown_InvalidType(t *testing.T) {
+	svc := NewPayeeService(&mockPayeeRepository{})
+
+	_, err := svc.GetPayeeBreakdown(domain.PayeeBreakdownQueryParams{Type: "both", Limit: 10})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
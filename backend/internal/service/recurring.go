@@ -0,0 +1,346 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+const (
+	// recurringMinOccurrences is the minimum number of charges for a weekly or monthly series
+	recurringMinOccurrences = 3
+	// recurringAmountTolerance is the relative deviation from the median amount still treated as the same charge
+	recurringAmountTolerance = 0.25
+	// recurringPriceChangeThreshold is the relative change of the last charge reported as a price change
+	recurringPriceChangeThreshold = 0.05
+	// recurringGapMatchRatio is the share of gaps that must fit the detected interval
+	recurringGapMatchRatio = 0.75
+	// recurringPayeeWords is the number of normalized words used to group payees
+	recurringPayeeWords = 4
+)
+
+// recurringIntervalRule describes the accepted gap in days and the grace period for one cadence
+type recurringIntervalRule struct {
+	interval       domain.RecurringInterval
+	minDays        float64
+	maxDays        float64
+	graceDays      int
+	minOccurrences int
+	perMonth       float64
+}
+
+var recurringIntervalRules = []recurringIntervalRule{
+	{interval: domain.RecurringIntervalWeekly, minDays: 6, maxDays: 8, graceDays: 3, minOccurrences: recurringMinOccurrences, perMonth: 52.0 / 12},
+	{interval: domain.RecurringIntervalMonthly, minDays: 26, maxDays: 35, graceDays: 7, minOccurrences: recurringMinOccurrences, perMonth: 1},
+	{interval: domain.RecurringIntervalYearly, minDays: 350, maxDays: 380, graceDays: 14, minOccurrences: 2, perMonth: 1.0 / 12},
+}
+
+// RecurringService detects recurring transactions such as subscriptions and bills
+type RecurringService interface {
+	GetRecurring(params domain.RecurringQueryParams) (*domain.RecurringResponse, error)
+	Analyze() (*domain.RecurringResponse, error)
+	Start(ctx context.Context, interval time.Duration)
+}
+
+type recurringService struct {
+	repo           repository.RecurringRepository
+	lookbackMonths int
+	now            func() time.Time
+
+	mu     sync.RWMutex
+	latest *domain.RecurringResponse
+}
+
+// NewRecurringService creates a new recurring service that scans lookbackMonths of history
+func NewRecurringService(repo repository.RecurringRepository, lookbackMonths int) RecurringService {
+	if lookbackMonths <= 0 {
+		lookbackMonths = 24
+	}
+	return &recurringService{
+		repo:           repo,
+		lookbackMonths: lookbackMonths,
+		now:            time.Now,
+	}
+}
+
+// GetRecurring returns the latest analysis, running one if the background
+// analyzer has not produced a result yet
+func (s *recurringService) GetRecurring(params domain.RecurringQueryParams) (*domain.RecurringResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	latest := s.latest
+	s.mu.RUnlock()
+
+	if latest == nil {
+		var err error
+		latest, err = s.Analyze()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if params.Type == "" {
+		return latest, nil
+	}
+
+	filtered := &domain.RecurringResponse{
+		AnalyzedAt: latest.AnalyzedAt,
+		Series:     []domain.RecurringSeries{},
+	}
+	for _, series := range latest.Series {
+		if series.Type != params.Type {
+			continue
+		}
+		filtered.Series = append(filtered.Series, series)
+		if !series.Missed {
+			filtered.MonthlyTotal += series.MonthlyAmount
+		}
+	}
+	return filtered, nil
+}
+
+// Analyze scans the transaction history and stores the detected series
+func (s *recurringService) Analyze() (*domain.RecurringResponse, error) {
+	now := s.now().UTC()
+
+	transactions, err := s.repo.ListSince(now.AddDate(0, -s.lookbackMonths, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.RecurringResponse{
+		AnalyzedAt: now,
+		Series:     detectRecurring(transactions, now),
+	}
+	for _, series := range response.Series {
+		if !series.Missed {
+			response.MonthlyTotal += series.MonthlyAmount
+		}
+	}
+
+	s.mu.Lock()
+	s.latest = response
+	s.mu.Unlock()
+
+	return response, nil
+}
+
+// Start runs Analyze immediately and then every interval until ctx is cancelled
+func (s *recurringService) Start(ctx context.Context, interval time.Duration) {
+	log := logger.Get()
+
+	run := func() {
+		result, err := s.Analyze()
+		if err != nil {
+			log.Error().Err(err).Msg("Recurring transaction analysis failed")
+			return
+		}
+		log.Debug().Int("series", len(result.Series)).Msg("Recurring transaction analysis completed")
+	}
+
+	go func() {
+		run()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
+
+// detectRecurring groups transactions by type and normalized payee and keeps
+// the groups whose amounts and gaps match a weekly, monthly or yearly cadence.
+// Transactions must be sorted by date.
+func detectRecurring(transactions []domain.Transaction, now time.Time) []domain.RecurringSeries {
+	type groupKey struct {
+		txType domain.TransactionType
+		payee  string
+	}
+
+	groups := make(map[groupKey][]domain.Transaction)
+	var order []groupKey
+	for _, tx := range transactions {
+		payee := normalizePayee(tx.Recipient)
+		if payee == "" {
+			payee = normalizePayee(tx.Description)
+		}
+		if payee == "" {
+			continue
+		}
+
+		key := groupKey{tx.Type, payee}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], tx)
+	}
+
+	series := []domain.RecurringSeries{}
+	for _, key := range order {
+		if s, ok := analyzeRecurringGroup(groups[key], now); ok {
+			series = append(series, s)
+		}
+	}
+
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].NextExpectedDate.Before(series[j].NextExpectedDate)
+	})
+
+	return series
+}
+
+// analyzeRecurringGroup checks whether a group of same-payee transactions is a recurring series
+func analyzeRecurringGroup(group []domain.Transaction, now time.Time) (domain.RecurringSeries, bool) {
+	if len(group) < 2 {
+		return domain.RecurringSeries{}, false
+	}
+
+	// All charges but the latest must be close to the median; the latest may
+	// differ so that price changes are reported rather than breaking the series
+	amounts := make([]float64, len(group)-1)
+	for i, tx := range group[:len(group)-1] {
+		amounts[i] = tx.Amount
+	}
+	median := medianOf(amounts)
+	for _, amount := range amounts {
+		if math.Abs(amount-median) > median*recurringAmountTolerance {
+			return domain.RecurringSeries{}, false
+		}
+	}
+
+	gaps := make([]float64, 0, len(group)-1)
+	for i := 1; i < len(group); i++ {
+		gaps = append(gaps, group[i].TransactionDate.Sub(group[i-1].TransactionDate).Hours()/24)
+	}
+
+	rule, ok := matchIntervalRule(gaps, len(group))
+	if !ok {
+		return domain.RecurringSeries{}, false
+	}
+
+	last := group[len(group)-1]
+	previous := group[len(group)-2]
+
+	series := domain.RecurringSeries{
+		Payee:          displayPayee(last),
+		Type:           last.Type,
+		Interval:       rule.interval,
+		Category:       last.Category,
+		Source:         last.Source,
+		Occurrences:    len(group),
+		LastDate:       last.TransactionDate,
+		LastAmount:     last.Amount,
+		PreviousAmount: previous.Amount,
+		TransactionIDs: make([]int64, 0, len(group)),
+	}
+
+	var total float64
+	for _, tx := range group {
+		total += tx.Amount
+		series.TransactionIDs = append(series.TransactionIDs, tx.ID)
+	}
+	series.AverageAmount = total / float64(len(group))
+	series.MonthlyAmount = series.AverageAmount * rule.perMonth
+
+	if previous.Amount > 0 {
+		series.PriceChangePercent = (last.Amount - previous.Amount) / previous.Amount * 100
+		series.PriceChanged = math.Abs(series.PriceChangePercent) >= recurringPriceChangeThreshold*100
+	}
+
+	series.NextExpectedDate = nextRecurringDate(last.TransactionDate, rule.interval)
+	series.Missed = now.After(series.NextExpectedDate.AddDate(0, 0, rule.graceDays))
+
+	return series, true
+}
+
+// matchIntervalRule returns the cadence that most gaps fit
+func matchIntervalRule(gaps []float64, occurrences int) (recurringIntervalRule, bool) {
+	median := medianOf(gaps)
+	for _, rule := range recurringIntervalRules {
+		if median < rule.minDays || median > rule.maxDays || occurrences < rule.minOccurrences {
+			continue
+		}
+
+		matched := 0
+		for _, gap := range gaps {
+			if gap >= rule.minDays && gap <= rule.maxDays {
+				matched++
+			}
+		}
+		if float64(matched) >= float64(len(gaps))*recurringGapMatchRatio {
+			return rule, true
+		}
+	}
+	return recurringIntervalRule{}, false
+}
+
+// nextRecurringDate projects the next charge date from the last one
+func nextRecurringDate(last time.Time, interval domain.RecurringInterval) time.Time {
+	switch interval {
+	case domain.RecurringIntervalWeekly:
+		return last.AddDate(0, 0, 7)
+	case domain.RecurringIntervalYearly:
+		return last.AddDate(1, 0, 0)
+	default:
+		return last.AddDate(0, 1, 0)
+	}
+}
+
+// normalizePayee lowercases text and keeps only its first letter-only words, so
+// "NETFLIX.COM 0123" and "Netflix.com 4567" map to the same payee
+func normalizePayee(text string) string {
+	words := strings.Fields(normalizeText(text))
+	if len(words) > recurringPayeeWords {
+		words = words[:recurringPayeeWords]
+	}
+	return strings.Join(words, " ")
+}
+
+// normalizeText lowercases text and reduces it to letter-only words separated by single spaces
+func normalizeText(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// displayPayee returns the human readable payee of a transaction
+func displayPayee(tx domain.Transaction) string {
+	if tx.Recipient != "" {
+		return tx.Recipient
+	}
+	return tx.Description
+}
+
+// medianOf returns the median of values without modifying them
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
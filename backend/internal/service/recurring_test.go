@@ -0,0 +1,170 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// mockRecurringRepository is a mock implementation of RecurringRepository for testing
+type mockRecurringRepository struct {
+	transactions []domain.Transaction
+	err          error
+	calls        int
+}
+
+func (m *mockRecurringRepository) ListSince(since time.Time) ([]domain.Transaction, error) {
+	m.calls++
+	return m.transactions, m.err
+}
+
+// monthlyCharges builds count monthly charges starting at start
+func monthlyCharges(recipient string, start time.Time, amounts ...float64) []domain.Transaction {
+	transactions := make([]domain.Transaction, len(amounts))
+	for i, amount := range amounts {
+		transactions[i] = domain.Transaction{
+			ID:              int64(i + 1),
+			Type:            domain.TransactionTypeOut,
+			Recipient:       recipient,
+			Category:        "Entertainment",
+			Source:          "Bank ABC",
+			Amount:          amount,
+			TransactionDate: start.AddDate(0, i, 0),
+		}
+	}
+	return transactions
+}
+
+func TestDetectRecurring_MonthlySubscription(t *testing.T) {
+	start := time.Date(2026, 1, 15, 8, 0, 0, 0, time.UTC)
+	transactions := monthlyCharges("NETFLIX.COM 0042", start, 260000, 260000, 260000, 260000)
+	now := time.Date(2026, 4, 20, 0, 0, 0, 0, time.UTC)
+
+	series := detectRecurring(transactions, now)
+
+	assert.Len(t, series, 1)
+	assert.Equal(t, domain.RecurringIntervalMonthly, series[0].Interval)
+	assert.Equal(t, 4, series[0].Occurrences)
+	assert.Equal(t, 260000.0, series[0].AverageAmount)
+	assert.Equal(t, time.Date(2026, 5, 15, 8, 0, 0, 0, time.UTC), series[0].NextExpectedDate)
+	assert.False(t, series[0].Missed)
+	assert.False(t, series[0].PriceChanged)
+}
+
+func TestDetectRecurring_GroupsSimilarDescriptions(t *testing.T) {
+	start := time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)
+	transactions := monthlyCharges("", start, 500000, 520000, 480000)
+	transactions[0].Description = "EVN thanh toan hoa don 0112"
+	transactions[1].Description = "EVN thanh toan hoa don 0212"
+	transactions[2].Description = "evn THANH TOAN hoa don 0312"
+
+	series := detectRecurring(transactions, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+
+	assert.Len(t, series, 1)
+	assert.Equal(t, "evn THANH TOAN hoa don 0312", series[0].Payee)
+}
+
+func TestDetectRecurring_PriceChange(t *testing.T) {
+	start := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	transactions := monthlyCharges("Netflix", start, 220000, 220000, 220000, 260000)
+
+	series := detectRecurring(transactions, time.Date(2026, 4, 16, 0, 0, 0, 0, time.UTC))
+
+	assert.Len(t, series, 1)
+	assert.True(t, series[0].PriceChanged)
+	assert.Equal(t, 220000.0, series[0].PreviousAmount)
+	assert.InDelta(t, 18.18, series[0].PriceChangePercent, 0.01)
+}
+
+func TestDetectRecurring_MissedCharge(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	transactions := monthlyCharges("Landlord", start, 5000000, 5000000, 5000000)
+
+	// Next charge expected on March 1st, grace period is a week
+	series := detectRecurring(transactions, time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC))
+
+	assert.Len(t, series, 1)
+	assert.True(t, series[0].Missed)
+}
+
+func TestDetectRecurring_Weekly(t *testing.T) {
+	start := time.Date(2026, 2, 2, 0, 0, 0, 0, time.UTC)
+	var transactions []domain.Transaction
+	for i := 0; i < 4; i++ {
+		transactions = append(transactions, domain.Transaction{
+			Type:            domain.TransactionTypeOut,
+			Recipient:       "Gym",
+			Amount:          100000,
+			TransactionDate: start.AddDate(0, 0, 7*i),
+		})
+	}
+
+	series := detectRecurring(transactions, start.AddDate(0, 0, 22))
+
+	assert.Len(t, series, 1)
+	assert.Equal(t, domain.RecurringIntervalWeekly, series[0].Interval)
+	assert.InDelta(t, 100000*52.0/12, series[0].MonthlyAmount, 0.01)
+}
+
+func TestDetectRecurring_IgnoresIrregularAndInconsistent(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Irregular gaps
+	irregular := []domain.Transaction{
+		{Type: domain.TransactionTypeOut, Recipient: "Coffee", Amount: 50000, TransactionDate: start},
+		{Type: domain.TransactionTypeOut, Recipient: "Coffee", Amount: 50000, TransactionDate: start.AddDate(0, 0, 2)},
+		{Type: domain.TransactionTypeOut, Recipient: "Coffee", Amount: 50000, TransactionDate: start.AddDate(0, 0, 19)},
+	}
+	// Monthly but with wildly different amounts
+	inconsistent := monthlyCharges("Shop", start, 100000, 900000, 300000)
+
+	series := detectRecurring(append(irregular, inconsistent...), start.AddDate(0, 2, 0))
+
+	assert.Empty(t, series)
+}
+
+func TestRecurringService_GetRecurring_FiltersByType(t *testing.T) {
+	start := time.Date(2026, 1, 25, 0, 0, 0, 0, time.UTC)
+	salary := monthlyCharges("ACME Corp", start, 30000000, 30000000, 30000000)
+	for i := range salary {
+		salary[i].Type = domain.TransactionTypeIn
+	}
+	rent := monthlyCharges("Landlord", start, 5000000, 5000000, 5000000)
+	repo := &mockRecurringRepository{transactions: append(salary, rent...)}
+
+	svc := NewRecurringService(repo, 12).(*recurringService)
+	svc.now = func() time.Time { return time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC) }
+
+	all, err := svc.GetRecurring(domain.RecurringQueryParams{})
+	assert.NoError(t, err)
+	assert.Len(t, all.Series, 2)
+
+	out, err := svc.GetRecurring(domain.RecurringQueryParams{Type: domain.TransactionTypeOut})
+	assert.NoError(t, err)
+	assert.Len(t, out.Series, 1)
+	assert.Equal(t, 5000000.0, out.MonthlyTotal)
+
+	// The cached analysis is reused
+	assert.Equal(t, 1, repo.calls)
+}
+
+func TestRecurringService_GetRecurring_RepositoryError(t *testing.T) {
+	svc := NewRecurringService(&mockRecurringRepository{err: errors.New("database error")}, 12)
+
+	_, err := svc.GetRecurring(domain.RecurringQueryParams{})
+
+	assert.Error(t, err)
+}
+
+func TestRecurringService_GetRecurring_InvalidType(t *testing.T) {
+	svc := NewRecurringService(&mockRecurringRepository{}, 12)
+
+	_, err := svc.GetRecurring(domain.RecurringQueryParams{Type: "sideways"})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
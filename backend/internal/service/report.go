@@ -0,0 +1,412 @@
+package service
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/jung-kurt/gofpdf"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+//go:embed templates/monthly_report.html
+var monthlyReportHTML string
+
+// reportNumberFormat is how amounts are written in reports
+var reportNumberFormat = domain.NumberFormat{Decimal: ".", Group: ","}
+
+// budgetStatusLabels are the display names of budget statuses
+var budgetStatusLabels = map[string]string{
+	domain.BudgetStatusOnTrack:  "On track",
+	domain.BudgetStatusWarning:  "Warning",
+	domain.BudgetStatusExceeded: "Exceeded",
+}
+
+// reportFuncs are the helpers available to report templates
+var reportFuncs = template.FuncMap{
+	"money":    reportMoney,
+	"percent":  reportPercent,
+	"change":   reportChange,
+	"signed":   reportSignedAmount,
+	"status":   reportStatus,
+	"date":     func(t time.Time) string { return t.UTC().Format("2006-01-02") },
+	"datetime": func(t time.Time) string { return t.UTC().Format("2006-01-02 15:04 UTC") },
+}
+
+var monthlyReportTemplate = template.Must(template.New("monthly_report").Funcs(reportFuncs).Parse(monthlyReportHTML))
+
+// ReportService builds the monthly statement and renders it to HTML or PDF
+type ReportService interface {
+	GetMonthlyReport(ctx context.Context, params *domain.MonthlyReportQueryParams) (*domain.MonthlyReport, error)
+	RenderMonthlyReport(w io.Writer, report *domain.MonthlyReport, format domain.ReportFormat) error
+}
+
+type reportService struct {
+	txRepo     repository.TransactionRepository
+	payeeRepo  repository.PayeeRepository
+	budgetRepo repository.BudgetRepository
+	currency   string
+	fontPath   string
+	now        func() time.Time
+}
+
+// NewReportService creates a new report service. currency labels the
+// amounts; fontPath optionally names a UTF-8 TrueType font for PDFs, without
+// which text is reduced to the Latin-1 range of the built-in PDF fonts.
+func NewReportService(txRepo repository.TransactionRepository, payeeRepo repository.PayeeRepository, budgetRepo repository.BudgetRepository, currency, fontPath string) ReportService {
+	return &reportService{
+		txRepo:     txRepo,
+		payeeRepo:  payeeRepo,
+		budgetRepo: budgetRepo,
+		currency:   currency,
+		fontPath:   fontPath,
+		now:        time.Now,
+	}
+}
+
+// GetMonthlyReport collects the statement of a calendar month. Budget status
+// uses the current budgets; for the running month it is projected from the
+// days elapsed so far. Cancelling ctx stops reading the transactions.
+func (s *reportService) GetMonthlyReport(ctx context.Context, params *domain.MonthlyReportQueryParams) (*domain.MonthlyReport, error) {
+	now := s.now().UTC()
+	if err := params.Validate(now); err != nil {
+		return nil, err
+	}
+
+	month, _ := time.Parse(domain.MonthLayout, params.Month)
+	start, end := domain.MonthPeriod(month)
+	previousStart, previousEnd := domain.MonthPeriod(start.AddDate(0, -1, 0))
+	dates := domain.DateRange{Start: &start, End: &end}
+
+	report := &domain.MonthlyReport{
+		Month:       params.Month,
+		Currency:    s.currency,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		GeneratedAt: now,
+	}
+
+	var err error
+	report.Summary, report.BiggestTransactions, err = summarizePeriod(ctx, s.txRepo, start, end, domain.ReportBiggestTransactions)
+	if err != nil {
+		return nil, err
+	}
+	report.PreviousSummary, _, err = summarizePeriod(ctx, s.txRepo, previousStart, previousEnd, 0)
+	if err != nil {
+		return nil, err
+	}
+	report.Comparison = domain.ComparePeriods(report.Summary, report.PreviousSummary)
+
+	report.Categories, err = s.txRepo.GetBreakdown(&domain.BreakdownFilter{
+		Type:      domain.TransactionTypeOut,
+		GroupBy:   domain.BreakdownByCategory,
+		DateRange: dates,
+	})
+	if err != nil {
+		return nil, err
+	}
+	report.Sources, err = s.txRepo.GetBreakdown(&domain.BreakdownFilter{
+		Type:      domain.TransactionTypeOut,
+		GroupBy:   domain.BreakdownBySource,
+		DateRange: dates,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.TopPayees, err = s.payeeRepo.GetBreakdown(&domain.PayeeBreakdownFilter{
+		DateRange: dates,
+		Type:      domain.TransactionTypeOut,
+		Limit:     domain.ReportTopPayees,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.budgetRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	spending, err := s.budgetRepo.GetCategorySpending(start, end)
+	if err != nil {
+		return nil, err
+	}
+	daysElapsed := end.Day()
+	if now.Before(end) {
+		daysElapsed = now.Day()
+	}
+	report.Budgets, report.TotalBudget, report.TotalBudgetSpent = summarizeBudgets(budgets, spending, daysElapsed, end.Day())
+
+	return report, nil
+}
+
+// summarizePeriod totals the transactions between start and end and keeps the
+// limit largest ones, biggest first
+func summarizePeriod(ctx context.Context, txRepo repository.TransactionRepository, start, end time.Time, limit int) (domain.PeriodSummary, []domain.Transaction, error) {
+	var summary domain.PeriodSummary
+	biggest := make([]domain.Transaction, 0, limit)
+
+	params := domain.ListTransactionsQueryParams{
+		StartDate: start.Format(time.RFC3339),
+		EndDate:   end.Add(time.Nanosecond).Format(time.RFC3339),
+	}
+	err := txRepo.Stream(ctx, params, func(tx *domain.Transaction) error {
+		// The end filter is inclusive and only second precision; drop
+		// anything from the first instant of the next period
+		if tx.TransactionDate.After(end) {
+			return nil
+		}
+
+		summary.TransactionCount++
+		if tx.Type == domain.TransactionTypeIn {
+			summary.Income += tx.Amount
+		} else {
+			summary.Expense += tx.Amount
+		}
+
+		if limit == 0 || (len(biggest) == limit && tx.Amount <= biggest[limit-1].Amount) {
+			return nil
+		}
+		i := sort.Search(len(biggest), func(i int) bool { return biggest[i].Amount < tx.Amount })
+		if len(biggest) < limit {
+			biggest = append(biggest, domain.Transaction{})
+		}
+		copy(biggest[i+1:], biggest[i:])
+		biggest[i] = *tx
+		return nil
+	})
+	if err != nil {
+		return summary, nil, err
+	}
+
+	summary.Net = summary.Income - summary.Expense
+	return summary, biggest, nil
+}
+
+// RenderMonthlyReport writes a report as an HTML page or a PDF document
+func (s *reportService) RenderMonthlyReport(w io.Writer, report *domain.MonthlyReport, format domain.ReportFormat) error {
+	if format == domain.ReportFormatPDF {
+		return s.renderMonthlyReportPDF(w, report)
+	}
+	return monthlyReportTemplate.Execute(w, report)
+}
+
+// PDF layout, in millimetres on A4 portrait
+const (
+	pdfMargin    = 15.0
+	pdfLineWidth = 180.0
+	pdfRowHeight = 6.0
+)
+
+// pdfColumn is one column of a PDF table
+type pdfColumn struct {
+	title string
+	width float64
+	align string // L or R
+}
+
+// renderMonthlyReportPDF lays out the same sections as the HTML template
+func (s *reportService) renderMonthlyReportPDF(w io.Writer, report *domain.MonthlyReport) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetTitle("Monthly statement "+report.Month, true)
+	pdf.SetCreator("personal-finance-tracker", true)
+
+	family, text := "Helvetica", pdfLatinText(pdf)
+	if s.fontPath != "" {
+		family, text = "ReportFont", func(value string) string { return value }
+		pdf.AddUTF8Font(family, "", s.fontPath)
+		pdf.AddUTF8Font(family, "B", s.fontPath)
+	}
+	pdf.AddPage()
+
+	heading := func(title string) {
+		pdf.Ln(4)
+		pdf.SetFont(family, "B", 13)
+		pdf.CellFormat(pdfLineWidth, 9, text(title), "B", 1, "L", false, 0, "")
+		pdf.Ln(1)
+	}
+	table := func(columns []pdfColumn, rows [][]string, empty string) {
+		if len(rows) == 0 {
+			pdf.SetFont(family, "", 10)
+			pdf.SetTextColor(119, 119, 119)
+			pdf.CellFormat(pdfLineWidth, pdfRowHeight, text(empty), "", 1, "L", false, 0, "")
+			pdf.SetTextColor(0, 0, 0)
+			return
+		}
+		pdf.SetFont(family, "B", 9)
+		pdf.SetFillColor(246, 246, 246)
+		for _, column := range columns {
+			pdf.CellFormat(column.width, pdfRowHeight, text(column.title), "B", 0, column.align, true, 0, "")
+		}
+		pdf.Ln(-1)
+		pdf.SetFont(family, "", 9)
+		for _, row := range rows {
+			for i, column := range columns {
+				pdf.CellFormat(column.width, pdfRowHeight, pdfFit(pdf, text, row[i], column.width), "B", 0, column.align, false, 0, "")
+			}
+			pdf.Ln(-1)
+		}
+	}
+
+	pdf.SetFont(family, "B", 18)
+	pdf.CellFormat(pdfLineWidth, 10, text("Monthly statement"), "", 1, "L", false, 0, "")
+	pdf.SetFont(family, "", 9)
+	pdf.SetTextColor(119, 119, 119)
+	pdf.CellFormat(pdfLineWidth, 5, text(fmt.Sprintf("%s - %s, generated %s, amounts in %s",
+		report.PeriodStart.Format("2006-01-02"), report.PeriodEnd.Format("2006-01-02"),
+		report.GeneratedAt.Format("2006-01-02 15:04 UTC"), report.Currency)), "", 1, "L", false, 0, "")
+	pdf.SetTextColor(0, 0, 0)
+
+	heading("Summary")
+	table([]pdfColumn{{"", 45, "L"}, {report.Month, 45, "R"}, {"Prior month", 45, "R"}, {"Change", 45, "R"}}, [][]string{
+		{"Income", reportMoney(report.Summary.Income), reportMoney(report.PreviousSummary.Income), reportChange(report.Comparison.IncomeChange, report.Comparison.IncomePercent)},
+		{"Expenses", reportMoney(report.Summary.Expense), reportMoney(report.PreviousSummary.Expense), reportChange(report.Comparison.ExpenseChange, report.Comparison.ExpensePercent)},
+		{"Net", reportMoney(report.Summary.Net), reportMoney(report.PreviousSummary.Net), reportChange(report.Comparison.NetChange, nil)},
+		{"Transactions", fmt.Sprint(report.Summary.TransactionCount), fmt.Sprint(report.PreviousSummary.TransactionCount), ""},
+	}, "")
+
+	breakdownColumns := func(label string) []pdfColumn {
+		return []pdfColumn{{label, 90, "L"}, {"Transactions", 30, "R"}, {"Amount", 35, "R"}, {"Share", 25, "R"}}
+	}
+	breakdownRows := func(items []domain.BreakdownResponse) [][]string {
+		rows := make([][]string, 0, len(items))
+		for _, item := range items {
+			rows = append(rows, []string{firstNonEmpty(item.Label, "Uncategorized"), fmt.Sprint(item.Count), reportMoney(item.Amount), reportPercent(item.Percentage)})
+		}
+		return rows
+	}
+
+	heading("Expenses by category")
+	table(breakdownColumns("Category"), breakdownRows(report.Categories), "No expenses this month.")
+
+	heading("Expenses by source")
+	table(breakdownColumns("Source"), breakdownRows(report.Sources), "No expenses this month.")
+
+	heading("Top payees")
+	payees := make([][]string, 0, len(report.TopPayees))
+	for _, payee := range report.TopPayees {
+		payees = append(payees, []string{payee.Name, fmt.Sprint(payee.Count), reportMoney(payee.Amount), reportPercent(payee.Percentage)})
+	}
+	table(breakdownColumns("Payee"), payees, "No payees this month.")
+
+	heading("Biggest transactions")
+	transactions := make([][]string, 0, len(report.BiggestTransactions))
+	for i := range report.BiggestTransactions {
+		tx := &report.BiggestTransactions[i]
+		transactions = append(transactions, []string{tx.TransactionDate.UTC().Format("2006-01-02"),
+			firstNonEmpty(tx.Recipient, tx.Description), tx.Category, tx.Source, reportSignedAmount(tx.Type, tx.Amount)})
+	}
+	table([]pdfColumn{{"Date", 25, "L"}, {"Recipient", 65, "L"}, {"Category", 30, "L"}, {"Source", 30, "L"}, {"Amount", 30, "R"}},
+		transactions, "No transactions this month.")
+
+	heading("Budgets")
+	budgets := make([][]string, 0, len(report.Budgets)+1)
+	for _, budget := range report.Budgets {
+		name := budget.Category
+		if budget.Source != "" {
+			name += " / " + budget.Source
+		}
+		budgets = append(budgets, []string{name, reportMoney(budget.Amount), reportMoney(budget.Spent),
+			reportMoney(budget.Remaining), reportPercent(budget.PercentUsed), reportStatus(budget.Status)})
+	}
+	if len(budgets) > 0 {
+		budgets = append(budgets, []string{"Total", reportMoney(report.TotalBudget), reportMoney(report.TotalBudgetSpent), "", "", ""})
+	}
+	table([]pdfColumn{{"Budget", 50, "L"}, {"Amount", 27, "R"}, {"Spent", 27, "R"}, {"Remaining", 27, "R"}, {"Used", 22, "R"}, {"Status", 27, "L"}},
+		budgets, "No budgets defined.")
+
+	return pdf.Output(w)
+}
+
+// pdfLatinText returns a translator from UTF-8 to the code page of the
+// built-in PDF fonts. Accents outside it are dropped (Vietnamese "Đồng"
+// becomes "Dong") rather than turned into dots.
+func pdfLatinText(pdf *gofpdf.Fpdf) func(string) string {
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+	return func(value string) string {
+		var b strings.Builder
+		for _, r := range value {
+			if r < 0x100 {
+				b.WriteRune(r)
+				continue
+			}
+			switch r {
+			case 'Đ':
+				b.WriteByte('D')
+				continue
+			case 'đ':
+				b.WriteByte('d')
+				continue
+			}
+			for _, base := range norm.NFD.String(string(r)) {
+				if !unicode.Is(unicode.Mn, base) {
+					b.WriteRune(base)
+				}
+			}
+		}
+		return translate(norm.NFC.String(b.String()))
+	}
+}
+
+// pdfFit translates value and shortens it with an ellipsis until it fits in
+// a cell of width
+func pdfFit(pdf *gofpdf.Fpdf, text func(string) string, value string, width float64) string {
+	const padding = 2.0
+	fitted := text(value)
+	runes := []rune(value)
+	for len(runes) > 0 && pdf.GetStringWidth(fitted)+padding > width {
+		runes = runes[:len(runes)-1]
+		fitted = text(string(runes) + "...")
+	}
+	return fitted
+}
+
+// reportMoney formats an amount with thousands separators
+func reportMoney(value float64) string {
+	return reportNumberFormat.Format(value)
+}
+
+// reportPercent formats a percentage with one decimal
+func reportPercent(value float64) string {
+	return fmt.Sprintf("%.1f%%", value)
+}
+
+// reportChange formats a change as a signed amount, followed by the relative
+// change when there is one
+func reportChange(amount float64, percent *float64) string {
+	formatted := reportMoney(amount)
+	if amount > 0 {
+		formatted = "+" + formatted
+	}
+	if percent != nil {
+		formatted += fmt.Sprintf(" (%+.1f%%)", *percent)
+	}
+	return formatted
+}
+
+// reportSignedAmount formats a transaction amount, negative for money out
+func reportSignedAmount(txType domain.TransactionType, amount float64) string {
+	if txType == domain.TransactionTypeOut {
+		return "-" + reportMoney(amount)
+	}
+	return "+" + reportMoney(amount)
+}
+
+// reportStatus returns the display name of a budget status
+func reportStatus(status string) string {
+	if label, ok := budgetStatusLabels[status]; ok {
+		return label
+	}
+	return status
+}
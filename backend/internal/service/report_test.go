@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// monthStream streams the transactions dated within the params' date range
+func monthStream(transactions []domain.Transaction) func(domain.ListTransactionsQueryParams, func(*domain.Transaction) error) error {
+	return func(params domain.ListTransactionsQueryParams, fn func(*domain.Transaction) error) error {
+		start, _ := time.Parse(time.RFC3339, params.StartDate)
+		end, _ := time.Parse(time.RFC3339, params.EndDate)
+		for i := range transactions {
+			date := transactions[i].TransactionDate
+			if date.Before(start) || date.After(end) {
+				continue
+			}
+			if err := fn(&transactions[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func reportTransactions() []domain.Transaction {
+	return []domain.Transaction{
+		{ID: 1, Type: domain.TransactionTypeIn, Amount: 5000, Recipient: "Employer", Category: "Salary", Source: "Bank", TransactionDate: time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: 2, Type: domain.TransactionTypeOut, Amount: 1200, Recipient: "Landlord", Category: "Housing", Source: "Bank", TransactionDate: time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)},
+		{ID: 3, Type: domain.TransactionTypeOut, Amount: 45.5, Recipient: "Grab", Category: "Transportation", Source: "Momo", TransactionDate: time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)},
+		{ID: 4, Type: domain.TransactionTypeOut, Amount: 300, Recipient: "Supermarket", Category: "Food", Source: "Bank", TransactionDate: time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)},
+		// Prior month and the first instant of the next month
+		{ID: 5, Type: domain.TransactionTypeIn, Amount: 4000, Category: "Salary", Source: "Bank", TransactionDate: time.Date(2026, 2, 1, 9, 0, 0, 0, time.UTC)},
+		{ID: 6, Type: domain.TransactionTypeOut, Amount: 1000, Category: "Housing", Source: "Bank", TransactionDate: time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)},
+		{ID: 7, Type: domain.TransactionTypeOut, Amount: 999, Category: "Food", Source: "Bank", TransactionDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+// newTestReportService creates a report service over mock repositories with a fixed clock
+func newTestReportService(txRepo *mockRepository, budgetRepo *mockBudgetRepository, now time.Time) *reportService {
+	svc := NewReportService(txRepo, &mockPayeeRepository{}, budgetRepo, "VND", "").(*reportService)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestGetMonthlyReport(t *testing.T) {
+	var filters []*domain.BreakdownFilter
+	txRepo := &mockRepository{
+		streamFunc: monthStream(reportTransactions()),
+		getBreakdownFunc: func(filter *domain.BreakdownFilter) ([]domain.BreakdownResponse, error) {
+			filters = append(filters, filter)
+			return []domain.BreakdownResponse{{Label: "Housing", Amount: 1200, Count: 1, Percentage: 77.6}}, nil
+		},
+	}
+	budgetRepo := &mockBudgetRepository{
+		budgets:  []domain.Budget{{ID: 1, Category: "Food", Amount: 250}},
+		spending: []domain.CategorySpending{{Category: "Food", Source: "Bank", Amount: 300}},
+	}
+	svc := newTestReportService(txRepo, budgetRepo, time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC))
+
+	report, err := svc.GetMonthlyReport(context.Background(), &domain.MonthlyReportQueryParams{Month: "2026-03"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "2026-03", report.Month)
+	assert.Equal(t, "VND", report.Currency)
+	assert.Equal(t, domain.PeriodSummary{Income: 5000, Expense: 1545.5, Net: 3454.5, TransactionCount: 4}, report.Summary)
+	assert.Equal(t, domain.PeriodSummary{Income: 4000, Expense: 1000, Net: 3000, TransactionCount: 2}, report.PreviousSummary)
+	require.NotNil(t, report.Comparison.IncomePercent)
+	assert.InDelta(t, 25, *report.Comparison.IncomePercent, 0.001)
+	assert.InDelta(t, 454.5, report.Comparison.NetChange, 0.001)
+
+	// Biggest first, without the transaction of the next month
+	ids := make([]int64, 0, len(report.BiggestTransactions))
+	for _, tx := range report.BiggestTransactions {
+		ids = append(ids, tx.ID)
+	}
+	assert.Equal(t, []int64{1, 2, 4, 3}, ids)
+
+	require.Len(t, filters, 2)
+	assert.Equal(t, domain.BreakdownByCategory, filters[0].GroupBy)
+	assert.Equal(t, domain.BreakdownBySource, filters[1].GroupBy)
+	assert.Equal(t, domain.TransactionTypeOut, filters[0].Type)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), *filters[0].Start)
+	assert.Len(t, report.TopPayees, 1)
+
+	// A past month counts as fully elapsed
+	require.Len(t, report.Budgets, 1)
+	assert.Equal(t, domain.BudgetStatusExceeded, report.Budgets[0].Status)
+	assert.InDelta(t, 300, report.Budgets[0].ProjectedSpend, 0.001)
+	assert.Equal(t, 250.0, report.TotalBudget)
+	assert.Equal(t, 300.0, report.TotalBudgetSpent)
+}
+
+func TestGetMonthlyReport_BiggestTransactionsLimit(t *testing.T) {
+	transactions := make([]domain.Transaction, 0, 15)
+	for i := 1; i <= 15; i++ {
+		transactions = append(transactions, domain.Transaction{
+			ID: int64(i), Type: domain.TransactionTypeOut, Amount: float64(i * 10),
+			TransactionDate: time.Date(2026, 3, i, 0, 0, 0, 0, time.UTC),
+		})
+	}
+	svc := newTestReportService(&mockRepository{streamFunc: monthStream(transactions)}, &mockBudgetRepository{}, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	report, err := svc.GetMonthlyReport(context.Background(), &domain.MonthlyReportQueryParams{Month: "2026-03"})
+	require.NoError(t, err)
+
+	require.Len(t, report.BiggestTransactions, domain.ReportBiggestTransactions)
+	assert.Equal(t, 150.0, report.BiggestTransactions[0].Amount)
+	assert.Equal(t, 60.0, report.BiggestTransactions[domain.ReportBiggestTransactions-1].Amount)
+	assert.Nil(t, report.Comparison.ExpensePercent, "no prior month to compare against")
+}
+
+func TestGetMonthlyReport_ValidationError(t *testing.T) {
+	svc := newTestReportService(&mockRepository{}, &mockBudgetRepository{}, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	_, err := svc.GetMonthlyReport(context.Background(), &domain.MonthlyReportQueryParams{Month: "2026-04"})
+
+	var validationErr *domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "month", validationErr.Field)
+}
+
+func TestGetMonthlyReport_RepositoryError(t *testing.T) {
+	txRepo := &mockRepository{
+		streamFunc: func(domain.ListTransactionsQueryParams, func(*domain.Transaction) error) error {
+			return errors.New("connection refused")
+		},
+	}
+	svc := newTestReportService(txRepo, &mockBudgetRepository{}, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+
+	_, err := svc.GetMonthlyReport(context.Background(), &domain.MonthlyReportQueryParams{})
+	assert.Error(t, err)
+}
+
+func TestGetMonthlyReport_Cancelled(t *testing.T) {
+	svc := newTestReportService(&mockRepository{}, &mockBudgetRepository{}, time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := svc.GetMonthlyReport(ctx, &domain.MonthlyReportQueryParams{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func testMonthlyReport() *domain.MonthlyReport {
+	percent := 12.5
+	return &domain.MonthlyReport{
+		Month:       "2026-03",
+		Currency:    "VND",
+		PeriodStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC),
+		GeneratedAt: time.Date(2026, 4, 1, 8, 0, 0, 0, time.UTC),
+		Summary:     domain.PeriodSummary{Income: 5000, Expense: 1545.5, Net: 3454.5, TransactionCount: 4},
+		Comparison:  domain.PeriodComparison{IncomeChange: 1000, IncomePercent: &percent},
+		Categories:  []domain.BreakdownResponse{{Label: "Housing", Amount: 1200, Count: 1, Percentage: 77.6}},
+		TopPayees:   []domain.PayeeBreakdown{{Name: "Cà phê <Highlands>", Amount: 80, Count: 2}},
+		BiggestTransactions: []domain.Transaction{
+			{Type: domain.TransactionTypeOut, Amount: 1200, Recipient: "Landlord", Category: "Housing", Source: "Bank", TransactionDate: time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		Budgets: []domain.BudgetProgress{{Category: "Food", Amount: 250, Spent: 300, Remaining: -50, PercentUsed: 120, Status: domain.BudgetStatusExceeded}},
+	}
+}
+
+func TestRenderMonthlyReport_HTML(t *testing.T) {
+	svc := newTestReportService(&mockRepository{}, &mockBudgetRepository{}, time.Now())
+
+	var buf bytes.Buffer
+	require.NoError(t, svc.RenderMonthlyReport(&buf, testMonthlyReport(), domain.ReportFormatHTML))
+
+	html := buf.String()
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "5,000.00")
+	assert.Contains(t, html, "&#43;1,000.00 (&#43;12.5%)")
+	assert.Contains(t, html, "-1,200.00")
+	assert.Contains(t, html, "Cà phê &lt;Highlands&gt;", "values are escaped")
+	assert.Contains(t, html, "Exceeded")
+	assert.Contains(t, html, "No expenses this month.", "empty source breakdown")
+}
+
+func TestRenderMonthlyReport_PDF(t *testing.T) {
+	svc := newTestReportService(&mockRepository{}, &mockBudgetRepository{}, time.Now())
+
+	var buf bytes.Buffer
+	require.NoError(t, svc.RenderMonthlyReport(&buf, testMonthlyReport(), domain.ReportFormatPDF))
+
+	assert.True(t, bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")))
+	assert.True(t, bytes.Contains(buf.Bytes(), []byte("%%EOF")))
+}
+
+func TestPDFLatinText(t *testing.T) {
+	text := pdfLatinText(gofpdf.New("P", "mm", "A4", ""))
+
+	assert.Equal(t, "Dong", text("Đồng"))
+	assert.Equal(t, "Nguyen Van A", text("Nguyễn Văn A"))
+	assert.Equal(t, "C\xe0 ph\xea", text("Cà phê"), "Latin-1 accents are kept")
+}
+
+func TestReportChange(t *testing.T) {
+	percent := -20.0
+	assert.Equal(t, "-250.00 (-20.0%)", reportChange(-250, &percent))
+	assert.Equal(t, "+1,000.00", reportChange(1000, nil))
+	assert.Equal(t, "0.00", reportChange(0, nil))
+}
@@ -0,0 +1,286 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// maxUpcomingPerSchedule bounds the occurrences listed for a single schedule
+const maxUpcomingPerSchedule = 1000
+
+// minPartialRecipientWords is the number of words a transaction's recipient
+// or description needs to match a schedule by being part of its recipient, so
+// a single common word such as a family name does not match every schedule
+const minPartialRecipientWords = 2
+
+// ScheduledService handles business logic for scheduled transactions
+type ScheduledService interface {
+	CreateScheduled(req *domain.ScheduledTransactionRequest) (*domain.ScheduledTransaction, error)
+	GetScheduled(id int64) (*domain.ScheduledTransaction, error)
+	ListScheduled() ([]domain.ScheduledTransaction, error)
+	UpdateScheduled(id int64, req *domain.ScheduledTransactionRequest) (*domain.ScheduledTransaction, error)
+	DeleteScheduled(id int64) error
+	ListPayments(id int64) ([]domain.ScheduledPayment, error)
+	ListUpcoming(params domain.UpcomingQueryParams) (*domain.UpcomingResponse, error)
+	MatchTransaction(tx *domain.Transaction) (*domain.ScheduledPayment, error)
+	OnTransactionsCreated(transactions []domain.Transaction)
+}
+
+type scheduledService struct {
+	repo repository.ScheduledRepository
+	now  func() time.Time
+}
+
+// NewScheduledService creates a new scheduled transaction service
+func NewScheduledService(repo repository.ScheduledRepository) ScheduledService {
+	return &scheduledService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+func (s *scheduledService) CreateScheduled(req *domain.ScheduledTransactionRequest) (*domain.ScheduledTransaction, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	scheduled := req.ToScheduledTransaction()
+	if err := s.repo.Create(scheduled); err != nil {
+		return nil, err
+	}
+
+	return scheduled, nil
+}
+
+func (s *scheduledService) GetScheduled(id int64) (*domain.ScheduledTransaction, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid scheduled transaction ID")
+	}
+	return s.repo.FindByID(id)
+}
+
+func (s *scheduledService) ListScheduled() ([]domain.ScheduledTransaction, error) {
+	return s.repo.List()
+}
+
+// UpdateScheduled replaces the recurrence rule while keeping the paid occurrences
+func (s *scheduledService) UpdateScheduled(id int64, req *domain.ScheduledTransactionRequest) (*domain.ScheduledTransaction, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.GetScheduled(id)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduled := req.ToScheduledTransaction()
+	scheduled.ID = existing.ID
+	scheduled.CreatedAt = existing.CreatedAt
+	scheduled.Occurrences = existing.Occurrences
+	scheduled.NextDueDate = scheduled.DueDate(scheduled.Occurrences)
+	scheduled.Active = scheduled.HasOccurrence(scheduled.Occurrences)
+
+	if err := s.repo.Update(scheduled); err != nil {
+		return nil, err
+	}
+
+	return scheduled, nil
+}
+
+func (s *scheduledService) DeleteScheduled(id int64) error {
+	if id <= 0 {
+		return errors.New("invalid scheduled transaction ID")
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *scheduledService) ListPayments(id int64) ([]domain.ScheduledPayment, error) {
+	if _, err := s.GetScheduled(id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListPayments(id)
+}
+
+// ListUpcoming returns every unpaid occurrence due within the next params.Days
+// days, including overdue occurrences from the past
+func (s *scheduledService) ListUpcoming(params domain.UpcomingQueryParams) (*domain.UpcomingResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := s.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	horizon := today.AddDate(0, 0, params.Days+1).Add(-time.Nanosecond)
+
+	schedules, err := s.repo.ListDueBefore(horizon)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &domain.UpcomingResponse{
+		Data: []domain.UpcomingTransaction{},
+		Days: params.Days,
+	}
+
+	for i := range schedules {
+		scheduled := &schedules[i]
+		for n := scheduled.Occurrences; n < scheduled.Occurrences+maxUpcomingPerSchedule && scheduled.HasOccurrence(n); n++ {
+			due := scheduled.DueDate(n)
+			if due.After(horizon) {
+				break
+			}
+
+			item := upcomingOccurrence(scheduled, due, today)
+			response.Data = append(response.Data, item)
+
+			if item.Status == domain.UpcomingStatusOverdue {
+				response.Overdue++
+			}
+			if item.Type == domain.TransactionTypeIn {
+				response.TotalIn += item.Amount
+			} else {
+				response.TotalOut += item.Amount
+			}
+		}
+	}
+
+	sort.SliceStable(response.Data, func(i, j int) bool {
+		return response.Data[i].DueDate.Before(response.Data[j].DueDate)
+	})
+
+	return response, nil
+}
+
+// upcomingOccurrence describes one unpaid occurrence relative to today
+func upcomingOccurrence(scheduled *domain.ScheduledTransaction, due, today time.Time) domain.UpcomingTransaction {
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(math.Round(dueDay.Sub(today).Hours() / 24))
+
+	status := domain.UpcomingStatusUpcoming
+	switch {
+	case days < 0:
+		status = domain.UpcomingStatusOverdue
+	case days == 0:
+		status = domain.UpcomingStatusDueToday
+	}
+
+	return domain.UpcomingTransaction{
+		ScheduledTransactionID: scheduled.ID,
+		Name:                   scheduled.Name,
+		Type:                   scheduled.Type,
+		Category:               scheduled.Category,
+		Recipient:              scheduled.Recipient,
+		Amount:                 scheduled.Amount,
+		DueDate:                due,
+		DaysUntilDue:           days,
+		Status:                 status,
+	}
+}
+
+// MatchTransaction marks the earliest unpaid occurrence matching tx as paid.
+// It returns nil when no scheduled transaction matches.
+func (s *scheduledService) MatchTransaction(tx *domain.Transaction) (*domain.ScheduledPayment, error) {
+	// Payments may arrive up to a week early
+	candidates, err := s.repo.ListDueBefore(tx.TransactionDate.AddDate(0, 0, domain.ScheduleMatchWindowDays))
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range candidates {
+		scheduled := &candidates[i]
+		if !matchesSchedule(scheduled, tx) {
+			continue
+		}
+
+		payment := &domain.ScheduledPayment{
+			ScheduledTransactionID: scheduled.ID,
+			TransactionID:          tx.ID,
+			DueDate:                scheduled.NextDueDate,
+			Amount:                 tx.Amount,
+		}
+
+		scheduled.Occurrences++
+		if scheduled.HasOccurrence(scheduled.Occurrences) {
+			scheduled.NextDueDate = scheduled.DueDate(scheduled.Occurrences)
+		} else {
+			scheduled.Active = false
+		}
+
+		if err := s.repo.RecordPayment(scheduled, payment); err != nil {
+			return nil, err
+		}
+		return payment, nil
+	}
+
+	return nil, nil
+}
+
+// OnTransactionsCreated matches newly stored transactions against scheduled ones.
+// Matching failures are logged and never fail the incoming webhook.
+func (s *scheduledService) OnTransactionsCreated(transactions []domain.Transaction) {
+	log := logger.Get()
+	for i := range transactions {
+		payment, err := s.MatchTransaction(&transactions[i])
+		if err != nil {
+			log.Error().Err(err).Int64("transaction_id", transactions[i].ID).Msg("Failed to match scheduled transaction")
+			continue
+		}
+		if payment != nil {
+			log.Info().
+				Int64("transaction_id", payment.TransactionID).
+				Int64("scheduled_transaction_id", payment.ScheduledTransactionID).
+				Msg("Scheduled transaction marked as paid")
+		}
+	}
+}
+
+// matchesSchedule checks type, amount tolerance, source and recipient of tx against a schedule
+func matchesSchedule(scheduled *domain.ScheduledTransaction, tx *domain.Transaction) bool {
+	if tx.Type != scheduled.Type {
+		return false
+	}
+
+	tolerance := scheduled.Amount*scheduled.AmountTolerance/100 + amountTolerance
+	if math.Abs(tx.Amount-scheduled.Amount) > tolerance {
+		return false
+	}
+
+	if scheduled.Source != "" && !strings.EqualFold(scheduled.Source, tx.Source) {
+		return false
+	}
+
+	if scheduled.Recipient == "" {
+		return true
+	}
+
+	// A recipient without letters could only match everything
+	expected := normalizeText(scheduled.Recipient)
+	if expected == "" {
+		return false
+	}
+	for _, text := range []string{tx.Recipient, tx.Description} {
+		actual := normalizeText(text)
+		if actual == "" {
+			continue
+		}
+		if containsWords(actual, expected) ||
+			(len(strings.Fields(actual)) >= minPartialRecipientWords && containsWords(expected, actual)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsWords reports whether the normalized text contains the words of
+// part in order as whole words, so "electric" is not part of "electricity"
+func containsWords(text, part string) bool {
+	return strings.Contains(" "+text+" ", " "+part+" ")
+}
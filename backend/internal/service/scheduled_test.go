@@ -0,0 +1,249 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// mockScheduledRepository is a mock implementation of ScheduledRepository for testing
+type mockScheduledRepository struct {
+	scheduled []domain.ScheduledTransaction
+	payments  []domain.ScheduledPayment
+}
+
+func (m *mockScheduledRepository) Create(scheduled *domain.ScheduledTransaction) error {
+	scheduled.ID = int64(len(m.scheduled) + 1)
+	m.scheduled = append(m.scheduled, *scheduled)
+	return nil
+}
+
+func (m *mockScheduledRepository) FindByID(id int64) (*domain.ScheduledTransaction, error) {
+	for i := range m.scheduled {
+		if m.scheduled[i].ID == id {
+			scheduled := m.scheduled[i]
+			return &scheduled, nil
+		}
+	}
+	return nil, repository.ErrScheduledTransactionNotFound
+}
+
+func (m *mockScheduledRepository) List() ([]domain.ScheduledTransaction, error) {
+	return m.scheduled, nil
+}
+
+func (m *mockScheduledRepository) ListDueBefore(before time.Time) ([]domain.ScheduledTransaction, error) {
+	var due []domain.ScheduledTransaction
+	for _, scheduled := range m.scheduled {
+		if scheduled.Active && !scheduled.NextDueDate.After(before) {
+			due = append(due, scheduled)
+		}
+	}
+	return due, nil
+}
+
+func (m *mockScheduledRepository) Update(scheduled *domain.ScheduledTransaction) error {
+	for i := range m.scheduled {
+		if m.scheduled[i].ID == scheduled.ID {
+			m.scheduled[i] = *scheduled
+		}
+	}
+	return nil
+}
+
+func (m *mockScheduledRepository) Delete(id int64) error {
+	return nil
+}
+
+func (m *mockScheduledRepository) RecordPayment(scheduled *domain.ScheduledTransaction, payment *domain.ScheduledPayment) error {
+	m.payments = append(m.payments, *payment)
+	return m.Update(scheduled)
+}
+
+func (m *mockScheduledRepository) ListPayments(scheduledID int64) ([]domain.ScheduledPayment, error) {
+	return m.payments, nil
+}
+
+// rentSchedule returns a monthly rent schedule due on the 5th starting January 2026
+func rentSchedule() domain.ScheduledTransaction {
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	return domain.ScheduledTransaction{
+		ID:              1,
+		Name:            "Rent",
+		Type:            domain.TransactionTypeOut,
+		Frequency:       domain.ScheduleFrequencyMonthly,
+		Recipient:       "Nguyen Van A",
+		StartDate:       start,
+		NextDueDate:     start,
+		IntervalCount:   1,
+		Amount:          5000000,
+		AmountTolerance: 5,
+		Active:          true,
+	}
+}
+
+// Test MatchTransaction
+
+func TestScheduledService_MatchTransaction_MarksPaidAndAdvances(t *testing.T) {
+	repo := &mockScheduledRepository{scheduled: []domain.ScheduledTransaction{rentSchedule()}}
+	svc := NewScheduledService(repo)
+
+	payment, err := svc.MatchTransaction(&domain.Transaction{
+		ID:              10,
+		Type:            domain.TransactionTypeOut,
+		Recipient:       "NGUYEN VAN A",
+		Amount:          5100000,
+		TransactionDate: time.Date(2026, 1, 3, 10, 0, 0, 0, time.UTC),
+	})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, payment)
+	assert.Equal(t, int64(10), payment.TransactionID)
+	assert.Equal(t, time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC), payment.DueDate)
+	assert.Equal(t, 1, repo.scheduled[0].Occurrences)
+	assert.Equal(t, time.Date(2026, 2, 5, 0, 0, 0, 0, time.UTC), repo.scheduled[0].NextDueDate)
+}
+
+func TestScheduledService_MatchTransaction_OutsideTolerance(t *testing.T) {
+	repo := &mockScheduledRepository{scheduled: []domain.ScheduledTransaction{rentSchedule()}}
+	svc := NewScheduledService(repo)
+
+	tests := []struct {
+		name string
+		tx   domain.Transaction
+	}{
+		{"amount", domain.Transaction{Type: domain.TransactionTypeOut, Recipient: "Nguyen Van A", Amount: 6000000, TransactionDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)}},
+		{"recipient", domain.Transaction{Type: domain.TransactionTypeOut, Recipient: "Tran Thi B", Amount: 5000000, TransactionDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)}},
+		{"type", domain.Transaction{Type: domain.TransactionTypeIn, Recipient: "Nguyen Van A", Amount: 5000000, TransactionDate: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)}},
+		{"too early", domain.Transaction{Type: domain.TransactionTypeOut, Recipient: "Nguyen Van A", Amount: 5000000, TransactionDate: time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment, err := svc.MatchTransaction(&tt.tx)
+
+			assert.NoError(t, err)
+			assert.Nil(t, payment)
+		})
+	}
+	assert.Empty(t, repo.payments)
+}
+
+func TestMatchesSchedule_Recipient(t *testing.T) {
+	tests := []struct {
+		name      string
+		recipient string
+		actual    string
+		want      bool
+	}{
+		{"same recipient", "Nguyen Van A", "NGUYEN VAN A", true},
+		{"recipient in description", "Nguyen Van A", "Transfer to Nguyen Van A 0123", true},
+		{"part of the recipient", "Nguyen Van A", "Nguyen Van", true},
+		{"one letter", "Nguyen Van A", "A", false},
+		{"single word", "Nguyen Van A", "nguyen", false},
+		{"prefix of the recipient", "Nguyen Van A", "Nguyen Va", false},
+		{"single word of the description", "Annual insurance", "annual", false},
+		{"part of a word", "Electricity EVN", "electric", false},
+		{"recipient is part of a word", "EVN", "Payment EVNHCM", false},
+		{"recipient prefix of a word", "Electric", "Electricity EVN", false},
+		{"recipient without letters", "0123 4567", "Anyone at all", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduled := rentSchedule()
+			scheduled.Recipient = tt.recipient
+			tx := &domain.Transaction{Type: scheduled.Type, Amount: scheduled.Amount, Recipient: tt.actual}
+
+			assert.Equal(t, tt.want, matchesSchedule(&scheduled, tx))
+		})
+	}
+}
+
+func TestScheduledService_MatchTransaction_OnceDeactivates(t *testing.T) {
+	scheduled := rentSchedule()
+	scheduled.Frequency = domain.ScheduleFrequencyOnce
+	repo := &mockScheduledRepository{scheduled: []domain.ScheduledTransaction{scheduled}}
+	svc := NewScheduledService(repo)
+
+	svc.OnTransactionsCreated([]domain.Transaction{{
+		ID:              3,
+		Type:            domain.TransactionTypeOut,
+		Description:     "Chuyen tien nguyen van a thang 1",
+		Amount:          5000000,
+		TransactionDate: time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC),
+	}})
+
+	assert.Len(t, repo.payments, 1)
+	assert.False(t, repo.scheduled[0].Active)
+}
+
+// Test ListUpcoming
+
+func TestScheduledService_ListUpcoming_OverdueAndUpcoming(t *testing.T) {
+	salaryStart := time.Date(2026, 1, 25, 0, 0, 0, 0, time.UTC)
+	salary := domain.ScheduledTransaction{
+		ID:            2,
+		Name:          "Salary",
+		Type:          domain.TransactionTypeIn,
+		Frequency:     domain.ScheduleFrequencyMonthly,
+		StartDate:     salaryStart,
+		NextDueDate:   salaryStart.AddDate(0, 2, 0),
+		IntervalCount: 1,
+		Occurrences:   2,
+		Amount:        30000000,
+		Active:        true,
+	}
+	repo := &mockScheduledRepository{scheduled: []domain.ScheduledTransaction{rentSchedule(), salary}}
+	svc := NewScheduledService(repo).(*scheduledService)
+	svc.now = func() time.Time { return time.Date(2026, 3, 5, 14, 0, 0, 0, time.UTC) }
+
+	upcoming, err := svc.ListUpcoming(domain.UpcomingQueryParams{Days: 30})
+
+	assert.NoError(t, err)
+	// Rent: Jan 5, Feb 5 overdue, Mar 5 due today, Apr 5 out of range. Salary: Mar 25
+	assert.Len(t, upcoming.Data, 4)
+	assert.Equal(t, domain.UpcomingStatusOverdue, upcoming.Data[0].Status)
+	assert.Equal(t, -59, upcoming.Data[0].DaysUntilDue)
+	assert.Equal(t, domain.UpcomingStatusDueToday, upcoming.Data[2].Status)
+	assert.Equal(t, "Salary", upcoming.Data[3].Name)
+	assert.Equal(t, 20, upcoming.Data[3].DaysUntilDue)
+	assert.Equal(t, 2, upcoming.Overdue)
+	assert.Equal(t, 15000000.0, upcoming.TotalOut)
+	assert.Equal(t, 30000000.0, upcoming.TotalIn)
+}
+
+func TestScheduledService_ListUpcoming_InvalidDays(t *testing.T) {
+	svc := NewScheduledService(&mockScheduledRepository{})
+
+	_, err := svc.ListUpcoming(domain.UpcomingQueryParams{Days: 0})
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "days", validationErr.Field)
+}
+
+// Test UpdateScheduled
+
+func TestScheduledService_UpdateScheduled_KeepsPaidOccurrences(t *testing.T) {
+	scheduled := rentSchedule()
+	scheduled.Occurrences = 2
+	repo := &mockScheduledRepository{scheduled: []domain.ScheduledTransaction{scheduled}}
+	svc := NewScheduledService(repo)
+
+	updated, err := svc.UpdateScheduled(1, &domain.ScheduledTransactionRequest{
+		Name:      "Rent",
+		Type:      domain.TransactionTypeOut,
+		Frequency: domain.ScheduleFrequencyMonthly,
+		StartDate: "2026-01-10",
+		Amount:    5500000,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, updated.Occurrences)
+	assert.Equal(t, time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), updated.NextDueDate)
+}
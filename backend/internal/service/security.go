@@ -0,0 +1,195 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// LoginThrottledError is returned when logins of an account or a client IP
+// are blocked after failed attempts
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool // Whether the account is locked rather than delayed
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return "account temporarily locked after too many failed logins"
+	}
+	return "too many failed logins, try again later"
+}
+
+// SecurityService protects logins against password guessing and keeps the
+// security log users can review. Failed logins block the account and the
+// client IP with growing delays until the account locks; locks expire on
+// their own or are lifted with Unlock.
+type SecurityService interface {
+	CheckLogin(email string, client domain.ClientInfo) error
+	LoginFailed(user *domain.User, email string, client domain.ClientInfo) error
+	LoginSucceeded(user *domain.User, client domain.ClientInfo) error
+	MFAFailed(user *domain.User, client domain.ClientInfo) error
+	RecordEvent(userID int64, eventType string, client domain.ClientInfo, details string) error
+	ListEvents(userID int64, params domain.SecurityEventQueryParams) ([]domain.SecurityEvent, error)
+	Unlock(email string) error
+	UnlockIP(ip string) error
+	DeleteExpiredThrottles() (int64, error)
+}
+
+type securityService struct {
+	repo     repository.SecurityRepository
+	userRepo repository.UserRepository
+	policy   domain.LoginPolicy
+	now      func() time.Time
+}
+
+// NewSecurityService creates a new security service enforcing policy
+func NewSecurityService(repo repository.SecurityRepository, userRepo repository.UserRepository, policy domain.LoginPolicy) SecurityService {
+	return &securityService{
+		repo:     repo,
+		userRepo: userRepo,
+		policy:   policy,
+		now:      time.Now,
+	}
+}
+
+// CheckLogin returns a *LoginThrottledError while logins to the account of
+// email or from the client IP are blocked
+func (s *securityService) CheckLogin(email string, client domain.ClientInfo) error {
+	keys := []string{domain.LoginAccountKey(email)}
+	if client.IPAddress != "" {
+		keys = append(keys, domain.LoginIPKey(client.IPAddress))
+	}
+
+	now := s.now()
+	throttles, err := s.repo.FindBlockedLogins(keys, now)
+	if err != nil {
+		return err
+	}
+	if len(throttles) == 0 {
+		return nil
+	}
+
+	throttled := &LoginThrottledError{}
+	for _, throttle := range throttles {
+		throttled.RetryAfter = max(throttled.RetryAfter, throttle.BlockedUntil.Sub(now))
+		throttled.Locked = throttled.Locked || throttle.Locked
+	}
+	return throttled
+}
+
+// LoginFailed counts a failed login to the account of email from a client
+// and blocks further attempts. user is nil when no account has the email;
+// such attempts are throttled all the same but not logged.
+func (s *securityService) LoginFailed(user *domain.User, email string, client domain.ClientInfo) error {
+	return s.recordFailure(user, email, client, domain.SecurityEventLoginFailed)
+}
+
+// MFAFailed counts a rejected two-factor code like a failed login, so codes
+// cannot be guessed any faster than passwords
+func (s *securityService) MFAFailed(user *domain.User, client domain.ClientInfo) error {
+	return s.recordFailure(user, user.Email, client, domain.SecurityEventMFAFailed)
+}
+
+// recordFailure counts a failure of the account of email and of the client
+// IP, blocks both for their delay or locks the account, and logs eventType
+func (s *securityService) recordFailure(user *domain.User, email string, client domain.ClientInfo, eventType string) error {
+	now := s.now()
+
+	throttle, err := s.repo.RecordLoginFailure(domain.LoginAccountKey(email), now, s.policy.FailureWindow)
+	if err != nil {
+		return err
+	}
+	locked := s.policy.Locks(throttle.Failures)
+	if locked {
+		err = s.repo.BlockLogin(throttle.Key, now.Add(s.policy.LockoutDuration), true)
+	} else if delay := s.policy.Delay(throttle.Failures); delay > 0 {
+		err = s.repo.BlockLogin(throttle.Key, now.Add(delay), false)
+	}
+	if err != nil {
+		return err
+	}
+
+	if client.IPAddress != "" {
+		ipThrottle, err := s.repo.RecordLoginFailure(domain.LoginIPKey(client.IPAddress), now, s.policy.FailureWindow)
+		if err != nil {
+			return err
+		}
+		if delay := s.policy.Delay(ipThrottle.Failures); delay > 0 {
+			if err := s.repo.BlockLogin(ipThrottle.Key, now.Add(delay), false); err != nil {
+				return err
+			}
+		}
+	}
+
+	if user == nil {
+		return nil
+	}
+	if err := s.RecordEvent(user.ID, eventType, client, ""); err != nil {
+		return err
+	}
+	if locked {
+		details := fmt.Sprintf("locked for %s after %d failed logins", s.policy.LockoutDuration, throttle.Failures)
+		return s.RecordEvent(user.ID, domain.SecurityEventAccountLocked, client, details)
+	}
+	return nil
+}
+
+// LoginSucceeded forgets the failed logins of the user's account. Failures of
+// the client IP are kept, so one valid account does not reset them.
+func (s *securityService) LoginSucceeded(user *domain.User, client domain.ClientInfo) error {
+	err := s.repo.DeleteLoginThrottle(domain.LoginAccountKey(user.Email))
+	if err != nil && !errors.Is(err, repository.ErrLoginThrottleNotFound) {
+		return err
+	}
+	return s.RecordEvent(user.ID, domain.SecurityEventLoginSucceeded, client, "")
+}
+
+// RecordEvent adds an event to a user's security log
+func (s *securityService) RecordEvent(userID int64, eventType string, client domain.ClientInfo, details string) error {
+	return s.repo.CreateEvent(domain.NewSecurityEvent(userID, eventType, client, details))
+}
+
+// ListEvents returns the security log of a user, newest first
+func (s *securityService) ListEvents(userID int64, params domain.SecurityEventQueryParams) ([]domain.SecurityEvent, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.ListEvents(userID, params)
+}
+
+// Unlock lifts the lockout or delay of the account of email and records it
+// in the account's security log
+func (s *securityService) Unlock(email string) error {
+	if err := s.repo.DeleteLoginThrottle(domain.LoginAccountKey(email)); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByEmail(domain.SanitizeEmail(email))
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if err := s.RecordEvent(user.ID, domain.SecurityEventAccountUnlocked, domain.ClientInfo{}, "unlocked by an administrator"); err != nil {
+		log := logger.Get()
+		log.Error().Err(err).Int64("user_id", user.ID).Msg("Failed to record account unlock")
+	}
+	return nil
+}
+
+// UnlockIP lifts the delay of a client IP
+func (s *securityService) UnlockIP(ip string) error {
+	return s.repo.DeleteLoginThrottle(domain.LoginIPKey(ip))
+}
+
+// DeleteExpiredThrottles deletes throttles that no longer block logins and
+// whose failures have been forgotten
+func (s *securityService) DeleteExpiredThrottles() (int64, error) {
+	return s.repo.DeleteExpiredLoginThrottles(s.now(), s.policy.FailureWindow)
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+// testLoginPolicy blocks for 1s, 2s and locks on the third failure
+var testLoginPolicy = domain.LoginPolicy{
+	MaxFailures:     3,
+	LockoutDuration: 15 * time.Minute,
+	FailureWindow:   15 * time.Minute,
+	BaseDelay:       time.Second,
+	MaxDelay:        time.Minute,
+}
+
+// mockSecurityRepository is an in-memory implementation of SecurityRepository for testing
+type mockSecurityRepository struct {
+	throttles map[string]*domain.LoginThrottle
+	events    []domain.SecurityEvent
+	err       error
+}
+
+func newMockSecurityRepository() *mockSecurityRepository {
+	return &mockSecurityRepository{throttles: make(map[string]*domain.LoginThrottle)}
+}
+
+func (m *mockSecurityRepository) FindBlockedLogins(keys []string, now time.Time) ([]domain.LoginThrottle, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var blocked []domain.LoginThrottle
+	for _, key := range keys {
+		if throttle, ok := m.throttles[key]; ok && throttle.BlockedUntil.After(now) {
+			blocked = append(blocked, *throttle)
+		}
+	}
+	return blocked, nil
+}
+
+func (m *mockSecurityRepository) RecordLoginFailure(key string, now time.Time, window time.Duration) (*domain.LoginThrottle, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	throttle, ok := m.throttles[key]
+	if !ok {
+		throttle = &domain.LoginThrottle{Key: key, BlockedUntil: now}
+		m.throttles[key] = throttle
+	}
+	if ok && throttle.LastFailedAt.After(now.Add(-window)) {
+		throttle.Failures++
+	} else {
+		throttle.Failures = 1
+	}
+	throttle.LastFailedAt = now
+	copied := *throttle
+	return &copied, nil
+}
+
+func (m *mockSecurityRepository) BlockLogin(key string, until time.Time, locked bool) error {
+	if throttle, ok := m.throttles[key]; ok {
+		throttle.BlockedUntil = until
+		throttle.Locked = locked
+	}
+	return nil
+}
+
+func (m *mockSecurityRepository) DeleteLoginThrottle(key string) error {
+	if _, ok := m.throttles[key]; !ok {
+		return repository.ErrLoginThrottleNotFound
+	}
+	delete(m.throttles, key)
+	return nil
+}
+
+func (m *mockSecurityRepository) DeleteExpiredLoginThrottles(now time.Time, window time.Duration) (int64, error) {
+	var deleted int64
+	for key, throttle := range m.throttles {
+		if !throttle.BlockedUntil.After(now) && !throttle.LastFailedAt.After(now.Add(-window)) {
+			delete(m.throttles, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *mockSecurityRepository) CreateEvent(event *domain.SecurityEvent) error {
+	event.ID = int64(len(m.events) + 1)
+	m.events = append(m.events, *event)
+	return nil
+}
+
+func (m *mockSecurityRepository) ListEvents(userID int64, params domain.SecurityEventQueryParams) ([]domain.SecurityEvent, error) {
+	var events []domain.SecurityEvent
+	for i := len(m.events) - 1; i >= 0 && len(events) < params.Limit; i-- {
+		if m.events[i].UserID == userID && (params.Type == "" || m.events[i].Type == params.Type) {
+			events = append(events, m.events[i])
+		}
+	}
+	return events, nil
+}
+
+// eventTypes returns the types of the recorded events in order
+func (m *mockSecurityRepository) eventTypes() []string {
+	var types []string
+	for _, event := range m.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func newTestSecurityService(userRepo repository.UserRepository) SecurityService {
+	return NewSecurityService(newMockSecurityRepository(), userRepo, testLoginPolicy)
+}
+
+// newClockedSecurityService returns a security service whose clock is at *now
+func newClockedSecurityService(repo *mockSecurityRepository, userRepo repository.UserRepository, now *time.Time) SecurityService {
+	svc := NewSecurityService(repo, userRepo, testLoginPolicy).(*securityService)
+	svc.now = func() time.Time { return *now }
+	return svc
+}
+
+var testClient = domain.ClientInfo{IPAddress: "203.0.113.7", UserAgent: "curl/8.5.0"}
+
+func TestSecurityService_LoginFailed_ProgressiveDelays(t *testing.T) {
+	repo := newMockSecurityRepository()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc := newClockedSecurityService(repo, &mockUserRepository{}, &now)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+
+	require.NoError(t, svc.LoginFailed(user, "Test@Example.com", testClient))
+
+	var throttled *LoginThrottledError
+	require.ErrorAs(t, svc.CheckLogin("test@example.com", domain.ClientInfo{}), &throttled)
+	assert.Equal(t, time.Second, throttled.RetryAfter)
+	assert.False(t, throttled.Locked)
+	require.ErrorAs(t, svc.CheckLogin("other@example.com", testClient), &throttled, "the client IP is blocked as well")
+
+	now = now.Add(time.Second)
+	assert.NoError(t, svc.CheckLogin("test@example.com", testClient))
+
+	require.NoError(t, svc.LoginFailed(user, "test@example.com", testClient))
+	require.ErrorAs(t, svc.CheckLogin("test@example.com", testClient), &throttled)
+	assert.Equal(t, 2*time.Second, throttled.RetryAfter)
+	assert.Equal(t, []string{domain.SecurityEventLoginFailed, domain.SecurityEventLoginFailed}, repo.eventTypes())
+	assert.Equal(t, "203.0.113.7", repo.events[0].IPAddress)
+}
+
+func TestSecurityService_LoginFailed_LocksAccount(t *testing.T) {
+	repo := newMockSecurityRepository()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc := newClockedSecurityService(repo, &mockUserRepository{}, &now)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+
+	for i := 0; i < testLoginPolicy.MaxFailures; i++ {
+		require.NoError(t, svc.LoginFailed(user, "test@example.com", testClient))
+		now = now.Add(time.Minute)
+	}
+
+	var throttled *LoginThrottledError
+	require.ErrorAs(t, svc.CheckLogin("test@example.com", domain.ClientInfo{IPAddress: "198.51.100.1"}), &throttled)
+	assert.True(t, throttled.Locked)
+	assert.Equal(t, 14*time.Minute, throttled.RetryAfter)
+	require.Len(t, repo.events, 4)
+	assert.Equal(t, domain.SecurityEventAccountLocked, repo.events[3].Type)
+	assert.Equal(t, "locked for 15m0s after 3 failed logins", repo.events[3].Details)
+
+	now = now.Add(14 * time.Minute)
+	assert.NoError(t, svc.CheckLogin("test@example.com", domain.ClientInfo{}), "the lockout expires")
+}
+
+func TestSecurityService_LoginFailed_ForgetsOldFailures(t *testing.T) {
+	repo := newMockSecurityRepository()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc := newClockedSecurityService(repo, &mockUserRepository{}, &now)
+
+	for i := 0; i < testLoginPolicy.MaxFailures-1; i++ {
+		require.NoError(t, svc.LoginFailed(nil, "test@example.com", domain.ClientInfo{}))
+	}
+	now = now.Add(testLoginPolicy.FailureWindow + time.Second)
+	require.NoError(t, svc.LoginFailed(nil, "test@example.com", domain.ClientInfo{}))
+
+	assert.Equal(t, 1, repo.throttles[domain.LoginAccountKey("test@example.com")].Failures)
+	assert.Empty(t, repo.events, "failed logins to unknown accounts are not logged")
+}
+
+func TestSecurityService_LoginSucceeded_ClearsAccountFailures(t *testing.T) {
+	repo := newMockSecurityRepository()
+	svc := NewSecurityService(repo, &mockUserRepository{}, testLoginPolicy)
+	user := &domain.User{ID: 1, Email: "test@example.com"}
+
+	require.NoError(t, svc.LoginFailed(user, "test@example.com", testClient))
+	require.NoError(t, svc.LoginSucceeded(user, testClient))
+
+	assert.NotContains(t, repo.throttles, domain.LoginAccountKey("test@example.com"))
+	assert.Contains(t, repo.throttles, domain.LoginIPKey("203.0.113.7"), "IP failures are kept")
+	assert.Equal(t, domain.SecurityEventLoginSucceeded, repo.events[1].Type)
+}
+
+func TestSecurityService_CheckLogin_RepositoryError(t *testing.T) {
+	repo := newMockSecurityRepository()
+	repo.err = errors.New("connection refused")
+	svc := NewSecurityService(repo, &mockUserRepository{}, testLoginPolicy)
+
+	err := svc.CheckLogin("test@example.com", testClient)
+
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestSecurityService_Unlock(t *testing.T) {
+	repo := newMockSecurityRepository()
+	user := &domain.User{ID: 7, Email: "test@example.com"}
+	svc := NewSecurityService(repo, &mockUserRepository{findByEmailUser: user}, testLoginPolicy)
+
+	for i := 0; i < testLoginPolicy.MaxFailures; i++ {
+		require.NoError(t, svc.LoginFailed(user, "test@example.com", testClient))
+	}
+
+	require.NoError(t, svc.Unlock(" TEST@example.com"))
+
+	assert.NoError(t, svc.CheckLogin("test@example.com", domain.ClientInfo{}))
+	last := repo.events[len(repo.events)-1]
+	assert.Equal(t, domain.SecurityEventAccountUnlocked, last.Type)
+	assert.Equal(t, int64(7), last.UserID)
+	assert.ErrorIs(t, svc.Unlock("test@example.com"), repository.ErrLoginThrottleNotFound)
+}
+
+func TestSecurityService_UnlockIP(t *testing.T) {
+	repo := newMockSecurityRepository()
+	svc := NewSecurityService(repo, &mockUserRepository{}, testLoginPolicy)
+
+	require.NoError(t, svc.LoginFailed(nil, "test@example.com", testClient))
+	require.NoError(t, svc.UnlockIP("203.0.113.7"))
+
+	assert.NotContains(t, repo.throttles, domain.LoginIPKey("203.0.113.7"))
+}
+
+func TestSecurityService_ListEvents(t *testing.T) {
+	repo := newMockSecurityRepository()
+	svc := NewSecurityService(repo, &mockUserRepository{}, testLoginPolicy)
+	require.NoError(t, svc.RecordEvent(1, domain.SecurityEventLoginSucceeded, testClient, ""))
+	require.NoError(t, svc.RecordEvent(2, domain.SecurityEventLoginSucceeded, testClient, ""))
+	require.NoError(t, svc.RecordEvent(1, domain.SecurityEventPasswordChanged, testClient, ""))
+
+	events, err := svc.ListEvents(1, domain.SecurityEventQueryParams{})
+
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.SecurityEventPasswordChanged, events[0].Type)
+
+	_, err = svc.ListEvents(1, domain.SecurityEventQueryParams{Type: "logout"})
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestSecurityService_DeleteExpiredThrottles(t *testing.T) {
+	repo := newMockSecurityRepository()
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	svc := newClockedSecurityService(repo, &mockUserRepository{}, &now)
+	require.NoError(t, svc.LoginFailed(nil, "test@example.com", testClient))
+
+	deleted, err := svc.DeleteExpiredThrottles()
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	now = now.Add(testLoginPolicy.FailureWindow)
+	deleted, err = svc.DeleteExpiredThrottles()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+}
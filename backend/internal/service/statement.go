@@ -0,0 +1,348 @@
+package service
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// statementEntry is one transaction read from an OFX or QIF file. Line is the
+// line where the entry starts; Err is set when the entry could not be read.
+type statementEntry struct {
+	Err         error
+	Transaction domain.Transaction
+	Line        int
+}
+
+// ofxStatement is the content of an OFX or QFX file
+type ofxStatement struct {
+	Org     string // Institution name from the sign-on response
+	Account string // First account ID in the file
+	Entries []statementEntry
+}
+
+// parseOFX reads the statement transactions of an OFX or QFX file. Both the
+// SGML variant (1.x, element tags left unclosed) and the XML variant (2.x)
+// are accepted. FITID becomes the external ID of each transaction.
+func parseOFX(r io.Reader) (*ofxStatement, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	content := string(data)
+
+	if !strings.Contains(strings.ToUpper(content), "<OFX>") {
+		return nil, &domain.ValidationError{Field: "file", Message: "file is not an OFX statement"}
+	}
+
+	statement := &ofxStatement{}
+	var current *statementEntry
+	var fields map[string]string
+
+	finish := func() {
+		if current == nil {
+			return
+		}
+		current.Transaction, current.Err = ofxTransaction(fields)
+		statement.Entries = append(statement.Entries, *current)
+		current = nil
+	}
+
+	line, pos := 1, 0
+	for {
+		open := strings.IndexByte(content[pos:], '<')
+		if open < 0 {
+			break
+		}
+		line += strings.Count(content[pos:pos+open], "\n")
+		start := pos + open
+
+		end := strings.IndexByte(content[start:], '>')
+		if end < 0 {
+			break
+		}
+		tag := strings.ToUpper(strings.TrimSpace(content[start+1 : start+end]))
+		pos = start + end + 1
+
+		text := content[pos:]
+		if next := strings.IndexByte(text, '<'); next >= 0 {
+			text = text[:next]
+		}
+		value := strings.TrimSpace(html.UnescapeString(text))
+
+		switch {
+		case strings.HasPrefix(tag, "?") || strings.HasPrefix(tag, "!"):
+			continue
+		case tag == "STMTTRN":
+			finish()
+			current = &statementEntry{Line: line}
+			fields = make(map[string]string)
+		case tag == "/STMTTRN" || tag == "/BANKTRANLIST":
+			finish()
+		case strings.HasPrefix(tag, "/"):
+			continue
+		case current != nil:
+			if _, ok := fields[tag]; !ok {
+				fields[tag] = value
+			}
+		case tag == "ORG" && statement.Org == "":
+			statement.Org = value
+		case tag == "ACCTID" && statement.Account == "":
+			statement.Account = value
+		}
+	}
+	finish()
+
+	return statement, nil
+}
+
+// ofxTransaction converts the fields of a STMTTRN aggregate. The sign of
+// TRNAMT decides the type; NAME is the payee and MEMO the description.
+func ofxTransaction(fields map[string]string) (domain.Transaction, error) {
+	tx := domain.Transaction{
+		Recipient:   fields["NAME"],
+		Description: fields["MEMO"],
+		ExternalID:  fields["FITID"],
+	}
+
+	date, err := parseOFXDate(fields["DTPOSTED"])
+	if err != nil {
+		return tx, &domain.ValidationError{
+			Field:   "transaction_date",
+			Message: fmt.Sprintf("invalid DTPOSTED %q", fields["DTPOSTED"]),
+		}
+	}
+	tx.TransactionDate = date
+
+	value := fields["TRNAMT"]
+	if !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	amount, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return tx, &domain.ValidationError{
+			Field:   "amount",
+			Message: fmt.Sprintf("invalid TRNAMT %q", fields["TRNAMT"]),
+		}
+	}
+
+	tx.Type = domain.TransactionTypeIn
+	if amount < 0 {
+		tx.Type = domain.TransactionTypeOut
+		amount = -amount
+	}
+	tx.Amount = amount
+
+	return tx, nil
+}
+
+// parseOFXDate parses an OFX date such as 20260305, 20260305120000.000 or
+// 20260305120000[-5:EST]. Dates without a zone are UTC.
+func parseOFXDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	loc := time.UTC
+	if open := strings.IndexByte(value, '['); open >= 0 {
+		zone := strings.TrimSuffix(value[open+1:], "]")
+		value = value[:open]
+		if colon := strings.IndexByte(zone, ':'); colon >= 0 {
+			zone = zone[:colon]
+		}
+		hours, err := strconv.ParseFloat(zone, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		loc = time.FixedZone("", int(hours*3600))
+	}
+
+	if dot := strings.IndexByte(value, '.'); dot >= 0 {
+		value = value[:dot]
+	}
+
+	switch len(value) {
+	case 8:
+		return time.ParseInLocation("20060102", value, loc)
+	case 12:
+		return time.ParseInLocation("200601021504", value, loc)
+	case 14:
+		return time.ParseInLocation("20060102150405", value, loc)
+	default:
+		return time.Time{}, fmt.Errorf("unexpected date length %d", len(value))
+	}
+}
+
+// qifSection is the kind of records following a QIF header line
+type qifSection int
+
+const (
+	qifSectionSkip qifSection = iota
+	qifSectionAccount
+	qifSectionTransactions
+)
+
+// qifTransactionTypes contains the QIF account types whose records are transactions.
+// Investment accounts and lists such as categories or memorized payees are skipped.
+var qifTransactionTypes = map[string]bool{
+	"bank":  true,
+	"cash":  true,
+	"ccard": true,
+	"oth a": true,
+	"oth l": true,
+}
+
+// parseQIF reads the bank, cash and credit card transactions of a QIF file
+// using dateFormat for the D field, and returns them with the first account
+// name. Transfers ("[Account]") become the Transfer category; other
+// categories are kept when their top level matches a known category
+// (e.g. "Food:Groceries").
+func parseQIF(r io.Reader, dateFormat string) ([]statementEntry, string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var entries []statementEntry
+	var account string
+	var fields map[byte]string
+	section, sawType := qifSectionSkip, false
+	start := 0
+
+	finish := func() {
+		if len(fields) == 0 {
+			return
+		}
+		switch section {
+		case qifSectionAccount:
+			if account == "" {
+				account = fields['N']
+			}
+		case qifSectionTransactions:
+			tx, err := qifTransaction(fields, dateFormat)
+			entries = append(entries, statementEntry{Line: start, Transaction: tx, Err: err})
+		}
+		fields = nil
+	}
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if line == 1 {
+			text = strings.TrimPrefix(text, "\ufeff")
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if text[0] == '!' {
+			finish()
+			header := strings.ToLower(strings.TrimSpace(text))
+			switch {
+			case header == "!account":
+				section = qifSectionAccount
+			case strings.HasPrefix(header, "!type:"):
+				sawType = true
+				section = qifSectionSkip
+				if qifTransactionTypes[strings.TrimPrefix(header, "!type:")] {
+					section = qifSectionTransactions
+				}
+			}
+			continue
+		}
+
+		if text[0] == '^' {
+			finish()
+			continue
+		}
+
+		if fields == nil {
+			fields = make(map[byte]string)
+			start = line
+		}
+		if _, ok := fields[text[0]]; !ok {
+			fields[text[0]] = strings.TrimSpace(text[1:])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, "", err
+	}
+	finish()
+
+	if !sawType {
+		return nil, "", &domain.ValidationError{Field: "file", Message: "file is not a QIF statement"}
+	}
+
+	return entries, account, nil
+}
+
+// qifTransaction converts the fields of a QIF record
+func qifTransaction(fields map[byte]string, dateFormat string) (domain.Transaction, error) {
+	tx := domain.Transaction{
+		Recipient:   fields['P'],
+		Description: fields['M'],
+		Category:    qifCategory(fields['L']),
+	}
+
+	date, err := parseQIFDate(fields['D'], dateFormat)
+	if err != nil {
+		return tx, &domain.ValidationError{
+			Field:   "transaction_date",
+			Message: fmt.Sprintf("date %q does not match format %s", fields['D'], dateFormat),
+		}
+	}
+	tx.TransactionDate = date
+
+	value, ok := fields['T']
+	if !ok {
+		value = fields['U']
+	}
+	amount, err := parseImportAmount(value, ".")
+	if err != nil {
+		return tx, &domain.ValidationError{
+			Field:   "amount",
+			Message: fmt.Sprintf("invalid amount %q", value),
+		}
+	}
+
+	tx.Type = domain.TransactionTypeIn
+	if amount < 0 {
+		tx.Type = domain.TransactionTypeOut
+		amount = -amount
+	}
+	tx.Amount = amount
+
+	return tx, nil
+}
+
+// parseQIFDate parses a QIF date. Quicken writes years after 1999 as 'YY and
+// pads with spaces, e.g. "3/ 5'26", so both forms are normalised first.
+func parseQIFDate(value, layout string) (time.Time, error) {
+	value = strings.ReplaceAll(strings.ReplaceAll(value, "'", "/"), " ", "")
+
+	date, err := time.Parse(layout, value)
+	if err != nil && strings.Contains(layout, "2006") {
+		date, err = time.Parse(strings.Replace(layout, "2006", "06", 1), value)
+	}
+	return date, err
+}
+
+// qifCategory maps a QIF category onto a known category, or returns empty
+func qifCategory(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") {
+		return "Transfer"
+	}
+
+	top := value
+	if colon := strings.IndexByte(top, ':'); colon >= 0 {
+		top = top[:colon]
+	}
+	for category := range domain.ValidCategories {
+		if category != "" && strings.EqualFold(category, strings.TrimSpace(top)) {
+			return category
+		}
+	}
+	return ""
+}
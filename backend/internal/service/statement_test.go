@@ -0,0 +1,217 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+const sgmlOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<SIGNONMSGSRSV1><SONRS>
+<FI><ORG>Chase Bank<FID>10898</FI>
+</SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKACCTFROM><BANKID>021000021<ACCTID>000123456<ACCTTYPE>CHECKING</BANKACCTFROM>
+<BANKTRANLIST>
+<DTSTART>20260301<DTEND>20260331
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20260305120000.000[-5:EST]
+<TRNAMT>-45.50
+<FITID>2026030501
+<NAME>STARBUCKS &amp; CO
+<MEMO>Card 1234
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20260310
+<TRNAMT>1200.00
+<FITID>2026031001
+<NAME>ACME PAYROLL
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlOFX = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX>
+  <BANKMSGSRSV1><STMTTRNRS><STMTRS>
+    <BANKACCTFROM><ACCTID>987</ACCTID></BANKACCTFROM>
+    <BANKTRANLIST>
+      <STMTTRN>
+        <TRNTYPE>POS</TRNTYPE>
+        <DTPOSTED>20260307093000</DTPOSTED>
+        <TRNAMT>-12.00</TRNAMT>
+        <FITID>A1</FITID>
+        <NAME>Bakery</NAME>
+      </STMTTRN>
+      <STMTTRN>
+        <DTPOSTED>not-a-date</DTPOSTED>
+        <TRNAMT>-3.00</TRNAMT>
+        <FITID>A2</FITID>
+      </STMTTRN>
+    </BANKTRANLIST>
+  </STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+// Test parseOFX
+
+func TestParseOFX_SGML(t *testing.T) {
+	statement, err := parseOFX(strings.NewReader(sgmlOFX))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Chase Bank", statement.Org)
+	assert.Equal(t, "000123456", statement.Account)
+	assert.Len(t, statement.Entries, 2)
+
+	debit := statement.Entries[0]
+	assert.NoError(t, debit.Err)
+	assert.Equal(t, 13, debit.Line)
+	assert.Equal(t, domain.TransactionTypeOut, debit.Transaction.Type)
+	assert.Equal(t, 45.50, debit.Transaction.Amount)
+	assert.Equal(t, "2026030501", debit.Transaction.ExternalID)
+	assert.Equal(t, "STARBUCKS & CO", debit.Transaction.Recipient)
+	assert.Equal(t, "Card 1234", debit.Transaction.Description)
+	assert.True(t, debit.Transaction.TransactionDate.Equal(time.Date(2026, 3, 5, 17, 0, 0, 0, time.UTC)))
+
+	credit := statement.Entries[1]
+	assert.NoError(t, credit.Err)
+	assert.Equal(t, domain.TransactionTypeIn, credit.Transaction.Type)
+	assert.Equal(t, 1200.00, credit.Transaction.Amount)
+	assert.Equal(t, "ACME PAYROLL", credit.Transaction.Recipient)
+}
+
+func TestParseOFX_XML(t *testing.T) {
+	statement, err := parseOFX(strings.NewReader(xmlOFX))
+
+	assert.NoError(t, err)
+	assert.Empty(t, statement.Org)
+	assert.Equal(t, "987", statement.Account)
+	assert.Len(t, statement.Entries, 2)
+
+	assert.NoError(t, statement.Entries[0].Err)
+	assert.Equal(t, "A1", statement.Entries[0].Transaction.ExternalID)
+	assert.Equal(t, "Bakery", statement.Entries[0].Transaction.Recipient)
+	assert.Equal(t, time.Date(2026, 3, 7, 9, 30, 0, 0, time.UTC), statement.Entries[0].Transaction.TransactionDate)
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, statement.Entries[1].Err, &validationErr)
+	assert.Equal(t, "transaction_date", validationErr.Field)
+	assert.Equal(t, "A2", statement.Entries[1].Transaction.ExternalID)
+}
+
+func TestParseOFX_NotOFX(t *testing.T) {
+	_, err := parseOFX(strings.NewReader("Date,Amount\n2026-03-05,-1.00\n"))
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "file", validationErr.Field)
+}
+
+func TestParseOFXDate(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"20260305", time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)},
+		{"202603051230", time.Date(2026, 3, 5, 12, 30, 0, 0, time.UTC)},
+		{"20260305123045.123", time.Date(2026, 3, 5, 12, 30, 45, 0, time.UTC)},
+		{"20260305000000[+7:ICT]", time.Date(2026, 3, 4, 17, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			date, err := parseOFXDate(tt.value)
+
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(date), "got %s", date)
+		})
+	}
+
+	_, err := parseOFXDate("2026")
+	assert.Error(t, err)
+}
+
+// Test parseQIF
+
+func TestParseQIF(t *testing.T) {
+	qif := "!Account\n" +
+		"NEveryday Checking\n" +
+		"TBank\n" +
+		"^\n" +
+		"!Type:Bank\n" +
+		"D3/ 5'26\n" +
+		"T-45.50\n" +
+		"PStarbucks\n" +
+		"MMorning coffee\n" +
+		"LFood:Coffee\n" +
+		"^\n" +
+		"D03/10/2026\n" +
+		"U1,200.00\n" +
+		"PAcme Payroll\n" +
+		"LSalary\n" +
+		"^\n" +
+		"D3/12/2026\n" +
+		"T-200.00\n" +
+		"L[Savings]\n" +
+		"^\n" +
+		"!Type:Cat\n" +
+		"NFood\n" +
+		"^\n" +
+		"!Type:Cash\n" +
+		"D13/40/2026\n" +
+		"T-5.00\n" +
+		"^\n"
+
+	entries, account, err := parseQIF(strings.NewReader(qif), domain.DefaultQIFDateFormat)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Everyday Checking", account)
+	assert.Len(t, entries, 4)
+
+	coffee := entries[0]
+	assert.NoError(t, coffee.Err)
+	assert.Equal(t, 6, coffee.Line)
+	assert.Equal(t, time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), coffee.Transaction.TransactionDate)
+	assert.Equal(t, domain.TransactionTypeOut, coffee.Transaction.Type)
+	assert.Equal(t, 45.50, coffee.Transaction.Amount)
+	assert.Equal(t, "Starbucks", coffee.Transaction.Recipient)
+	assert.Equal(t, "Morning coffee", coffee.Transaction.Description)
+	assert.Equal(t, "Food", coffee.Transaction.Category)
+
+	salary := entries[1]
+	assert.NoError(t, salary.Err)
+	assert.Equal(t, domain.TransactionTypeIn, salary.Transaction.Type)
+	assert.Equal(t, 1200.00, salary.Transaction.Amount)
+	assert.Equal(t, "Salary", salary.Transaction.Category)
+
+	assert.Equal(t, "Transfer", entries[2].Transaction.Category)
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, entries[3].Err, &validationErr)
+	assert.Equal(t, "transaction_date", validationErr.Field)
+}
+
+func TestParseQIF_NotQIF(t *testing.T) {
+	_, _, err := parseQIF(strings.NewReader("Date,Amount\n"), domain.DefaultQIFDateFormat)
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "file", validationErr.Field)
+}
+
+func TestQIFCategory(t *testing.T) {
+	assert.Equal(t, "Food", qifCategory("food:Groceries"))
+	assert.Equal(t, "Transfer", qifCategory("[Credit Card]"))
+	assert.Equal(t, "", qifCategory("Hobbies"))
+	assert.Equal(t, "", qifCategory(""))
+}
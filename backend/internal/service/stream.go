@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+const (
+	streamBufferSize   = 2 * domain.MaxStreamNotification // events queued per client before it is dropped as too slow
+	streamReconnectMin = time.Second
+	streamReconnectMax = 30 * time.Second
+)
+
+// StreamService pushes stored transactions and updated summary totals to
+// connected clients. Notifications go through the database, so clients of
+// every API replica see transactions stored by any of them.
+type StreamService interface {
+	Subscribe(lastEventID int64) (*StreamSubscription, error)
+	Start(ctx context.Context)
+	OnTransactionsCreated(transactions []domain.Transaction)
+	OnTransactionUpdated(previous, updated domain.Transaction)
+}
+
+// StreamSubscription is the event feed of one client
+type StreamSubscription struct {
+	Backlog []domain.StreamEvent      // Replayed transactions and the current summary
+	Events  <-chan domain.StreamEvent // Closed when the client falls behind or the stream stops
+	Close   func()                    // Unsubscribes; safe to call more than once
+}
+
+type streamService struct {
+	repo    repository.StreamRepository
+	txRepo  repository.TransactionRepository
+	mu      sync.Mutex
+	clients map[chan domain.StreamEvent]struct{}
+}
+
+// NewStreamService creates a new stream service. Start must be called for
+// clients to receive live events.
+func NewStreamService(repo repository.StreamRepository, txRepo repository.TransactionRepository) StreamService {
+	return &streamService{
+		repo:    repo,
+		txRepo:  txRepo,
+		clients: make(map[chan domain.StreamEvent]struct{}),
+	}
+}
+
+// Subscribe registers a client. With a lastEventID the newest transactions
+// stored after it are replayed, up to MaxStreamReplay; the backlog always
+// ends with the current summary. Live events may repeat replayed transactions.
+func (s *streamService) Subscribe(lastEventID int64) (*StreamSubscription, error) {
+	if lastEventID < 0 {
+		return nil, &domain.ValidationError{
+			Field:   "Last-Event-ID",
+			Message: "Last-Event-ID must be a transaction ID",
+		}
+	}
+
+	// Register before loading the backlog so nothing stored in between is missed
+	events := make(chan domain.StreamEvent, streamBufferSize)
+	s.mu.Lock()
+	s.clients[events] = struct{}{}
+	s.mu.Unlock()
+	unsubscribe := func() { s.disconnect(events) }
+
+	var backlog []domain.StreamEvent
+	if lastEventID > 0 {
+		transactions, err := s.repo.ListTransactionsAfter(lastEventID, domain.MaxStreamReplay)
+		if err != nil {
+			unsubscribe()
+			return nil, err
+		}
+		for _, tx := range transactions {
+			backlog = append(backlog, domain.NewTransactionCreatedEvent(tx))
+		}
+	}
+
+	summary, err := s.txRepo.GetSummary()
+	if err != nil {
+		unsubscribe()
+		return nil, err
+	}
+	backlog = append(backlog, domain.StreamEvent{Event: domain.StreamEventSummary, Data: summary})
+
+	return &StreamSubscription{Backlog: backlog, Events: events, Close: unsubscribe}, nil
+}
+
+// Start listens for transaction notifications until ctx is cancelled,
+// reconnecting with backoff. Clients are disconnected whenever the listener
+// stops, as they may have missed events; they reconnect with Last-Event-ID.
+func (s *streamService) Start(ctx context.Context) {
+	log := logger.Get()
+
+	go func() {
+		wait := streamReconnectMin
+		for {
+			err := s.repo.Listen(ctx, func() { wait = streamReconnectMin }, s.dispatch)
+			s.disconnectAll()
+			if ctx.Err() != nil {
+				return
+			}
+
+			log.Warn().Err(err).Dur("retry_in", wait).Msg("Transaction stream listener disconnected")
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(wait):
+			}
+			wait = min(wait*2, streamReconnectMax)
+		}
+	}()
+}
+
+// OnTransactionsCreated notifies every replica of the stored transactions
+func (s *streamService) OnTransactionsCreated(transactions []domain.Transaction) {
+	for start := 0; start < len(transactions); start += domain.MaxStreamNotification {
+		end := min(start+domain.MaxStreamNotification, len(transactions))
+		ids := make([]int64, 0, end-start)
+		for _, tx := range transactions[start:end] {
+			ids = append(ids, tx.ID)
+		}
+		s.notify(domain.TransactionNotification{Created: ids})
+	}
+}
+
+// OnTransactionUpdated notifies every replica of the updated transaction
+func (s *streamService) OnTransactionUpdated(previous, updated domain.Transaction) {
+	s.notify(domain.TransactionNotification{Updated: []int64{updated.ID}})
+}
+
+func (s *streamService) notify(notification domain.TransactionNotification) {
+	log := logger.Get()
+
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to encode transaction notification")
+		return
+	}
+	if err := s.repo.Notify(string(payload)); err != nil {
+		log.Error().Err(err).Msg("Failed to send transaction notification")
+	}
+}
+
+// dispatch loads the transactions of a notification once and sends them,
+// followed by the new summary, to every client of this replica
+func (s *streamService) dispatch(payload string) {
+	log := logger.Get()
+
+	var notification domain.TransactionNotification
+	if err := json.Unmarshal([]byte(payload), &notification); err != nil {
+		log.Warn().Err(err).Msg("Ignoring malformed transaction notification")
+		return
+	}
+
+	s.mu.Lock()
+	idle := len(s.clients) == 0
+	s.mu.Unlock()
+	if idle {
+		return
+	}
+
+	created := make(map[int64]bool, len(notification.Created))
+	for _, id := range notification.Created {
+		created[id] = true
+	}
+	ids := append(append([]int64{}, notification.Created...), notification.Updated...)
+	transactions, err := s.repo.FindTransactions(ids)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load streamed transactions")
+		return
+	}
+
+	events := make([]domain.StreamEvent, 0, len(transactions)+1)
+	for _, tx := range transactions {
+		if created[tx.ID] {
+			events = append(events, domain.NewTransactionCreatedEvent(tx))
+		} else {
+			events = append(events, domain.StreamEvent{Event: domain.StreamEventTransactionUpdated, Data: tx})
+		}
+	}
+
+	summary, err := s.txRepo.GetSummary()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to load streamed summary")
+	} else {
+		events = append(events, domain.StreamEvent{Event: domain.StreamEventSummary, Data: summary})
+	}
+
+	s.broadcast(events)
+}
+
+// broadcast queues events for every client without blocking
+func (s *streamService) broadcast(events []domain.StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for client := range s.clients {
+		if !queueStreamEvents(client, events) {
+			// The client fell behind; it resumes from its last event when it reconnects
+			delete(s.clients, client)
+			close(client)
+		}
+	}
+}
+
+// queueStreamEvents sends events to client without blocking, reporting whether all of them fit
+func queueStreamEvents(client chan<- domain.StreamEvent, events []domain.StreamEvent) bool {
+	for _, event := range events {
+		select {
+		case client <- event:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func (s *streamService) disconnect(client chan domain.StreamEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.clients[client]; ok {
+		delete(s.clients, client)
+		close(client)
+	}
+}
+
+func (s *streamService) disconnectAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for client := range s.clients {
+		delete(s.clients, client)
+		close(client)
+	}
+}
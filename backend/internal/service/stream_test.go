@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+)
+
+// mockStreamRepository is a mock implementation of StreamRepository for testing.
+// Listen delivers the payloads sent to notifications, then fails with listenErr
+// or blocks until the context is cancelled.
+type mockStreamRepository struct {
+	transactions  []domain.Transaction
+	notified      []string
+	notifications chan string
+	listenErr     error
+	after         int64
+}
+
+func (m *mockStreamRepository) Notify(payload string) error {
+	m.notified = append(m.notified, payload)
+	return nil
+}
+
+func (m *mockStreamRepository) Listen(ctx context.Context, onListening func(), fn func(payload string)) error {
+	onListening()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case payload, ok := <-m.notifications:
+			if !ok {
+				return m.listenErr
+			}
+			fn(payload)
+		}
+	}
+}
+
+func (m *mockStreamRepository) FindTransactions(ids []int64) ([]domain.Transaction, error) {
+	var found []domain.Transaction
+	for _, tx := range m.transactions {
+		for _, id := range ids {
+			if tx.ID == id {
+				found = append(found, tx)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (m *mockStreamRepository) ListTransactionsAfter(id int64, limit int) ([]domain.Transaction, error) {
+	m.after = id
+	var found []domain.Transaction
+	for _, tx := range m.transactions {
+		if tx.ID > id {
+			found = append(found, tx)
+		}
+	}
+	return found, nil
+}
+
+func newTestStreamService(repo *mockStreamRepository) StreamService {
+	txRepo := &mockRepository{
+		getSummaryFunc: func() (*domain.SummaryResponse, error) {
+			return &domain.SummaryResponse{TotalIncome: 500, TransactionCount: int64(len(repo.transactions))}, nil
+		},
+	}
+	return NewStreamService(repo, txRepo)
+}
+
+// receive waits for the next n events of a subscription
+func receive(t *testing.T, events <-chan domain.StreamEvent, n int) []domain.StreamEvent {
+	t.Helper()
+	var received []domain.StreamEvent
+	for len(received) < n {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok, "subscription closed after %d events", len(received))
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatalf("expected %d events, got %d", n, len(received))
+		}
+	}
+	return received
+}
+
+func TestStreamService_Subscribe_SummaryOnly(t *testing.T) {
+	svc := newTestStreamService(&mockStreamRepository{})
+
+	subscription, err := svc.Subscribe(0)
+
+	require.NoError(t, err)
+	defer subscription.Close()
+	require.Len(t, subscription.Backlog, 1)
+	assert.Equal(t, domain.StreamEventSummary, subscription.Backlog[0].Event)
+}
+
+func TestStreamService_Subscribe_ReplaysAfterLastEventID(t *testing.T) {
+	repo := &mockStreamRepository{transactions: []domain.Transaction{{ID: 4}, {ID: 5}, {ID: 6}}}
+	svc := newTestStreamService(repo)
+
+	subscription, err := svc.Subscribe(4)
+
+	require.NoError(t, err)
+	defer subscription.Close()
+	assert.Equal(t, int64(4), repo.after)
+	require.Len(t, subscription.Backlog, 3)
+	assert.Equal(t, int64(5), subscription.Backlog[0].ID)
+	assert.Equal(t, int64(6), subscription.Backlog[1].ID)
+	assert.Equal(t, domain.StreamEventTransactionCreated, subscription.Backlog[1].Event)
+	assert.Equal(t, domain.StreamEventSummary, subscription.Backlog[2].Event)
+}
+
+func TestStreamService_Subscribe_InvalidLastEventID(t *testing.T) {
+	svc := newTestStreamService(&mockStreamRepository{})
+
+	_, err := svc.Subscribe(-1)
+
+	var validationErr *domain.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestStreamService_OnTransactionsCreated_SplitsNotifications(t *testing.T) {
+	repo := &mockStreamRepository{}
+	svc := newTestStreamService(repo)
+
+	transactions := make([]domain.Transaction, domain.MaxStreamNotification+1)
+	for i := range transactions {
+		transactions[i].ID = int64(i + 1)
+	}
+	svc.OnTransactionsCreated(transactions)
+	svc.OnTransactionUpdated(domain.Transaction{ID: 3}, domain.Transaction{ID: 3})
+
+	require.Len(t, repo.notified, 3)
+	var last domain.TransactionNotification
+	require.NoError(t, json.Unmarshal([]byte(repo.notified[1]), &last))
+	assert.Equal(t, []int64{int64(domain.MaxStreamNotification + 1)}, last.Created)
+	assert.Equal(t, `{"updated":[3]}`, repo.notified[2])
+}
+
+func TestStreamService_DispatchesNotifications(t *testing.T) {
+	repo := &mockStreamRepository{
+		transactions:  []domain.Transaction{{ID: 7, Amount: 10}, {ID: 8, Amount: 20}},
+		notifications: make(chan string, 1),
+	}
+	svc := newTestStreamService(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc.Start(ctx)
+
+	subscription, err := svc.Subscribe(0)
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	repo.notifications <- `{"created":[8],"updated":[7]}`
+	events := receive(t, subscription.Events, 3)
+
+	assert.Equal(t, domain.StreamEventTransactionUpdated, events[0].Event)
+	assert.Zero(t, events[0].ID, "updates must not move the resume position")
+	assert.Equal(t, domain.StreamEventTransactionCreated, events[1].Event)
+	assert.Equal(t, int64(8), events[1].ID)
+	assert.Equal(t, domain.StreamEventSummary, events[2].Event)
+}
+
+func TestStreamService_ListenerFailureDisconnectsClients(t *testing.T) {
+	repo := &mockStreamRepository{notifications: make(chan string), listenErr: errors.New("connection reset")}
+	svc := newTestStreamService(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscription, err := svc.Subscribe(0)
+	require.NoError(t, err)
+	svc.Start(ctx)
+
+	close(repo.notifications)
+
+	select {
+	case _, ok := <-subscription.Events:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("expected the subscription to be closed")
+	}
+	subscription.Close()
+}
+
+func TestStreamService_DropsSlowClients(t *testing.T) {
+	svc := newTestStreamService(&mockStreamRepository{}).(*streamService)
+
+	slow, err := svc.Subscribe(0)
+	require.NoError(t, err)
+	defer slow.Close()
+
+	events := make([]domain.StreamEvent, streamBufferSize)
+	svc.broadcast(events)
+	svc.broadcast(events[:1])
+
+	assert.Len(t, receive(t, slow.Events, streamBufferSize), streamBufferSize)
+	_, ok := <-slow.Events
+	assert.False(t, ok, "a client whose buffer overflowed must be disconnected")
+}
@@ -0,0 +1,631 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/logger"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+	"github.com/dev/personal-finance-tracker/backend/internal/security"
+)
+
+var (
+	// ErrTakeoutInProgress is returned when a takeout of the user is still being built
+	ErrTakeoutInProgress = errors.New("a takeout is already being prepared")
+	// ErrTakeoutNotReady is returned when downloading a takeout that is pending or failed
+	ErrTakeoutNotReady = errors.New("takeout is not ready")
+)
+
+const (
+	// takeoutBatch is how many takeouts one BuildDue run builds at most
+	takeoutBatch = 5
+	// takeoutLease is how long a worker may build a takeout before another claims it
+	takeoutLease = 30 * time.Minute
+	// takeoutAPIKeyPrefix is how many characters of the API key an archive reveals
+	takeoutAPIKeyPrefix = 4
+)
+
+// takeoutTransactionColumns are the columns of transactions.csv
+var takeoutTransactionColumns = []domain.ExportColumn{
+	domain.ExportColumnID,
+	domain.ExportColumnTransactionDate,
+	domain.ExportColumnType,
+	domain.ExportColumnAmount,
+	domain.ExportColumnCategory,
+	domain.ExportColumnDescription,
+	domain.ExportColumnSource,
+	domain.ExportColumnSourceAccount,
+	domain.ExportColumnRecipient,
+	domain.ExportColumnExternalID,
+	domain.ExportColumnAnomalous,
+	domain.ExportColumnCreatedAt,
+}
+
+// TakeoutService builds zip archives of everything stored about a user and
+// restores such archives, possibly made by another instance
+type TakeoutService interface {
+	Request(userID int64) (*domain.Takeout, error)
+	List(userID int64) ([]domain.Takeout, error)
+	Get(userID, id int64) (*domain.Takeout, error)
+	Download(userID, id int64) (*domain.Takeout, error)
+	BuildDue(ctx context.Context) (int, error)
+	DeleteExpired() (int64, error)
+	Restore(userID int64, archive io.ReaderAt, size int64) (*domain.TakeoutRestoreResult, error)
+}
+
+type takeoutService struct {
+	userRepo    repository.UserRepository
+	takeoutRepo repository.TakeoutRepository
+	txRepo      repository.TransactionRepository
+	payeeRepo   repository.PayeeRepository
+	budgetRepo  repository.BudgetRepository
+	sanitizer   *security.Sanitizer
+	ttl         time.Duration
+	syncLimit   int64
+	now         func() time.Time
+}
+
+// NewTakeoutService creates a new takeout service. Archives of up to
+// syncLimit transactions are built while the request waits, larger ones by
+// BuildDue. Finished archives can be downloaded for ttl.
+func NewTakeoutService(
+	userRepo repository.UserRepository,
+	takeoutRepo repository.TakeoutRepository,
+	txRepo repository.TransactionRepository,
+	payeeRepo repository.PayeeRepository,
+	budgetRepo repository.BudgetRepository,
+	ttl time.Duration,
+	syncLimit int,
+) TakeoutService {
+	if ttl <= 0 {
+		ttl = domain.DefaultTakeoutTTL
+	}
+	if syncLimit < 0 {
+		syncLimit = domain.DefaultTakeoutSyncLimit
+	}
+	return &takeoutService{
+		userRepo:    userRepo,
+		takeoutRepo: takeoutRepo,
+		txRepo:      txRepo,
+		payeeRepo:   payeeRepo,
+		budgetRepo:  budgetRepo,
+		sanitizer:   security.NewSanitizer(),
+		ttl:         ttl,
+		syncLimit:   int64(syncLimit),
+		now:         time.Now,
+	}
+}
+
+// Request starts a takeout of the user. Small ledgers are archived at once
+// and the takeout is returned ready; otherwise it is returned pending.
+func (s *takeoutService) Request(userID int64) (*domain.Takeout, error) {
+	pending, err := s.takeoutRepo.HasPending(userID)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, ErrTakeoutInProgress
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, transactions, err := s.txRepo.List(domain.ListTransactionsQueryParams{Page: 1, PageSize: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	takeout := &domain.Takeout{
+		UserID:     userID,
+		Status:     domain.TakeoutStatusPending,
+		LeaseUntil: s.now(),
+	}
+	if transactions <= s.syncLimit {
+		archive, err := s.build(context.Background(), user)
+		if err != nil {
+			return nil, err
+		}
+		s.complete(takeout, archive)
+	}
+
+	if err := s.takeoutRepo.Create(takeout); err != nil {
+		return nil, err
+	}
+	takeout.Archive = nil
+	return takeout, nil
+}
+
+// List returns the takeouts of the user that have not expired, newest first
+func (s *takeoutService) List(userID int64) ([]domain.Takeout, error) {
+	return s.takeoutRepo.List(userID, s.now())
+}
+
+// Get returns a takeout of the user without its archive
+func (s *takeoutService) Get(userID, id int64) (*domain.Takeout, error) {
+	return s.takeoutRepo.Find(userID, id, s.now())
+}
+
+// Download returns a ready takeout of the user with its archive
+func (s *takeoutService) Download(userID, id int64) (*domain.Takeout, error) {
+	takeout, err := s.takeoutRepo.FindArchive(userID, id, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if takeout.Status != domain.TakeoutStatusReady {
+		return nil, ErrTakeoutNotReady
+	}
+	return takeout, nil
+}
+
+// BuildDue builds pending takeouts and returns how many are ready. A takeout
+// that cannot be built is marked failed rather than retried.
+func (s *takeoutService) BuildDue(ctx context.Context) (int, error) {
+	takeouts, err := s.takeoutRepo.ClaimPending(s.now(), takeoutLease, takeoutBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	var built int
+	for i := range takeouts {
+		if err := ctx.Err(); err != nil {
+			return built, err
+		}
+
+		takeout := &takeouts[i]
+		archive, err := s.buildFor(ctx, takeout.UserID)
+		if err != nil {
+			log := logger.Get()
+			log.Error().Err(err).Int64("takeout_id", takeout.ID).Int64("user_id", takeout.UserID).Msg("Failed to build takeout")
+			s.fail(takeout)
+		} else {
+			s.complete(takeout, archive)
+			built++
+		}
+
+		if err := s.takeoutRepo.Save(takeout); err != nil {
+			return built, err
+		}
+	}
+	return built, nil
+}
+
+// DeleteExpired deletes the takeouts that can no longer be downloaded
+func (s *takeoutService) DeleteExpired() (int64, error) {
+	return s.takeoutRepo.DeleteExpired(s.now())
+}
+
+// buildFor archives the data of a user who may have been deleted meanwhile
+func (s *takeoutService) buildFor(ctx context.Context, userID int64) ([]byte, error) {
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.build(ctx, user)
+}
+
+// complete marks a takeout ready with its archive
+func (s *takeoutService) complete(takeout *domain.Takeout, archive []byte) {
+	now := s.now()
+	expiresAt := now.Add(s.ttl)
+	takeout.Status = domain.TakeoutStatusReady
+	takeout.Archive = archive
+	takeout.Size = int64(len(archive))
+	takeout.CompletedAt = &now
+	takeout.ExpiresAt = &expiresAt
+}
+
+// fail marks a takeout failed; it expires like a ready one so users see why
+func (s *takeoutService) fail(takeout *domain.Takeout) {
+	now := s.now()
+	expiresAt := now.Add(s.ttl)
+	takeout.Status = domain.TakeoutStatusFailed
+	takeout.Error = "archive could not be built"
+	takeout.CompletedAt = &now
+	takeout.ExpiresAt = &expiresAt
+}
+
+// build writes the zip archive of a user, reading transactions until ctx is done
+func (s *takeoutService) build(ctx context.Context, user *domain.User) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := &takeoutArchive{zip: zip.NewWriter(&buf)}
+
+	profile := domain.TakeoutProfile{
+		CreatedAt:   user.CreatedAt,
+		LastLoginAt: user.LastLoginAt,
+		Settings:    user.Settings,
+		UUID:        user.UUID.String(),
+		Email:       user.Email,
+		Name:        user.Name,
+	}
+	if err := archive.writeJSON(domain.TakeoutProfileFile, profile, 1); err != nil {
+		return nil, err
+	}
+
+	prefix := user.APIKey
+	if len(prefix) > takeoutAPIKeyPrefix {
+		prefix = prefix[:takeoutAPIKeyPrefix]
+	}
+	apiKey := domain.TakeoutAPIKey{CreatedAt: user.CreatedAt, Prefix: prefix}
+	if err := archive.writeJSON(domain.TakeoutAPIKeyFile, apiKey, 1); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.writeTransactions(ctx, archive)
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.writeJSON(domain.TakeoutCategoriesFile, categories, len(categories)); err != nil {
+		return nil, err
+	}
+	err = archive.writeCSV(domain.TakeoutCategoriesCSVFile, []string{"name", "transactions", "total_in", "total_out"}, len(categories), func(i int) []string {
+		category := categories[i]
+		return []string{
+			csvSafeText(category.Name),
+			strconv.FormatInt(category.Transactions, 10),
+			domain.PlainNumberFormat.Format(category.TotalIn),
+			domain.PlainNumberFormat.Format(category.TotalOut),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payees, err := s.payeeRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.writeJSON(domain.TakeoutPayeesFile, payees, len(payees)); err != nil {
+		return nil, err
+	}
+	err = archive.writeCSV(domain.TakeoutPayeesCSVFile, []string{"id", "name", "default_category", "aliases"}, len(payees), func(i int) []string {
+		payee := payees[i]
+		patterns := make([]string, len(payee.Aliases))
+		for j, alias := range payee.Aliases {
+			patterns[j] = alias.Pattern
+		}
+		return []string{
+			strconv.FormatInt(payee.ID, 10),
+			csvSafeText(payee.Name),
+			payee.DefaultCategory,
+			csvSafeText(strings.Join(patterns, "|")),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.budgetRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.writeJSON(domain.TakeoutBudgetsFile, budgets, len(budgets)); err != nil {
+		return nil, err
+	}
+	err = archive.writeCSV(domain.TakeoutBudgetsCSVFile, []string{"id", "category", "source", "amount"}, len(budgets), func(i int) []string {
+		budget := budgets[i]
+		return []string{
+			strconv.FormatInt(budget.ID, 10),
+			budget.Category,
+			csvSafeText(budget.Source),
+			domain.PlainNumberFormat.Format(budget.Amount),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.takeoutRepo.ListSecurityEvents(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.writeJSON(domain.TakeoutSecurityEventsFile, events, len(events)); err != nil {
+		return nil, err
+	}
+	err = archive.writeCSV(domain.TakeoutSecurityEventsCSVFile, []string{"id", "created_at", "type", "ip_address", "user_agent", "details"}, len(events), func(i int) []string {
+		event := events[i]
+		return []string{
+			strconv.FormatInt(event.ID, 10),
+			event.CreatedAt.UTC().Format(time.RFC3339),
+			event.Type,
+			event.IPAddress,
+			csvSafeText(event.UserAgent),
+			csvSafeText(event.Details),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := domain.TakeoutManifest{
+		CreatedAt:     s.now().UTC(),
+		Generator:     domain.TakeoutGenerator,
+		UserUUID:      user.UUID.String(),
+		Files:         archive.files,
+		SchemaVersion: domain.TakeoutSchemaVersion,
+	}
+	if err := archive.writeJSON(domain.TakeoutManifestFile, manifest, 0); err != nil {
+		return nil, err
+	}
+	if err := archive.zip.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeTransactions writes transactions.json and transactions.csv and
+// returns the totals per category, seen along the way
+func (s *takeoutService) writeTransactions(ctx context.Context, archive *takeoutArchive) ([]domain.TakeoutCategory, error) {
+	byName := make(map[string]*domain.TakeoutCategory, len(domain.ValidCategories))
+	for name := range domain.ValidCategories {
+		if name != "" {
+			byName[name] = &domain.TakeoutCategory{Name: name}
+		}
+	}
+
+	err := archive.writeFile(domain.TakeoutTransactionsFile, func(w io.Writer) (int, error) {
+		buf := bufio.NewWriter(w)
+		buf.WriteString("[")
+		var records int
+		err := s.txRepo.Stream(ctx, domain.ListTransactionsQueryParams{}, func(tx *domain.Transaction) error {
+			encoded, err := json.Marshal(tx)
+			if err != nil {
+				return err
+			}
+			if records > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n  ")
+			buf.Write(encoded)
+			records++
+
+			category, ok := byName[tx.Category]
+			if !ok {
+				category = &domain.TakeoutCategory{Name: tx.Category}
+				byName[tx.Category] = category
+			}
+			category.Transactions++
+			if tx.Type == domain.TransactionTypeIn {
+				category.TotalIn += tx.Amount
+			} else {
+				category.TotalOut += tx.Amount
+			}
+			return nil
+		})
+		if err != nil {
+			return 0, err
+		}
+		buf.WriteString("\n]\n")
+		return records, buf.Flush()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = archive.writeFile(domain.TakeoutTransactionsCSVFile, func(w io.Writer) (int, error) {
+		writer := newCSVExportWriter(w, takeoutTransactionColumns, domain.PlainNumberFormat)
+		if err := writer.WriteHeader(takeoutTransactionColumns); err != nil {
+			return 0, err
+		}
+		var records int
+		err := s.txRepo.Stream(ctx, domain.ListTransactionsQueryParams{}, func(tx *domain.Transaction) error {
+			records++
+			return writer.WriteTransaction(tx)
+		})
+		if err != nil {
+			return 0, err
+		}
+		return records, writer.Close()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]domain.TakeoutCategory, 0, len(byName))
+	for _, category := range byName {
+		categories = append(categories, *category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	return categories, nil
+}
+
+// takeoutArchive writes the files of an archive and lists them for the manifest
+type takeoutArchive struct {
+	zip   *zip.Writer
+	files []domain.TakeoutFile
+}
+
+// writeFile adds a file whose content fn writes, returning its number of records
+func (a *takeoutArchive) writeFile(name string, fn func(w io.Writer) (int, error)) error {
+	f, err := a.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	hash := sha256.New()
+	records, err := fn(io.MultiWriter(f, hash))
+	if err != nil {
+		return err
+	}
+	if name != domain.TakeoutManifestFile {
+		a.files = append(a.files, domain.TakeoutFile{
+			Name:    name,
+			SHA256:  hex.EncodeToString(hash.Sum(nil)),
+			Records: records,
+		})
+	}
+	return nil
+}
+
+// writeJSON adds an indented JSON file
+func (a *takeoutArchive) writeJSON(name string, value interface{}, records int) error {
+	return a.writeFile(name, func(w io.Writer) (int, error) {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return records, encoder.Encode(value)
+	})
+}
+
+// writeCSV adds a CSV file with a header and the given number of rows
+func (a *takeoutArchive) writeCSV(name string, header []string, rows int, row func(i int) []string) error {
+	return a.writeFile(name, func(w io.Writer) (int, error) {
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return 0, err
+		}
+		for i := 0; i < rows; i++ {
+			if err := writer.Write(row(i)); err != nil {
+				return 0, err
+			}
+		}
+		writer.Flush()
+		return rows, writer.Error()
+	})
+}
+
+// Restore brings an archive into the account of the user and into the
+// ledger, which must be empty. The security log and API key are not
+// restored; the account keeps its own.
+func (s *takeoutService) Restore(userID int64, archive io.ReaderAt, size int64) (*domain.TakeoutRestoreResult, error) {
+	reader, err := zip.NewReader(archive, size)
+	if err != nil {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: "file is not a zip archive",
+		}
+	}
+	files := make(map[string]*zip.File, len(reader.File))
+	for _, f := range reader.File {
+		files[f.Name] = f
+	}
+
+	var manifest domain.TakeoutManifest
+	content, err := readTakeoutFile(files, domain.TakeoutManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeTakeoutJSON(domain.TakeoutManifestFile, content, &manifest); err != nil {
+		return nil, err
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+
+	contents := make(map[string][]byte, len(manifest.Files))
+	for _, file := range manifest.Files {
+		content, err := readTakeoutFile(files, file.Name)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(content)
+		if hex.EncodeToString(sum[:]) != file.SHA256 {
+			return nil, &domain.ValidationError{
+				Field:   "file",
+				Message: file.Name + " does not match its checksum in the manifest",
+			}
+		}
+		contents[file.Name] = content
+	}
+
+	var profile domain.TakeoutProfile
+	var ledger domain.TakeoutLedger
+	decode := []struct {
+		name  string
+		value interface{}
+	}{
+		{domain.TakeoutProfileFile, &profile},
+		{domain.TakeoutTransactionsFile, &ledger.Transactions},
+		{domain.TakeoutPayeesFile, &ledger.Payees},
+		{domain.TakeoutBudgetsFile, &ledger.Budgets},
+	}
+	for _, file := range decode {
+		if err := decodeTakeoutJSON(file.name, contents[file.name], file.value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := profile.Settings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := ledger.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	name := s.sanitizer.CleanInput(profile.Name, domain.MaxNameLength)
+	if name == "" {
+		name = user.Name
+	}
+
+	if err := s.takeoutRepo.Restore(user.ID, name, profile.Settings, &ledger); err != nil {
+		return nil, err
+	}
+	return &domain.TakeoutRestoreResult{
+		Transactions: len(ledger.Transactions),
+		Payees:       len(ledger.Payees),
+		Budgets:      len(ledger.Budgets),
+	}, nil
+}
+
+// readTakeoutFile reads a file of an archive, refusing files too large to restore
+func readTakeoutFile(files map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: "archive has no " + name,
+		}
+	}
+	if f.UncompressedSize64 > domain.MaxTakeoutFileSize {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: name + " is too large to restore",
+		}
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: name + " could not be read",
+		}
+	}
+	defer rc.Close()
+
+	// The size in the header is not trusted
+	content, err := io.ReadAll(io.LimitReader(rc, domain.MaxTakeoutFileSize+1))
+	if err != nil || len(content) > domain.MaxTakeoutFileSize {
+		return nil, &domain.ValidationError{
+			Field:   "file",
+			Message: name + " could not be read",
+		}
+	}
+	return content, nil
+}
+
+// decodeTakeoutJSON decodes a JSON file of an archive
+func decodeTakeoutJSON(name string, content []byte, value interface{}) error {
+	if err := json.Unmarshal(content, value); err != nil {
+		return &domain.ValidationError{
+			Field:   "file",
+			Message: name + " is not valid JSON: " + err.Error(),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
+)
+
+type mockTakeoutRepository struct {
+	takeouts    []domain.Takeout
+	pending     bool
+	claimed     []domain.Takeout
+	saved       []domain.Takeout
+	events      []domain.SecurityEvent
+	restoreErr  error
+	restoreName string
+	restored    *domain.TakeoutLedger
+	settings    domain.UserSettings
+}
+
+func (m *mockTakeoutRepository) Create(takeout *domain.Takeout) error {
+	takeout.ID = int64(len(m.takeouts) + 1)
+	m.takeouts = append(m.takeouts, *takeout)
+	return nil
+}
+
+func (m *mockTakeoutRepository) Find(userID, id int64, now time.Time) (*domain.Takeout, error) {
+	takeout, err := m.FindArchive(userID, id, now)
+	if err != nil {
+		return nil, err
+	}
+	takeout.Archive = nil
+	return takeout, nil
+}
+
+func (m *mockTakeoutRepository) FindArchive(userID, id int64, now time.Time) (*domain.Takeout, error) {
+	for i := range m.takeouts {
+		takeout := m.takeouts[i]
+		if takeout.ID == id && takeout.UserID == userID && (takeout.ExpiresAt == nil || takeout.ExpiresAt.After(now)) {
+			return &takeout, nil
+		}
+	}
+	return nil, repository.ErrTakeoutNotFound
+}
+
+func (m *mockTakeoutRepository) List(userID int64, now time.Time) ([]domain.Takeout, error) {
+	return m.takeouts, nil
+}
+
+func (m *mockTakeoutRepository) HasPending(userID int64) (bool, error) {
+	return m.pending, nil
+}
+
+func (m *mockTakeoutRepository) ClaimPending(now time.Time, lease time.Duration, limit int) ([]domain.Takeout, error) {
+	return m.claimed, nil
+}
+
+func (m *mockTakeoutRepository) Save(takeout *domain.Takeout) error {
+	m.saved = append(m.saved, *takeout)
+	return nil
+}
+
+func (m *mockTakeoutRepository) DeleteExpired(now time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockTakeoutRepository) ListSecurityEvents(userID int64) ([]domain.SecurityEvent, error) {
+	return m.events, nil
+}
+
+func (m *mockTakeoutRepository) Restore(userID int64, name string, settings domain.UserSettings, ledger *domain.TakeoutLedger) error {
+	if m.restoreErr != nil {
+		return m.restoreErr
+	}
+	m.restoreName = name
+	m.settings = settings
+	m.restored = ledger
+	return nil
+}
+
+// takeoutTestDeps holds the mocks behind a takeout service under test
+type takeoutTestDeps struct {
+	takeouts *mockTakeoutRepository
+	txRepo   *mockRepository
+	payees   *mockPayeeRepository
+	budgets  *mockBudgetRepository
+}
+
+// newTestTakeoutService creates a takeout service over a small ledger with a fixed clock
+func newTestTakeoutService(t *testing.T, syncLimit int, now time.Time) (*takeoutService, *takeoutTestDeps) {
+	t.Helper()
+	user := createTestUser(t, "test@example.com", "Password123")
+	user.Settings = domain.UserSettings{Locale: "de-DE", Timezone: "Europe/Berlin"}
+	payeeID := int64(1)
+	deps := &takeoutTestDeps{
+		takeouts: &mockTakeoutRepository{
+			events: []domain.SecurityEvent{{ID: 1, UserID: 1, Type: domain.SecurityEventLoginSucceeded, IPAddress: "203.0.113.7"}},
+		},
+		txRepo: &mockRepository{
+			streamed: []domain.Transaction{
+				{ID: 10, Type: domain.TransactionTypeOut, Amount: 12.5, Category: "Food", Source: "MoMo", PayeeID: &payeeID,
+					Description: "=SUM(A1)", TransactionDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+				{ID: 11, Type: domain.TransactionTypeIn, Amount: 1000, Category: "Salary", Source: "Bank",
+					TransactionDate: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)},
+			},
+			listFunc: func(params domain.ListTransactionsQueryParams) ([]domain.Transaction, int64, error) {
+				return nil, 2, nil
+			},
+		},
+		payees: &mockPayeeRepository{
+			payees: []domain.Payee{{ID: 1, Name: "Corner Shop", DefaultCategory: "Food", Aliases: []domain.PayeeAlias{{ID: 3, PayeeID: 1, Pattern: "corner"}}}},
+		},
+		budgets: &mockBudgetRepository{
+			budgets: []domain.Budget{{ID: 2, Category: "Food", Amount: 300}},
+		},
+	}
+	svc := NewTakeoutService(&mockUserRepository{findByIDUser: user}, deps.takeouts, deps.txRepo,
+		deps.payees, deps.budgets, 24*time.Hour, syncLimit).(*takeoutService)
+	svc.now = func() time.Time { return now }
+	return svc, deps
+}
+
+// readTestArchive returns the files of a zip archive by name
+func readTestArchive(t *testing.T, archive []byte) map[string][]byte {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte, len(reader.File))
+	for _, f := range reader.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = content
+	}
+	return files
+}
+
+// writeTestArchive zips files with a manifest listing their checksums
+func writeTestArchive(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	manifest := domain.TakeoutManifest{SchemaVersion: domain.TakeoutSchemaVersion}
+	for name, content := range files {
+		sum := sha256.Sum256([]byte(content))
+		manifest.Files = append(manifest.Files, domain.TakeoutFile{Name: name, SHA256: hex.EncodeToString(sum[:])})
+	}
+	encoded, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	withManifest := map[string]string{domain.TakeoutManifestFile: string(encoded)}
+	for name, content := range files {
+		withManifest[name] = content
+	}
+	return zipTestFiles(t, withManifest)
+}
+
+// zipTestFiles zips files as they are
+func zipTestFiles(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := writer.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return buf.Bytes()
+}
+
+func TestTakeoutService_Request_BuildsSmallLedgerAtOnce(t *testing.T) {
+	now := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+	svc, deps := newTestTakeoutService(t, 10, now)
+
+	takeout, err := svc.Request(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TakeoutStatusReady, takeout.Status)
+	assert.Nil(t, takeout.Archive)
+	assert.Equal(t, now.Add(24*time.Hour), *takeout.ExpiresAt)
+	require.Len(t, deps.takeouts.takeouts, 1)
+	stored := deps.takeouts.takeouts[0]
+	assert.Equal(t, int64(len(stored.Archive)), takeout.Size)
+
+	files := readTestArchive(t, stored.Archive)
+	var manifest domain.TakeoutManifest
+	require.NoError(t, json.Unmarshal(files[domain.TakeoutManifestFile], &manifest))
+	assert.Equal(t, domain.TakeoutSchemaVersion, manifest.SchemaVersion)
+	assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", manifest.UserUUID)
+	assert.Len(t, manifest.Files, len(files)-1)
+	for _, file := range manifest.Files {
+		sum := sha256.Sum256(files[file.Name])
+		assert.Equal(t, hex.EncodeToString(sum[:]), file.SHA256, file.Name)
+		if file.Name == domain.TakeoutTransactionsFile || file.Name == domain.TakeoutTransactionsCSVFile {
+			assert.Equal(t, 2, file.Records, file.Name)
+		}
+	}
+
+	var apiKey domain.TakeoutAPIKey
+	require.NoError(t, json.Unmarshal(files[domain.TakeoutAPIKeyFile], &apiKey))
+	assert.Equal(t, "test", apiKey.Prefix)
+	for name, content := range files {
+		assert.NotContains(t, string(content), "test-api-key", name)
+	}
+
+	var categories []domain.TakeoutCategory
+	require.NoError(t, json.Unmarshal(files[domain.TakeoutCategoriesFile], &categories))
+	for _, category := range categories {
+		switch category.Name {
+		case "Food":
+			assert.Equal(t, int64(1), category.Transactions)
+			assert.Equal(t, 12.5, category.TotalOut)
+		case "Salary":
+			assert.Equal(t, 1000.0, category.TotalIn)
+		}
+	}
+
+	csvContent := string(files[domain.TakeoutTransactionsCSVFile])
+	assert.True(t, strings.HasPrefix(csvContent, "id,transaction_date,type,amount,"))
+	assert.Contains(t, csvContent, "'=SUM(A1)")
+	assert.Contains(t, string(files[domain.TakeoutPayeesCSVFile]), "Corner Shop,Food,corner")
+	assert.Contains(t, string(files[domain.TakeoutSecurityEventsCSVFile]), "203.0.113.7")
+}
+
+func TestTakeoutService_Request_LargeLedgerIsBuiltLater(t *testing.T) {
+	now := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+	svc, deps := newTestTakeoutService(t, 1, now)
+
+	takeout, err := svc.Request(1)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TakeoutStatusPending, takeout.Status)
+	assert.Equal(t, now, takeout.LeaseUntil)
+	assert.Empty(t, deps.takeouts.takeouts[0].Archive)
+
+	deps.takeouts.claimed = []domain.Takeout{*takeout}
+	built, err := svc.BuildDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, built)
+	require.Len(t, deps.takeouts.saved, 1)
+	assert.Equal(t, domain.TakeoutStatusReady, deps.takeouts.saved[0].Status)
+	assert.NotEmpty(t, deps.takeouts.saved[0].Archive)
+}
+
+func TestTakeoutService_Request_InProgress(t *testing.T) {
+	svc, deps := newTestTakeoutService(t, 10, time.Now())
+	deps.takeouts.pending = true
+
+	_, err := svc.Request(1)
+
+	assert.ErrorIs(t, err, ErrTakeoutInProgress)
+	assert.Empty(t, deps.takeouts.takeouts)
+}
+
+func TestTakeoutService_BuildDue_MarksFailed(t *testing.T) {
+	now := time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC)
+	svc, deps := newTestTakeoutService(t, 1, now)
+	deps.budgets.listErr = assert.AnError
+	deps.takeouts.claimed = []domain.Takeout{{ID: 3, UserID: 1, Status: domain.TakeoutStatusPending}}
+
+	built, err := svc.BuildDue(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, built)
+	require.Len(t, deps.takeouts.saved, 1)
+	assert.Equal(t, domain.TakeoutStatusFailed, deps.takeouts.saved[0].Status)
+	assert.Equal(t, now.Add(24*time.Hour), *deps.takeouts.saved[0].ExpiresAt)
+}
+
+func TestTakeoutService_Download_NotReady(t *testing.T) {
+	svc, deps := newTestTakeoutService(t, 10, time.Now())
+	deps.takeouts.takeouts = []domain.Takeout{{ID: 1, UserID: 1, Status: domain.TakeoutStatusPending}}
+
+	_, err := svc.Download(1, 1)
+	assert.ErrorIs(t, err, ErrTakeoutNotReady)
+
+	_, err = svc.Download(2, 1)
+	assert.ErrorIs(t, err, repository.ErrTakeoutNotFound)
+}
+
+func TestTakeoutService_Restore_RoundTrip(t *testing.T) {
+	svc, deps := newTestTakeoutService(t, 10, time.Now())
+	_, err := svc.Request(1)
+	require.NoError(t, err)
+	archive := deps.takeouts.takeouts[0].Archive
+
+	result, err := svc.Restore(1, bytes.NewReader(archive), int64(len(archive)))
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.TakeoutRestoreResult{Transactions: 2, Payees: 1, Budgets: 1}, result)
+	assert.Equal(t, "Test User", deps.takeouts.restoreName)
+	assert.Equal(t, "Europe/Berlin", deps.takeouts.settings.Timezone)
+	restored := deps.takeouts.restored
+	assert.Equal(t, "=SUM(A1)", restored.Transactions[0].Description)
+	require.NotNil(t, restored.Transactions[0].PayeeID)
+	assert.Equal(t, int64(1), *restored.Transactions[0].PayeeID)
+	assert.Equal(t, "corner", restored.Payees[0].Aliases[0].Pattern)
+	assert.Equal(t, 300.0, restored.Budgets[0].Amount)
+}
+
+func TestTakeoutService_Restore_Errors(t *testing.T) {
+	valid := map[string]string{
+		domain.TakeoutProfileFile:      `{"name": "Old Name"}`,
+		domain.TakeoutTransactionsFile: `[]`,
+		domain.TakeoutPayeesFile:       `[]`,
+		domain.TakeoutBudgetsFile:      `[]`,
+	}
+	tampered := map[string]string{domain.TakeoutManifestFile: `{"schema_version": 1, "files": [
+		{"name": "profile.json", "sha256": "00"}, {"name": "transactions.json", "sha256": "00"},
+		{"name": "payees.json", "sha256": "00"}, {"name": "budgets.json", "sha256": "00"}]}`}
+	for name, content := range valid {
+		tampered[name] = content
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr string
+	}{
+		{"not a zip", []byte("plain text"), "file is not a zip archive"},
+		{"checksum mismatch", zipTestFiles(t, tampered), "profile.json does not match its checksum in the manifest"},
+		{"invalid json", writeTestArchive(t, map[string]string{
+			domain.TakeoutProfileFile:      `{}`,
+			domain.TakeoutTransactionsFile: `{`,
+			domain.TakeoutPayeesFile:       `[]`,
+			domain.TakeoutBudgetsFile:      `[]`,
+		}), "transactions.json is not valid JSON"},
+		{"missing file", writeTestArchive(t, map[string]string{domain.TakeoutProfileFile: `{}`}), "archive must contain"},
+		{"invalid record", writeTestArchive(t, map[string]string{
+			domain.TakeoutProfileFile:      `{}`,
+			domain.TakeoutTransactionsFile: `[]`,
+			domain.TakeoutPayeesFile:       `[]`,
+			domain.TakeoutBudgetsFile:      `[{"category": "Food", "amount": 0}]`,
+		}), "budgets.json record 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, deps := newTestTakeoutService(t, 10, time.Now())
+
+			_, err := svc.Restore(1, bytes.NewReader(tt.archive), int64(len(tt.archive)))
+
+			var validationErr *domain.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "file", validationErr.Field)
+			assert.Contains(t, validationErr.Message, tt.wantErr)
+			assert.Nil(t, deps.takeouts.restored)
+		})
+	}
+}
+
+func TestTakeoutService_Restore_LedgerNotEmpty(t *testing.T) {
+	svc, deps := newTestTakeoutService(t, 10, time.Now())
+	deps.takeouts.restoreErr = repository.ErrLedgerNotEmpty
+	archive := writeTestArchive(t, map[string]string{
+		domain.TakeoutProfileFile:      `{"name": ""}`,
+		domain.TakeoutTransactionsFile: `[]`,
+		domain.TakeoutPayeesFile:       `[]`,
+		domain.TakeoutBudgetsFile:      `[]`,
+	})
+
+	_, err := svc.Restore(1, bytes.NewReader(archive), int64(len(archive)))
+
+	assert.ErrorIs(t, err, repository.ErrLedgerNotEmpty)
+}
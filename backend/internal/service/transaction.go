@@ -2,6 +2,11 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
 
 	"github.com/dev/personal-finance-tracker/backend/internal/domain"
 	"github.com/dev/personal-finance-tracker/backend/internal/repository"
@@ -12,24 +17,64 @@ import (
 type TransactionService interface {
 	CreateTransaction(req *domain.CreateTransactionRequest) (*domain.Transaction, error)
 	CreateBatchTransaction(req *domain.BatchTransactionRequest) ([]domain.Transaction, error)
+	UpdateTransaction(id int64, req *domain.CreateTransactionRequest) (*domain.Transaction, error)
 	GetTransactionByID(id int64) (*domain.Transaction, error)
 	ListTransactions(params domain.ListTransactionsQueryParams) ([]domain.Transaction, int64, error)
 	GetSummary() (*domain.SummaryResponse, error)
 	GetTrends(period string) (*domain.TrendsResponse, error)
 	GetBreakdownBySource() ([]domain.BreakdownResponse, error)
 	GetBreakdownByCategory() ([]domain.BreakdownResponse, error)
+	GetBreakdown(params domain.BreakdownQueryParams) ([]domain.BreakdownResponse, error)
+	GetPivot(params domain.PivotQueryParams) (*domain.PivotResponse, error)
+}
+
+// TransactionHook is notified after transactions have been stored
+type TransactionHook interface {
+	OnTransactionsCreated(transactions []domain.Transaction)
+}
+
+// TransactionUpdateHook is implemented by hooks that are also notified after
+// a transaction has been updated
+type TransactionUpdateHook interface {
+	OnTransactionUpdated(previous, updated domain.Transaction)
+}
+
+// TransactionPreparer is implemented by hooks that also adjust transactions
+// before they are stored, e.g. to link them to a payee
+type TransactionPreparer interface {
+	PrepareTransactions(transactions []domain.Transaction)
 }
 
 type transactionService struct {
-	repo       repository.TransactionRepository
+	repo      repository.TransactionRepository
 	sanitizer *security.Sanitizer
+	hooks     []TransactionHook
+	preparers []TransactionPreparer
+	updaters  []TransactionUpdateHook
 }
 
-// NewTransactionService creates a new transaction service
-func NewTransactionService(repo repository.TransactionRepository) TransactionService {
+// NewTransactionService creates a new transaction service.
+// Hooks are called synchronously, in order, after each successful create;
+// hooks that implement TransactionPreparer are also called before storing and
+// those that implement TransactionUpdateHook after each update.
+func NewTransactionService(repo repository.TransactionRepository, hooks ...TransactionHook) TransactionService {
+	var preparers []TransactionPreparer
+	var updaters []TransactionUpdateHook
+	for _, hook := range hooks {
+		if preparer, ok := hook.(TransactionPreparer); ok {
+			preparers = append(preparers, preparer)
+		}
+		if updater, ok := hook.(TransactionUpdateHook); ok {
+			updaters = append(updaters, updater)
+		}
+	}
+
 	return &transactionService{
-		repo:       repo,
+		repo:      repo,
 		sanitizer: security.NewSanitizer(),
+		hooks:     hooks,
+		preparers: preparers,
+		updaters:  updaters,
 	}
 }
 
@@ -40,17 +85,7 @@ func (s *transactionService) CreateTransaction(req *domain.CreateTransactionRequ
 	}
 
 	// Additional explicit sanitization for defense-in-depth
-	req.Source = s.sanitizer.CleanInput(req.Source, domain.MaxSourceLength)
-	if req.Category != "" {
-		req.Category = s.sanitizer.CleanInput(req.Category, domain.MaxCategoryLength)
-	}
-	req.Description = s.sanitizer.CleanInput(req.Description, domain.MaxDescriptionLength)
-	if req.SourceAccount != "" {
-		req.SourceAccount = s.sanitizer.CleanInput(req.SourceAccount, domain.MaxAccountLength)
-	}
-	if req.Recipient != "" {
-		req.Recipient = s.sanitizer.CleanInput(req.Recipient, domain.MaxRecipientLength)
-	}
+	s.sanitize(req)
 
 	// Convert request to domain
 	transaction, err := req.ToTransaction()
@@ -58,11 +93,64 @@ func (s *transactionService) CreateTransaction(req *domain.CreateTransactionRequ
 		return nil, err
 	}
 
+	prepared := []domain.Transaction{*transaction}
+	s.prepare(prepared)
+	*transaction = prepared[0]
+
 	// Create transaction (repository uses parameterized queries)
 	if err := s.repo.Create(transaction); err != nil {
 		return nil, err
 	}
 
+	s.notifyCreated([]domain.Transaction{*transaction})
+
+	return transaction, nil
+}
+
+// UpdateTransaction replaces the fields of a stored transaction, keeping its
+// external ID when the request has none
+func (s *transactionService) UpdateTransaction(id int64, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+	if id <= 0 {
+		return nil, errors.New("invalid transaction ID")
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	s.sanitize(req)
+
+	previous, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, repository.ErrTransactionNotFound
+		}
+		return nil, err
+	}
+
+	transaction, err := req.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	transaction.ID = previous.ID
+	transaction.CreatedAt = previous.CreatedAt
+	transaction.Anomalous = previous.Anomalous
+	// The external ID is the dedup key of webhooks and imports; an update that
+	// leaves it out must not clear it
+	if transaction.ExternalID == "" {
+		transaction.ExternalID = previous.ExternalID
+	}
+
+	prepared := []domain.Transaction{*transaction}
+	s.prepare(prepared)
+	*transaction = prepared[0]
+
+	if err := s.repo.Update(transaction); err != nil {
+		return nil, err
+	}
+
+	for _, updater := range s.updaters {
+		updater.OnTransactionUpdated(*previous, *transaction)
+	}
+
 	return transaction, nil
 }
 
@@ -87,6 +175,9 @@ func (s *transactionService) CreateBatchTransaction(req *domain.BatchTransaction
 		if t.Recipient != "" {
 			t.Recipient = s.sanitizer.CleanInput(t.Recipient, domain.MaxRecipientLength)
 		}
+		if t.ExternalID != "" {
+			t.ExternalID = s.sanitizer.CleanInput(t.ExternalID, domain.MaxExternalIDLength)
+		}
 
 		// Convert request to domain
 		transaction, err := t.ToTransaction()
@@ -97,14 +188,50 @@ func (s *transactionService) CreateBatchTransaction(req *domain.BatchTransaction
 		transactions = append(transactions, *transaction)
 	}
 
+	s.prepare(transactions)
+
 	// Create transactions in batch
 	if err := s.repo.CreateInBatch(transactions); err != nil {
 		return nil, err
 	}
 
+	s.notifyCreated(transactions)
+
 	return transactions, nil
 }
 
+// sanitize cleans the free-text fields of a request
+func (s *transactionService) sanitize(req *domain.CreateTransactionRequest) {
+	req.Source = s.sanitizer.CleanInput(req.Source, domain.MaxSourceLength)
+	if req.Category != "" {
+		req.Category = s.sanitizer.CleanInput(req.Category, domain.MaxCategoryLength)
+	}
+	req.Description = s.sanitizer.CleanInput(req.Description, domain.MaxDescriptionLength)
+	if req.SourceAccount != "" {
+		req.SourceAccount = s.sanitizer.CleanInput(req.SourceAccount, domain.MaxAccountLength)
+	}
+	if req.Recipient != "" {
+		req.Recipient = s.sanitizer.CleanInput(req.Recipient, domain.MaxRecipientLength)
+	}
+	if req.ExternalID != "" {
+		req.ExternalID = s.sanitizer.CleanInput(req.ExternalID, domain.MaxExternalIDLength)
+	}
+}
+
+// prepare lets every registered preparer adjust transactions before they are stored
+func (s *transactionService) prepare(transactions []domain.Transaction) {
+	for _, preparer := range s.preparers {
+		preparer.PrepareTransactions(transactions)
+	}
+}
+
+// notifyCreated passes newly stored transactions to every registered hook
+func (s *transactionService) notifyCreated(transactions []domain.Transaction) {
+	for _, hook := range s.hooks {
+		hook.OnTransactionsCreated(transactions)
+	}
+}
+
 func (s *transactionService) GetTransactionByID(id int64) (*domain.Transaction, error) {
 	// Validate ID to prevent path traversal or injection
 	if id <= 0 {
@@ -161,3 +288,179 @@ func (s *transactionService) GetBreakdownBySource() ([]domain.BreakdownResponse,
 func (s *transactionService) GetBreakdownByCategory() ([]domain.BreakdownResponse, error) {
 	return s.repo.GetBreakdownByCategory()
 }
+
+func (s *transactionService) GetBreakdown(params domain.BreakdownQueryParams) ([]domain.BreakdownResponse, error) {
+	// Validate dimension, type and date range before touching the repository
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter, err := params.ToFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetBreakdown(filter)
+}
+
+func (s *transactionService) GetPivot(params domain.PivotQueryParams) (*domain.PivotResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	filter, err := params.ToFilter()
+	if err != nil {
+		return nil, err
+	}
+
+	// Fill in a default window so the matrix always has a dense set of columns
+	applyDefaultPivotRange(filter, time.Now())
+
+	columns := pivotPeriodLabels(filter.Period, *filter.Start, *filter.End)
+	if len(columns) > domain.MaxPivotColumns {
+		return nil, &domain.ValidationError{
+			Field:   "period",
+			Message: fmt.Sprintf("date range too large for %s period (max %d columns)", filter.Period, domain.MaxPivotColumns),
+		}
+	}
+
+	cells, err := s.repo.GetPivot(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildPivot(filter, columns, cells), nil
+}
+
+// applyDefaultPivotRange fills missing date bounds: the end defaults to now and
+// the start to 30 days, 12 weeks or 12 months before the end
+func applyDefaultPivotRange(filter *domain.PivotFilter, now time.Time) {
+	if filter.End == nil {
+		end := now.UTC()
+		filter.End = &end
+	}
+	if filter.Start == nil {
+		// Truncate first so month arithmetic never overflows (e.g. Oct 31 - 11 months)
+		start := truncateToPeriod(filter.Period, *filter.End)
+		switch filter.Period {
+		case "daily":
+			start = start.AddDate(0, 0, -29)
+		case "weekly":
+			start = start.AddDate(0, 0, -7*11)
+		default:
+			start = start.AddDate(0, -11, 0)
+		}
+		filter.Start = &start
+	}
+}
+
+// truncateToPeriod returns the first instant of the period containing t
+func truncateToPeriod(period string, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case "weekly":
+		// ISO weeks start on Monday
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case "monthly":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// pivotPeriodLabel formats t the same way the repository's TO_CHAR formats do
+func pivotPeriodLabel(period string, t time.Time) string {
+	switch period {
+	case "weekly":
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case "monthly":
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+// pivotPeriodLabels lists every period label between start and end inclusive
+func pivotPeriodLabels(period string, start, end time.Time) []string {
+	labels := []string{}
+	end = end.UTC()
+	for t := truncateToPeriod(period, start); !t.After(end); {
+		labels = append(labels, pivotPeriodLabel(period, t))
+		// Stop early rather than building an unbounded slice
+		if len(labels) > domain.MaxPivotColumns {
+			break
+		}
+		switch period {
+		case "weekly":
+			t = t.AddDate(0, 0, 7)
+		case "monthly":
+			t = t.AddDate(0, 1, 0)
+		default:
+			t = t.AddDate(0, 0, 1)
+		}
+	}
+	return labels
+}
+
+// buildPivot turns sparse aggregate cells into a dense matrix with totals
+func buildPivot(filter *domain.PivotFilter, columns []string, cells []domain.PivotCell) *domain.PivotResponse {
+	// Include any column the database produced that the calendar walk missed
+	columnIndex := make(map[string]int, len(columns))
+	for _, column := range columns {
+		columnIndex[column] = 0
+	}
+	for _, cell := range cells {
+		if _, ok := columnIndex[cell.ColumnLabel]; !ok {
+			columnIndex[cell.ColumnLabel] = 0
+			columns = append(columns, cell.ColumnLabel)
+		}
+	}
+	sort.Strings(columns)
+	for i, column := range columns {
+		columnIndex[column] = i
+	}
+
+	rowIndex := make(map[string]int)
+	rows := []domain.PivotRow{}
+	columnTotals := make([]float64, len(columns))
+	var grandTotal float64
+
+	for _, cell := range cells {
+		i, ok := rowIndex[cell.RowLabel]
+		if !ok {
+			i = len(rows)
+			rowIndex[cell.RowLabel] = i
+			rows = append(rows, domain.PivotRow{
+				Label:  cell.RowLabel,
+				Values: make([]float64, len(columns)),
+			})
+		}
+
+		j := columnIndex[cell.ColumnLabel]
+		rows[i].Values[j] += cell.Amount
+		rows[i].Total += cell.Amount
+		columnTotals[j] += cell.Amount
+		grandTotal += cell.Amount
+	}
+
+	// Largest rows first, as in the breakdown endpoints
+	sort.SliceStable(rows, func(a, b int) bool {
+		if rows[a].Total != rows[b].Total {
+			return rows[a].Total > rows[b].Total
+		}
+		return rows[a].Label < rows[b].Label
+	})
+
+	return &domain.PivotResponse{
+		Type:         filter.Type,
+		RowDimension: filter.Rows,
+		Period:       filter.Period,
+		Columns:      columns,
+		Rows:         rows,
+		ColumnTotals: columnTotals,
+		GrandTotal:   grandTotal,
+	}
+}
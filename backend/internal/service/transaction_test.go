@@ -1,11 +1,15 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/dev/personal-finance-tracker/backend/internal/domain"
+	"github.com/dev/personal-finance-tracker/backend/internal/repository"
 )
 
 // mockRepository is a mock implementation of TransactionRepository for testing
@@ -14,10 +18,15 @@ type mockRepository struct {
 	createInBatchFunc    func(transactions []domain.Transaction) error
 	findByIDFunc         func(id int64) (*domain.Transaction, error)
 	listFunc             func(params domain.ListTransactionsQueryParams) ([]domain.Transaction, int64, error)
+	streamed             []domain.Transaction
+	streamFunc           func(params domain.ListTransactionsQueryParams, fn func(tx *domain.Transaction) error) error
 	getSummaryFunc       func() (*domain.SummaryResponse, error)
 	getTrendsFunc        func(period string) ([]domain.TrendDataPoint, error)
 	getBreakdownSource   func() ([]domain.BreakdownResponse, error)
 	getBreakdownCategory func() ([]domain.BreakdownResponse, error)
+	getBreakdownFunc     func(filter *domain.BreakdownFilter) ([]domain.BreakdownResponse, error)
+	getPivotFunc         func(filter *domain.PivotFilter) ([]domain.PivotCell, error)
+	updateFunc           func(tx *domain.Transaction) error
 }
 
 func (m *mockRepository) Create(tx *domain.Transaction) error {
@@ -34,6 +43,13 @@ func (m *mockRepository) CreateInBatch(transactions []domain.Transaction) error
 	return nil
 }
 
+func (m *mockRepository) Update(tx *domain.Transaction) error {
+	if m.updateFunc != nil {
+		return m.updateFunc(tx)
+	}
+	return nil
+}
+
 func (m *mockRepository) FindByID(id int64) (*domain.Transaction, error) {
 	if m.findByIDFunc != nil {
 		return m.findByIDFunc(id)
@@ -48,6 +64,21 @@ func (m *mockRepository) List(params domain.ListTransactionsQueryParams) ([]doma
 	return []domain.Transaction{}, 0, nil
 }
 
+func (m *mockRepository) Stream(ctx context.Context, params domain.ListTransactionsQueryParams, fn func(tx *domain.Transaction) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if m.streamFunc != nil {
+		return m.streamFunc(params, fn)
+	}
+	for i := range m.streamed {
+		if err := fn(&m.streamed[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *mockRepository) GetSummary() (*domain.SummaryResponse, error) {
 	if m.getSummaryFunc != nil {
 		return m.getSummaryFunc()
@@ -76,6 +107,20 @@ func (m *mockRepository) GetBreakdownByCategory() ([]domain.BreakdownResponse, e
 	return []domain.BreakdownResponse{}, nil
 }
 
+func (m *mockRepository) GetBreakdown(filter *domain.BreakdownFilter) ([]domain.BreakdownResponse, error) {
+	if m.getBreakdownFunc != nil {
+		return m.getBreakdownFunc(filter)
+	}
+	return []domain.BreakdownResponse{}, nil
+}
+
+func (m *mockRepository) GetPivot(filter *domain.PivotFilter) ([]domain.PivotCell, error) {
+	if m.getPivotFunc != nil {
+		return m.getPivotFunc(filter)
+	}
+	return []domain.PivotCell{}, nil
+}
+
 // Test CreateTransaction
 
 func TestCreateTransaction_Success(t *testing.T) {
@@ -265,6 +310,234 @@ func TestCreateBatchTransaction_Success(t *testing.T) {
 	}
 }
 
+// recordingHook records the transactions passed to OnTransactionsCreated
+type recordingHook struct {
+	created []domain.Transaction
+}
+
+func (h *recordingHook) OnTransactionsCreated(transactions []domain.Transaction) {
+	h.created = append(h.created, transactions...)
+}
+
+func TestCreateTransaction_NotifiesHooks(t *testing.T) {
+	mockRepo := &mockRepository{
+		createFunc: func(tx *domain.Transaction) error {
+			tx.ID = 7
+			return nil
+		},
+	}
+	hook := &recordingHook{}
+	service := NewTransactionService(mockRepo, hook)
+
+	_, err := service.CreateTransaction(&domain.CreateTransactionRequest{
+		Amount:          5000000,
+		Type:            domain.TransactionTypeOut,
+		Source:          "Bank ABC",
+		Recipient:       "Landlord",
+		TransactionDate: time.Now().Format(time.RFC3339),
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(hook.created) != 1 || hook.created[0].ID != 7 {
+		t.Errorf("expected hook to receive the stored transaction, got %+v", hook.created)
+	}
+}
+
+// preparingHook assigns a payee before transactions are stored
+type preparingHook struct {
+	recordingHook
+}
+
+func (h *preparingHook) PrepareTransactions(transactions []domain.Transaction) {
+	for i := range transactions {
+		payeeID := int64(3)
+		transactions[i].PayeeID = &payeeID
+		transactions[i].Category = "Food"
+	}
+}
+
+func TestCreateTransaction_PreparesBeforeStoring(t *testing.T) {
+	var stored domain.Transaction
+	mockRepo := &mockRepository{
+		createFunc: func(tx *domain.Transaction) error {
+			stored = *tx
+			return nil
+		},
+	}
+	service := NewTransactionService(mockRepo, &preparingHook{})
+
+	tx, err := service.CreateTransaction(&domain.CreateTransactionRequest{
+		Amount:          45000,
+		Type:            domain.TransactionTypeOut,
+		Source:          "Bank ABC",
+		Recipient:       "GRAB*FOOD 1234",
+		TransactionDate: time.Now().Format(time.RFC3339),
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stored.PayeeID == nil || *stored.PayeeID != 3 || stored.Category != "Food" {
+		t.Errorf("expected payee and category to be set before storing, got %+v", stored)
+	}
+	if tx.PayeeID == nil || *tx.PayeeID != 3 {
+		t.Errorf("expected returned transaction to carry the payee, got %+v", tx)
+	}
+}
+
+// updatingHook records the transactions passed to OnTransactionUpdated
+type updatingHook struct {
+	recordingHook
+	previous []domain.Transaction
+	updated  []domain.Transaction
+}
+
+func (h *updatingHook) OnTransactionUpdated(previous, updated domain.Transaction) {
+	h.previous = append(h.previous, previous)
+	h.updated = append(h.updated, updated)
+}
+
+func TestUpdateTransaction_NotifiesUpdateHooks(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	var stored domain.Transaction
+	mockRepo := &mockRepository{
+		findByIDFunc: func(id int64) (*domain.Transaction, error) {
+			return &domain.Transaction{ID: id, Amount: 100, Type: domain.TransactionTypeOut, CreatedAt: createdAt, Anomalous: true}, nil
+		},
+		updateFunc: func(tx *domain.Transaction) error {
+			stored = *tx
+			return nil
+		},
+	}
+	hook := &updatingHook{}
+	service := NewTransactionService(mockRepo, hook)
+
+	tx, err := service.UpdateTransaction(4, &domain.CreateTransactionRequest{
+		Amount:          250,
+		Type:            domain.TransactionTypeOut,
+		Source:          "Bank ABC",
+		TransactionDate: time.Now().Format(time.RFC3339),
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tx.ID != 4 || tx.Amount != 250 || !tx.CreatedAt.Equal(createdAt) || !tx.Anomalous {
+		t.Errorf("expected the stored transaction to keep its id, creation time and flag, got %+v", tx)
+	}
+	if stored.ID != 4 || stored.Amount != 250 {
+		t.Errorf("expected the update to be stored, got %+v", stored)
+	}
+	if len(hook.updated) != 1 || hook.previous[0].Amount != 100 || hook.updated[0].Amount != 250 {
+		t.Errorf("expected hook to receive the previous and updated transaction, got %+v -> %+v", hook.previous, hook.updated)
+	}
+	if len(hook.created) != 0 {
+		t.Errorf("expected create hooks not to be called, got %d transactions", len(hook.created))
+	}
+}
+
+func TestUpdateTransaction_KeepsExternalID(t *testing.T) {
+	var stored domain.Transaction
+	mockRepo := &mockRepository{
+		findByIDFunc: func(id int64) (*domain.Transaction, error) {
+			return &domain.Transaction{ID: id, Amount: 100, Type: domain.TransactionTypeOut, ExternalID: "FITID-881"}, nil
+		},
+		updateFunc: func(tx *domain.Transaction) error {
+			stored = *tx
+			return nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+	req := &domain.CreateTransactionRequest{
+		Amount:          250,
+		Type:            domain.TransactionTypeOut,
+		Source:          "Bank ABC",
+		TransactionDate: time.Now().Format(time.RFC3339),
+	}
+
+	if _, err := service.UpdateTransaction(4, req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stored.ExternalID != "FITID-881" {
+		t.Errorf("expected an update without external_id to keep it, got %q", stored.ExternalID)
+	}
+
+	req.ExternalID = "FITID-900"
+	if _, err := service.UpdateTransaction(4, req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if stored.ExternalID != "FITID-900" {
+		t.Errorf("expected an update with external_id to replace it, got %q", stored.ExternalID)
+	}
+}
+
+func TestUpdateTransaction_NotFound(t *testing.T) {
+	mockRepo := &mockRepository{
+		findByIDFunc: func(id int64) (*domain.Transaction, error) {
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	_, err := service.UpdateTransaction(4, &domain.CreateTransactionRequest{
+		Amount:          250,
+		Type:            domain.TransactionTypeOut,
+		Source:          "Bank ABC",
+		TransactionDate: time.Now().Format(time.RFC3339),
+	})
+
+	if !errors.Is(err, repository.ErrTransactionNotFound) {
+		t.Errorf("expected ErrTransactionNotFound, got %v", err)
+	}
+}
+
+func TestUpdateTransaction_ValidationError(t *testing.T) {
+	mockRepo := &mockRepository{
+		updateFunc: func(tx *domain.Transaction) error {
+			t.Error("expected the transaction not to be stored")
+			return nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	_, err := service.UpdateTransaction(4, &domain.CreateTransactionRequest{
+		Amount:          250,
+		Type:            domain.TransactionTypeOut,
+		Source:          "Bank ABC",
+		TransactionDate: "not-a-date",
+	})
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestCreateBatchTransaction_RepositoryErrorSkipsHooks(t *testing.T) {
+	mockRepo := &mockRepository{
+		createInBatchFunc: func(transactions []domain.Transaction) error {
+			return errors.New("database error")
+		},
+	}
+	hook := &recordingHook{}
+	service := NewTransactionService(mockRepo, hook)
+
+	_, err := service.CreateBatchTransaction(&domain.BatchTransactionRequest{
+		Transactions: []domain.CreateTransactionRequest{
+			{Amount: 100, Type: domain.TransactionTypeOut, Source: "Bank ABC", TransactionDate: time.Now().Format(time.RFC3339)},
+		},
+	})
+
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if len(hook.created) != 0 {
+		t.Errorf("expected hook not to be called, got %d transactions", len(hook.created))
+	}
+}
+
 func TestCreateBatchTransaction_Empty(t *testing.T) {
 	mockRepo := &mockRepository{}
 	service := NewTransactionService(mockRepo)
@@ -517,3 +790,202 @@ func TestGetBreakdownByCategory_Success(t *testing.T) {
 		t.Errorf("expected 1 breakdown item, got %d", len(breakdown))
 	}
 }
+
+// Test GetBreakdown
+
+func TestGetBreakdown_AppliesDefaults(t *testing.T) {
+	var captured *domain.BreakdownFilter
+	mockRepo := &mockRepository{
+		getBreakdownFunc: func(filter *domain.BreakdownFilter) ([]domain.BreakdownResponse, error) {
+			captured = filter
+			return []domain.BreakdownResponse{
+				{Label: "Food", Amount: 300, Percentage: 100, Count: 3},
+			}, nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	breakdown, err := service.GetBreakdown(domain.BreakdownQueryParams{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(breakdown) != 1 {
+		t.Errorf("expected 1 breakdown item, got %d", len(breakdown))
+	}
+	if captured.Type != domain.TransactionTypeOut {
+		t.Errorf("expected default type 'out', got %s", captured.Type)
+	}
+	if captured.GroupBy != domain.BreakdownByCategory {
+		t.Errorf("expected default group_by 'category', got %s", captured.GroupBy)
+	}
+}
+
+func TestGetBreakdown_IncomeWithDateRange(t *testing.T) {
+	var captured *domain.BreakdownFilter
+	mockRepo := &mockRepository{
+		getBreakdownFunc: func(filter *domain.BreakdownFilter) ([]domain.BreakdownResponse, error) {
+			captured = filter
+			return []domain.BreakdownResponse{}, nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	_, err := service.GetBreakdown(domain.BreakdownQueryParams{
+		Type:      domain.TransactionTypeIn,
+		GroupBy:   domain.BreakdownBySourceAccount,
+		StartDate: "2026-01-01",
+		EndDate:   "2026-01-31",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if captured.Type != domain.TransactionTypeIn {
+		t.Errorf("expected type 'in', got %s", captured.Type)
+	}
+	if captured.Start == nil || captured.End == nil {
+		t.Fatal("expected date range to be set")
+	}
+}
+
+func TestGetBreakdown_InvalidGroupBy(t *testing.T) {
+	mockRepo := &mockRepository{
+		getBreakdownFunc: func(filter *domain.BreakdownFilter) ([]domain.BreakdownResponse, error) {
+			t.Error("repository should not be called for invalid params")
+			return nil, nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	_, err := service.GetBreakdown(domain.BreakdownQueryParams{GroupBy: "amount; DROP TABLE"})
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if validationErr.Field != "group_by" {
+		t.Errorf("expected field 'group_by', got %s", validationErr.Field)
+	}
+}
+
+// Test GetPivot
+
+func TestGetPivot_DenseMatrixWithTotals(t *testing.T) {
+	mockRepo := &mockRepository{
+		getPivotFunc: func(filter *domain.PivotFilter) ([]domain.PivotCell, error) {
+			return []domain.PivotCell{
+				{RowLabel: "Food", ColumnLabel: "2026-01", Amount: 100},
+				{RowLabel: "Housing", ColumnLabel: "2026-01", Amount: 500},
+				{RowLabel: "Food", ColumnLabel: "2026-03", Amount: 50},
+			}, nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	pivot, err := service.GetPivot(domain.PivotQueryParams{
+		StartDate: "2026-01-01",
+		EndDate:   "2026-03-31",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	expectedColumns := []string{"2026-01", "2026-02", "2026-03"}
+	if len(pivot.Columns) != len(expectedColumns) {
+		t.Fatalf("expected columns %v, got %v", expectedColumns, pivot.Columns)
+	}
+	for i, column := range expectedColumns {
+		if pivot.Columns[i] != column {
+			t.Errorf("expected column %d to be %s, got %s", i, column, pivot.Columns[i])
+		}
+	}
+	if len(pivot.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(pivot.Rows))
+	}
+	// Rows are ordered by total, largest first
+	if pivot.Rows[0].Label != "Housing" || pivot.Rows[0].Total != 500 {
+		t.Errorf("expected Housing row first with total 500, got %+v", pivot.Rows[0])
+	}
+	food := pivot.Rows[1]
+	if food.Values[0] != 100 || food.Values[1] != 0 || food.Values[2] != 50 {
+		t.Errorf("unexpected Food values %v", food.Values)
+	}
+	if pivot.ColumnTotals[0] != 600 || pivot.ColumnTotals[1] != 0 || pivot.ColumnTotals[2] != 50 {
+		t.Errorf("unexpected column totals %v", pivot.ColumnTotals)
+	}
+	if pivot.GrandTotal != 650 {
+		t.Errorf("expected grand total 650, got %f", pivot.GrandTotal)
+	}
+}
+
+func TestGetPivot_WeeklyLabelsMatchISOWeeks(t *testing.T) {
+	var captured *domain.PivotFilter
+	mockRepo := &mockRepository{
+		getPivotFunc: func(filter *domain.PivotFilter) ([]domain.PivotCell, error) {
+			captured = filter
+			return []domain.PivotCell{}, nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	pivot, err := service.GetPivot(domain.PivotQueryParams{
+		Period:    "weekly",
+		StartDate: "2025-12-29",
+		EndDate:   "2026-01-11",
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pivot.Columns) != 2 || pivot.Columns[0] != "2026-W01" || pivot.Columns[1] != "2026-W02" {
+		t.Errorf("unexpected weekly columns %v", pivot.Columns)
+	}
+	if captured.Start == nil || captured.End == nil {
+		t.Error("expected date range to be passed to repository")
+	}
+}
+
+func TestGetPivot_DefaultRange(t *testing.T) {
+	var captured *domain.PivotFilter
+	mockRepo := &mockRepository{
+		getPivotFunc: func(filter *domain.PivotFilter) ([]domain.PivotCell, error) {
+			captured = filter
+			return []domain.PivotCell{}, nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	pivot, err := service.GetPivot(domain.PivotQueryParams{})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(pivot.Columns) != 12 {
+		t.Errorf("expected 12 monthly columns by default, got %d", len(pivot.Columns))
+	}
+	if captured.Start == nil || captured.End == nil {
+		t.Error("expected default date range to be applied")
+	}
+}
+
+func TestGetPivot_RangeTooLarge(t *testing.T) {
+	mockRepo := &mockRepository{
+		getPivotFunc: func(filter *domain.PivotFilter) ([]domain.PivotCell, error) {
+			t.Error("repository should not be called for oversized ranges")
+			return nil, nil
+		},
+	}
+	service := NewTransactionService(mockRepo)
+
+	_, err := service.GetPivot(domain.PivotQueryParams{
+		Period:    "daily",
+		StartDate: "2020-01-01",
+		EndDate:   "2026-01-01",
+	})
+
+	var validationErr *domain.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,3 @@
+-- Rollback migration for budgets table
+DROP INDEX IF EXISTS idx_budgets_category_source;
+DROP TABLE IF EXISTS budgets;
@@ -0,0 +1,17 @@
+-- Create budgets table
+CREATE TABLE IF NOT EXISTS budgets (
+    id         BIGSERIAL PRIMARY KEY,
+    category   VARCHAR(50) NOT NULL,
+    source     VARCHAR(100) NOT NULL DEFAULT '',
+    amount     DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- One budget per category and source
+CREATE UNIQUE INDEX IF NOT EXISTS idx_budgets_category_source ON budgets(category, source);
+
+-- Create comments for documentation
+COMMENT ON TABLE budgets IS 'Monthly spending budgets per category, optionally scoped to a source';
+COMMENT ON COLUMN budgets.source IS 'Bank or e-wallet the budget applies to; empty for all sources';
+COMMENT ON COLUMN budgets.amount IS 'Monthly budgeted amount';
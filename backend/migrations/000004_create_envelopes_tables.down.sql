@@ -0,0 +1,4 @@
+-- Drop envelope tables
+DROP TABLE IF EXISTS envelope_moves;
+DROP TABLE IF EXISTS envelope_allocations;
+DROP TABLE IF EXISTS envelopes;
@@ -0,0 +1,43 @@
+-- Create envelopes table
+CREATE TABLE IF NOT EXISTS envelopes (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL UNIQUE,
+    category   VARCHAR(50) NOT NULL UNIQUE,
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create envelope allocations table
+CREATE TABLE IF NOT EXISTS envelope_allocations (
+    id             BIGSERIAL PRIMARY KEY,
+    envelope_id    BIGINT NOT NULL REFERENCES envelopes(id) ON DELETE CASCADE,
+    transaction_id BIGINT REFERENCES transactions(id) ON DELETE SET NULL,
+    month          VARCHAR(7) NOT NULL,
+    amount         DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    note           VARCHAR(255),
+    created_at     TIMESTAMP DEFAULT NOW()
+);
+
+-- Create envelope moves table
+CREATE TABLE IF NOT EXISTS envelope_moves (
+    id               BIGSERIAL PRIMARY KEY,
+    from_envelope_id BIGINT NOT NULL REFERENCES envelopes(id) ON DELETE CASCADE,
+    to_envelope_id   BIGINT NOT NULL REFERENCES envelopes(id) ON DELETE CASCADE,
+    month            VARCHAR(7) NOT NULL,
+    amount           DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    note             VARCHAR(255),
+    created_at       TIMESTAMP DEFAULT NOW(),
+    CHECK (from_envelope_id <> to_envelope_id)
+);
+
+-- Create indexes for balance roll-ups
+CREATE INDEX IF NOT EXISTS idx_envelope_allocations_envelope_month ON envelope_allocations(envelope_id, month);
+CREATE INDEX IF NOT EXISTS idx_envelope_allocations_transaction_id ON envelope_allocations(transaction_id);
+CREATE INDEX IF NOT EXISTS idx_envelope_moves_month ON envelope_moves(month);
+
+-- Create comments for documentation
+COMMENT ON TABLE envelopes IS 'Zero-based budgeting envelopes, each drawn down by spending in one category';
+COMMENT ON TABLE envelope_allocations IS 'Money assigned to an envelope for a month, optionally funded by an income transaction';
+COMMENT ON TABLE envelope_moves IS 'Money moved between envelopes, e.g. to cover overspending';
+COMMENT ON COLUMN envelope_allocations.month IS 'Budget month in YYYY-MM format';
+COMMENT ON COLUMN envelope_moves.month IS 'Budget month in YYYY-MM format';
@@ -0,0 +1,3 @@
+-- Drop scheduled transaction tables
+DROP TABLE IF EXISTS scheduled_payments;
+DROP TABLE IF EXISTS scheduled_transactions;
@@ -0,0 +1,42 @@
+-- Create scheduled transactions table
+CREATE TABLE IF NOT EXISTS scheduled_transactions (
+    id               BIGSERIAL PRIMARY KEY,
+    name             VARCHAR(100) NOT NULL,
+    type             VARCHAR(20) NOT NULL CHECK (type IN ('in', 'out')),
+    frequency        VARCHAR(20) NOT NULL CHECK (frequency IN ('once', 'weekly', 'monthly', 'yearly')),
+    interval_count   INTEGER NOT NULL DEFAULT 1 CHECK (interval_count > 0),
+    category         VARCHAR(50),
+    source           VARCHAR(100),
+    recipient        VARCHAR(100),
+    amount           DECIMAL(15,2) NOT NULL CHECK (amount > 0),
+    amount_tolerance DECIMAL(5,2) NOT NULL DEFAULT 5,
+    start_date       TIMESTAMP NOT NULL,
+    end_date         TIMESTAMP,
+    next_due_date    TIMESTAMP NOT NULL,
+    occurrences      INTEGER NOT NULL DEFAULT 0,
+    active           BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMP DEFAULT NOW(),
+    updated_at       TIMESTAMP DEFAULT NOW()
+);
+
+-- Create scheduled payments table
+CREATE TABLE IF NOT EXISTS scheduled_payments (
+    id                       BIGSERIAL PRIMARY KEY,
+    scheduled_transaction_id BIGINT NOT NULL REFERENCES scheduled_transactions(id) ON DELETE CASCADE,
+    transaction_id           BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    due_date                 TIMESTAMP NOT NULL,
+    amount                   DECIMAL(15,2) NOT NULL,
+    matched_at               TIMESTAMP DEFAULT NOW()
+);
+
+-- Create indexes for matching and upcoming queries
+CREATE INDEX IF NOT EXISTS idx_scheduled_transactions_active_next_due ON scheduled_transactions(active, next_due_date);
+CREATE INDEX IF NOT EXISTS idx_scheduled_payments_scheduled_id ON scheduled_payments(scheduled_transaction_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_scheduled_payments_transaction_id ON scheduled_payments(transaction_id);
+
+-- Create comments for documentation
+COMMENT ON TABLE scheduled_transactions IS 'Expected future transactions with a recurrence rule, e.g. rent or salary';
+COMMENT ON TABLE scheduled_payments IS 'Transactions matched to paid occurrences of scheduled transactions';
+COMMENT ON COLUMN scheduled_transactions.amount_tolerance IS 'Accepted amount deviation in percent when matching';
+COMMENT ON COLUMN scheduled_transactions.occurrences IS 'Number of occurrences already paid';
+COMMENT ON COLUMN scheduled_transactions.next_due_date IS 'Due date of the first unpaid occurrence';
@@ -0,0 +1,3 @@
+-- Drop goal tables
+DROP TABLE IF EXISTS goal_contributions;
+DROP TABLE IF EXISTS goals;
@@ -0,0 +1,32 @@
+-- Create goals table
+CREATE TABLE IF NOT EXISTS goals (
+    id             BIGSERIAL PRIMARY KEY,
+    name           VARCHAR(100) NOT NULL UNIQUE,
+    target_amount  DECIMAL(15,2) NOT NULL CHECK (target_amount > 0),
+    start_date     TIMESTAMP NOT NULL,
+    target_date    TIMESTAMP NOT NULL,
+    source_account VARCHAR(100),
+    tag            VARCHAR(50),
+    category       VARCHAR(50),
+    created_at     TIMESTAMP DEFAULT NOW(),
+    updated_at     TIMESTAMP DEFAULT NOW(),
+    CHECK (target_date > start_date)
+);
+
+-- Create goal contributions table
+CREATE TABLE IF NOT EXISTS goal_contributions (
+    id                BIGSERIAL PRIMARY KEY,
+    goal_id           BIGINT NOT NULL REFERENCES goals(id) ON DELETE CASCADE,
+    contribution_date TIMESTAMP NOT NULL,
+    amount            DECIMAL(15,2) NOT NULL CHECK (amount <> 0),
+    note              VARCHAR(255),
+    created_at        TIMESTAMP DEFAULT NOW()
+);
+
+-- Create indexes for progress calculations
+CREATE INDEX IF NOT EXISTS idx_goal_contributions_goal_id ON goal_contributions(goal_id);
+
+-- Create comments for documentation
+COMMENT ON TABLE goals IS 'Savings goals funded by linked account, tagged or categorised transactions and manual contributions';
+COMMENT ON TABLE goal_contributions IS 'Manual allocations to a goal; negative amounts are withdrawals';
+COMMENT ON COLUMN goals.tag IS 'Transactions whose description contains #tag count towards the goal';
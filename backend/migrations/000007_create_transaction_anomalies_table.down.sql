@@ -0,0 +1,3 @@
+-- Drop transaction anomalies
+DROP TABLE IF EXISTS transaction_anomalies;
+ALTER TABLE transactions DROP COLUMN IF EXISTS anomalous;
@@ -0,0 +1,22 @@
+-- Add anomaly flag to transactions
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS anomalous BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Create transaction anomalies table
+CREATE TABLE IF NOT EXISTS transaction_anomalies (
+    id             BIGSERIAL PRIMARY KEY,
+    transaction_id BIGINT NOT NULL REFERENCES transactions(id) ON DELETE CASCADE,
+    reason         VARCHAR(30) NOT NULL CHECK (reason IN ('category_amount', 'recipient_amount', 'new_recipient', 'burst')),
+    detail         VARCHAR(255),
+    score          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP DEFAULT NOW(),
+    UNIQUE (transaction_id, reason)
+);
+
+-- Create indexes for listing flagged transactions
+CREATE INDEX IF NOT EXISTS idx_transactions_anomalous ON transactions(anomalous) WHERE anomalous;
+CREATE INDEX IF NOT EXISTS idx_transaction_anomalies_reason ON transaction_anomalies(reason);
+
+-- Create comments for documentation
+COMMENT ON TABLE transaction_anomalies IS 'Reasons a transaction was flagged as unusual at ingest';
+COMMENT ON COLUMN transaction_anomalies.score IS 'Amount or count relative to the threshold; values above 1 exceed it';
+COMMENT ON COLUMN transactions.anomalous IS 'True when at least one anomaly was recorded for the transaction';
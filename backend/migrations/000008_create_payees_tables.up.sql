@@ -0,0 +1,27 @@
+-- Create payees table
+CREATE TABLE IF NOT EXISTS payees (
+    id               BIGSERIAL PRIMARY KEY,
+    name             VARCHAR(100) NOT NULL UNIQUE,
+    default_category VARCHAR(50),
+    created_at       TIMESTAMP DEFAULT NOW(),
+    updated_at       TIMESTAMP DEFAULT NOW()
+);
+
+-- Create payee aliases table
+CREATE TABLE IF NOT EXISTS payee_aliases (
+    id       BIGSERIAL PRIMARY KEY,
+    payee_id BIGINT NOT NULL REFERENCES payees(id) ON DELETE CASCADE,
+    pattern  VARCHAR(100) NOT NULL UNIQUE
+);
+
+-- Link transactions to payees
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS payee_id BIGINT REFERENCES payees(id) ON DELETE SET NULL;
+
+-- Create indexes for payee lookups and breakdowns
+CREATE INDEX IF NOT EXISTS idx_payee_aliases_payee_id ON payee_aliases(payee_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_payee_id ON transactions(payee_id);
+
+-- Create comments for documentation
+COMMENT ON TABLE payees IS 'Normalised merchants and people that transactions are linked to';
+COMMENT ON TABLE payee_aliases IS 'Case-insensitive patterns matched against the whole recipient or description; * matches any characters';
+COMMENT ON COLUMN transactions.payee_id IS 'Payee resolved from recipient or description at ingest or by a later merge';
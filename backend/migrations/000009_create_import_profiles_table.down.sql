@@ -0,0 +1,3 @@
+-- Drop import profiles table
+DROP INDEX IF EXISTS idx_transactions_lower_source_date;
+DROP TABLE IF EXISTS import_profiles;
@@ -0,0 +1,36 @@
+-- Create import profiles table
+CREATE TABLE IF NOT EXISTS import_profiles (
+    id                 BIGSERIAL PRIMARY KEY,
+    name               VARCHAR(100) NOT NULL UNIQUE,
+    source             VARCHAR(100) NOT NULL,
+    source_account     VARCHAR(100),
+    delimiter          VARCHAR(1) NOT NULL DEFAULT ',',
+    date_column        VARCHAR(100) NOT NULL,
+    date_format        VARCHAR(50) NOT NULL,
+    decimal_separator  VARCHAR(1) NOT NULL DEFAULT '.',
+    amount_column      VARCHAR(100),
+    amount_sign        VARCHAR(20) NOT NULL DEFAULT 'negative_out',
+    debit_column       VARCHAR(100),
+    credit_column      VARCHAR(100),
+    description_column VARCHAR(100),
+    recipient_column   VARCHAR(100),
+    category_column    VARCHAR(100),
+    skip_rows          INTEGER NOT NULL DEFAULT 0,
+    created_at         TIMESTAMP DEFAULT NOW(),
+    updated_at         TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT chk_import_profiles_amount_sign CHECK (amount_sign IN ('negative_out', 'positive_out')),
+    CONSTRAINT chk_import_profiles_amount_columns CHECK (
+        (amount_column IS NOT NULL AND amount_column <> '') OR
+        (debit_column IS NOT NULL AND debit_column <> '') OR
+        (credit_column IS NOT NULL AND credit_column <> '')
+    )
+);
+
+-- Create index for duplicate detection of imported rows
+CREATE INDEX IF NOT EXISTS idx_transactions_lower_source_date ON transactions(LOWER(source), transaction_date);
+
+-- Create comments for documentation
+COMMENT ON TABLE import_profiles IS 'Saved column mappings for CSV bank statement imports';
+COMMENT ON COLUMN import_profiles.date_format IS 'Go time layout, e.g. 02/01/2006';
+COMMENT ON COLUMN import_profiles.amount_sign IS 'How a single amount column encodes direction: negative_out or positive_out';
+COMMENT ON COLUMN import_profiles.skip_rows IS 'Lines skipped before the header row';
@@ -0,0 +1,3 @@
+-- Drop external ID from transactions
+DROP INDEX IF EXISTS idx_transactions_source_external_id;
+ALTER TABLE transactions DROP COLUMN IF EXISTS external_id;
@@ -0,0 +1,8 @@
+-- Add external ID to transactions
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS external_id VARCHAR(255);
+
+-- Create index for skipping re-imported statement rows
+CREATE INDEX IF NOT EXISTS idx_transactions_source_external_id ON transactions(LOWER(source), external_id) WHERE external_id IS NOT NULL AND external_id <> '';
+
+-- Create comments for documentation
+COMMENT ON COLUMN transactions.external_id IS 'Bank-assigned transaction ID, e.g. the OFX FITID, used to skip re-imported rows';
@@ -0,0 +1,2 @@
+-- Drop digest subscriptions table
+DROP TABLE IF EXISTS digest_subscriptions;
@@ -0,0 +1,16 @@
+-- Create digest subscriptions table
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id             BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    weekly              BOOLEAN NOT NULL DEFAULT FALSE,
+    monthly             BOOLEAN NOT NULL DEFAULT FALSE,
+    last_weekly_period  VARCHAR(10) NOT NULL DEFAULT '',
+    last_monthly_period VARCHAR(10) NOT NULL DEFAULT '',
+    last_sent_at        TIMESTAMP,
+    created_at          TIMESTAMP DEFAULT NOW(),
+    updated_at          TIMESTAMP DEFAULT NOW()
+);
+
+-- Create comments for documentation
+COMMENT ON TABLE digest_subscriptions IS 'Weekly and monthly digest email choices per user';
+COMMENT ON COLUMN digest_subscriptions.last_weekly_period IS 'ISO week of the last weekly digest sent, e.g. 2026-W10';
+COMMENT ON COLUMN digest_subscriptions.last_monthly_period IS 'Month of the last monthly digest sent, e.g. 2026-03';
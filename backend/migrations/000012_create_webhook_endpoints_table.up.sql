@@ -0,0 +1,47 @@
+-- Create webhook endpoints table
+CREATE TABLE IF NOT EXISTS webhook_endpoints (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url         VARCHAR(2048) NOT NULL,
+    description VARCHAR(255),
+    events      JSONB NOT NULL,
+    secret      VARCHAR(100) NOT NULL,
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMP DEFAULT NOW(),
+    updated_at  TIMESTAMP DEFAULT NOW()
+);
+
+-- Create webhook deliveries table
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              BIGSERIAL PRIMARY KEY,
+    endpoint_id     BIGINT NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    event_id        VARCHAR(50) NOT NULL,
+    event_type      VARCHAR(50) NOT NULL,
+    payload         JSONB NOT NULL,
+    status          VARCHAR(20) NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP,
+    delivered_at    TIMESTAMP,
+    redelivery_of   BIGINT,
+    response_status INTEGER,
+    response_body   TEXT,
+    error           TEXT,
+    duration_ms     BIGINT,
+    created_at      TIMESTAMP DEFAULT NOW(),
+    updated_at      TIMESTAMP DEFAULT NOW(),
+    CONSTRAINT chk_webhook_deliveries_status CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+-- Create indexes for the delivery worker and the delivery log
+CREATE INDEX IF NOT EXISTS idx_webhook_endpoints_user_id ON webhook_endpoints(user_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_endpoint_id ON webhook_deliveries(endpoint_id, id DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event_id ON webhook_deliveries(event_id);
+
+-- Create comments for documentation
+COMMENT ON TABLE webhook_endpoints IS 'URLs that receive signed transaction, budget and anomaly events';
+COMMENT ON COLUMN webhook_endpoints.events IS 'Subscribed event types, e.g. ["transaction.created", "budget.exceeded"]';
+COMMENT ON COLUMN webhook_endpoints.secret IS 'HMAC-SHA256 key of the X-Webhook-Signature header';
+COMMENT ON TABLE webhook_deliveries IS 'Delivery log of webhook events, retried with exponential backoff';
+COMMENT ON COLUMN webhook_deliveries.next_attempt_at IS 'When the next attempt is due; NULL once delivered or failed';
+COMMENT ON COLUMN webhook_deliveries.redelivery_of IS 'Delivery whose event was manually sent again';
@@ -0,0 +1,16 @@
+-- Create rate limit buckets table
+-- UNLOGGED: buckets are short-lived and may be lost on a crash, which only resets limits
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key        VARCHAR(255) PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    allowed    BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Create index for deleting idle buckets
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated_at ON rate_limit_buckets(updated_at);
+
+-- Create comments for documentation
+COMMENT ON TABLE rate_limit_buckets IS 'Token buckets of the postgres rate limit store, shared by all API replicas';
+COMMENT ON COLUMN rate_limit_buckets.key IS 'Policy and client, e.g. auth:203.0.113.7';
+COMMENT ON COLUMN rate_limit_buckets.allowed IS 'Whether the last request was allowed';
@@ -0,0 +1,3 @@
+-- Drop security tables
+DROP TABLE IF EXISTS security_events;
+DROP TABLE IF EXISTS login_throttles;
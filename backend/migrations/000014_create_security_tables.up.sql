@@ -0,0 +1,32 @@
+-- Create login throttles table
+-- One row per account (account:<email>) or client IP (ip:<address>) with recent failed logins
+CREATE TABLE IF NOT EXISTS login_throttles (
+    key            VARCHAR(320) PRIMARY KEY,
+    failures       INTEGER NOT NULL,
+    last_failed_at TIMESTAMPTZ NOT NULL,
+    blocked_until  TIMESTAMPTZ NOT NULL,
+    locked         BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+-- Create security events table
+CREATE TABLE IF NOT EXISTS security_events (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent VARCHAR(255),
+    details    VARCHAR(255),
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create indexes for the cleanup job and the security log
+CREATE INDEX IF NOT EXISTS idx_login_throttles_blocked_until ON login_throttles(blocked_until);
+CREATE INDEX IF NOT EXISTS idx_security_events_user_id ON security_events(user_id, id DESC);
+
+-- Create comments for documentation
+COMMENT ON TABLE login_throttles IS 'Failed logins per account and client IP, with the block they caused';
+COMMENT ON COLUMN login_throttles.failures IS 'Failed logins in a row; reset once none happened for login.failure_window';
+COMMENT ON COLUMN login_throttles.blocked_until IS 'Logins are refused until then';
+COMMENT ON COLUMN login_throttles.locked IS 'Whether the block is an account lockout rather than a delay';
+COMMENT ON TABLE security_events IS 'Security log of each user: logins, lockouts, unlocks and password changes';
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked or password.changed';
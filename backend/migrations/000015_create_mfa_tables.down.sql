@@ -0,0 +1,5 @@
+-- Drop two-factor authentication tables
+DROP TABLE IF EXISTS mfa_recovery_codes;
+DROP TABLE IF EXISTS user_mfa;
+
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked or password.changed';
@@ -0,0 +1,32 @@
+-- Create two-factor authentication table
+-- One row per enrolled user; enabled once the first TOTP code is verified
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id        BIGINT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret         VARCHAR(64) NOT NULL,
+    enabled        BOOLEAN NOT NULL DEFAULT FALSE,
+    enabled_at     TIMESTAMP,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at     TIMESTAMP DEFAULT NOW(),
+    updated_at     TIMESTAMP DEFAULT NOW()
+);
+
+-- Create recovery codes table
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash  VARCHAR(64) NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create index for looking up a user's codes
+CREATE INDEX IF NOT EXISTS idx_mfa_recovery_codes_user_id ON mfa_recovery_codes(user_id, code_hash);
+
+-- Create comments for documentation
+COMMENT ON TABLE user_mfa IS 'TOTP two-factor authentication of users';
+COMMENT ON COLUMN user_mfa.secret IS 'Base32 TOTP secret shared with the authenticator app';
+COMMENT ON COLUMN user_mfa.last_used_step IS 'Time step of the last accepted code; codes up to it are refused as replays';
+COMMENT ON TABLE mfa_recovery_codes IS 'One-time recovery codes replacing a TOTP code';
+COMMENT ON COLUMN mfa_recovery_codes.code_hash IS 'Hex SHA-256 of the normalized code';
+COMMENT ON COLUMN mfa_recovery_codes.used_at IS 'When the code was used; NULL while it is still valid';
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked, password.changed, mfa.enabled, mfa.disabled, mfa.failed, mfa.recovery_code_used or mfa.recovery_codes_regenerated';
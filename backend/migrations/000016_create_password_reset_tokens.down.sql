@@ -0,0 +1,5 @@
+-- Drop password reset tokens table
+DROP TABLE IF EXISTS password_reset_tokens;
+ALTER TABLE users DROP COLUMN IF EXISTS sessions_revoked_at;
+
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked, password.changed, mfa.enabled, mfa.disabled, mfa.failed, mfa.recovery_code_used or mfa.recovery_codes_regenerated';
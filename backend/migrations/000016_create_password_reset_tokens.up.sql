@@ -0,0 +1,24 @@
+-- Revoke sessions on password changes and resets
+-- Tokens issued before sessions_revoked_at are refused
+ALTER TABLE users ADD COLUMN IF NOT EXISTS sessions_revoked_at TIMESTAMP;
+
+-- Create password reset tokens table
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create indexes for invalidating a user's tokens and deleting expired ones
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_expires_at ON password_reset_tokens(expires_at);
+
+-- Create comments for documentation
+COMMENT ON COLUMN users.sessions_revoked_at IS 'Access tokens issued before this time are refused; set by password changes and resets';
+COMMENT ON TABLE password_reset_tokens IS 'Single-use password reset links; a new link invalidates the unused ones';
+COMMENT ON COLUMN password_reset_tokens.token_hash IS 'Hex SHA-256 of the token sent in the link';
+COMMENT ON COLUMN password_reset_tokens.used_at IS 'When the token was used; NULL while it is still valid';
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked, password.changed, password.reset_requested, password.reset, mfa.enabled, mfa.disabled, mfa.failed, mfa.recovery_code_used or mfa.recovery_codes_regenerated';
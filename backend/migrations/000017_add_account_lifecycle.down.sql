@@ -0,0 +1,8 @@
+-- Drop email change tokens table and account lifecycle columns
+DROP TABLE IF EXISTS email_change_tokens;
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deactivated_at;
+ALTER TABLE users DROP COLUMN IF EXISTS settings;
+
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked, password.changed, password.reset_requested, password.reset, mfa.enabled, mfa.disabled, mfa.failed, mfa.recovery_code_used or mfa.recovery_codes_regenerated';
@@ -0,0 +1,32 @@
+-- Add profile settings and account lifecycle to users
+-- Deactivated accounts with deletion_scheduled_at are deleted for good once it passes
+ALTER TABLE users ADD COLUMN IF NOT EXISTS settings JSONB NOT NULL DEFAULT '{}';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at TIMESTAMP;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMP;
+
+-- Create index for finding accounts due for deletion
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+-- Create email change tokens table
+CREATE TABLE IF NOT EXISTS email_change_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    new_email  VARCHAR(255) NOT NULL,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP NOT NULL,
+    used_at    TIMESTAMP,
+    created_at TIMESTAMP DEFAULT NOW()
+);
+
+-- Create indexes for a user's pending change and deleting expired tokens
+CREATE INDEX IF NOT EXISTS idx_email_change_tokens_user_id ON email_change_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_email_change_tokens_expires_at ON email_change_tokens(expires_at);
+
+-- Create comments for documentation
+COMMENT ON COLUMN users.settings IS 'Preferences of the user as JSON: locale, timezone';
+COMMENT ON COLUMN users.deactivated_at IS 'When the user deactivated or deleted the account; NULL while active';
+COMMENT ON COLUMN users.deletion_scheduled_at IS 'When the account and its data are deleted for good; NULL unless deletion was requested';
+COMMENT ON TABLE email_change_tokens IS 'Single-use links confirming a new email; a new link invalidates the unused ones';
+COMMENT ON COLUMN email_change_tokens.token_hash IS 'Hex SHA-256 of the token sent in the link';
+COMMENT ON COLUMN email_change_tokens.used_at IS 'When the token was used; NULL while it is still valid';
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked, account.deactivated, account.reactivated, account.deletion_scheduled, password.changed, password.reset_requested, password.reset, email.change_requested, email.changed, mfa.enabled, mfa.disabled, mfa.failed, mfa.recovery_code_used or mfa.recovery_codes_regenerated';
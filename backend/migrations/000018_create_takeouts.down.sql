@@ -0,0 +1,2 @@
+-- Drop takeouts table
+DROP TABLE IF EXISTS takeouts;
@@ -0,0 +1,26 @@
+-- Create takeouts table
+-- Archives of everything stored about a user, downloadable until expires_at
+CREATE TABLE IF NOT EXISTS takeouts (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status       VARCHAR(20) NOT NULL,
+    error        VARCHAR(255),
+    archive      BYTEA,
+    size         BIGINT NOT NULL DEFAULT 0,
+    lease_until  TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    expires_at   TIMESTAMP,
+    created_at   TIMESTAMP DEFAULT NOW()
+);
+
+-- Create indexes for a user's takeouts, claiming pending ones and deleting expired ones
+CREATE INDEX IF NOT EXISTS idx_takeouts_user_id ON takeouts(user_id);
+CREATE INDEX IF NOT EXISTS idx_takeouts_lease_until ON takeouts(lease_until) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_takeouts_expires_at ON takeouts(expires_at);
+
+-- Create comments for documentation
+COMMENT ON TABLE takeouts IS 'Zip archives of the profile, ledger and security log of a user';
+COMMENT ON COLUMN takeouts.status IS 'pending, ready or failed';
+COMMENT ON COLUMN takeouts.archive IS 'The zip archive; NULL until ready';
+COMMENT ON COLUMN takeouts.lease_until IS 'A pending takeout is built once this passes; workers move it forward while building';
+COMMENT ON COLUMN takeouts.expires_at IS 'When the takeout is deleted; NULL while pending';
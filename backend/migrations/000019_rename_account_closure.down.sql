@@ -0,0 +1,6 @@
+-- Restore the previous account closing event type
+UPDATE security_events SET type = 'account.deletion_scheduled' WHERE type = 'account.closed';
+
+COMMENT ON COLUMN users.deactivated_at IS 'When the user deactivated or deleted the account; NULL while active';
+COMMENT ON COLUMN users.deletion_scheduled_at IS 'When the account and its data are deleted for good; NULL unless deletion was requested';
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked, account.deactivated, account.reactivated, account.deletion_scheduled, password.changed, password.reset_requested, password.reset, email.change_requested, email.changed, mfa.enabled, mfa.disabled, mfa.failed, mfa.recovery_code_used or mfa.recovery_codes_regenerated';
@@ -0,0 +1,7 @@
+-- Closing an account removes the sign-in account only; the shared ledger is kept
+UPDATE security_events SET type = 'account.closed' WHERE type = 'account.deletion_scheduled';
+
+-- Update comments for documentation
+COMMENT ON COLUMN users.deactivated_at IS 'When the user deactivated or closed the account; NULL while active';
+COMMENT ON COLUMN users.deletion_scheduled_at IS 'When the sign-in account of a closed account is removed; ledger data is kept. NULL unless the account was closed';
+COMMENT ON COLUMN security_events.type IS 'login.succeeded, login.failed, account.locked, account.unlocked, account.deactivated, account.reactivated, account.closed, password.changed, password.reset_requested, password.reset, email.change_requested, email.changed, mfa.enabled, mfa.disabled, mfa.failed, mfa.recovery_code_used or mfa.recovery_codes_regenerated';
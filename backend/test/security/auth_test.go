@@ -35,6 +35,13 @@ func (m *mockSecurityService) CreateBatchTransaction(req *domain.BatchTransactio
 	return []domain.Transaction{{ID: 1}}, nil
 }
 
+func (m *mockSecurityService) UpdateTransaction(id int64, req *domain.CreateTransactionRequest) (*domain.Transaction, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &domain.Transaction{ID: id}, nil
+}
+
 func (m *mockSecurityService) GetTransactionByID(id int64) (*domain.Transaction, error) {
 	return &domain.Transaction{ID: id}, nil
 }
@@ -59,6 +66,14 @@ func (m *mockSecurityService) GetBreakdownByCategory() ([]domain.BreakdownRespon
 	return []domain.BreakdownResponse{}, nil
 }
 
+func (m *mockSecurityService) GetBreakdown(params domain.BreakdownQueryParams) ([]domain.BreakdownResponse, error) {
+	return []domain.BreakdownResponse{}, nil
+}
+
+func (m *mockSecurityService) GetPivot(params domain.PivotQueryParams) (*domain.PivotResponse, error) {
+	return &domain.PivotResponse{}, nil
+}
+
 func setupSecurityRouter(apiKey string) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()